@@ -0,0 +1,459 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/llm/clmscript"
+	"github.com/lightninglabs/loop/lndclient"
+	"github.com/lightningnetwork/lnd/keychain"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DefaultChainScanKeyWindow is the default number of account keys that
+	// are derived and checked during a chain scan recovery.
+	DefaultChainScanKeyWindow uint32 = 50
+
+	// DefaultBatchKeyWindow is the default number of batch keys, starting
+	// at the initial batch key, that are tried for each account output.
+	DefaultBatchKeyWindow uint32 = 50
+
+	// DefaultSpendTimeout is the default time we wait for the historical
+	// spend of an account output to be found before assuming the output is
+	// still unspent.
+	DefaultSpendTimeout = 30 * time.Second
+)
+
+var (
+	// errNoSpend is returned if no spend of an output was found within the
+	// configured spend timeout.
+	errNoSpend = errors.New("no spend found")
+)
+
+// ChainRecoveryConfig contains all dependencies and parameters of a recovery
+// that only uses the chain and the trader's lnd node and not the auctioneer.
+type ChainRecoveryConfig struct {
+	// Wallet is used to derive the trader keys.
+	Wallet lndclient.WalletKitClient
+
+	// Signer is used to derive the shared secrets of the accounts.
+	Signer lndclient.SignerClient
+
+	// ChainNotifier is used to find the spends of account outputs.
+	ChainNotifier lndclient.ChainNotifierClient
+
+	// TxSource provides the transactions of the wallet. The funding
+	// transactions of all accounts must be among them.
+	TxSource TxSource
+
+	// AuctioneerKey is the auctioneer's static base key.
+	AuctioneerKey *btcec.PublicKey
+
+	// InitialBatchKey is the first batch key of the auctioneer. The batch
+	// key of each account is found by incrementing it.
+	InitialBatchKey *btcec.PublicKey
+
+	// StartIndex is the index of the first trader key to check.
+	StartIndex uint32
+
+	// NumKeys is the number of trader keys to derive and check.
+	NumKeys uint32
+
+	// BatchKeyWindow is the number of batch keys, starting at the initial
+	// batch key, that are tried for each funding output.
+	BatchKeyWindow uint32
+
+	// HeightHint is the earliest height any account could have been
+	// funded at. If zero, the height one maximum account lifetime before
+	// the best height is used.
+	HeightHint uint32
+
+	// BestHeight is the current height of the chain.
+	BestHeight uint32
+
+	// SpendTimeout is the time we wait for the historical spend of an
+	// output before assuming it is still unspent.
+	SpendTimeout time.Duration
+}
+
+// candidateOutput is a P2WSH output of a wallet transaction that could be the
+// funding output of an account.
+type candidateOutput struct {
+	outPoint wire.OutPoint
+	value    btcutil.Amount
+}
+
+// traderKeyCandidate holds the key and shared secret of one derivation index.
+type traderKeyCandidate struct {
+	keyDesc *keychain.KeyDescriptor
+	secret  [32]byte
+}
+
+// RecoverAccountsFromChain tries to find the current outputs of all accounts
+// of the trader by only looking at the chain. The funding outputs of the
+// accounts are located among the wallet's transactions by trying all trader
+// keys, batch keys and expiries in the configured ranges. Each account found is
+// then followed through all its spends until the unspent output is reached.
+// Accounts that were closed are not returned.
+func RecoverAccountsFromChain(ctx context.Context,
+	cfg *ChainRecoveryConfig) ([]*Account, error) {
+
+	if cfg.AuctioneerKey == nil || cfg.InitialBatchKey == nil {
+		return nil, errors.New("auctioneer key and initial batch key " +
+			"are required for a chain scan")
+	}
+	if cfg.NumKeys == 0 {
+		cfg.NumKeys = DefaultChainScanKeyWindow
+	}
+	if cfg.BatchKeyWindow == 0 {
+		cfg.BatchKeyWindow = DefaultBatchKeyWindow
+	}
+	if cfg.SpendTimeout == 0 {
+		cfg.SpendTimeout = DefaultSpendTimeout
+	}
+	if cfg.HeightHint == 0 && cfg.BestHeight > maxAccountExpiry {
+		cfg.HeightHint = cfg.BestHeight - maxAccountExpiry
+	}
+	if cfg.HeightHint > cfg.BestHeight {
+		return nil, fmt.Errorf("height hint %d is above best height %d",
+			cfg.HeightHint, cfg.BestHeight)
+	}
+
+	// An account funded at height h has an expiry between h plus the
+	// minimum and h plus the maximum account lifetime.
+	minExpiry := cfg.HeightHint + minAccountExpiry
+	maxExpiry := cfg.BestHeight + maxAccountExpiry
+
+	candidates, err := findCandidateOutputs(ctx, cfg.TxSource)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		log.Infof("No possible account outputs found in wallet")
+		return nil, nil
+	}
+
+	// Deriving the keys involves calls to lnd, so we do that up front
+	// before spinning up the CPU bound search.
+	traderKeys := make([]*traderKeyCandidate, 0, cfg.NumKeys)
+	for i := uint32(0); i < cfg.NumKeys; i++ {
+		keyDesc, err := deriveAccountKey(
+			ctx, cfg.Wallet, cfg.StartIndex+i,
+		)
+		if err != nil {
+			return nil, err
+		}
+		secret, err := cfg.Signer.DeriveSharedKey(
+			ctx, cfg.AuctioneerKey, &keyDesc.KeyLocator,
+		)
+		if err != nil {
+			return nil, err
+		}
+		traderKeys = append(traderKeys, &traderKeyCandidate{
+			keyDesc: keyDesc,
+			secret:  secret,
+		})
+	}
+
+	log.Infof("Searching %d possible account outputs for %d trader keys, "+
+		"%d batch keys and expiries in [%d, %d]", len(candidates),
+		cfg.NumKeys, cfg.BatchKeyWindow, minExpiry, maxExpiry)
+
+	fundedAccounts, err := searchFundingOutputs(
+		ctx, cfg, candidates, traderKeys, minExpiry, maxExpiry,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Now follow each account to its current output.
+	var accounts []*Account
+	for _, acct := range fundedAccounts {
+		err := followAccountSpends(ctx, cfg, acct)
+		if err != nil {
+			traderKey := acct.TraderKey.PubKey.SerializeCompressed()
+			return nil, fmt.Errorf("unable to follow account "+
+				"%x: %v", traderKey, err)
+		}
+		if acct.State == StateClosed {
+			log.Infof("Account %x was closed on-chain",
+				acct.TraderKey.PubKey.SerializeCompressed())
+			continue
+		}
+		accounts = append(accounts, acct)
+	}
+
+	return accounts, nil
+}
+
+// findCandidateOutputs returns all P2WSH outputs of the wallet's transactions
+// that are large enough to be an account output, keyed by their script.
+func findCandidateOutputs(ctx context.Context,
+	txSource TxSource) (map[string][]candidateOutput, error) {
+
+	txs, err := txSource.ListTransactions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[string][]candidateOutput)
+	for _, tx := range txs {
+		txHash := tx.TxHash()
+		for idx, txOut := range tx.TxOut {
+			value := btcutil.Amount(txOut.Value)
+			isP2WSH := txscript.IsPayToWitnessScriptHash(
+				txOut.PkScript,
+			)
+			if value < MinAccountValue || !isP2WSH {
+				continue
+			}
+
+			script := string(txOut.PkScript)
+			candidates[script] = append(
+				candidates[script], candidateOutput{
+					outPoint: wire.OutPoint{
+						Hash:  txHash,
+						Index: uint32(idx),
+					},
+					value: value,
+				},
+			)
+		}
+	}
+
+	return candidates, nil
+}
+
+// searchFundingOutputs tries all combinations of trader keys, batch keys and
+// expiries to find the funding outputs among the candidates. The search is
+// spread over all available CPUs, one trader key at a time.
+func searchFundingOutputs(ctx context.Context, cfg *ChainRecoveryConfig,
+	candidates map[string][]candidateOutput,
+	traderKeys []*traderKeyCandidate, minExpiry,
+	maxExpiry uint32) ([]*Account, error) {
+
+	// All batch keys are the same for every trader key, so we only
+	// compute them once.
+	batchKeys := make([]*btcec.PublicKey, cfg.BatchKeyWindow)
+	batchKey := cfg.InitialBatchKey
+	for i := range batchKeys {
+		batchKeys[i] = batchKey
+		batchKey = clmscript.IncrementKey(batchKey)
+	}
+
+	var (
+		mu       sync.Mutex
+		accounts []*Account
+		keyChan  = make(chan *traderKeyCandidate)
+	)
+	eg, ctx := errgroup.WithContext(ctx)
+	for i := 0; i < runtime.NumCPU(); i++ {
+		eg.Go(func() error {
+			for traderKey := range keyChan {
+				acct := matchTraderKey(
+					ctx, cfg, candidates, traderKey,
+					batchKeys, minExpiry, maxExpiry,
+				)
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if acct == nil {
+					continue
+				}
+
+				mu.Lock()
+				accounts = append(accounts, acct)
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+
+	eg.Go(func() error {
+		defer close(keyChan)
+		for _, traderKey := range traderKeys {
+			select {
+			case keyChan <- traderKey:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// matchTraderKey tries all batch keys and expiries for a single trader key and
+// returns the account of the first funding output found or nil if there is
+// none. Each trader key can only belong to one account.
+func matchTraderKey(ctx context.Context, cfg *ChainRecoveryConfig,
+	candidates map[string][]candidateOutput, traderKey *traderKeyCandidate,
+	batchKeys []*btcec.PublicKey, minExpiry, maxExpiry uint32) *Account {
+
+	for _, batchKey := range batchKeys {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		template := clmscript.NewAccountScriptTemplate(
+			traderKey.keyDesc.PubKey, cfg.AuctioneerKey, batchKey,
+			traderKey.secret,
+		)
+		expiry, output, ok := matchExpiry(
+			template, candidates, minExpiry, maxExpiry,
+		)
+		if !ok {
+			continue
+		}
+
+		log.Infof("Found funding output %v of account %x",
+			output.outPoint,
+			traderKey.keyDesc.PubKey.SerializeCompressed())
+
+		return &Account{
+			Value:         output.value,
+			Expiry:        expiry,
+			TraderKey:     traderKey.keyDesc,
+			AuctioneerKey: cfg.AuctioneerKey,
+			BatchKey:      batchKey,
+			Secret:        traderKey.secret,
+			State:         StateOpen,
+			HeightHint:    cfg.HeightHint,
+			OutPoint:      output.outPoint,
+		}
+	}
+
+	return nil
+}
+
+// matchExpiry tries all expiries in the given inclusive range with the script
+// template and returns the first candidate output that matches.
+func matchExpiry(template *clmscript.AccountScriptTemplate,
+	candidates map[string][]candidateOutput, minExpiry,
+	maxExpiry uint32) (uint32, *candidateOutput, bool) {
+
+	for expiry := minExpiry; ; expiry++ {
+		outputs, ok := candidates[string(template.Script(expiry))]
+		if ok {
+			return expiry, &outputs[0], true
+		}
+
+		// Checking the end of the range here instead of in the loop
+		// condition prevents an endless loop if the range ends at the
+		// maximum value.
+		if expiry >= maxExpiry {
+			return 0, nil, false
+		}
+	}
+}
+
+// followAccountSpends follows the given account through all spends of its
+// output until the current, unspent output is found. The account is updated in
+// place. If the account was closed, its state is set to StateClosed.
+func followAccountSpends(ctx context.Context, cfg *ChainRecoveryConfig,
+	acct *Account) error {
+
+	for {
+		pkScript, err := clmscript.AccountScript(
+			acct.Expiry, acct.TraderKey.PubKey, acct.AuctioneerKey,
+			acct.BatchKey, acct.Secret,
+		)
+		if err != nil {
+			return err
+		}
+
+		spendTx, spendHeight, err := waitForSpend(
+			ctx, cfg, acct.OutPoint, pkScript, acct.HeightHint,
+		)
+		switch {
+		// The output is still unspent, so this is the current state of
+		// the account.
+		case err == errNoSpend:
+			acct.State = StateOpen
+			if cfg.BestHeight >= acct.Expiry {
+				acct.State = StateExpired
+			}
+			return nil
+
+		case err != nil:
+			return err
+		}
+
+		// If the account was spent through the multi-sig path, it was
+		// either modified, participated in a batch or was closed. If it
+		// was recreated, the output uses the next batch key.
+		nextBatchKey := clmscript.IncrementKey(acct.BatchKey)
+		nextScript, err := clmscript.AccountScript(
+			acct.Expiry, acct.TraderKey.PubKey, acct.AuctioneerKey,
+			nextBatchKey, acct.Secret,
+		)
+		if err != nil {
+			return err
+		}
+		idx, ok := clmscript.LocateOutputScript(spendTx, nextScript)
+		if !ok {
+			acct.State = StateClosed
+			acct.CloseTx = spendTx
+			return nil
+		}
+
+		log.Debugf("Account %x was recreated in transaction %v",
+			acct.TraderKey.PubKey.SerializeCompressed(),
+			spendTx.TxHash())
+
+		acct.BatchKey = nextBatchKey
+		acct.Value = btcutil.Amount(spendTx.TxOut[idx].Value)
+		acct.OutPoint = wire.OutPoint{
+			Hash:  spendTx.TxHash(),
+			Index: idx,
+		}
+		acct.HeightHint = spendHeight
+	}
+}
+
+// waitForSpend waits for the spend of the given output. If none is found within
+// the configured timeout, errNoSpend is returned. If the given context is
+// canceled before that, its error is returned instead.
+func waitForSpend(ctx context.Context, cfg *ChainRecoveryConfig,
+	outPoint wire.OutPoint, pkScript []byte,
+	heightHint uint32) (*wire.MsgTx, uint32, error) {
+
+	spendCtx, cancel := context.WithTimeout(ctx, cfg.SpendTimeout)
+	defer cancel()
+
+	spendChan, errChan, err := cfg.ChainNotifier.RegisterSpendNtfn(
+		spendCtx, &outPoint, pkScript, int32(heightHint),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	select {
+	case spend := <-spendChan:
+		return spend.SpendingTx, uint32(spend.SpendingHeight), nil
+
+	case err := <-errChan:
+		return nil, 0, err
+
+	case <-spendCtx.Done():
+		// Only the timeout of this call means that there is no spend,
+		// a canceled scan must not be mistaken for an unspent output.
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+		return nil, 0, errNoSpend
+	}
+}
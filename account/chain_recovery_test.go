@@ -0,0 +1,189 @@
+package account
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightninglabs/llm/clmscript"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+// TestRecoverAccountsFromChain makes sure an account is found in the wallet's
+// transactions and followed through its spends to its current output.
+func TestRecoverAccountsFromChain(t *testing.T) {
+	t.Parallel()
+
+	const (
+		bestHeight = 1000
+		heightHint = 500
+		expiry     = 2000
+	)
+
+	// The account was funded while the auctioneer was already at its
+	// second batch key.
+	fundingBatchKey := clmscript.IncrementKey(testBatchKey)
+	fundingScript, err := clmscript.AccountScript(
+		expiry, testTraderKey, testAuctioneerKey, fundingBatchKey,
+		sharedSecret,
+	)
+	if err != nil {
+		t.Fatalf("unable to create account script: %v", err)
+	}
+	fundingTx := &wire.MsgTx{
+		Version: 2,
+		TxOut: []*wire.TxOut{
+			{Value: 1000, PkScript: []byte{0x00, 0x14}},
+			{
+				Value:    btcutil.SatoshiPerBitcoin,
+				PkScript: fundingScript,
+			},
+		},
+	}
+
+	// It then participated in a batch which recreated it with the next
+	// batch key.
+	batchKey := clmscript.IncrementKey(fundingBatchKey)
+	batchScript, err := clmscript.AccountScript(
+		expiry, testTraderKey, testAuctioneerKey, batchKey,
+		sharedSecret,
+	)
+	if err != nil {
+		t.Fatalf("unable to create account script: %v", err)
+	}
+	batchTx := &wire.MsgTx{
+		Version: 2,
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{
+				Hash:  fundingTx.TxHash(),
+				Index: 1,
+			},
+		}},
+		TxOut: []*wire.TxOut{
+			{
+				Value:    btcutil.SatoshiPerBitcoin / 2,
+				PkScript: batchScript,
+			},
+		},
+	}
+
+	// A transaction that spends the account without recreating it closes
+	// the account.
+	closeTx := &wire.MsgTx{
+		Version: 2,
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{
+				Hash:  batchTx.TxHash(),
+				Index: 0,
+			},
+		}},
+		TxOut: []*wire.TxOut{{Value: 1000, PkScript: []byte{0x00}}},
+	}
+
+	testCases := []struct {
+		name     string
+		spends   []*wire.MsgTx
+		expected []*Account
+	}{{
+		name:   "account recreated in batch",
+		spends: []*wire.MsgTx{batchTx},
+		expected: []*Account{{
+			Value:         btcutil.SatoshiPerBitcoin / 2,
+			Expiry:        expiry,
+			TraderKey:     testTraderKeyDesc,
+			AuctioneerKey: testAuctioneerKey,
+			BatchKey:      batchKey,
+			Secret:        sharedSecret,
+			State:         StateOpen,
+			HeightHint:    bestHeight - 10,
+			OutPoint: wire.OutPoint{
+				Hash:  batchTx.TxHash(),
+				Index: 0,
+			},
+		}},
+	}, {
+		name:     "account closed",
+		spends:   []*wire.MsgTx{batchTx, closeTx},
+		expected: nil,
+	}}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			wallet := newMockWallet()
+			wallet.addTx(fundingTx)
+			notifier := newMockChainNotifier()
+
+			// Deliver all spends one after the other. The last
+			// output is never spent so its spend registration
+			// times out.
+			go func() {
+				for _, tx := range testCase.spends {
+					spend := &chainntnfs.SpendDetail{
+						SpendingTx:     tx,
+						SpendingHeight: bestHeight - 10,
+					}
+					notifier.spendChan <- spend
+				}
+			}()
+
+			accounts, err := RecoverAccountsFromChain(
+				context.Background(), &ChainRecoveryConfig{
+					Wallet:          wallet,
+					Signer:          wallet,
+					ChainNotifier:   notifier,
+					TxSource:        wallet,
+					AuctioneerKey:   testAuctioneerKey,
+					InitialBatchKey: testBatchKey,
+					NumKeys:         1,
+					BatchKeyWindow:  3,
+					HeightHint:      heightHint,
+					BestHeight:      bestHeight,
+					SpendTimeout:    100 * time.Millisecond,
+				},
+			)
+			if err != nil {
+				t.Fatalf("unable to recover accounts: %v", err)
+			}
+
+			if !reflect.DeepEqual(accounts, testCase.expected) {
+				t.Fatalf("expected accounts: %v\ngot: %v",
+					spew.Sdump(testCase.expected),
+					spew.Sdump(accounts))
+			}
+		})
+	}
+}
+
+// TestWaitForSpendCanceled makes sure only the spend timeout is reported as an
+// unspent output and a canceled recovery returns the context's error instead.
+func TestWaitForSpendCanceled(t *testing.T) {
+	t.Parallel()
+
+	cfg := &ChainRecoveryConfig{
+		ChainNotifier: newMockChainNotifier(),
+		SpendTimeout:  100 * time.Millisecond,
+	}
+
+	_, _, err := waitForSpend(
+		context.Background(), cfg, wire.OutPoint{}, nil, 0,
+	)
+	if err != errNoSpend {
+		t.Fatalf("expected errNoSpend after timeout, got %v", err)
+	}
+
+	cfg.SpendTimeout = time.Minute
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err = waitForSpend(ctx, cfg, wire.OutPoint{}, nil, 0)
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
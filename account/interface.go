@@ -151,6 +151,14 @@ type Account struct {
 	//	- StatePendingClosed
 	//	- StateClosed
 	CloseTx *wire.MsgTx
+
+	// Label is an optional user-defined name of the account. It's only
+	// known to the trader and never sent to the auctioneer.
+	Label string
+
+	// Tags is an optional set of user-defined key/value pairs attached to
+	// the account. Just like the label, they are only known to the trader.
+	Tags map[string]string
 }
 
 // Output returns the current on-chain output associated with the account.
@@ -207,6 +215,8 @@ func (a *Account) Copy(modifiers ...Modifier) *Account {
 		State:      a.State,
 		HeightHint: a.HeightHint,
 		OutPoint:   a.OutPoint,
+		Label:      a.Label,
+		Tags:       CopyTags(a.Tags),
 	}
 
 	if a.CloseTx != nil {
@@ -268,8 +278,66 @@ func CloseTxModifier(tx *wire.MsgTx) Modifier {
 	}
 }
 
+// LabelModifier is a functional option that modifies the label of an account.
+func LabelModifier(label string) Modifier {
+	return func(account *Account) {
+		account.Label = label
+	}
+}
+
+// TagsModifier is a functional option that sets the given tags of an account
+// and removes the tags with the given keys.
+func TagsModifier(setTags map[string]string, removeTags []string) Modifier {
+	return func(account *Account) {
+		account.Tags = UpdateTags(account.Tags, setTags, removeTags)
+	}
+}
+
+// CopyTags returns a copy of the given tags. A nil map is returned if there are
+// no tags.
+func CopyTags(tags map[string]string) map[string]string {
+	return UpdateTags(tags, nil, nil)
+}
+
+// UpdateTags returns a copy of the given tags with the tags in setTags added or
+// overwritten and all tags with a key in removeTags removed. A nil map is
+// returned if no tags remain.
+func UpdateTags(tags, setTags map[string]string,
+	removeTags []string) map[string]string {
+
+	result := make(map[string]string, len(tags)+len(setTags))
+	for key, value := range tags {
+		result[key] = value
+	}
+	for key, value := range setTags {
+		result[key] = value
+	}
+	for _, key := range removeTags {
+		delete(result, key)
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// MatchesTags returns true if the given tags contain all of the tags in filter
+// with the same value. An empty filter value matches any value of the key.
+func MatchesTags(tags, filter map[string]string) bool {
+	for key, value := range filter {
+		tagValue, ok := tags[key]
+		if !ok || (value != "" && tagValue != value) {
+			return false
+		}
+	}
+	return true
+}
+
 // Store is responsible for storing and retrieving account information reliably.
 type Store interface {
+	RecoveryStore
+
 	// AddAccount adds a record for the account to the database.
 	AddAccount(*Account) error
 
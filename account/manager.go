@@ -206,9 +206,11 @@ func (m *Manager) Stop() {
 }
 
 // InitAccount handles a request to create a new account with the provided
-// parameters.
+// parameters. The given modifiers are applied to the account before it is
+// persisted, which allows setting optional fields like its label.
 func (m *Manager) InitAccount(ctx context.Context, value btcutil.Amount,
-	expiry uint32, bestHeight uint32) (*Account, error) {
+	expiry uint32, bestHeight uint32, modifiers ...Modifier) (*Account,
+	error) {
 
 	// First, make sure we have valid parameters to create the account.
 	if err := validateAccountParams(value, expiry, bestHeight); err != nil {
@@ -256,6 +258,9 @@ func (m *Manager) InitAccount(ctx context.Context, value btcutil.Amount,
 		State:         StateInitiated,
 		HeightHint:    bestHeight,
 	}
+	for _, modifier := range modifiers {
+		modifier(account)
+	}
 	if err := m.cfg.Store.AddAccount(account); err != nil {
 		return nil, err
 	}
@@ -967,13 +972,13 @@ func (m *Manager) RecoverAccount(ctx context.Context, account *Account) error {
 
 	// An account recovered with the help of the auctioneer has two missing
 	// values: The trader key locator index and the shared secret. Both of
-	// these values can be restored by going through a list of keys up to
-	// a maximum number.
+	// these values can be restored by looking up the key in the key index
+	// cache or going through a list of keys up to a maximum number.
 	//
 	// TODO(guggero): Remove this once the Signer.DeriveSharedKey RPC also
 	// accepts KeyDescriptors with only the pubkey and family set.
 	traderKeyIndex, err := findTraderKeyIndex(
-		ctx, m.cfg.Wallet, account.TraderKey.PubKey,
+		ctx, m.cfg.Wallet, m.cfg.Store, account.TraderKey.PubKey,
 	)
 	if err != nil {
 		return fmt.Errorf("could not find trader key index: %v", err)
@@ -1006,6 +1011,70 @@ func (m *Manager) RecoverAccount(ctx context.Context, account *Account) error {
 	return m.resumeAccount(ctx, account, false)
 }
 
+// RestoreAccount re-introduces an account that was read from a static account
+// backup into the database and starts watching it on-chain. Because the backup
+// doesn't know about the latest state of an account, the account is resumed in
+// a state that doesn't require the help of the auctioneer: Accounts that are
+// past their expiry are marked as expired so they can be swept through the
+// expiration path, all others are watched for their spend or confirmation.
+func (m *Manager) RestoreAccount(ctx context.Context, account *Account,
+	bestHeight uint32) error {
+
+	// Make sure the backup actually belongs to the connected lnd node by
+	// deriving the trader key and shared secret again.
+	keyDesc, err := m.cfg.Wallet.DeriveKey(
+		ctx, &account.TraderKey.KeyLocator,
+	)
+	if err != nil {
+		return err
+	}
+	if !keyDesc.PubKey.IsEqual(account.TraderKey.PubKey) {
+		return fmt.Errorf("trader key %x can't be derived by the "+
+			"connected lnd node",
+			account.TraderKey.PubKey.SerializeCompressed())
+	}
+	secret, err := m.cfg.Signer.DeriveSharedKey(
+		ctx, account.AuctioneerKey, &account.TraderKey.KeyLocator,
+	)
+	if err != nil {
+		return err
+	}
+	if secret != account.Secret {
+		return fmt.Errorf("shared secret of account %x doesn't match",
+			account.TraderKey.PubKey.SerializeCompressed())
+	}
+
+	switch account.State {
+	// We don't know whether the latest transaction of the account ever
+	// confirmed, so we wait for its confirmation without re-publishing it
+	// or notifying the auctioneer.
+	case StatePendingOpen, StatePendingUpdate:
+		account.State = StatePendingUpdate
+
+	// The backup doesn't contain the closing transaction of an account, so
+	// we wait for the spend of the account output again, which marks the
+	// account as closed.
+	case StateOpen, StateExpired, StatePendingClosed:
+		account.State = StateOpen
+		if bestHeight >= account.Expiry {
+			account.State = StateExpired
+		}
+
+	default:
+		return fmt.Errorf("account in state %v can't be restored",
+			account.State)
+	}
+
+	if err := m.cfg.Store.AddAccount(account); err != nil {
+		return err
+	}
+
+	log.Infof("Restored account %x in state %v from static backup",
+		account.TraderKey.PubKey.SerializeCompressed(), account.State)
+
+	return m.resumeAccount(ctx, account, false)
+}
+
 // determineWitnessType determines the appropriate witness type to use for the
 // spending transaction for an account based on whether it has expired or not.
 func determineWitnessType(account *Account, bestHeight uint32) witnessType {
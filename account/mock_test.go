@@ -49,11 +49,14 @@ type mockStore struct {
 	mu               sync.Mutex
 	accounts         map[[33]byte]Account
 	onFinalizedBatch func() error
+	keyIndexes       map[[33]byte]uint32
+	progress         *RecoveryProgress
 }
 
 func newMockStore() *mockStore {
 	return &mockStore{
-		accounts: make(map[[33]byte]Account),
+		accounts:   make(map[[33]byte]Account),
+		keyIndexes: make(map[[33]byte]uint32),
 	}
 }
 
@@ -150,6 +153,52 @@ func (s *mockStore) LockID() (wtxmgr.LockID, error) {
 	return wtxmgr.LockID{1}, nil
 }
 
+func (s *mockStore) AddAccountKeyIndexes(keys []*keychain.KeyDescriptor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		var traderKey [33]byte
+		copy(traderKey[:], key.PubKey.SerializeCompressed())
+		s.keyIndexes[traderKey] = key.Index
+	}
+	return nil
+}
+
+func (s *mockStore) AccountKeyIndex(key *btcec.PublicKey) (uint32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var traderKey [33]byte
+	copy(traderKey[:], key.SerializeCompressed())
+
+	index, ok := s.keyIndexes[traderKey]
+	if !ok {
+		return 0, ErrKeyIndexNotFound
+	}
+	return index, nil
+}
+
+func (s *mockStore) PutRecoveryProgress(progress *RecoveryProgress) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	progressCopy := *progress
+	s.progress = &progressCopy
+	return nil
+}
+
+func (s *mockStore) RecoveryProgress() (*RecoveryProgress, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.progress == nil {
+		return nil, ErrNoRecoveryProgress
+	}
+	progressCopy := *s.progress
+	return &progressCopy, nil
+}
+
 type mockAuctioneer struct {
 	Auctioneer
 
@@ -236,6 +285,15 @@ func (w *mockWallet) DeriveNextKey(ctx context.Context,
 	return testTraderKeyDesc, nil
 }
 
+func (w *mockWallet) DeriveKey(ctx context.Context,
+	keyLocator *keychain.KeyLocator) (*keychain.KeyDescriptor, error) {
+
+	return &keychain.KeyDescriptor{
+		KeyLocator: *keyLocator,
+		PubKey:     testTraderKey,
+	}, nil
+}
+
 func (w *mockWallet) DeriveSharedKey(ctx context.Context,
 	ephemeralKey *btcec.PublicKey,
 	keyLocator *keychain.KeyLocator) ([32]byte, error) {
@@ -2,6 +2,7 @@ package account
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/btcsuite/btcd/btcec"
@@ -12,48 +13,224 @@ import (
 
 var (
 	// DefaultAccountKeyWindow is the number of account keys that are
-	// derived to be checked on recovery. This is the absolute maximum
-	// number of accounts that can ever be restored. But the trader doesn't
-	// necessarily make as many requests on recovery, if no accounts are
-	// found for a certain number of tries.
+	// derived to find the index of a trader key that isn't in the key index
+	// cache yet.
 	DefaultAccountKeyWindow uint32 = 500
+
+	// DefaultRecoveryGap is the default number of successive account keys
+	// that must be unknown to the auctioneer before the recovery stops
+	// looking for further accounts.
+	DefaultRecoveryGap uint32 = 50
+
+	// ErrKeyIndexNotFound is returned if the derivation index of an account
+	// key isn't in the key index cache.
+	ErrKeyIndexNotFound = errors.New("account key index not found")
+
+	// ErrNoRecoveryProgress is returned if there is no stored progress of
+	// a previous recovery that could be resumed.
+	ErrNoRecoveryProgress = errors.New("no recovery progress found")
 )
 
-// GenerateRecoveryKeys generates a list of key descriptors for all possible
-// keys that could be used for trader accounts, up to a hard coded limit.
-func GenerateRecoveryKeys(ctx context.Context,
-	wallet lndclient.WalletKitClient) ([]*keychain.KeyDescriptor, error) {
+// RecoveryStore is responsible for storing the information needed to find
+// account keys quickly and to resume an interrupted recovery.
+type RecoveryStore interface {
+	// AddAccountKeyIndexes adds the derivation index of each of the given
+	// account keys to the key index cache.
+	AddAccountKeyIndexes([]*keychain.KeyDescriptor) error
 
-	acctKeys := make([]*keychain.KeyDescriptor, DefaultAccountKeyWindow)
-	for i := uint32(0); i < DefaultAccountKeyWindow; i++ {
-		key, err := wallet.DeriveKey(ctx, &keychain.KeyLocator{
-			Family: clmscript.AccountKeyFamily,
-			Index:  i,
-		})
+	// AccountKeyIndex looks up the derivation index of the given account
+	// key in the key index cache. If it isn't found, ErrKeyIndexNotFound
+	// is returned.
+	AccountKeyIndex(*btcec.PublicKey) (uint32, error)
+
+	// PutRecoveryProgress stores the progress of the current recovery,
+	// replacing any previously stored progress.
+	PutRecoveryProgress(*RecoveryProgress) error
+
+	// RecoveryProgress returns the stored progress of the last recovery.
+	// If there is none, ErrNoRecoveryProgress is returned.
+	RecoveryProgress() (*RecoveryProgress, error)
+}
+
+// RecoveryProgress describes how far an account recovery has progressed. It is
+// stored after each key so an interrupted recovery can be resumed.
+type RecoveryProgress struct {
+	// StartIndex is the first account key index that was checked.
+	StartIndex uint32
+
+	// NextIndex is the account key index that is checked next.
+	NextIndex uint32
+
+	// Gap is the number of successive unused account keys after which the
+	// recovery stops.
+	Gap uint32
+
+	// NumUnused is the number of successive account keys checked so far
+	// that were unknown to the auctioneer.
+	NumUnused uint32
+
+	// NumRecovered is the number of accounts recovered so far.
+	NumRecovered uint32
+
+	// Complete is true if the gap limit was reached and the recovery is
+	// finished.
+	Complete bool
+}
+
+// RecoveryConfig contains all dependencies of a gap limit based account
+// recovery.
+type RecoveryConfig struct {
+	// Wallet is used to derive the account keys.
+	Wallet lndclient.WalletKitClient
+
+	// Store is used to cache key indexes, persist the recovery progress
+	// and to skip accounts that were already recovered.
+	Store Store
+
+	// FetchAccount asks the recovery source for the account of the given
+	// key. If there is no account for the key, nil is returned.
+	FetchAccount func(context.Context, *keychain.KeyDescriptor) (*Account,
+		error)
+
+	// RecoverAccount stores a fetched account and starts watching it.
+	RecoverAccount func(context.Context, *Account) error
+}
+
+// NewRecoveryProgress creates the progress of a new recovery that starts at the
+// given key index. If gap is zero, DefaultRecoveryGap is used.
+func NewRecoveryProgress(startIndex, gap uint32) *RecoveryProgress {
+	if gap == 0 {
+		gap = DefaultRecoveryGap
+	}
+
+	return &RecoveryProgress{
+		StartIndex: startIndex,
+		NextIndex:  startIndex,
+		Gap:        gap,
+	}
+}
+
+// RecoverAccounts derives account keys one by one, starting at the next index
+// of the given progress, and recovers the accounts of all keys known to the
+// recovery source. Because the trader derives a new account key for each
+// attempt of opening an account, there can be "holes" in the list of keys
+// that are actually used. For example if there is insufficient balance in lnd,
+// a key gets "used up" but no account is ever created with it. That's why the
+// recovery only stops once the configured gap of successive unused keys is
+// reached. Each recovered account is stored right away and the progress is
+// persisted after each key, so an interrupted recovery can be resumed with the
+// stored progress.
+func RecoverAccounts(ctx context.Context, cfg *RecoveryConfig,
+	progress *RecoveryProgress) error {
+
+	for !progress.Complete {
+		if progress.NumUnused >= progress.Gap {
+			progress.Complete = true
+			return cfg.Store.PutRecoveryProgress(progress)
+		}
+
+		keyDesc, err := deriveAccountKey(
+			ctx, cfg.Wallet, progress.NextIndex,
+		)
+		if err != nil {
+			return err
+		}
+		err = cfg.Store.AddAccountKeyIndexes(
+			[]*keychain.KeyDescriptor{keyDesc},
+		)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		acctKeys[i] = key
+		found, err := recoverAccountKey(ctx, cfg, keyDesc)
+		if err != nil {
+			return fmt.Errorf("could not recover account %x: %v",
+				keyDesc.PubKey.SerializeCompressed(), err)
+		}
+
+		if found {
+			progress.NumUnused = 0
+			progress.NumRecovered++
+		} else {
+			progress.NumUnused++
+		}
+		progress.NextIndex++
+
+		if err := cfg.Store.PutRecoveryProgress(progress); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recoverAccountKey recovers the account of a single key if there is one. If
+// the account was already recovered before an interruption, it is not fetched
+// again. The returned boolean indicates whether the key is used by an account.
+func recoverAccountKey(ctx context.Context, cfg *RecoveryConfig,
+	keyDesc *keychain.KeyDescriptor) (bool, error) {
+
+	_, err := cfg.Store.Account(keyDesc.PubKey)
+	if err == nil {
+		log.Debugf("Account %x already recovered",
+			keyDesc.PubKey.SerializeCompressed())
+		return true, nil
+	}
+
+	acct, err := cfg.FetchAccount(ctx, keyDesc)
+	if err != nil {
+		return false, err
+	}
+	if acct == nil {
+		return false, nil
 	}
-	return acctKeys, nil
+
+	if err := cfg.RecoverAccount(ctx, acct); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// deriveAccountKey derives the account key with the given index.
+func deriveAccountKey(ctx context.Context, wallet lndclient.WalletKitClient,
+	index uint32) (*keychain.KeyDescriptor, error) {
+
+	return wallet.DeriveKey(ctx, &keychain.KeyLocator{
+		Family: clmscript.AccountKeyFamily,
+		Index:  index,
+	})
 }
 
 // findTraderKeyIndex tries to find the derivation index of a trader's account
-// public key within the account family. Only DefaultAccountKeyWindow number of
-// keys will be tried. If the index wasn't found up to that number, an error is
-// returned.
+// public key within the account family. The key index cache is consulted first.
+// If the key isn't cached, only DefaultAccountKeyWindow number of keys will be
+// tried and added to the cache. If the index wasn't found up to that number, an
+// error is returned.
 func findTraderKeyIndex(ctx context.Context, wallet lndclient.WalletKitClient,
-	traderKey *btcec.PublicKey) (uint32, error) {
+	store RecoveryStore, traderKey *btcec.PublicKey) (uint32, error) {
+
+	index, err := store.AccountKeyIndex(traderKey)
+	switch {
+	case err == nil:
+		return index, nil
 
+	case err != ErrKeyIndexNotFound:
+		return 0, err
+	}
+
+	keys := make([]*keychain.KeyDescriptor, 0, DefaultAccountKeyWindow)
+	defer func() {
+		if err := store.AddAccountKeyIndexes(keys); err != nil {
+			log.Errorf("Unable to cache account key indexes: %v",
+				err)
+		}
+	}()
 	for i := uint32(0); i < DefaultAccountKeyWindow; i++ {
-		desc, err := wallet.DeriveKey(ctx, &keychain.KeyLocator{
-			Family: clmscript.AccountKeyFamily,
-			Index:  i,
-		})
+		desc, err := deriveAccountKey(ctx, wallet, i)
 		if err != nil {
 			return 0, err
 		}
+		keys = append(keys, desc)
 
 		// We've found what we're looking for.
 		if desc.PubKey.IsEqual(traderKey) {
@@ -62,8 +239,8 @@ func findTraderKeyIndex(ctx context.Context, wallet lndclient.WalletKitClient,
 	}
 
 	// Should never happen unless the wrong lnd instance is connected or
-	// someone has more than DefaultAccountKeyWindow accounts in which case
-	// this constant probably needs to be increased.
+	// someone has more than DefaultAccountKeyWindow accounts that weren't
+	// recovered through RecoverAccounts which caches all keys it checks.
 	return 0, fmt.Errorf("account key unable to locate in %d keys, "+
 		"possibly the connected lnd instance doesn't use the correct "+
 		"seed", DefaultAccountKeyWindow)
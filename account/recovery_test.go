@@ -2,46 +2,145 @@ package account
 
 import (
 	"context"
+	"errors"
 	"math"
+	"reflect"
 	"testing"
 
+	"github.com/davecgh/go-spew/spew"
 	"github.com/lightninglabs/loop/test"
+	"github.com/lightningnetwork/lnd/keychain"
 )
 
-// TestGenerateRecoveryKeys tests that a certain number of keys can be created
-// for account recovery.
-func TestGenerateRecoveryKeys(t *testing.T) {
+// TestRecoverAccounts tests that account keys are derived until the gap limit
+// is reached and that an interrupted recovery can be resumed from its stored
+// progress without asking for the same keys again.
+func TestRecoverAccounts(t *testing.T) {
 	t.Parallel()
 
+	// The accounts have holes of unused keys in between that are smaller
+	// than the gap.
+	const gap = 4
+	usedIndexes := map[uint32]bool{0: true, 1: true, 5: true, 9: true}
+
+	ctx := context.Background()
 	lnd := test.NewMockLnd()
-	keys, err := GenerateRecoveryKeys(context.Background(), lnd.WalletKit)
+	store := newMockStore()
+
+	fetched := make(map[uint32]int)
+	failIndex := uint32(5)
+	cfg := &RecoveryConfig{
+		Wallet: lnd.WalletKit,
+		Store:  store,
+		FetchAccount: func(_ context.Context,
+			keyDesc *keychain.KeyDescriptor) (*Account, error) {
+
+			if keyDesc.Index == failIndex {
+				failIndex = math.MaxUint32
+				return nil, errors.New("connection lost")
+			}
+
+			fetched[keyDesc.Index]++
+			if !usedIndexes[keyDesc.Index] {
+				return nil, nil
+			}
+			return &Account{TraderKey: keyDesc}, nil
+		},
+		RecoverAccount: func(_ context.Context, acct *Account) error {
+			return store.AddAccount(acct)
+		},
+	}
+
+	// The first attempt fails half way through.
+	err := RecoverAccounts(ctx, cfg, NewRecoveryProgress(0, gap))
+	if err == nil {
+		t.Fatalf("expected recovery to fail")
+	}
+	progress, err := store.RecoveryProgress()
+	if err != nil {
+		t.Fatalf("unable to fetch progress: %v", err)
+	}
+	expectedProgress := &RecoveryProgress{
+		StartIndex:   0,
+		NextIndex:    5,
+		Gap:          gap,
+		NumUnused:    3,
+		NumRecovered: 2,
+	}
+	if !reflect.DeepEqual(progress, expectedProgress) {
+		t.Fatalf("expected progress: %v\ngot: %v",
+			spew.Sdump(expectedProgress), spew.Sdump(progress))
+	}
+
+	// Resuming must continue at the failed key and stop once the gap
+	// after the last account is reached.
+	if err := RecoverAccounts(ctx, cfg, progress); err != nil {
+		t.Fatalf("unable to resume recovery: %v", err)
+	}
+	expectedProgress = &RecoveryProgress{
+		StartIndex:   0,
+		NextIndex:    14,
+		Gap:          gap,
+		NumUnused:    gap,
+		NumRecovered: 4,
+		Complete:     true,
+	}
+	if !reflect.DeepEqual(progress, expectedProgress) {
+		t.Fatalf("expected progress: %v\ngot: %v",
+			spew.Sdump(expectedProgress), spew.Sdump(progress))
+	}
+	storedProgress, err := store.RecoveryProgress()
 	if err != nil {
-		t.Fatalf("could not generate keys: %v", err)
+		t.Fatalf("unable to fetch progress: %v", err)
+	}
+	if !reflect.DeepEqual(storedProgress, expectedProgress) {
+		t.Fatalf("expected stored progress: %v\ngot: %v",
+			spew.Sdump(expectedProgress),
+			spew.Sdump(storedProgress))
 	}
 
-	if len(keys) != int(DefaultAccountKeyWindow) {
-		t.Fatalf("unexpected number of keys, got %d wanted %d",
-			len(keys), DefaultAccountKeyWindow)
+	accounts, _ := store.Accounts()
+	if len(accounts) != len(usedIndexes) {
+		t.Fatalf("expected %d accounts, got %d", len(usedIndexes),
+			len(accounts))
+	}
+	for i := uint32(0); i < expectedProgress.NextIndex; i++ {
+		if fetched[i] != 1 {
+			t.Fatalf("expected key %d to be fetched once, got %d",
+				i, fetched[i])
+		}
+	}
+
+	// All keys that were checked must be in the key index cache.
+	if len(store.keyIndexes) != int(expectedProgress.NextIndex) {
+		t.Fatalf("expected %d cached key indexes, got %d",
+			expectedProgress.NextIndex, len(store.keyIndexes))
 	}
 }
 
 // TestFindTraderKeyIndex tests that a trader key index can be found by just
-// supplying the public key.
+// supplying the public key and that the derived keys are cached.
 func TestFindTraderKeyIndex(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
 	lnd := test.NewMockLnd()
-	keys, err := GenerateRecoveryKeys(context.Background(), lnd.WalletKit)
-	if err != nil {
-		t.Fatalf("could not generate keys: %v", err)
-	}
+	store := newMockStore()
 
 	// The mock doesn't support more than 256 private keys, but that's fine
 	// for our purposes.
-	for idx := 0; idx < math.MaxUint8; idx++ {
-		key := keys[idx]
+	keys := make([]*keychain.KeyDescriptor, math.MaxUint8)
+	for idx := range keys {
+		key, err := deriveAccountKey(ctx, lnd.WalletKit, uint32(idx))
+		if err != nil {
+			t.Fatalf("could not derive key: %v", err)
+		}
+		keys[idx] = key
+	}
+
+	for _, key := range keys {
 		keyIndex, err := findTraderKeyIndex(
-			context.Background(), lnd.WalletKit, key.PubKey,
+			ctx, lnd.WalletKit, store, key.PubKey,
 		)
 		if err != nil {
 			t.Fatalf("could not find trader key index: %v", err)
@@ -52,4 +151,18 @@ func TestFindTraderKeyIndex(t *testing.T) {
 				keyIndex, key.Index)
 		}
 	}
+
+	// Now that all keys are cached, no wallet is needed to find them.
+	for _, key := range keys {
+		keyIndex, err := findTraderKeyIndex(ctx, nil, store, key.PubKey)
+		if err != nil {
+			t.Fatalf("could not find cached trader key index: %v",
+				err)
+		}
+
+		if keyIndex != key.Index {
+			t.Fatalf("invalid key found, got %d wanted %d",
+				keyIndex, key.Index)
+		}
+	}
 }
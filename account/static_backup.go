@@ -0,0 +1,318 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/loop/lndclient"
+	"github.com/lightningnetwork/lnd/keychain"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	// StaticBackupKeyFamily is the key family used to derive the key the
+	// static account backup is encrypted with.
+	StaticBackupKeyFamily keychain.KeyFamily = 221
+
+	// staticBackupVersion is the current version of the serialization
+	// format of the static account backup.
+	staticBackupVersion uint8 = 0
+)
+
+var (
+	// staticBackupKeyLoc is the key locator of the base key the encryption
+	// key of the static account backup is derived from.
+	staticBackupKeyLoc = keychain.KeyLocator{
+		Family: StaticBackupKeyFamily,
+		Index:  0,
+	}
+
+	// byteOrder is the byte order used to serialize the static account
+	// backup.
+	byteOrder = binary.BigEndian
+
+	// ErrUnknownBackupVersion is returned if a static account backup was
+	// created with a newer, unknown serialization format.
+	ErrUnknownBackupVersion = errors.New("unknown static account backup " +
+		"version")
+)
+
+// StaticBackup maintains an encrypted file that contains everything needed to
+// watch and eventually sweep all accounts through their expiration path
+// without the help of the auctioneer or the trader database. Only the lnd node
+// the accounts were created with is able to decrypt the file.
+type StaticBackup struct {
+	path   string
+	wallet lndclient.WalletKitClient
+	signer lndclient.SignerClient
+
+	// mu guards access to the backup file and the encryption key.
+	mu            sync.Mutex
+	encryptionKey []byte
+}
+
+// NewStaticBackup creates a new static account backup that is written to the
+// given path.
+func NewStaticBackup(path string, wallet lndclient.WalletKitClient,
+	signer lndclient.SignerClient) *StaticBackup {
+
+	return &StaticBackup{
+		path:   path,
+		wallet: wallet,
+		signer: signer,
+	}
+}
+
+// Update replaces the content of the static account backup with the given
+// accounts. The new file is written to a temporary location first and then
+// atomically moved into place so a crash never leaves a corrupted backup.
+func (b *StaticBackup) Update(ctx context.Context, accounts []*Account) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.encryptionKey == nil {
+		key, err := deriveBackupKey(ctx, b.wallet, b.signer)
+		if err != nil {
+			return fmt.Errorf("unable to derive backup key: %v",
+				err)
+		}
+		b.encryptionKey = key
+	}
+
+	var plaintext bytes.Buffer
+	if err := serializeStaticBackup(&plaintext, accounts); err != nil {
+		return err
+	}
+	ciphertext, err := encryptBackup(plaintext.Bytes(), b.encryptionKey)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(b.path), 0700); err != nil {
+		return err
+	}
+	tempFile := b.path + ".tmp"
+	if err := ioutil.WriteFile(tempFile, ciphertext, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tempFile, b.path)
+}
+
+// ReadStaticBackup decrypts and deserializes a static account backup with the
+// help of the lnd node the accounts were created with.
+func ReadStaticBackup(ctx context.Context, backup []byte,
+	wallet lndclient.WalletKitClient,
+	signer lndclient.SignerClient) ([]*Account, error) {
+
+	key, err := deriveBackupKey(ctx, wallet, signer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive backup key: %v", err)
+	}
+	plaintext, err := decryptBackup(backup, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt backup, possibly "+
+			"the connected lnd instance doesn't use the correct "+
+			"seed: %v", err)
+	}
+
+	return deserializeStaticBackup(bytes.NewReader(plaintext))
+}
+
+// deriveBackupKey derives the key the static account backup is encrypted with.
+// We can't get the private key of our base key from lnd, so we use ECDH of the
+// base key with itself instead. Only the owner of the private key can compute
+// the result: key = SHA256(baseKey * basePubKey).
+func deriveBackupKey(ctx context.Context, wallet lndclient.WalletKitClient,
+	signer lndclient.SignerClient) ([]byte, error) {
+
+	keyLoc := staticBackupKeyLoc
+	baseKey, err := wallet.DeriveKey(ctx, &keyLoc)
+	if err != nil {
+		return nil, err
+	}
+	key, err := signer.DeriveSharedKey(ctx, baseKey.PubKey, &keyLoc)
+	if err != nil {
+		return nil, err
+	}
+
+	return key[:], nil
+}
+
+// encryptBackup encrypts the given plaintext with a 24-byte chachapoly AEAD
+// instance. The random nonce is prepended to the ciphertext and also used as
+// associated data.
+func encryptBackup(plaintext, key []byte) ([]byte, error) {
+	cipher, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	var nonce [chacha20poly1305.NonceSizeX]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+
+	ciphertext := cipher.Seal(nil, nonce[:], plaintext, nonce[:])
+	return append(nonce[:], ciphertext...), nil
+}
+
+// decryptBackup decrypts a backup that was encrypted with encryptBackup.
+func decryptBackup(packed, key []byte) ([]byte, error) {
+	if len(packed) < chacha20poly1305.NonceSizeX {
+		return nil, fmt.Errorf("backup size too small, must be at "+
+			"least %v bytes", chacha20poly1305.NonceSizeX)
+	}
+
+	cipher, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := packed[:chacha20poly1305.NonceSizeX]
+	ciphertext := packed[chacha20poly1305.NonceSizeX:]
+	return cipher.Open(nil, nonce, ciphertext, nonce)
+}
+
+// serializeStaticBackup writes the static part of all given accounts to the
+// writer. Accounts that were never funded or are already closed are skipped as
+// there's nothing to recover for them.
+func serializeStaticBackup(w io.Writer, accounts []*Account) error {
+	var backupAccounts []*Account
+	for _, a := range accounts {
+		if a.State == StateInitiated || a.State == StateClosed {
+			continue
+		}
+		backupAccounts = append(backupAccounts, a)
+	}
+
+	err := binary.Write(w, byteOrder, staticBackupVersion)
+	if err != nil {
+		return err
+	}
+	numAccounts := uint32(len(backupAccounts))
+	if err := binary.Write(w, byteOrder, numAccounts); err != nil {
+		return err
+	}
+
+	for _, a := range backupAccounts {
+		if err := serializeBackupAccount(w, a); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// serializeBackupAccount writes the static part of a single account to the
+// writer.
+func serializeBackupAccount(w io.Writer, a *Account) error {
+	fields := []interface{}{
+		uint32(a.TraderKey.Family), a.TraderKey.Index,
+		a.TraderKey.PubKey.SerializeCompressed(),
+		a.AuctioneerKey.SerializeCompressed(),
+		a.BatchKey.SerializeCompressed(), a.Secret, a.Expiry,
+		uint64(a.Value), a.HeightHint, a.OutPoint.Hash,
+		a.OutPoint.Index, uint8(a.State),
+	}
+	for _, field := range fields {
+		if err := binary.Write(w, byteOrder, field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deserializeStaticBackup reads all accounts from a static account backup.
+func deserializeStaticBackup(r io.Reader) ([]*Account, error) {
+	var version uint8
+	if err := binary.Read(r, byteOrder, &version); err != nil {
+		return nil, err
+	}
+	if version > staticBackupVersion {
+		return nil, ErrUnknownBackupVersion
+	}
+
+	var numAccounts uint32
+	if err := binary.Read(r, byteOrder, &numAccounts); err != nil {
+		return nil, err
+	}
+
+	var accounts []*Account
+	for i := uint32(0); i < numAccounts; i++ {
+		a, err := deserializeBackupAccount(r)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read account %d: %v",
+				i, err)
+		}
+		accounts = append(accounts, a)
+	}
+
+	return accounts, nil
+}
+
+// deserializeBackupAccount reads the static part of a single account.
+func deserializeBackupAccount(r io.Reader) (*Account, error) {
+	var (
+		family, index, expiry, heightHint, outputIndex uint32
+		traderKey, auctioneerKey, batchKey             [33]byte
+		secret                                         [32]byte
+		value                                          uint64
+		outPoint                                       wire.OutPoint
+		state                                          uint8
+	)
+	fields := []interface{}{
+		&family, &index, &traderKey, &auctioneerKey, &batchKey,
+		&secret, &expiry, &value, &heightHint, &outPoint.Hash,
+		&outputIndex, &state,
+	}
+	for _, field := range fields {
+		if err := binary.Read(r, byteOrder, field); err != nil {
+			return nil, err
+		}
+	}
+	outPoint.Index = outputIndex
+
+	traderPubKey, err := btcec.ParsePubKey(traderKey[:], btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	auctioneerPubKey, err := btcec.ParsePubKey(
+		auctioneerKey[:], btcec.S256(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	batchPubKey, err := btcec.ParsePubKey(batchKey[:], btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Account{
+		Value:  btcutil.Amount(value),
+		Expiry: expiry,
+		TraderKey: &keychain.KeyDescriptor{
+			KeyLocator: keychain.KeyLocator{
+				Family: keychain.KeyFamily(family),
+				Index:  index,
+			},
+			PubKey: traderPubKey,
+		},
+		AuctioneerKey: auctioneerPubKey,
+		BatchKey:      batchPubKey,
+		Secret:        secret,
+		State:         State(state),
+		HeightHint:    heightHint,
+		OutPoint:      outPoint,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package account
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestStaticBackup makes sure accounts written to the static account backup
+// can be read back and that a modified backup is rejected.
+func TestStaticBackup(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "static-backup")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	openAccount := &Account{
+		Value:         btcutil.SatoshiPerBitcoin,
+		Expiry:        1337,
+		TraderKey:     testTraderKeyDesc,
+		AuctioneerKey: testAuctioneerKey,
+		BatchKey:      testBatchKey,
+		Secret:        sharedSecret,
+		State:         StateOpen,
+		HeightHint:    1,
+		OutPoint:      wire.OutPoint{Index: 1},
+	}
+	initiatedAccount := openAccount.Copy(StateModifier(StateInitiated))
+	closedAccount := openAccount.Copy(StateModifier(StateClosed))
+
+	ctx := context.Background()
+	wallet := newMockWallet()
+	backupFile := filepath.Join(tempDir, "accounts.backup")
+	backup := NewStaticBackup(backupFile, wallet, wallet)
+	err = backup.Update(ctx, []*Account{
+		initiatedAccount, openAccount, closedAccount,
+	})
+	if err != nil {
+		t.Fatalf("unable to update backup: %v", err)
+	}
+
+	// Only the open account should be contained in the backup.
+	rawBackup, err := ioutil.ReadFile(backupFile)
+	if err != nil {
+		t.Fatalf("unable to read backup: %v", err)
+	}
+	accounts, err := ReadStaticBackup(ctx, rawBackup, wallet, wallet)
+	if err != nil {
+		t.Fatalf("unable to read backup: %v", err)
+	}
+	if len(accounts) != 1 {
+		t.Fatalf("expected 1 account, got %d", len(accounts))
+	}
+	if !reflect.DeepEqual(accounts[0], openAccount) {
+		t.Fatalf("expected account: %v\ngot: %v",
+			spew.Sdump(openAccount), spew.Sdump(accounts[0]))
+	}
+
+	// Any modification of the backup must be detected.
+	rawBackup[len(rawBackup)-1] ^= 0x01
+	_, err = ReadStaticBackup(ctx, rawBackup, wallet, wallet)
+	if err == nil {
+		t.Fatalf("expected modified backup to be rejected")
+	}
+}
+
+// TestRestoreAccount makes sure an account from the static account backup is
+// restored in a state that allows it to be swept without the auctioneer.
+func TestRestoreAccount(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	h.start()
+	defer h.stop()
+
+	const expiry = 1337
+	acct := &Account{
+		Value:         btcutil.SatoshiPerBitcoin,
+		Expiry:        expiry,
+		TraderKey:     testTraderKeyDesc,
+		AuctioneerKey: testAuctioneerKey,
+		BatchKey:      testBatchKey,
+		Secret:        sharedSecret,
+		State:         StateOpen,
+		HeightHint:    1,
+		OutPoint:      wire.OutPoint{Index: 1},
+	}
+
+	// A backup that doesn't belong to our lnd node must be rejected.
+	otherSecret := acct.Copy()
+	otherSecret.Secret = [32]byte{1}
+	err := h.manager.RestoreAccount(
+		context.Background(), otherSecret, expiry-1,
+	)
+	if err == nil {
+		t.Fatalf("expected account with wrong secret to be rejected")
+	}
+
+	// Restoring an account past its expiry should mark it as expired so
+	// it can be swept through the expiration path.
+	err = h.manager.RestoreAccount(context.Background(), acct, expiry)
+	if err != nil {
+		t.Fatalf("unable to restore account: %v", err)
+	}
+	h.assertAccountExists(acct.Copy(StateModifier(StateExpired)))
+	h.assertAccountNotSubscribed(acct.TraderKey.PubKey)
+}
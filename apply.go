@@ -0,0 +1,356 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lightninglabs/llm/clmrpc"
+	"github.com/lightninglabs/llm/order"
+)
+
+// orderKey identifies a managed order by its account and label.
+type orderKey struct {
+	traderKey [33]byte
+	label     string
+}
+
+// orderAction is a single step that is needed to get from the live orders to
+// the desired ones.
+type orderAction struct {
+	action clmrpc.OrderAction_ActionType
+	key    orderKey
+
+	// existing is the live order that is canceled or replaced. It's nil
+	// for new orders.
+	existing order.Order
+
+	// desired is the order that is submitted. It's nil for cancellations.
+	desired order.Order
+
+	description string
+}
+
+// ApplyOrders reconciles the active orders with the desired set of orders.
+// Orders are identified by their account and label. Missing orders are
+// submitted and orders whose parameters changed are replaced. Orders that
+// aren't desired are only canceled if their account is explicitly managed.
+func (s *rpcServer) ApplyOrders(ctx context.Context,
+	req *clmrpc.ApplyOrdersRequest) (*clmrpc.ApplyOrdersResponse, error) {
+
+	managed := make(map[[33]byte]struct{}, len(req.TraderKeys))
+	for _, rawKey := range req.TraderKeys {
+		var traderKey [33]byte
+		copy(traderKey[:], rawKey)
+		managed[traderKey] = struct{}{}
+	}
+
+	desired := make([]order.Order, 0, len(req.Asks)+len(req.Bids))
+	for _, ask := range req.Asks {
+		o, err := parseRPCAsk(ask)
+		if err != nil {
+			return nil, err
+		}
+		desired = append(desired, o)
+	}
+	for _, bid := range req.Bids {
+		o, err := parseRPCBid(bid)
+		if err != nil {
+			return nil, err
+		}
+		desired = append(desired, o)
+	}
+
+	// Make sure we don't act on orders the server already canceled or
+	// executed. A plan is only a preview, so the refreshed states are
+	// only stored if we actually act on them.
+	live, err := s.server.db.GetOrders()
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.syncOrderStates(ctx, live, !req.PlanOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	actions, err := planOrderActions(managed, desired, live)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &clmrpc.ApplyOrdersResponse{
+		Actions: make([]*clmrpc.OrderAction, 0, len(actions)),
+	}
+	for _, action := range actions {
+		rpcAction := &clmrpc.OrderAction{
+			Action:      action.action,
+			TraderKey:   action.key.traderKey[:],
+			Label:       action.key.label,
+			Description: action.description,
+		}
+		if action.existing != nil {
+			nonce := action.existing.Nonce()
+			rpcAction.OrderNonce = nonce[:]
+		}
+		resp.Actions = append(resp.Actions, rpcAction)
+
+		if req.PlanOnly {
+			continue
+		}
+
+		err := s.applyOrderAction(ctx, action)
+		if err != nil {
+			log.Errorf("Unable to %v: %v", action.description, err)
+			rpcAction.Error = err.Error()
+			continue
+		}
+		if action.desired != nil {
+			nonce := action.desired.Nonce()
+			rpcAction.NewOrderNonce = nonce[:]
+		}
+	}
+
+	return resp, nil
+}
+
+// applyOrderAction executes a single planned order action.
+func (s *rpcServer) applyOrderAction(ctx context.Context,
+	action *orderAction) error {
+
+	var (
+		invalidOrder *clmrpc.InvalidOrder
+		err          error
+	)
+	switch action.action {
+	case clmrpc.OrderAction_SUBMIT:
+		serverParams, err := s.prepareOrder(ctx, action.desired)
+		if err != nil {
+			return err
+		}
+		invalidOrder, err = s.sendOrder(
+			ctx, action.desired, serverParams,
+		)
+		if err != nil {
+			return err
+		}
+
+	case clmrpc.OrderAction_CANCEL:
+		return s.orderManager.CancelOrder(ctx, action.existing.Nonce())
+
+	case clmrpc.OrderAction_REPLACE:
+		invalidOrder, err = s.replaceOrder(
+			ctx, action.existing.Nonce(), action.desired,
+		)
+		if err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unknown action %v", action.action)
+	}
+
+	if invalidOrder != nil {
+		return fmt.Errorf("order rejected: %v", invalidOrder.FailString)
+	}
+	return nil
+}
+
+// planOrderActions compares the desired orders with the live orders and
+// returns the actions needed to reconcile them. Only live orders that have the
+// label of a desired order are touched, unless their account is managed. All
+// live orders of a managed account that aren't desired are canceled. All
+// cancellations come first so the balance they reserve is available again for
+// the orders that are submitted afterwards.
+func planOrderActions(managed map[[33]byte]struct{}, desired,
+	live []order.Order) ([]*orderAction, error) {
+
+	// Every desired order must be uniquely identifiable.
+	desiredByKey := make(map[orderKey]order.Order, len(desired))
+	for _, o := range desired {
+		key := orderKey{
+			traderKey: o.Details().AcctKey,
+			label:     o.Details().Label,
+		}
+		if key.label == "" {
+			return nil, fmt.Errorf("desired %v order of account "+
+				"%x has no label", o.Type(), key.traderKey[:])
+		}
+		if _, ok := desiredByKey[key]; ok {
+			return nil, fmt.Errorf("duplicate label %q for "+
+				"account %x", key.label, key.traderKey[:])
+		}
+		desiredByKey[key] = o
+	}
+
+	// Orders of accounts that aren't managed may have been placed manually,
+	// so they are left alone unless they carry the label of a desired
+	// order.
+	liveByKey := make(map[orderKey][]order.Order)
+	for _, o := range live {
+		kit := o.Details()
+		if kit.State.Archived() {
+			continue
+		}
+
+		key := orderKey{traderKey: kit.AcctKey, label: kit.Label}
+		_, isManaged := managed[kit.AcctKey]
+		_, isDesired := desiredByKey[key]
+		if !isManaged && !isDesired {
+			continue
+		}
+		liveByKey[key] = append(liveByKey[key], o)
+	}
+
+	var actions []*orderAction
+	cancel := func(key orderKey, o order.Order, reason string) {
+		actions = append(actions, &orderAction{
+			action:   clmrpc.OrderAction_CANCEL,
+			key:      key,
+			existing: o,
+			description: fmt.Sprintf("cancel %v order %v (%s)",
+				o.Type(), o.Nonce(), reason),
+		})
+	}
+
+	for key, o := range desiredByKey {
+		liveOrders := liveByKey[key]
+		delete(liveByKey, key)
+
+		// Only a single live order per label is kept, all others are
+		// canceled.
+		for i := 1; i < len(liveOrders); i++ {
+			cancel(key, liveOrders[i], "duplicate label")
+		}
+
+		switch {
+		case len(liveOrders) == 0:
+			actions = append(actions, &orderAction{
+				action:  clmrpc.OrderAction_SUBMIT,
+				key:     key,
+				desired: o,
+				description: fmt.Sprintf("submit %v order %q: "+
+					"%s", o.Type(), key.label,
+					describeOrder(o)),
+			})
+
+		// An order can't be replaced with one of a different type,
+		// so it's canceled and the new one is submitted.
+		case liveOrders[0].Type() != o.Type():
+			cancel(key, liveOrders[0], "type changed")
+			actions = append(actions, &orderAction{
+				action:  clmrpc.OrderAction_SUBMIT,
+				key:     key,
+				desired: o,
+				description: fmt.Sprintf("submit %v order %q: "+
+					"%s", o.Type(), key.label,
+					describeOrder(o)),
+			})
+
+		default:
+			changes := orderChanges(liveOrders[0], o)
+			if len(changes) == 0 {
+				continue
+			}
+			actions = append(actions, &orderAction{
+				action:   clmrpc.OrderAction_REPLACE,
+				key:      key,
+				existing: liveOrders[0],
+				desired:  o,
+				description: fmt.Sprintf("replace %v order %q "+
+					"(%v): %s", o.Type(), key.label,
+					liveOrders[0].Nonce(),
+					strings.Join(changes, ", ")),
+			})
+		}
+	}
+
+	// All remaining live orders belong to managed accounts and aren't
+	// desired anymore.
+	for key, liveOrders := range liveByKey {
+		for _, o := range liveOrders {
+			cancel(key, o, "not in order file")
+		}
+	}
+
+	// Make the plan deterministic and execute all cancellations first.
+	sort.Slice(actions, func(i, j int) bool {
+		a, b := actions[i], actions[j]
+		if a.action != b.action {
+			return actionPriority(a.action) <
+				actionPriority(b.action)
+		}
+		if cmp := bytes.Compare(
+			a.key.traderKey[:], b.key.traderKey[:],
+		); cmp != 0 {
+			return cmp < 0
+		}
+		return a.description < b.description
+	})
+
+	return actions, nil
+}
+
+// actionPriority returns the position of an action type in the plan.
+func actionPriority(action clmrpc.OrderAction_ActionType) int {
+	switch action {
+	case clmrpc.OrderAction_CANCEL:
+		return 0
+	case clmrpc.OrderAction_REPLACE:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// describeOrder returns a short human readable description of the order's
+// parameters.
+func describeOrder(o order.Order) string {
+	kit := o.Details()
+	return fmt.Sprintf("%v at %d ppm for %d blocks", kit.Amt,
+		kit.FixedRate, orderDuration(o))
+}
+
+// orderDuration returns the max duration of an ask or the min duration of a
+// bid.
+func orderDuration(o order.Order) uint32 {
+	switch o := o.(type) {
+	case *order.Ask:
+		return o.MaxDuration
+
+	case *order.Bid:
+		return o.MinDuration
+
+	default:
+		return 0
+	}
+}
+
+// orderChanges returns a description of every parameter that differs between
+// the existing and the desired order. Both orders must be of the same type.
+func orderChanges(existing, desired order.Order) []string {
+	var (
+		changes []string
+		oldKit  = existing.Details()
+		newKit  = desired.Details()
+	)
+	change := func(name string, oldValue, newValue interface{}) {
+		if oldValue != newValue {
+			changes = append(changes, fmt.Sprintf("%s %v -> %v",
+				name, oldValue, newValue))
+		}
+	}
+
+	change("amt", oldKit.Amt, newKit.Amt)
+	change("rate_fixed", oldKit.FixedRate, newKit.FixedRate)
+	change("duration_blocks", orderDuration(existing),
+		orderDuration(desired))
+	change("funding_fee_rate", oldKit.FundingFeeRate,
+		newKit.FundingFeeRate)
+	change("min_units_match", oldKit.MinUnitsMatch,
+		newKit.MinUnitsMatch)
+	change("all_or_none", oldKit.AllOrNone, newKit.AllOrNone)
+
+	return changes
+}
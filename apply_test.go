@@ -0,0 +1,319 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/llm/clmrpc"
+	"github.com/lightninglabs/llm/order"
+)
+
+// testOrder creates an order of the given account and type for the order
+// reconciliation tests.
+func testOrder(nonce byte, acct byte, orderType order.Type, label string,
+	amt btcutil.Amount, state order.State) order.Order {
+
+	kit := order.NewKit(order.Nonce{nonce})
+	kit.AcctKey = [33]byte{acct}
+	kit.Label = label
+	kit.Amt = amt
+	kit.Units = order.NewSupplyFromSats(amt)
+	kit.UnitsUnfulfilled = kit.Units
+	kit.FixedRate = 5
+	kit.State = state
+
+	if orderType == order.TypeAsk {
+		return &order.Ask{Kit: *kit, MaxDuration: 2016}
+	}
+	return &order.Bid{Kit: *kit, MinDuration: 144}
+}
+
+// expectedAction is an action the order reconciliation is expected to plan.
+type expectedAction struct {
+	action clmrpc.OrderAction_ActionType
+	label  string
+
+	// existing is the nonce of the live order that is canceled or
+	// replaced, if any.
+	existing byte
+}
+
+// TestPlanOrderActions makes sure the live orders are reconciled with the
+// desired orders using the minimal set of actions and that only orders of
+// managed accounts are canceled if they aren't desired.
+func TestPlanOrderActions(t *testing.T) {
+	t.Parallel()
+
+	const (
+		acctA = 0x0a
+		acctB = 0x0b
+	)
+	var (
+		ask       = order.TypeAsk
+		bid       = order.TypeBid
+		submitted = order.StateSubmitted
+	)
+
+	testCases := []struct {
+		name     string
+		managed  []byte
+		desired  []order.Order
+		live     []order.Order
+		expected []expectedAction
+		err      string
+	}{{
+		name: "submit new order",
+		desired: []order.Order{
+			testOrder(1, acctA, ask, "a", 200_000, submitted),
+		},
+		expected: []expectedAction{{
+			action: clmrpc.OrderAction_SUBMIT,
+			label:  "a",
+		}},
+	}, {
+		name: "unchanged order",
+		desired: []order.Order{
+			testOrder(1, acctA, ask, "a", 200_000, submitted),
+		},
+		live: []order.Order{
+			testOrder(2, acctA, ask, "a", 200_000, submitted),
+		},
+	}, {
+		name: "replace changed order",
+		desired: []order.Order{
+			testOrder(1, acctA, ask, "a", 300_000, submitted),
+		},
+		live: []order.Order{
+			testOrder(2, acctA, ask, "a", 200_000, submitted),
+		},
+		expected: []expectedAction{{
+			action:   clmrpc.OrderAction_REPLACE,
+			label:    "a",
+			existing: 2,
+		}},
+	}, {
+		name:    "cancel order that is no longer desired",
+		managed: []byte{acctA},
+		live: []order.Order{
+			testOrder(2, acctA, bid, "a", 200_000, submitted),
+			testOrder(3, acctB, bid, "b", 200_000, submitted),
+		},
+		expected: []expectedAction{{
+			action:   clmrpc.OrderAction_CANCEL,
+			label:    "a",
+			existing: 2,
+		}},
+	}, {
+		name: "cancel before submit on type change",
+		desired: []order.Order{
+			testOrder(1, acctA, ask, "a", 200_000, submitted),
+		},
+		live: []order.Order{
+			testOrder(2, acctA, bid, "a", 200_000, submitted),
+		},
+		expected: []expectedAction{{
+			action:   clmrpc.OrderAction_CANCEL,
+			label:    "a",
+			existing: 2,
+		}, {
+			action: clmrpc.OrderAction_SUBMIT,
+			label:  "a",
+		}},
+	}, {
+		name: "duplicate live labels",
+		desired: []order.Order{
+			testOrder(1, acctA, ask, "a", 200_000, submitted),
+		},
+		live: []order.Order{
+			testOrder(2, acctA, ask, "a", 200_000, submitted),
+			testOrder(3, acctA, ask, "a", 200_000, submitted),
+		},
+		expected: []expectedAction{{
+			action:   clmrpc.OrderAction_CANCEL,
+			label:    "a",
+			existing: 3,
+		}},
+	}, {
+		name: "duplicate desired labels",
+		desired: []order.Order{
+			testOrder(1, acctA, ask, "a", 200_000, submitted),
+			testOrder(2, acctA, bid, "a", 200_000, submitted),
+		},
+		err: "duplicate label",
+	}, {
+		name: "desired order without label",
+		desired: []order.Order{
+			testOrder(1, acctA, ask, "", 200_000, submitted),
+		},
+		err: "has no label",
+	}, {
+		name:    "unlabeled live order in managed account",
+		managed: []byte{acctA},
+		desired: []order.Order{
+			testOrder(1, acctA, ask, "a", 200_000, submitted),
+		},
+		live: []order.Order{
+			testOrder(2, acctA, ask, "a", 200_000, submitted),
+			testOrder(3, acctA, bid, "", 200_000, submitted),
+		},
+		expected: []expectedAction{{
+			action:   clmrpc.OrderAction_CANCEL,
+			label:    "",
+			existing: 3,
+		}},
+	}, {
+		name: "undesired orders of unmanaged account are kept",
+		desired: []order.Order{
+			testOrder(1, acctA, ask, "a", 300_000, submitted),
+		},
+		live: []order.Order{
+			testOrder(2, acctA, ask, "a", 200_000, submitted),
+			testOrder(3, acctA, bid, "", 200_000, submitted),
+			testOrder(4, acctA, bid, "b", 200_000, submitted),
+		},
+		expected: []expectedAction{{
+			action:   clmrpc.OrderAction_REPLACE,
+			label:    "a",
+			existing: 2,
+		}},
+	}, {
+		name: "archived live orders are ignored",
+		desired: []order.Order{
+			testOrder(1, acctA, ask, "a", 200_000, submitted),
+		},
+		live: []order.Order{
+			testOrder(
+				2, acctA, ask, "a", 200_000,
+				order.StateExecuted,
+			),
+			testOrder(
+				3, acctA, bid, "b", 200_000,
+				order.StateCanceled,
+			),
+		},
+		expected: []expectedAction{{
+			action: clmrpc.OrderAction_SUBMIT,
+			label:  "a",
+		}},
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			managed := make(map[[33]byte]struct{})
+			for _, acct := range tc.managed {
+				managed[[33]byte{acct}] = struct{}{}
+			}
+
+			actions, err := planOrderActions(
+				managed, tc.desired, tc.live,
+			)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(
+					err.Error(), tc.err,
+				) {
+
+					t.Fatalf("expected error %q, got %v",
+						tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to plan actions: %v", err)
+			}
+
+			if len(actions) != len(tc.expected) {
+				t.Fatalf("expected %d actions, got %d",
+					len(tc.expected), len(actions))
+			}
+			for i, expected := range tc.expected {
+				action := actions[i]
+				var existing byte
+				if action.existing != nil {
+					existing = action.existing.Nonce()[0]
+				}
+				if action.action != expected.action ||
+					action.key.label != expected.label ||
+					existing != expected.existing {
+
+					t.Fatalf("unexpected action %d: %v",
+						i, action.description)
+				}
+			}
+		})
+	}
+}
+
+// TestApplyOrdersPlanOnly makes sure a plan has no side effects, neither on the
+// auction server nor in the database, while applying it does.
+func TestApplyOrdersPlanOnly(t *testing.T) {
+	mock := &mockAuctioneerServer{
+		orderState: &clmrpc.ServerOrderStateResponse{
+			State: clmrpc.OrderState(
+				order.StatePartiallyFilled,
+			),
+		},
+	}
+	server, o, cleanup := newTestRPCServer(t, mock)
+	defer cleanup()
+
+	traderKey := o.Details().AcctKey
+	req := &clmrpc.ApplyOrdersRequest{
+		TraderKeys: [][]byte{traderKey[:]},
+		PlanOnly:   true,
+	}
+
+	// The only order of the account isn't desired anymore, so the plan
+	// cancels it.
+	ctx := context.Background()
+	resp, err := server.ApplyOrders(ctx, req)
+	if err != nil {
+		t.Fatalf("unable to plan orders: %v", err)
+	}
+	if len(resp.Actions) != 1 ||
+		resp.Actions[0].Action != clmrpc.OrderAction_CANCEL {
+
+		t.Fatalf("expected a single cancellation, got %v",
+			resp.Actions)
+	}
+
+	assertState := func(state order.State) {
+		t.Helper()
+
+		dbOrder, err := server.server.db.GetOrder(o.Nonce())
+		if err != nil {
+			t.Fatalf("unable to get order: %v", err)
+		}
+		if dbOrder.Details().State != state {
+			t.Fatalf("expected state %v, got %v", state,
+				dbOrder.Details().State)
+		}
+	}
+
+	// Neither the refreshed state nor the cancellation must have been
+	// applied.
+	assertState(order.StateSubmitted)
+	if atomic.LoadUint32(&mock.numCancels) != 0 {
+		t.Fatalf("order was canceled in plan only mode")
+	}
+
+	// Applying the same plan actually cancels the order.
+	req.PlanOnly = false
+	resp, err = server.ApplyOrders(ctx, req)
+	if err != nil {
+		t.Fatalf("unable to apply orders: %v", err)
+	}
+	if len(resp.Actions) != 1 || resp.Actions[0].Error != "" {
+		t.Fatalf("unexpected actions: %v", resp.Actions)
+	}
+	assertState(order.StateCanceled)
+	if atomic.LoadUint32(&mock.numCancels) != 1 {
+		t.Fatalf("order was not canceled")
+	}
+}
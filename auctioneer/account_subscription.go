@@ -43,12 +43,15 @@ func (s *acctSubscription) authenticate(ctx context.Context) error {
 	// Now send the commitment to the server which should trigger it to send
 	// a challenge back. We need to track the subscription from now on so
 	// the goroutine reading the incoming messages knows which subscription
-	// to add the received challenge to.
+	// to add the received challenge to. We announce the oldest batch
+	// version we support so servers that don't know the newer versions yet
+	// still accept us. The server picks the version of each batch and we
+	// verify every version we support.
 	err = s.sendMsg(&clmrpc.ClientAuctionMessage{
 		Msg: &clmrpc.ClientAuctionMessage_Commit{
 			Commit: &clmrpc.AccountCommitment{
 				CommitHash:   s.commitHash[:],
-				BatchVersion: uint32(order.CurrentVersion),
+				BatchVersion: uint32(order.MinSupportedVersion),
 			},
 		},
 	})
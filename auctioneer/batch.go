@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"errors"
-	"strings"
 
 	"github.com/btcsuite/btcd/wire"
 	"github.com/lightninglabs/llm/account"
@@ -12,13 +11,6 @@ import (
 	"github.com/lightninglabs/llm/order"
 )
 
-var (
-	// ErrBatchNotFinalized is an error returned by the auctioneer when we
-	// attempt to query it for a batch snapshot, but the batch has not been
-	// finalized by them yet.
-	ErrBatchNotFinalized = errors.New("batch snapshot not found")
-)
-
 // BatchSource abstracts the source of a trader's pending batch.
 type BatchSource interface {
 	// PendingBatch retrieves the ID and transaction of the current pending
@@ -47,9 +39,7 @@ func (c *Client) checkPendingBatch() error {
 	finalizedTx, err := c.finalizedBatchTx(id)
 	// If the batch has not been finalized yet, there's nothing to do but
 	// wait to receive its Finalize message.
-	//
-	// TODO(wilmer): Does gRPC wrap errors returned from an RPC?
-	if err != nil && strings.Contains(err.Error(), ErrBatchNotFinalized.Error()) {
+	if errors.Is(err, ErrBatchNotFinalized) {
 		return nil
 	}
 	if err != nil {
@@ -69,7 +59,7 @@ func (c *Client) finalizedBatchTx(id order.BatchID) (*wire.MsgTx, error) {
 	req := &clmrpc.RelevantBatchRequest{Id: id[:]}
 	batch, err := c.client.RelevantBatchSnapshot(context.Background(), req)
 	if err != nil {
-		return nil, err
+		return nil, DecodeError(err)
 	}
 
 	var batchTx wire.MsgTx
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -19,8 +20,10 @@ import (
 	"github.com/lightninglabs/llm/clmrpc"
 	"github.com/lightninglabs/llm/clmscript"
 	"github.com/lightninglabs/llm/order"
+	"github.com/lightninglabs/llm/streamlog"
 	"github.com/lightninglabs/loop/lndclient"
 	"github.com/lightningnetwork/lnd/keychain"
+	socks "golang.org/x/net/proxy"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
@@ -30,13 +33,6 @@ import (
 const (
 	initialConnectRetries = 3
 	reconnectRetries      = 10
-
-	// maxUnusedAccountKeyLookup is the number of successive account keys
-	// that we try and the server does not know of before aborting recovery.
-	// This is necessary to skip "holes" in our list of keys that can happen
-	// if the user tries to open an account but that fails. Then some keys
-	// aren't used.
-	maxUnusedAccountKeyLookup = 50
 )
 
 var (
@@ -54,9 +50,34 @@ type Config struct {
 	// ServerAddress is the domain:port of the auctioneer server.
 	ServerAddress string
 
+	// BackupServerAddresses is a list of additional domain:port addresses
+	// of the auctioneer server. If the connection to the current address
+	// fails repeatedly, the client fails over to the next address in the
+	// list, starting with ServerAddress.
+	BackupServerAddresses []string
+
+	// FailoverThreshold is the number of consecutive connection failures
+	// after which the client fails over to the next address. If it is
+	// zero, DefaultFailoverThreshold is used.
+	FailoverThreshold int
+
+	// KeepaliveInterval is the interval in which the connection to the
+	// auctioneer server is probed while the stream is open. A value of zero
+	// disables the keepalive.
+	KeepaliveInterval time.Duration
+
+	// KeepaliveTimeout is the time the auctioneer server has to answer a
+	// keepalive probe. If it is zero, DefaultKeepaliveTimeout is used.
+	KeepaliveTimeout time.Duration
+
 	// Insecure signals that no TLS should be used if set to true.
 	Insecure bool
 
+	// Proxy is the host:port of a SOCKS5 proxy, like the one of a tor
+	// daemon, through which the auction server is dialed. This is required
+	// to connect to an auction server on an onion address.
+	Proxy string
+
 	// TLSPathServer is the path to a local file that holds the auction
 	// server's TLS certificate. This is only needed if the server is using
 	// a self signed cert.
@@ -82,6 +103,10 @@ type Config struct {
 	// BatchSource provides information about the current pending batch, if
 	// any.
 	BatchSource BatchSource
+
+	// StreamRecorder, if set, records every message exchanged over the
+	// auction stream so batches can be replayed later for debugging.
+	StreamRecorder *streamlog.Recorder
 }
 
 // Client performs the client side part of auctions. This interface exists to be
@@ -95,14 +120,19 @@ type Client struct {
 	StreamErrChan  chan error
 	FromServerChan chan *clmrpc.ServerAuctionMessage
 
+	// connMtx guards the connection to the server which is replaced on
+	// failover.
+	connMtx    sync.RWMutex
 	serverConn *grpc.ClientConn
 	client     clmrpc.ChannelAuctioneerClient
+	conns      *connTracker
 
 	quit            chan struct{}
 	wg              sync.WaitGroup
 	serverStream    clmrpc.ChannelAuctioneer_SubscribeBatchAuctionClient
 	streamMutex     sync.Mutex
 	streamCancel    func()
+	streamQuit      chan struct{}
 	subscribedAccts map[[33]byte]*acctSubscription
 }
 
@@ -110,14 +140,21 @@ type Client struct {
 func NewClient(cfg *Config) (*Client, error) {
 	var err error
 	cfg.DialOpts, err = getAuctionServerDialOpts(
-		cfg.Insecure, cfg.TLSPathServer, cfg.DialOpts...,
+		cfg.Insecure, cfg.TLSPathServer, cfg.Proxy, cfg.DialOpts...,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	endpoints := append(
+		[]string{cfg.ServerAddress}, cfg.BackupServerAddresses...,
+	)
+
+	conns := newConnTracker(endpoints, cfg.FailoverThreshold)
+
 	return &Client{
 		cfg:             cfg,
+		conns:           conns,
 		FromServerChan:  make(chan *clmrpc.ServerAuctionMessage),
 		StreamErrChan:   make(chan error),
 		quit:            make(chan struct{}),
@@ -131,26 +168,66 @@ func (c *Client) Start() error {
 		return nil
 	}
 
-	serverConn, err := grpc.Dial(c.cfg.ServerAddress, c.cfg.DialOpts...)
+	return c.dial(c.conns.endpoint())
+}
+
+// dial creates the connection to the server with the given address and
+// replaces the current one, if any.
+func (c *Client) dial(address string) error {
+	serverConn, err := grpc.Dial(address, c.cfg.DialOpts...)
 	if err != nil {
 		return fmt.Errorf("unable to connect to RPC server: %v",
 			err)
 	}
 
+	c.connMtx.Lock()
+	oldConn := c.serverConn
 	c.serverConn = serverConn
 	c.client = clmrpc.NewChannelAuctioneerClient(serverConn)
+	c.connMtx.Unlock()
+
+	if oldConn != nil {
+		if err := oldConn.Close(); err != nil {
+			log.Errorf("Unable to close connection: %v", err)
+		}
+	}
 
 	return nil
 }
 
+// rpcClient returns the client of the connection currently in use.
+func (c *Client) rpcClient() clmrpc.ChannelAuctioneerClient {
+	c.connMtx.RLock()
+	defer c.connMtx.RUnlock()
+
+	return c.client
+}
+
 // getAuctionServerDialOpts returns the dial options to connect to the auction
 // server.
-func getAuctionServerDialOpts(insecure bool, tlsPath string,
+func getAuctionServerDialOpts(insecure bool, tlsPath, proxy string,
 	dialOpts ...grpc.DialOption) ([]grpc.DialOption, error) {
 
 	// Create a copy of the dial options array.
 	opts := dialOpts
 
+	// If a proxy is specified, all connections are made through it. The
+	// address is passed to the proxy as is so that host names, including
+	// onion addresses, are resolved by the proxy.
+	if proxy != "" {
+		dialer, err := socks.SOCKS5("tcp", proxy, nil, socks.Direct)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %v: %v", proxy,
+				err)
+		}
+		proxyDialer := func(_ context.Context, addr string) (net.Conn,
+			error) {
+
+			return dialer.Dial("tcp", addr)
+		}
+		opts = append(opts, grpc.WithContextDialer(proxyDialer))
+	}
+
 	// There are three options to connect to a auction server, either
 	// insecure, using a self-signed certificate or with a certificate
 	// signed by a public CA.
@@ -189,6 +266,9 @@ func (c *Client) Stop() error {
 	}
 	c.wg.Wait()
 	close(c.FromServerChan)
+
+	c.connMtx.Lock()
+	defer c.connMtx.Unlock()
 	return c.serverConn.Close()
 }
 
@@ -203,6 +283,7 @@ func (c *Client) closeStream() error {
 	log.Debugf("Closing server stream")
 	err := c.serverStream.CloseSend()
 	c.streamCancel()
+	close(c.streamQuit)
 	c.serverStream = nil
 
 	// Close all pending subscriptions.
@@ -219,11 +300,12 @@ func (c *Client) closeStream() error {
 func (c *Client) ReserveAccount(ctx context.Context,
 	value btcutil.Amount) (*account.Reservation, error) {
 
-	resp, err := c.client.ReserveAccount(ctx, &clmrpc.ReserveAccountRequest{
+	req := &clmrpc.ReserveAccountRequest{
 		AccountValue: uint64(value),
-	})
+	}
+	resp, err := c.rpcClient().ReserveAccount(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, DecodeError(err)
 	}
 
 	auctioneerKey, err := btcec.ParsePubKey(
@@ -253,7 +335,7 @@ func (c *Client) InitAccount(ctx context.Context, account *account.Account) erro
 		return fmt.Errorf("unable to construct account output: %v", err)
 	}
 
-	_, err = c.client.InitAccount(ctx, &clmrpc.ServerInitAccountRequest{
+	req := &clmrpc.ServerInitAccountRequest{
 		AccountPoint: &clmrpc.OutPoint{
 			Txid:        account.OutPoint.Hash[:],
 			OutputIndex: account.OutPoint.Index,
@@ -262,8 +344,9 @@ func (c *Client) InitAccount(ctx context.Context, account *account.Account) erro
 		AccountValue:  uint64(account.Value),
 		AccountExpiry: account.Expiry,
 		TraderKey:     account.TraderKey.PubKey.SerializeCompressed(),
-	})
-	return err
+	}
+	_, err = c.rpcClient().InitAccount(ctx, req)
+	return DecodeError(err)
 }
 
 // ModifyAccount sends an intent to the auctioneer that we'd like to modify the
@@ -305,14 +388,15 @@ func (c *Client) ModifyAccount(ctx context.Context, account *account.Account,
 		}
 	}
 
-	resp, err := c.client.ModifyAccount(ctx, &clmrpc.ServerModifyAccountRequest{
+	req := &clmrpc.ServerModifyAccountRequest{
 		TraderKey:  account.TraderKey.PubKey.SerializeCompressed(),
 		NewInputs:  rpcInputs,
 		NewOutputs: rpcOutputs,
 		NewParams:  rpcNewParams,
-	})
+	}
+	resp, err := c.rpcClient().ModifyAccount(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, DecodeError(err)
 	}
 
 	return resp.AccountSig, nil
@@ -343,6 +427,8 @@ func (c *Client) SubmitOrder(ctx context.Context, o order.Order,
 		NodePub:                serverParams.NodePubkey[:],
 		NodeAddr:               nodeAddrs,
 		FundingFeeRateSatPerKw: uint64(o.Details().FundingFeeRate),
+		MinUnitsMatch:          uint32(o.Details().MinUnitsMatch),
+		AllOrNone:              o.Details().AllOrNone,
 	}
 
 	// Split into server message which is type specific.
@@ -372,9 +458,9 @@ func (c *Client) SubmitOrder(ctx context.Context, o order.Order,
 	}
 
 	// Submit the finished request and parse the response.
-	resp, err := c.client.SubmitOrder(ctx, rpcRequest)
+	resp, err := c.rpcClient().SubmitOrder(ctx, rpcRequest)
 	if err != nil {
-		return err
+		return DecodeError(err)
 	}
 	switch submitResp := resp.Details.(type) {
 	case *clmrpc.ServerSubmitOrderResponse_InvalidOrder:
@@ -393,10 +479,11 @@ func (c *Client) SubmitOrder(ctx context.Context, o order.Order,
 
 // CancelOrder sends an order cancellation message to the server.
 func (c *Client) CancelOrder(ctx context.Context, nonce order.Nonce) error {
-	_, err := c.client.CancelOrder(ctx, &clmrpc.ServerCancelOrderRequest{
+	req := &clmrpc.ServerCancelOrderRequest{
 		OrderNonce: nonce[:],
-	})
-	return err
+	}
+	_, err := c.rpcClient().CancelOrder(ctx, req)
+	return DecodeError(err)
 }
 
 // OrderState queries the state of an order on the server. This only returns the
@@ -405,9 +492,14 @@ func (c *Client) CancelOrder(ctx context.Context, nonce order.Nonce) error {
 func (c *Client) OrderState(ctx context.Context, nonce order.Nonce) (
 	*clmrpc.ServerOrderStateResponse, error) {
 
-	return c.client.OrderState(ctx, &clmrpc.ServerOrderStateRequest{
+	req := &clmrpc.ServerOrderStateRequest{
 		OrderNonce: nonce[:],
-	})
+	}
+	resp, err := c.rpcClient().OrderState(ctx, req)
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+	return resp, nil
 }
 
 // SubscribeAccountUpdates opens a stream to the server and subscribes
@@ -496,12 +588,20 @@ func (c *Client) connectAndAuthenticate(ctx context.Context,
 		// that's fine. We just skip this account key and try the next
 		// one. If we're not in recovery mode, this is a hard failure.
 		case *clmrpc.ServerAuctionMessage_Error:
-			if !recovery {
-				return nil, false, fmt.Errorf("error "+
-					"subscribing to account: %v",
-					msg.Error.Error)
+			if recovery {
+				return sub, false, nil
+			}
+
+			errType := errors.New(msg.Error.Error)
+			switch msg.Error.ErrorCode {
+			case clmrpc.SubscribeError_ACCOUNT_DOES_NOT_EXIST:
+				errType = ErrAccountNotFound
+			}
+			return nil, false, &Error{
+				Err: errType,
+				Msg: fmt.Sprintf("error subscribing to "+
+					"account: %v", msg.Error.Error),
 			}
-			return sub, false, nil
 
 		default:
 			return nil, false, fmt.Errorf("unknown message "+
@@ -513,82 +613,59 @@ func (c *Client) connectAndAuthenticate(ctx context.Context,
 	}
 }
 
-// RecoverAccounts tries to recover all given accounts with the help of the
-// auctioneer server. Because the trader derives a new account key for each
-// attempt of opening an account, there can be "holes" in our list of keys that
-// are actually used. For example if there is insufficient balance in lnd, a key
-// gets "used up" but no account is ever created with it. We'll do a sweep to
-// ensure we generate a key only up to the point where it's required. The total
-// number of requested recoveries is returned upon completion.
-func (c *Client) RecoverAccounts(ctx context.Context,
-	accountKeys []*keychain.KeyDescriptor) ([]*account.Account, error) {
-
-	numNotFoundAccounts := 0
-	var recoveredAccounts []*account.Account
-	for _, keyDesc := range accountKeys {
-		acctKeyBytes := keyDesc.PubKey.SerializeCompressed()
-		subscription, canRecover, err := c.connectAndAuthenticate(
-			ctx, keyDesc, true,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("could not recover account %x: %v",
-				acctKeyBytes, err)
-		}
+// RecoverAccount asks the auction server to send back its view of the account
+// with the given key. If the server doesn't know of an account for the key, nil
+// is returned.
+func (c *Client) RecoverAccount(ctx context.Context,
+	keyDesc *keychain.KeyDescriptor) (*account.Account, error) {
 
-		if !canRecover {
-			numNotFoundAccounts++
+	acctKeyBytes := keyDesc.PubKey.SerializeCompressed()
+	subscription, canRecover, err := c.connectAndAuthenticate(
+		ctx, keyDesc, true,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !canRecover {
+		return nil, nil
+	}
 
-			// Stop looking for further accounts if we've got a
-			// certain number of negative responses from the server.
-			if numNotFoundAccounts > maxUnusedAccountKeyLookup {
-				return recoveredAccounts, nil
-			}
+	// Ask the auctioneer to send us back the state as it knows it in its
+	// database. The response to this message will be handled outside of
+	// the client.
+	err = c.SendAuctionMessage(&clmrpc.ClientAuctionMessage{
+		Msg: &clmrpc.ClientAuctionMessage_Recover{
+			Recover: &clmrpc.AccountRecovery{
+				TraderKey: acctKeyBytes,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error sending recover request: %v",
+			err)
+	}
 
-			continue
+	// Now we wait for the server to send us the account to recover.
+	select {
+	case msg := <-subscription.msgChan:
+		acctMsg, ok := msg.Msg.(*clmrpc.ServerAuctionMessage_Account)
+		if !ok {
+			return nil, fmt.Errorf("received unexpected recovery "+
+				"message from server: %v", msg)
 		}
-
-		// Reset our not found counter as we've found a recoverable
-		// account here. Then ask the auctioneer to send us back the
-		// state as it knows it in its database. The response to this
-		// message will be handled outside of the client.
-		numNotFoundAccounts = 0
-		err = c.SendAuctionMessage(&clmrpc.ClientAuctionMessage{
-			Msg: &clmrpc.ClientAuctionMessage_Recover{
-				Recover: &clmrpc.AccountRecovery{
-					TraderKey: acctKeyBytes,
-				},
-			},
-		})
+		acct, err := unmarshallServerAccount(acctMsg.Account)
 		if err != nil {
-			return nil, fmt.Errorf("error sending recover "+
-				"request: %v", err)
+			return nil, fmt.Errorf("error recovering account: %v",
+				err)
 		}
+		return acct, nil
 
-		// Now we wait for the server to send us the account to recover.
-		select {
-		case msg := <-subscription.msgChan:
-			acctMsg, ok := msg.Msg.(*clmrpc.ServerAuctionMessage_Account)
-			if !ok {
-				return nil, fmt.Errorf("received unexpected "+
-					"recovery message from server: %v",
-					msg)
-			}
-			acct, err := unmarshallServerAccount(acctMsg.Account)
-			if err != nil {
-				return nil, fmt.Errorf("error recovering "+
-					"account: %v", err)
-			}
-			recoveredAccounts = append(recoveredAccounts, acct)
-
-		case <-ctx.Done():
-			return nil, fmt.Errorf("user canceled operation")
+	case <-ctx.Done():
+		return nil, fmt.Errorf("user canceled operation")
 
-		case <-c.quit:
-			return nil, ErrClientShutdown
-		}
+	case <-c.quit:
+		return nil, ErrClientShutdown
 	}
-
-	return recoveredAccounts, nil
 }
 
 // SendAuctionMessage sends an auction message through the long-lived stream to
@@ -599,7 +676,23 @@ func (c *Client) SendAuctionMessage(msg *clmrpc.ClientAuctionMessage) error {
 		return fmt.Errorf("cannot send message, stream not open")
 	}
 
-	return c.serverStream.Send(msg)
+	if err := c.serverStream.Send(msg); err != nil {
+		return err
+	}
+	c.record(streamlog.NewClientRecord(msg))
+
+	return nil
+}
+
+// record writes a message of the auction stream to the stream recorder, if
+// one is configured. Recording is best effort and never interrupts the stream.
+func (c *Client) record(rec *streamlog.Record) {
+	if c.cfg.StreamRecorder == nil {
+		return
+	}
+	if err := c.cfg.StreamRecorder.Record(rec); err != nil {
+		log.Warnf("Unable to record auction message: %v", err)
+	}
 }
 
 // wait blocks for a given amount of time but returns immediately if the client
@@ -632,13 +725,17 @@ func (c *Client) connectServerStream(initialBackoff time.Duration,
 			return err
 		}
 		ctx, c.streamCancel = context.WithCancel(context.Background())
-		c.serverStream, err = c.client.SubscribeBatchAuction(ctx)
+		c.serverStream, err = c.rpcClient().SubscribeBatchAuction(ctx)
 		if err == nil {
 			log.Debugf("Connected successfully to server after "+
 				"%d tries", i+1)
 			break
 		}
 
+		// Fail over to the next server if this one keeps failing.
+		c.conns.recordEvent(EventConnectFailed, err)
+		c.recordFailure(err)
+
 		// Connect wasn't successful, cancel the context and increase
 		// the time we'll wait until the next try.
 		backoff *= 2
@@ -657,20 +754,31 @@ func (c *Client) connectServerStream(initialBackoff time.Duration,
 	if err != nil {
 		log.Errorf("Connection to server failed after %d retries",
 			numRetries)
-		return err
+		return DecodeError(err)
 	}
 
 	// Read incoming messages and send them to the channel where
 	// the order manager is listening on. We can only send our first message
 	// to the server after we've received the challenge, which we'll track
 	// with its own wait group.
-	log.Infof("Successfully connected to auction server")
+	log.Infof("Successfully connected to auction server %v",
+		c.conns.endpoint())
+	c.conns.markConnected()
+	c.streamQuit = make(chan struct{})
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
 		c.readIncomingStream()
 	}()
 
+	if c.cfg.KeepaliveInterval > 0 {
+		c.wg.Add(1)
+		go func(streamQuit <-chan struct{}) {
+			defer c.wg.Done()
+			c.keepalive(streamQuit)
+		}(c.streamQuit)
+	}
+
 	return nil
 }
 
@@ -724,6 +832,10 @@ func (c *Client) readIncomingStream() { // nolint:gocyclo
 			return
 		}
 
+		// Any message proves the connection to be healthy.
+		c.conns.markHealthy()
+		c.record(streamlog.NewServerRecord(msg))
+
 		// We only handle three kinds of messages here, those related to
 		// the initial challenge, to the account recovery and the
 		// shutdown. Everything else is passed into the channel to be
@@ -858,11 +970,13 @@ func (c *Client) sendToSubscription(traderAccountKey []byte,
 // incremental backoff time we wait between trials. If the connection succeeds,
 // all previous subscriptions are sent again.
 func (c *Client) HandleServerShutdown(err error) error {
+	c.conns.markDisconnected(err)
 	if err == nil {
 		log.Infof("Server is shutting down, will reconnect in %v",
 			c.cfg.MinBackoff)
 	} else {
 		log.Errorf("Error in stream, trying to reconnect: %v", err)
+		c.recordFailure(err)
 	}
 	err = c.closeStream()
 	if err != nil {
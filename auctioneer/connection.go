@@ -0,0 +1,330 @@
+package auctioneer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/llm/clmrpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultFailoverThreshold is the default number of consecutive
+	// connection failures after which the client fails over to the next
+	// auction server address.
+	DefaultFailoverThreshold = 3
+
+	// DefaultKeepaliveTimeout is the default time the auction server has
+	// to answer a keepalive probe.
+	DefaultKeepaliveTimeout = 10 * time.Second
+
+	// maxConnectionEvents is the maximum number of connection events that
+	// are kept in the connection history.
+	maxConnectionEvents = 100
+)
+
+// ConnectionEventType is the type of an event in the connection history.
+type ConnectionEventType uint8
+
+const (
+	// EventConnected is recorded when the stream to the auction server was
+	// opened successfully.
+	EventConnected ConnectionEventType = iota
+
+	// EventConnectFailed is recorded when opening the stream to the
+	// auction server failed.
+	EventConnectFailed
+
+	// EventDisconnected is recorded when the stream to the auction server
+	// was closed, either because of an error or because the server shut
+	// down.
+	EventDisconnected
+
+	// EventKeepaliveFailed is recorded when the auction server didn't
+	// answer a keepalive probe in time.
+	EventKeepaliveFailed
+
+	// EventFailover is recorded when the client switched to another
+	// auction server address.
+	EventFailover
+)
+
+// String returns a human readable representation of the event type.
+func (t ConnectionEventType) String() string {
+	switch t {
+	case EventConnected:
+		return "Connected"
+
+	case EventConnectFailed:
+		return "ConnectFailed"
+
+	case EventDisconnected:
+		return "Disconnected"
+
+	case EventKeepaliveFailed:
+		return "KeepaliveFailed"
+
+	case EventFailover:
+		return "Failover"
+
+	default:
+		return fmt.Sprintf("unknown<%d>", t)
+	}
+}
+
+// ConnectionEvent is a single event in the connection history.
+type ConnectionEvent struct {
+	// Timestamp is the time the event happened.
+	Timestamp time.Time
+
+	// Type is the type of the event.
+	Type ConnectionEventType
+
+	// Endpoint is the auction server address the event relates to. For a
+	// failover, this is the address that was switched to.
+	Endpoint string
+
+	// Err is the error that caused the event, if any.
+	Err error
+}
+
+// ConnectionState is a snapshot of the state of the connection to the auction
+// server.
+type ConnectionState struct {
+	// Endpoints is the list of all configured auction server addresses,
+	// starting with the primary one.
+	Endpoints []string
+
+	// CurrentEndpoint is the auction server address currently in use.
+	CurrentEndpoint string
+
+	// Connected is true if the stream to the auction server is open.
+	Connected bool
+
+	// LastError is the last connection related error, if any.
+	LastError error
+
+	// LastErrorTime is the time the last error happened.
+	LastErrorTime time.Time
+
+	// Events is the connection history, oldest event first.
+	Events []ConnectionEvent
+}
+
+// connTracker keeps track of the configured auction server addresses, the one
+// currently in use and the history of the connection.
+type connTracker struct {
+	mu sync.Mutex
+
+	endpoints     []string
+	current       int
+	connected     bool
+	failures      int
+	lastErr       error
+	lastErrTime   time.Time
+	events        []ConnectionEvent
+	failoverLimit int
+}
+
+// newConnTracker creates a new tracker for the given addresses.
+func newConnTracker(endpoints []string, failoverLimit int) *connTracker {
+	if failoverLimit <= 0 {
+		failoverLimit = DefaultFailoverThreshold
+	}
+	return &connTracker{
+		endpoints:     endpoints,
+		failoverLimit: failoverLimit,
+	}
+}
+
+// endpoint returns the auction server address currently in use.
+func (t *connTracker) endpoint() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.endpoints[t.current]
+}
+
+// recordEvent adds an event for the current endpoint to the history.
+func (t *connTracker) recordEvent(eventType ConnectionEventType, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.addEvent(eventType, t.endpoints[t.current], err)
+}
+
+// addEvent adds an event to the history. The caller must hold the mutex.
+func (t *connTracker) addEvent(eventType ConnectionEventType, endpoint string,
+	err error) {
+
+	now := time.Now()
+	t.events = append(t.events, ConnectionEvent{
+		Timestamp: now,
+		Type:      eventType,
+		Endpoint:  endpoint,
+		Err:       err,
+	})
+	if len(t.events) > maxConnectionEvents {
+		t.events = t.events[len(t.events)-maxConnectionEvents:]
+	}
+	if err != nil {
+		t.lastErr = err
+		t.lastErrTime = now
+	}
+}
+
+// markConnected records that the stream to the current endpoint was opened.
+func (t *connTracker) markConnected() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.connected = true
+	t.addEvent(EventConnected, t.endpoints[t.current], nil)
+}
+
+// markDisconnected records that the stream was closed.
+func (t *connTracker) markDisconnected(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.connected {
+		return
+	}
+	t.connected = false
+	t.addEvent(EventDisconnected, t.endpoints[t.current], err)
+}
+
+// markHealthy records that the current endpoint answered, which resets the
+// failure count.
+func (t *connTracker) markHealthy() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.failures = 0
+}
+
+// markFailure counts a failure of the current endpoint. If the failure limit is
+// reached and there is another endpoint, that endpoint becomes the current one
+// and its address is returned.
+func (t *connTracker) markFailure() (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.failures++
+	if t.failures < t.failoverLimit || len(t.endpoints) < 2 {
+		return "", false
+	}
+
+	t.failures = 0
+	t.current = (t.current + 1) % len(t.endpoints)
+	next := t.endpoints[t.current]
+	t.addEvent(EventFailover, next, nil)
+
+	return next, true
+}
+
+// state returns a snapshot of the connection state.
+func (t *connTracker) state() *ConnectionState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	events := make([]ConnectionEvent, len(t.events))
+	copy(events, t.events)
+	endpoints := make([]string, len(t.endpoints))
+	copy(endpoints, t.endpoints)
+
+	return &ConnectionState{
+		Endpoints:       endpoints,
+		CurrentEndpoint: t.endpoints[t.current],
+		Connected:       t.connected,
+		LastError:       t.lastErr,
+		LastErrorTime:   t.lastErrTime,
+		Events:          events,
+	}
+}
+
+// ConnectionState returns a snapshot of the state and history of the
+// connection to the auction server.
+func (c *Client) ConnectionState() *ConnectionState {
+	return c.conns.state()
+}
+
+// recordFailure counts a failure of the current auction server and fails over
+// to the next one if the failure limit is reached.
+func (c *Client) recordFailure(err error) {
+	next, failover := c.conns.markFailure()
+	if !failover {
+		return
+	}
+
+	log.Warnf("Failing over to auction server %v after repeated "+
+		"errors: %v", next, err)
+	if err := c.dial(next); err != nil {
+		log.Errorf("Unable to fail over to auction server %v: %v",
+			next, err)
+	}
+}
+
+// keepalive periodically probes the auction server while the stream is open.
+// Because the probe is sent over the same connection as the stream, a half-dead
+// connection is detected a lot sooner than by TCP. If the server doesn't answer
+// in time, the error is reported to the stream error channel which makes the
+// client reconnect.
+//
+// NOTE: This method must be called as a subroutine because it blocks as long as
+// the stream is open.
+func (c *Client) keepalive(streamQuit <-chan struct{}) {
+	ticker := time.NewTicker(c.cfg.KeepaliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := c.probe()
+			if err == nil {
+				c.conns.markHealthy()
+				continue
+			}
+
+			log.Warnf("Auction server didn't answer keepalive: %v",
+				err)
+			err = fmt.Errorf("keepalive failed: %v", err)
+			c.conns.recordEvent(EventKeepaliveFailed, err)
+			select {
+			case c.StreamErrChan <- err:
+			case <-streamQuit:
+			case <-c.quit:
+			}
+			return
+
+		case <-streamQuit:
+			return
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// probe sends a cheap request to the auction server. Any answer, even an error
+// returned by the server itself, proves that the connection is alive.
+func (c *Client) probe() error {
+	timeout := c.cfg.KeepaliveTimeout
+	if timeout == 0 {
+		timeout = DefaultKeepaliveTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	_, err := c.rpcClient().FeeQuote(ctx, &clmrpc.FeeQuoteRequest{})
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return err
+
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,122 @@
+package auctioneer
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestConnTrackerFailover makes sure the tracker only fails over after the
+// configured number of consecutive failures and cycles through all endpoints.
+func TestConnTrackerFailover(t *testing.T) {
+	t.Parallel()
+
+	tracker := newConnTracker([]string{"a", "b", "c"}, 2)
+
+	// A single failure shouldn't cause a failover.
+	if _, failover := tracker.markFailure(); failover {
+		t.Fatalf("unexpected failover after one failure")
+	}
+
+	// A healthy answer resets the failure count.
+	tracker.markHealthy()
+	if _, failover := tracker.markFailure(); failover {
+		t.Fatalf("unexpected failover after reset")
+	}
+
+	expected := []string{"b", "c", "a"}
+	for _, addr := range expected {
+		next, failover := tracker.markFailure()
+		if !failover || next != addr {
+			t.Fatalf("expected failover to %v, got %v (%v)", addr,
+				next, failover)
+		}
+		if tracker.endpoint() != addr {
+			t.Fatalf("expected current endpoint %v, got %v", addr,
+				tracker.endpoint())
+		}
+
+		if _, failover := tracker.markFailure(); failover {
+			t.Fatalf("unexpected failover after one failure")
+		}
+	}
+
+	state := tracker.state()
+	if len(state.Events) != len(expected) {
+		t.Fatalf("expected %d events, got %d", len(expected),
+			len(state.Events))
+	}
+	for i, event := range state.Events {
+		if event.Type != EventFailover ||
+			event.Endpoint != expected[i] {
+
+			t.Fatalf("unexpected event %v to %v", event.Type,
+				event.Endpoint)
+		}
+	}
+
+	// With a single endpoint there is nothing to fail over to.
+	single := newConnTracker([]string{"a"}, 1)
+	if _, failover := single.markFailure(); failover {
+		t.Fatalf("unexpected failover with single endpoint")
+	}
+}
+
+// TestConnTrackerState makes sure the connection state and history are tracked
+// correctly.
+func TestConnTrackerState(t *testing.T) {
+	t.Parallel()
+
+	tracker := newConnTracker([]string{"a", "b"}, 0)
+	if tracker.failoverLimit != DefaultFailoverThreshold {
+		t.Fatalf("expected default failover threshold, got %d",
+			tracker.failoverLimit)
+	}
+
+	// A disconnect without being connected is not recorded.
+	tracker.markDisconnected(nil)
+	if len(tracker.state().Events) != 0 {
+		t.Fatalf("unexpected disconnect event")
+	}
+
+	tracker.markConnected()
+	if !tracker.state().Connected {
+		t.Fatalf("expected to be connected")
+	}
+
+	errDisconnect := errors.New("transport is closing")
+	tracker.markDisconnected(errDisconnect)
+	state := tracker.state()
+	if state.Connected {
+		t.Fatalf("expected to be disconnected")
+	}
+	if state.LastError != errDisconnect || state.LastErrorTime.IsZero() {
+		t.Fatalf("unexpected last error %v", state.LastError)
+	}
+	if len(state.Events) != 2 ||
+		state.Events[0].Type != EventConnected ||
+		state.Events[1].Type != EventDisconnected {
+
+		t.Fatalf("unexpected events %v", state.Events)
+	}
+
+	// The history is trimmed to the most recent events.
+	for i := 0; i < maxConnectionEvents+10; i++ {
+		tracker.recordEvent(EventConnectFailed, errDisconnect)
+	}
+	state = tracker.state()
+	if len(state.Events) != maxConnectionEvents {
+		t.Fatalf("expected %d events, got %d", maxConnectionEvents,
+			len(state.Events))
+	}
+	for _, event := range state.Events {
+		if event.Type != EventConnectFailed {
+			t.Fatalf("expected oldest events to be trimmed")
+		}
+	}
+
+	// The returned state must be a copy.
+	state.Endpoints[0] = "x"
+	if tracker.endpoint() != "a" {
+		t.Fatalf("state is not a copy")
+	}
+}
@@ -0,0 +1,216 @@
+package auctioneer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lightninglabs/loop/lsat"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ErrorDomain is the domain of the ErrorInfo details attached to the
+	// gRPC status of all typed auctioneer errors.
+	ErrorDomain = "auctioneer.llm.lightning.engineering"
+)
+
+var (
+	// ErrAccountNotFound is returned if the auctioneer doesn't know the
+	// account a request refers to.
+	ErrAccountNotFound = errors.New("account not found")
+
+	// ErrOrderNotFound is returned if the auctioneer doesn't know the order
+	// a request refers to.
+	ErrOrderNotFound = errors.New("order not found")
+
+	// ErrBatchNotFinalized is an error returned by the auctioneer when we
+	// attempt to query it for a batch snapshot, but the batch has not been
+	// finalized by them yet.
+	ErrBatchNotFinalized = errors.New("batch snapshot not found")
+
+	// ErrInsufficientBalance is returned if an account doesn't have enough
+	// balance for the requested action.
+	ErrInsufficientBalance = errors.New("insufficient account balance")
+
+	// ErrVersionMismatch is returned if the auctioneer doesn't support the
+	// version of a message or order sent by the trader.
+	ErrVersionMismatch = errors.New("version mismatch")
+
+	// ErrRateLimited is returned if the auctioneer rejected a request
+	// because too many requests were sent.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrPaymentRequired is returned if a request could not be made
+	// because the LSAT required for it has not been paid.
+	ErrPaymentRequired = errors.New("LSAT payment required")
+
+	// errorTypes maps each of the typed errors to the reason used in the
+	// ErrorInfo details and the gRPC status code.
+	errorTypes = map[error]errorType{
+		ErrAccountNotFound: {
+			reason: "ACCOUNT_NOT_FOUND",
+			code:   codes.NotFound,
+		},
+		ErrOrderNotFound: {
+			reason: "ORDER_NOT_FOUND",
+			code:   codes.NotFound,
+		},
+		ErrBatchNotFinalized: {
+			reason: "BATCH_NOT_FINALIZED",
+			code:   codes.NotFound,
+		},
+		ErrInsufficientBalance: {
+			reason: "INSUFFICIENT_BALANCE",
+			code:   codes.FailedPrecondition,
+		},
+		ErrVersionMismatch: {
+			reason: "VERSION_MISMATCH",
+			code:   codes.FailedPrecondition,
+		},
+		ErrRateLimited: {
+			reason: "RATE_LIMITED",
+			code:   codes.ResourceExhausted,
+		},
+		ErrPaymentRequired: {
+			reason: "PAYMENT_REQUIRED",
+			code:   lsat.GRPCErrCode,
+		},
+	}
+)
+
+// errorType describes how a typed error is transported in a gRPC status.
+type errorType struct {
+	// reason is the reason of the ErrorInfo details.
+	reason string
+
+	// code is the gRPC status code.
+	code codes.Code
+}
+
+// Error is a typed error returned by the auctioneer. It wraps one of the
+// sentinel errors of this package so callers can branch on it with errors.Is
+// while keeping the auctioneer's original error message.
+type Error struct {
+	// Err is the sentinel error that describes the type of the error.
+	Err error
+
+	// Msg is the error message as returned by the auctioneer.
+	Msg string
+}
+
+// NewError creates a typed error of the given type with a formatted message.
+func NewError(errType error, format string, args ...interface{}) *Error {
+	return &Error{
+		Err: errType,
+		Msg: fmt.Sprintf(format, args...),
+	}
+}
+
+// Error returns the error message as returned by the auctioneer.
+//
+// NOTE: This is part of the error interface.
+func (e *Error) Error() string {
+	return e.Msg
+}
+
+// Unwrap returns the sentinel error that describes the type of the error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Reason returns the reason that identifies the type of the error in the
+// ErrorInfo details of a gRPC status.
+func (e *Error) Reason() string {
+	return errorTypes[e.Err].reason
+}
+
+// GRPCStatus returns the gRPC status of the error, including the ErrorInfo
+// details that allow the receiver to decode the type of the error. This makes
+// sure that the status is sent if the error is returned from a gRPC handler.
+func (e *Error) GRPCStatus() *status.Status {
+	errType, ok := errorTypes[e.Err]
+	if !ok {
+		return status.New(codes.Unknown, e.Msg)
+	}
+
+	st := status.New(errType.code, e.Msg)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: errType.reason,
+		Domain: ErrorDomain,
+	})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+// DecodeError turns an error returned from a gRPC call to the auctioneer into
+// a typed error if possible. The ErrorInfo details of the status are used if
+// present. Otherwise the type is derived from the status code and message for
+// compatibility with servers that don't send any details. Errors that can't be
+// decoded are returned unchanged.
+func DecodeError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() == codes.OK {
+		return err
+	}
+
+	typed := func(errType error) error {
+		return &Error{Err: errType, Msg: st.Message()}
+	}
+
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.Domain != ErrorDomain {
+			continue
+		}
+		for errType, t := range errorTypes {
+			if t.reason == info.Reason {
+				return typed(errType)
+			}
+		}
+	}
+
+	msg := strings.ToLower(st.Message())
+	switch {
+	case strings.Contains(msg, ErrBatchNotFinalized.Error()):
+		return typed(ErrBatchNotFinalized)
+
+	case st.Code() == lsat.GRPCErrCode && msg == lsat.GRPCErrMessage:
+		return typed(ErrPaymentRequired)
+
+	case st.Code() == codes.ResourceExhausted:
+		return typed(ErrRateLimited)
+
+	case st.Code() == codes.NotFound && strings.Contains(msg, "account"):
+		return typed(ErrAccountNotFound)
+
+	case st.Code() == codes.NotFound && strings.Contains(msg, "order"):
+		return typed(ErrOrderNotFound)
+
+	default:
+		return err
+	}
+}
+
+// StatusError returns the gRPC status error of a typed auctioneer error, even
+// if it was wrapped. Other errors are returned unchanged. This can be used to
+// forward the type of an auctioneer error to the trader's RPC clients.
+func StatusError(err error) error {
+	var typed *Error
+	if errors.As(err, &typed) {
+		st := typed.GRPCStatus()
+		if err.Error() == typed.Msg {
+			return st.Err()
+		}
+
+		// Keep the context that was added while wrapping the error.
+		proto := st.Proto()
+		proto.Message = err.Error()
+		return status.FromProto(proto).Err()
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+package auctioneer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lightninglabs/loop/lsat"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestDecodeError makes sure errors returned from the auctioneer are decoded
+// into the correct typed error.
+func TestDecodeError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected error
+	}{{
+		name:     "typed error round trip",
+		err:      statusErr(ErrOrderNotFound, "no such order"),
+		expected: ErrOrderNotFound,
+	}, {
+		name:     "details take precedence over message",
+		err:      statusErr(ErrRateLimited, "account gone"),
+		expected: ErrRateLimited,
+	}, {
+		name: "legacy batch not finalized",
+		err: status.Error(
+			codes.Unknown, ErrBatchNotFinalized.Error(),
+		),
+		expected: ErrBatchNotFinalized,
+	}, {
+		name:     "payment required",
+		err:      status.Error(lsat.GRPCErrCode, lsat.GRPCErrMessage),
+		expected: ErrPaymentRequired,
+	}, {
+		name:     "rate limited",
+		err:      status.Error(codes.ResourceExhausted, "slow down"),
+		expected: ErrRateLimited,
+	}, {
+		name:     "account not found by code",
+		err:      status.Error(codes.NotFound, "Account abc unknown"),
+		expected: ErrAccountNotFound,
+	}, {
+		name:     "order not found by code",
+		err:      status.Error(codes.NotFound, "order abc unknown"),
+		expected: ErrOrderNotFound,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := DecodeError(tc.err)
+			if !errors.Is(err, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected,
+					err)
+			}
+
+			// The auctioneer's message must be kept as is.
+			st, _ := status.FromError(tc.err)
+			if err.Error() != st.Message() {
+				t.Fatalf("unexpected message %q", err.Error())
+			}
+		})
+	}
+
+	// Errors that can't be typed are returned unchanged.
+	unknown := []error{
+		nil,
+		errors.New("not a status"),
+		status.Error(codes.Internal, "something else"),
+	}
+	for _, err := range unknown {
+		if decoded := DecodeError(err); decoded != err {
+			t.Fatalf("expected %v to be unchanged, got %v", err,
+				decoded)
+		}
+	}
+}
+
+// TestStatusError makes sure wrapped typed errors are turned into a status
+// error that can be decoded again.
+func TestStatusError(t *testing.T) {
+	t.Parallel()
+
+	typed := NewError(ErrInsufficientBalance, "need more coins")
+	wrapped := fmt.Errorf("unable to submit: %w", typed)
+
+	err := StatusError(wrapped)
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("expected status error, got %v", err)
+	}
+	if st.Code() != codes.FailedPrecondition {
+		t.Fatalf("unexpected code %v", st.Code())
+	}
+	if st.Message() != wrapped.Error() {
+		t.Fatalf("unexpected message %q", st.Message())
+	}
+	if !errors.Is(DecodeError(err), ErrInsufficientBalance) {
+		t.Fatalf("status error can't be decoded")
+	}
+
+	// Untyped errors are returned unchanged.
+	plain := errors.New("plain")
+	if StatusError(plain) != plain {
+		t.Fatalf("untyped error was changed")
+	}
+}
+
+// statusErr returns the gRPC status error of a typed error.
+func statusErr(errType error, msg string) error {
+	return NewError(errType, msg).GRPCStatus().Err()
+}
@@ -0,0 +1,142 @@
+package llm
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/lightninglabs/llm/clientdb"
+	"github.com/lightninglabs/llm/clmrpc"
+)
+
+const (
+	// defaultBackupDirname is the name of the directory within the network
+	// directory that scheduled backups are written to if no other
+	// directory is configured.
+	defaultBackupDirname = "backups"
+
+	// defaultBackupRetention is the default number of scheduled backups
+	// that are kept before the oldest ones are removed.
+	defaultBackupRetention = 7
+
+	// backupChunkSize is the maximum number of bytes of a database snapshot
+	// that are sent in a single BackupDB response message.
+	backupChunkSize = 64 * 1024
+)
+
+var (
+	// errBackupUnsupported is returned if a backup is requested but the
+	// configured database backend doesn't support hot backups.
+	errBackupUnsupported = errors.New("database backups are only " +
+		"supported by the bbolt backend")
+)
+
+// backupStore is implemented by database backends that can write a consistent
+// snapshot of themselves while the daemon is running.
+type backupStore interface {
+	// Backup writes a consistent snapshot of the whole database to the
+	// given writer and returns the database version of the snapshot and
+	// the number of bytes written.
+	Backup(w io.Writer) (uint32, int64, error)
+
+	// BackupToDir writes a snapshot of the database to a new, time stamped
+	// file in the given directory and returns its full path.
+	BackupToDir(backupDir string) (string, error)
+}
+
+var _ backupStore = (*clientdb.DB)(nil)
+
+// backupDir returns the directory scheduled backups are written to.
+func backupDir(cfg *Config) string {
+	if cfg.BackupDir != "" {
+		return cfg.BackupDir
+	}
+	return filepath.Join(cfg.BaseDir, cfg.Network, defaultBackupDirname)
+}
+
+// backupChunkWriter is an io.Writer that sends everything written to it as
+// chunks of a BackupDB response stream.
+type backupChunkWriter struct {
+	stream clmrpc.Trader_BackupDBServer
+}
+
+// Write sends the given bytes to the client, split into chunks of at most
+// backupChunkSize bytes.
+func (w *backupChunkWriter) Write(p []byte) (int, error) {
+	written := 0
+	for written < len(p) {
+		end := written + backupChunkSize
+		if end > len(p) {
+			end = len(p)
+		}
+		err := w.stream.Send(&clmrpc.BackupDBResponse{
+			Chunk: p[written:end],
+		})
+		if err != nil {
+			return written, err
+		}
+		written = end
+	}
+	return len(p), nil
+}
+
+// streamBackup writes a snapshot of the given store to the response stream.
+// Because the version is only known once the snapshot was taken, it is sent in
+// a final message without a chunk.
+func streamBackup(store backupStore,
+	stream clmrpc.Trader_BackupDBServer) (int64, error) {
+
+	w := bufio.NewWriterSize(&backupChunkWriter{stream: stream},
+		backupChunkSize)
+	version, n, err := store.Backup(w)
+	if err != nil {
+		return 0, err
+	}
+	if err := w.Flush(); err != nil {
+		return 0, err
+	}
+
+	err = stream.Send(&clmrpc.BackupDBResponse{
+		DbVersion: version,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// backupHandler periodically writes a snapshot of the database to the backup
+// directory and removes the oldest snapshots that exceed the retention limit.
+//
+// NOTE: This method must be run as a goroutine.
+func (s *rpcServer) backupHandler(store backupStore) {
+	defer s.wg.Done()
+
+	cfg := s.server.cfg
+	dir := backupDir(cfg)
+	ticker := time.NewTicker(cfg.BackupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			backupFile, err := store.BackupToDir(dir)
+			if err != nil {
+				log.Errorf("Unable to back up database: %v",
+					err)
+				continue
+			}
+			log.Infof("Database backed up to %v", backupFile)
+
+			err = clientdb.PruneBackups(dir, cfg.BackupRetention)
+			if err != nil {
+				log.Errorf("Unable to prune backups: %v", err)
+			}
+
+		case <-s.quit:
+			return
+		}
+	}
+}
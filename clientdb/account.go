@@ -8,6 +8,25 @@ import (
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/coreos/bbolt"
 	"github.com/lightninglabs/llm/account"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+// The TLV types of an account record.
+const (
+	accountValueType         tlv.Type = 0
+	accountExpiryType        tlv.Type = 2
+	accountTraderKeyType     tlv.Type = 4
+	accountAuctioneerKeyType tlv.Type = 6
+	accountBatchKeyType      tlv.Type = 8
+	accountSecretType        tlv.Type = 10
+	accountStateType         tlv.Type = 12
+	accountHeightHintType    tlv.Type = 14
+	accountOutPointType      tlv.Type = 16
+	accountCloseTxType       tlv.Type = 18
+
+	// The label and tags of an account are only known to the trader.
+	accountLabelType tlv.Type = 19
+	accountTagsType  tlv.Type = 21
 )
 
 var (
@@ -30,7 +49,7 @@ func getAccountKey(account *account.Account) []byte {
 func (db *DB) AddAccount(account *account.Account) error {
 	accountKey := getAccountKey(account)
 	var accountBuf bytes.Buffer
-	if err := serializeAccount(&accountBuf, account); err != nil {
+	if err := SerializeAccount(&accountBuf, account); err != nil {
 		return err
 	}
 
@@ -77,23 +96,14 @@ func updateAccount(src, dst *bbolt.Bucket, accountKey []byte,
 		return ErrAccountNotFound
 	}
 
-	dbAccount, err := deserializeAccount(
-		bytes.NewReader(accountBytes),
+	_, newAccountBytes, err := ApplyAccountModifiers(
+		accountBytes, modifiers,
 	)
 	if err != nil {
 		return err
 	}
 
-	for _, modifier := range modifiers {
-		modifier(dbAccount)
-	}
-
-	var accountBuf bytes.Buffer
-	if err := serializeAccount(&accountBuf, dbAccount); err != nil {
-		return err
-	}
-
-	return dst.Put(accountKey, accountBuf.Bytes())
+	return dst.Put(accountKey, newAccountBytes)
 }
 
 // Account retrieves a specific account by trader key or returns
@@ -111,7 +121,7 @@ func (db *DB) Account(traderKey *btcec.PublicKey) (*account.Account, error) {
 			return ErrAccountNotFound
 		}
 
-		acct, err = deserializeAccount(bytes.NewReader(accountBytes))
+		acct, err = DeserializeAccount(bytes.NewReader(accountBytes))
 		return err
 	})
 	if err != nil {
@@ -131,7 +141,7 @@ func (db *DB) Accounts() ([]*account.Account, error) {
 		}
 
 		return accounts.ForEach(func(k, v []byte) error {
-			acct, err := deserializeAccount(bytes.NewReader(v))
+			acct, err := DeserializeAccount(bytes.NewReader(v))
 			if err != nil {
 				return err
 			}
@@ -146,45 +156,121 @@ func (db *DB) Accounts() ([]*account.Account, error) {
 	return res, nil
 }
 
-func serializeAccount(w io.Writer, a *account.Account) error {
-	err := WriteElements(
-		w, a.Value, a.Expiry, a.TraderKey, a.AuctioneerKey, a.BatchKey,
-		a.Secret, a.State, a.HeightHint, a.OutPoint,
+// ApplyAccountModifiers decodes a serialized account, applies the given
+// modifiers to it and returns the modified account together with its new
+// serialization. Any unknown odd TLV records of the original serialization are
+// carried over so they survive the update.
+func ApplyAccountModifiers(rawAccount []byte,
+	modifiers []account.Modifier) (*account.Account, []byte, error) {
+
+	dbAccount, unknown, err := deserializeAccount(
+		bytes.NewReader(rawAccount),
 	)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	// The close transaction is only found in the following states.
-	if a.State == account.StatePendingClosed ||
-		a.State == account.StateClosed {
+	for _, modifier := range modifiers {
+		modifier(dbAccount)
+	}
 
-		if err := WriteElement(w, a.CloseTx); err != nil {
-			return err
-		}
+	var accountBuf bytes.Buffer
+	err = serializeAccount(&accountBuf, dbAccount, unknown)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return nil
+	return dbAccount, accountBuf.Bytes(), nil
+}
+
+// SerializeAccount serializes an account to a writer as a TLV stream.
+func SerializeAccount(w io.Writer, a *account.Account) error {
+	return serializeAccount(w, a, nil)
+}
+
+// DeserializeAccount deserializes an account from a TLV stream. Unknown odd
+// types are skipped.
+func DeserializeAccount(r io.Reader) (*account.Account, error) {
+	a, _, err := deserializeAccount(r)
+	return a, err
 }
 
-func deserializeAccount(r io.Reader) (*account.Account, error) {
-	var a account.Account
-	err := ReadElements(
-		r, &a.Value, &a.Expiry, &a.TraderKey, &a.AuctioneerKey,
-		&a.BatchKey, &a.Secret, &a.State, &a.HeightHint, &a.OutPoint,
+// serializeAccount serializes an account and the given unknown records to a
+// writer as a TLV stream.
+func serializeAccount(w io.Writer, a *account.Account,
+	unknown tlv.TypeMap) error {
+
+	records := []tlv.Record{
+		elementRecord(accountValueType, &a.Value),
+		elementRecord(accountExpiryType, &a.Expiry),
+		elementRecord(accountTraderKeyType, &a.TraderKey),
+		elementRecord(accountAuctioneerKeyType, &a.AuctioneerKey),
+		elementRecord(accountBatchKeyType, &a.BatchKey),
+		elementRecord(accountSecretType, &a.Secret),
+		elementRecord(accountStateType, &a.State),
+		elementRecord(accountHeightHintType, &a.HeightHint),
+		elementRecord(accountOutPointType, &a.OutPoint),
+	}
+
+	// The close transaction is only known once the account is being
+	// closed.
+	if a.CloseTx != nil {
+		records = append(
+			records, elementRecord(accountCloseTxType, &a.CloseTx),
+		)
+	}
+	if a.Label != "" {
+		label := []byte(a.Label)
+		records = append(records, tlv.MakePrimitiveRecord(
+			accountLabelType, &label,
+		))
+	}
+	if len(a.Tags) > 0 {
+		records = append(
+			records, elementRecord(accountTagsType, &a.Tags),
+		)
+	}
+
+	return encodeTLVStream(w, unknown, records...)
+}
+
+// deserializeAccount deserializes an account from a TLV stream and returns it
+// together with all unknown odd records found in the stream.
+func deserializeAccount(r io.Reader) (*account.Account, tlv.TypeMap, error) {
+	var (
+		a     account.Account
+		label []byte
+	)
+	parsedTypes, err := decodeTLVStream(
+		r,
+		elementRecord(accountValueType, &a.Value),
+		elementRecord(accountExpiryType, &a.Expiry),
+		elementRecord(accountTraderKeyType, &a.TraderKey),
+		elementRecord(accountAuctioneerKeyType, &a.AuctioneerKey),
+		elementRecord(accountBatchKeyType, &a.BatchKey),
+		elementRecord(accountSecretType, &a.Secret),
+		elementRecord(accountStateType, &a.State),
+		elementRecord(accountHeightHintType, &a.HeightHint),
+		elementRecord(accountOutPointType, &a.OutPoint),
+		elementRecord(accountCloseTxType, &a.CloseTx),
+		tlv.MakePrimitiveRecord(accountLabelType, &label),
+		elementRecord(accountTagsType, &a.Tags),
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// The close transaction is only found in the following states.
-	if a.State == account.StatePendingClosed ||
-		a.State == account.StateClosed {
-
-		if err := ReadElement(r, &a.CloseTx); err != nil {
-			return nil, err
-		}
+	// The close transaction is the only field that isn't always written.
+	err = requireTypes(
+		parsedTypes, accountValueType, accountExpiryType,
+		accountTraderKeyType, accountAuctioneerKeyType,
+		accountBatchKeyType, accountSecretType, accountStateType,
+		accountHeightHintType, accountOutPointType,
+	)
+	if err != nil {
+		return nil, nil, err
 	}
+	a.Label = string(label)
 
-	return &a, nil
+	return &a, unknownRecords(parsedTypes), nil
 }
@@ -108,6 +108,28 @@ func TestAccounts(t *testing.T) {
 	}
 	assertAccountExists(t, db, a)
 
+	// Label the account and attach some tags to it. Removing one of the
+	// tags afterwards should leave the others untouched.
+	err = db.UpdateAccount(
+		a, account.LabelModifier("market maker"),
+		account.TagsModifier(map[string]string{
+			"desk": "alpha", "purpose": "liquidity",
+		}, nil),
+	)
+	if err != nil {
+		t.Fatalf("unable to update account: %v", err)
+	}
+	assertAccountExists(t, db, a)
+	err = db.UpdateAccount(a, account.TagsModifier(nil, []string{"desk"}))
+	if err != nil {
+		t.Fatalf("unable to update account: %v", err)
+	}
+	expectedTags := map[string]string{"purpose": "liquidity"}
+	if !reflect.DeepEqual(a.Tags, expectedTags) {
+		t.Fatalf("unexpected tags: %v", a.Tags)
+	}
+	assertAccountExists(t, db, a)
+
 	// Now, transition the account from StatePendingOpen to
 	// StatePendingClosed and include a closing transaction. If the database
 	// update is successful, the in-memory account should be updated as
@@ -0,0 +1,113 @@
+package clientdb
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/coreos/bbolt"
+)
+
+// Backup writes a consistent snapshot of the whole database to the given
+// writer. The snapshot is taken from a single read transaction so the database
+// can be used concurrently while the backup is written. The database version
+// of the snapshot and the number of bytes written are returned.
+func (db *DB) Backup(w io.Writer) (uint32, int64, error) {
+	var (
+		version uint32
+		n       int64
+	)
+	err := db.View(func(tx *bbolt.Tx) error {
+		metadata, err := getBucket(tx, metadataBucketKey)
+		if err != nil {
+			return err
+		}
+		version, err = getDBVersion(metadata)
+		if err != nil {
+			return err
+		}
+
+		n, err = tx.WriteTo(w)
+		return err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return version, n, nil
+}
+
+// BackupToDir writes a snapshot of the database to a new, time stamped file in
+// the given directory. The file is first written under a temporary name and
+// only renamed once it is completely synced to disk, so an interrupted backup
+// never leaves a truncated snapshot behind. The full path of the created file
+// is returned.
+func (db *DB) BackupToDir(backupDir string) (string, error) {
+	if err := os.MkdirAll(backupDir, 0700); err != nil {
+		return "", err
+	}
+
+	tempFile := filepath.Join(backupDir, dbFilename+".tmp")
+	f, err := os.OpenFile(
+		tempFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, dbFilePermission,
+	)
+	if err != nil {
+		return "", err
+	}
+	version, _, err := db.Backup(f)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(tempFile)
+		return "", err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tempFile)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tempFile)
+		return "", err
+	}
+
+	fileName := fmt.Sprintf(
+		"%s.v%d.%s%s", dbFilename, version,
+		time.Now().UTC().Format("20060102T150405.000000000Z"),
+		migrationBackupSuffix,
+	)
+	backupFile := filepath.Join(backupDir, fileName)
+	if err := os.Rename(tempFile, backupFile); err != nil {
+		return "", err
+	}
+
+	return backupFile, nil
+}
+
+// ListBackups returns the full paths of all backups in the given directory,
+// oldest first.
+func ListBackups(backupDir string) ([]string, error) {
+	return listBackupFiles(backupDir)
+}
+
+// PruneBackups removes the oldest backups in the given directory until at most
+// numRetain backups are left. A value of zero keeps all backups.
+func PruneBackups(backupDir string, numRetain int) error {
+	if numRetain <= 0 {
+		return nil
+	}
+
+	backups, err := listBackupFiles(backupDir)
+	if err != nil {
+		return err
+	}
+	for len(backups) > numRetain {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package clientdb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coreos/bbolt"
+)
+
+// TestBackup makes sure a hot backup contains all records, can be restored and
+// that old backups are pruned.
+func TestBackup(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+
+	acct := fixtureAccount()
+	if err := db.AddAccount(acct); err != nil {
+		t.Fatalf("unable to add account: %v", err)
+	}
+
+	// A snapshot streamed to a writer must be a complete database.
+	var buf bytes.Buffer
+	version, n, err := db.Backup(&buf)
+	if err != nil {
+		t.Fatalf("unable to back up db: %v", err)
+	}
+	if version != latestDBVersion {
+		t.Fatalf("expected version %d, got %d", latestDBVersion,
+			version)
+	}
+	if n != int64(buf.Len()) {
+		t.Fatalf("expected %d bytes written, got %d", buf.Len(), n)
+	}
+
+	backupDir, err := ioutil.TempDir("", "client-db-backup")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(backupDir)
+
+	backupFile := filepath.Join(backupDir, "stream.db")
+	err = ioutil.WriteFile(backupFile, buf.Bytes(), dbFilePermission)
+	if err != nil {
+		t.Fatalf("unable to write backup: %v", err)
+	}
+	if _, err := ValidateBackup(backupFile); err != nil {
+		t.Fatalf("invalid backup: %v", err)
+	}
+
+	// Restoring the snapshot into an empty directory must give us back
+	// the account.
+	restoreDir, err := ioutil.TempDir("", "client-db-restore")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(restoreDir)
+
+	replacedPath, err := RestoreBackup(restoreDir, backupFile)
+	if err != nil {
+		t.Fatalf("unable to restore backup: %v", err)
+	}
+	if replacedPath != "" {
+		t.Fatalf("unexpected replaced db %v", replacedPath)
+	}
+	restoredDB, err := New(restoreDir)
+	if err != nil {
+		t.Fatalf("unable to open restored db: %v", err)
+	}
+	assertAccountExists(t, restoredDB, acct)
+	restoredDB.Close()
+
+	// Only the configured number of scheduled backups must be kept.
+	for i := 0; i < 3; i++ {
+		if _, err := db.BackupToDir(backupDir); err != nil {
+			t.Fatalf("unable to back up db: %v", err)
+		}
+	}
+	backups, err := ListBackups(backupDir)
+	if err != nil {
+		t.Fatalf("unable to list backups: %v", err)
+	}
+	if len(backups) != 3 {
+		t.Fatalf("expected 3 backups, got %d", len(backups))
+	}
+	if err := PruneBackups(backupDir, 2); err != nil {
+		t.Fatalf("unable to prune backups: %v", err)
+	}
+	prunedBackups, err := ListBackups(backupDir)
+	if err != nil {
+		t.Fatalf("unable to list backups: %v", err)
+	}
+	if len(prunedBackups) != 2 {
+		t.Fatalf("expected 2 backups, got %d", len(prunedBackups))
+	}
+	if prunedBackups[1] != backups[2] {
+		t.Fatalf("newest backup was pruned")
+	}
+}
+
+// TestValidateBackupVersion makes sure a snapshot of a newer database version
+// is rejected.
+func TestValidateBackupVersion(t *testing.T) {
+	t.Parallel()
+
+	dir, cleanup := copyFixture(t, fixtureV0)
+	defer cleanup()
+
+	path := filepath.Join(dir, dbFilename)
+	db, err := bbolt.Open(path, dbFilePermission, nil)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		return setDBVersion(
+			tx.Bucket(metadataBucketKey), latestDBVersion+1,
+		)
+	})
+	db.Close()
+	if err != nil {
+		t.Fatalf("unable to set version: %v", err)
+	}
+
+	if _, err := ValidateBackup(path); err != ErrDBReversion {
+		t.Fatalf("expected ErrDBReversion, got %v", err)
+	}
+}
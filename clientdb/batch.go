@@ -33,6 +33,10 @@ var (
 	// updates of an order that has matched in a batch.
 	pendingBatchOrdersBucketKey = []byte("pending-orders")
 
+	// batchCountKey is the key of the top level batch bucket that stores
+	// the number of batches that were completed so far.
+	batchCountKey = []byte("count")
+
 	zeroBatchID order.BatchID
 )
 
@@ -225,8 +229,42 @@ func (db *DB) MarkBatchComplete() error {
 		if _, err := pendingBatchID(tx); err != nil {
 			return err
 		}
-		return applyBatchUpdates(tx)
+		if err := applyBatchUpdates(tx); err != nil {
+			return err
+		}
+
+		bucket, err := getBucket(tx, batchBucketKey)
+		if err != nil {
+			return err
+		}
+		var count [4]byte
+		byteOrder.PutUint32(count[:], batchCount(bucket)+1)
+		return bucket.Put(batchCountKey, count[:])
+	})
+}
+
+// BatchCount returns the number of batches that were completed so far.
+func (db *DB) BatchCount() (uint32, error) {
+	var count uint32
+	err := db.View(func(tx *bbolt.Tx) error {
+		bucket, err := getBucket(tx, batchBucketKey)
+		if err != nil {
+			return err
+		}
+		count = batchCount(bucket)
+		return nil
 	})
+	return count, err
+}
+
+// batchCount returns the number of completed batches stored in the given batch
+// bucket.
+func batchCount(bucket *bbolt.Bucket) uint32 {
+	countBytes := bucket.Get(batchCountKey)
+	if len(countBytes) != 4 {
+		return 0
+	}
+	return byteOrder.Uint32(countBytes)
 }
 
 // applyBatchUpdates applies the staged updates for any accounts and orders that
@@ -3,6 +3,7 @@ package clientdb
 import (
 	"encoding/binary"
 	"io"
+	"sort"
 
 	"github.com/btcsuite/btcd/wire"
 	"github.com/lightninglabs/llm/account"
@@ -52,6 +53,9 @@ func WriteElement(w io.Writer, element interface{}) error {
 	case order.Nonce:
 		return lnwire.WriteElement(w, e[:])
 
+	case order.GroupID:
+		return lnwire.WriteElement(w, e[:])
+
 	case chainfee.SatPerKWeight:
 		return lnwire.WriteElement(w, uint64(e))
 
@@ -79,6 +83,30 @@ func WriteElement(w io.Writer, element interface{}) error {
 			return err
 		}
 
+	// Maps are written with sorted keys so the serialization is
+	// deterministic.
+	case map[string]string:
+		keys := make([]string, 0, len(e))
+		for key := range e {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		err := lnwire.WriteElement(w, uint32(len(keys)))
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			err = wire.WriteVarString(w, 0, key)
+			if err != nil {
+				return err
+			}
+			err = wire.WriteVarString(w, 0, e[key])
+			if err != nil {
+				return err
+			}
+		}
+
 	default:
 		return lnwire.WriteElement(w, element)
 	}
@@ -149,6 +177,11 @@ func ReadElement(r io.Reader, element interface{}) error {
 			return err
 		}
 
+	case *order.GroupID:
+		if err := lnwire.ReadElement(r, e[:]); err != nil {
+			return err
+		}
+
 	case *chainfee.SatPerKWeight:
 		var v uint64
 		if err := lnwire.ReadElement(r, &v); err != nil {
@@ -189,6 +222,26 @@ func ReadElement(r io.Reader, element interface{}) error {
 		}
 		*e = &tx
 
+	case *map[string]string:
+		var numEntries uint32
+		if err := lnwire.ReadElement(r, &numEntries); err != nil {
+			return err
+		}
+
+		m := make(map[string]string)
+		for i := uint32(0); i < numEntries; i++ {
+			key, err := wire.ReadVarString(r, 0)
+			if err != nil {
+				return err
+			}
+			value, err := wire.ReadVarString(r, 0)
+			if err != nil {
+				return err
+			}
+			m[key] = value
+		}
+		*e = m
+
 	default:
 		return lnwire.ReadElement(r, element)
 	}
@@ -48,7 +48,7 @@ func New(dir string) (*DB, error) {
 
 	// Attempt to sync the database's current version with the latest known
 	// version available.
-	if err := syncVersions(db); err != nil {
+	if err := syncVersions(db, MigrationBackupDir(dir)); err != nil {
 		return nil, err
 	}
 
@@ -98,6 +98,10 @@ func initDB(filepath string, firstInit bool) (*bbolt.DB, error) {
 			return err
 		}
 		_, err = tx.CreateBucketIfNotExists(batchBucketKey)
+		if err != nil {
+			return err
+		}
+		_, err = tx.CreateBucketIfNotExists(recoveryBucketKey)
 		return err
 	})
 	if err != nil {
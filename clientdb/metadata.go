@@ -38,7 +38,10 @@ var (
 	// of database don't match with latest version this list will be used
 	// for retrieving all migration function that are need to apply to the
 	// current db.
-	migrations []migration
+	migrations = []migration{
+		// Migration #1 moves accounts and orders to the TLV format.
+		migrateRecordsToTLV,
+	}
 
 	latestDBVersion = uint32(len(migrations))
 )
@@ -89,7 +92,10 @@ func getNestedBucket(bucket *bbolt.Bucket, key []byte,
 // syncVersions function is used for safe db version synchronization. It
 // applies migration functions to the current database and recovers the
 // previous state of db if at least one error/panic appeared during migration.
-func syncVersions(db *bbolt.DB) error {
+// If a backup directory is given, a snapshot of the database is written to it
+// before any migration is applied. After all migrations ran, the content of the
+// database is verified before the changes are committed.
+func syncVersions(db *bbolt.DB, backupDir string) error {
 	var currentVersion uint32
 	err := db.View(func(tx *bbolt.Tx) error {
 		metadata, err := getBucket(tx, metadataBucketKey)
@@ -124,6 +130,17 @@ func syncVersions(db *bbolt.DB) error {
 		return nil
 	}
 
+	// Take a snapshot of the database before touching it so the user
+	// can go back to it should anything go wrong.
+	if backupDir != "" {
+		backupFile, err := backupDB(db, backupDir, currentVersion)
+		if err != nil {
+			return fmt.Errorf("unable to create pre-migration "+
+				"backup: %v", err)
+		}
+		log.Infof("Created pre-migration backup %v", backupFile)
+	}
+
 	log.Infof("Performing database schema migration")
 
 	// Otherwise we execute the migrations serially within a single database
@@ -139,6 +156,13 @@ func syncVersions(db *bbolt.DB) error {
 			}
 		}
 
+		// Make sure all records can still be read after the migration
+		// before we commit it.
+		if err := verifyDB(tx); err != nil {
+			return fmt.Errorf("migrated database failed "+
+				"verification: %v", err)
+		}
+
 		metadata, err := getBucket(tx, metadataBucketKey)
 		if err != nil {
 			return err
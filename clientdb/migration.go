@@ -0,0 +1,356 @@
+package clientdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightninglabs/llm/order"
+)
+
+const (
+	// migrationBackupDirname is the name of the directory within the
+	// database directory that holds the snapshots taken before a migration
+	// is applied.
+	migrationBackupDirname = "migration-backups"
+
+	// migrationBackupSuffix is the file extension of a pre-migration
+	// snapshot.
+	migrationBackupSuffix = ".backup"
+
+	// defaultOpenTimeout is the time we wait for the file lock when opening
+	// an existing database for copying. This prevents us from blocking
+	// forever if the database is currently in use by a running llmd.
+	defaultOpenTimeout = 5 * time.Second
+)
+
+var (
+	// ErrNoDatabase is returned if an operation requires an existing
+	// database file but none was found.
+	ErrNoDatabase = errors.New("no database file found")
+)
+
+// MigrationBackupDir returns the directory in which pre-migration snapshots of
+// the database in the given directory are stored.
+func MigrationBackupDir(dir string) string {
+	return filepath.Join(dir, migrationBackupDirname)
+}
+
+// backupDB writes a consistent snapshot of the database to a new file in the
+// given directory. The file name contains the database version and the time of
+// the backup. The full path of the created file is returned.
+func backupDB(db *bbolt.DB, backupDir string, version uint32) (string, error) {
+	if err := os.MkdirAll(backupDir, 0700); err != nil {
+		return "", err
+	}
+
+	fileName := fmt.Sprintf(
+		"%s.v%d.%s%s", dbFilename, version,
+		time.Now().UTC().Format("20060102T150405Z"),
+		migrationBackupSuffix,
+	)
+	backupFile := filepath.Join(backupDir, fileName)
+	err := db.View(func(tx *bbolt.Tx) error {
+		return tx.CopyFile(backupFile, dbFilePermission)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return backupFile, nil
+}
+
+// verifyDB makes sure all accounts, orders and the pending batch, if there is
+// one, can be deserialized.
+func verifyDB(tx *bbolt.Tx) error {
+	accounts, err := getBucket(tx, accountBucketKey)
+	if err != nil {
+		return err
+	}
+	if err := verifyAccountBucket(accounts); err != nil {
+		return err
+	}
+
+	orders, err := getBucket(tx, ordersBucketKey)
+	if err != nil {
+		return err
+	}
+	if err := verifyOrderBucket(orders); err != nil {
+		return err
+	}
+
+	batch, err := getBucket(tx, batchBucketKey)
+	if err != nil {
+		return err
+	}
+	pendingAccounts := batch.Bucket(pendingBatchAccountsBucketKey)
+	if pendingAccounts != nil {
+		if err := verifyAccountBucket(pendingAccounts); err != nil {
+			return err
+		}
+	}
+	pendingOrders := batch.Bucket(pendingBatchOrdersBucketKey)
+	if pendingOrders != nil {
+		if err := verifyOrderBucket(pendingOrders); err != nil {
+			return err
+		}
+	}
+	if batch.Get(pendingBatchTxKey) != nil {
+		if _, err := pendingBatchTx(tx); err != nil {
+			return fmt.Errorf("invalid pending batch tx: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// verifyAccountBucket makes sure all accounts stored directly in the given
+// bucket can be deserialized.
+func verifyAccountBucket(bucket *bbolt.Bucket) error {
+	return bucket.ForEach(func(k, v []byte) error {
+		if len(k) != 33 || v == nil {
+			return nil
+		}
+		_, err := DeserializeAccount(bytes.NewReader(v))
+		if err != nil {
+			return fmt.Errorf("invalid account %x: %v", k, err)
+		}
+		return nil
+	})
+}
+
+// verifyOrderBucket makes sure all orders stored in the nested per-nonce
+// buckets of the given bucket can be deserialized.
+func verifyOrderBucket(bucket *bbolt.Bucket) error {
+	return bucket.ForEach(func(k, v []byte) error {
+		var nonce order.Nonce
+		if v != nil || len(k) != len(nonce) {
+			return nil
+		}
+		copy(nonce[:], k)
+
+		return fetchOrderTX(bucket, nonce, func(nonce order.Nonce,
+			rawOrder []byte) error {
+
+			r := bytes.NewReader(rawOrder)
+			if _, err := DeserializeOrder(nonce, r); err != nil {
+				return fmt.Errorf("invalid order %x: %v",
+					nonce[:], err)
+			}
+			return nil
+		})
+	})
+}
+
+// DryRunMigration applies all outstanding migrations to a temporary copy of the
+// database in the given directory and verifies the result. The original
+// database is never modified.
+func DryRunMigration(dir string) error {
+	path := filepath.Join(dir, dbFilename)
+	if !fileExists(path) {
+		return ErrNoDatabase
+	}
+
+	tempDir, err := ioutil.TempDir("", "llm-dry-run")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+
+	copyPath := filepath.Join(tempDir, dbFilename)
+	if err := copyDBFile(path, copyPath); err != nil {
+		return fmt.Errorf("unable to copy database: %v", err)
+	}
+
+	db, err := bbolt.Open(copyPath, dbFilePermission, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	log.Infof("Running migrations on temporary copy %v", copyPath)
+
+	return syncVersions(db, "")
+}
+
+// ListMigrationBackups returns the full paths of all pre-migration snapshots of
+// the database in the given directory, oldest first.
+func ListMigrationBackups(dir string) ([]string, error) {
+	return listBackupFiles(MigrationBackupDir(dir))
+}
+
+// listBackupFiles returns the full paths of all database snapshots in the
+// given directory, oldest first.
+func listBackupFiles(backupDir string) ([]string, error) {
+	files, err := ioutil.ReadDir(backupDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []string
+	for _, file := range files {
+		if file.IsDir() ||
+			!strings.HasSuffix(file.Name(), migrationBackupSuffix) {
+
+			continue
+		}
+		backups = append(backups, filepath.Join(backupDir, file.Name()))
+	}
+
+	// The time stamp in the file name sorts lexicographically.
+	sort.Strings(backups)
+
+	return backups, nil
+}
+
+// RestoreBackup replaces the database in the given directory with the given
+// snapshot, which can either be a pre-migration snapshot or a regular backup.
+// The current database is kept next to it with a time stamped suffix so the
+// restore itself can be reverted and its new path is returned. The database
+// must not be in use while it is restored. If the snapshot is at an older
+// version, the outstanding migrations will run the next time the database is
+// opened.
+func RestoreBackup(dir, backupFile string) (string, error) {
+	// Make sure the backup is a valid database before we replace anything.
+	if _, err := ValidateBackup(backupFile); err != nil {
+		return "", fmt.Errorf("invalid backup: %v", err)
+	}
+
+	// Move the current database out of the way instead of deleting it.
+	// We first make sure it isn't in use by trying to acquire its lock.
+	path := filepath.Join(dir, dbFilename)
+	var replacedPath string
+	if fileExists(path) {
+		currentDB, err := bbolt.Open(
+			path, dbFilePermission, &bbolt.Options{
+				Timeout: defaultOpenTimeout,
+			},
+		)
+		if err != nil {
+			return "", fmt.Errorf("unable to lock current "+
+				"database, is llmd still running? %v", err)
+		}
+		if err := currentDB.Close(); err != nil {
+			return "", err
+		}
+
+		replacedPath = fmt.Sprintf(
+			"%s.replaced.%s", path,
+			time.Now().UTC().Format("20060102T150405Z"),
+		)
+		if err := os.Rename(path, replacedPath); err != nil {
+			return "", err
+		}
+	}
+
+	if err := copyFile(backupFile, path); err != nil {
+		return "", err
+	}
+
+	return replacedPath, nil
+}
+
+// ValidateBackup makes sure the given file is a database snapshot this version
+// of the software can open and returns its database version. Snapshots that
+// are already at the latest version are fully verified.
+func ValidateBackup(backupFile string) (uint32, error) {
+	db, err := bbolt.Open(backupFile, dbFilePermission, &bbolt.Options{
+		ReadOnly: true,
+		Timeout:  defaultOpenTimeout,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	var version uint32
+	err = db.View(func(tx *bbolt.Tx) error {
+		metadata, err := getBucket(tx, metadataBucketKey)
+		if err != nil {
+			return err
+		}
+		version, err = getDBVersion(metadata)
+		if err != nil {
+			return err
+		}
+		switch {
+		case version > latestDBVersion:
+			return ErrDBReversion
+
+		// Records of older versions can only be read after the
+		// migrations have been applied.
+		case version < latestDBVersion:
+			return nil
+		}
+
+		return verifyDB(tx)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// copyDBFile creates a consistent copy of a bolt database file. The file lock
+// of the source database is respected so no partially written state is copied.
+func copyDBFile(src, dst string) error {
+	db, err := bbolt.Open(src, dbFilePermission, &bbolt.Options{
+		ReadOnly: true,
+		Timeout:  defaultOpenTimeout,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	return db.View(func(tx *bbolt.Tx) error {
+		return tx.CopyFile(dst, dbFilePermission)
+	})
+}
+
+// copyFile copies a regular file byte by byte and syncs it to disk.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	out, err := os.OpenFile(
+		dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, dbFilePermission,
+	)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
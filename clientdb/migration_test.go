@@ -0,0 +1,423 @@
+package clientdb
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/coreos/bbolt"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/order"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+var (
+	// updateFixtures can be set to regenerate the fixture databases in the
+	// testdata directory:
+	//
+	//	go test ./clientdb -run TestUpdateFixtures -update-fixtures
+	updateFixtures = flag.Bool(
+		"update-fixtures", false, "regenerate the fixture databases",
+	)
+
+	// fixtureV0 is a database at version 0 that contains an account, an
+	// ask, a bid and a pending batch, all in the legacy record format.
+	fixtureV0 = filepath.Join("testdata", "v0.db")
+)
+
+// fixtureAccount returns the account stored in the fixture databases.
+func fixtureAccount() *account.Account {
+	return &account.Account{
+		Value:         btcutil.SatoshiPerBitcoin,
+		Expiry:        1337,
+		TraderKey:     testTraderKeyDesc,
+		AuctioneerKey: testAuctioneerKey,
+		BatchKey:      testBatchKey,
+		Secret:        sharedSecret,
+		State:         account.StateClosed,
+		HeightHint:    1,
+		OutPoint:      testOutPoint,
+		CloseTx: &wire.MsgTx{
+			Version: 2,
+			TxIn: []*wire.TxIn{{
+				PreviousOutPoint: testOutPoint,
+				SignatureScript:  []byte{},
+			}},
+			TxOut: []*wire.TxOut{},
+		},
+	}
+}
+
+// fixtureKit returns an order kit with a deterministic preimage.
+func fixtureKit(preimageByte byte) *order.Kit {
+	kit := order.NewKitWithPreimage(lntypes.Preimage{preimageByte})
+	kit.Version = order.VersionDefault
+	kit.State = order.StateSubmitted
+	kit.FixedRate = 21
+	kit.Amt = 500000
+	kit.Units = 5
+	kit.UnitsUnfulfilled = 5
+	kit.MultiSigKeyLocator = keychain.KeyLocator{
+		Family: 123,
+		Index:  345,
+	}
+	kit.FundingFeeRate = chainfee.FeePerKwFloor
+	copy(kit.AcctKey[:], testTraderKey.SerializeCompressed())
+	return kit
+}
+
+// fixtureOrders returns the ask and bid stored in the fixture databases.
+func fixtureOrders() (*order.Ask, *order.Bid) {
+	ask := &order.Ask{
+		Kit:         *fixtureKit(1),
+		MaxDuration: 2016,
+	}
+	bid := &order.Bid{
+		Kit:         *fixtureKit(2),
+		MinDuration: 1337,
+	}
+	return ask, bid
+}
+
+// createFixtureV0 creates the fixtureV0 database at the given path.
+func createFixtureV0(path string) error {
+	db, err := initDB(path, true)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	acct := fixtureAccount()
+	ask, bid := fixtureOrders()
+	return db.Update(func(tx *bbolt.Tx) error {
+		var acctBuf bytes.Buffer
+		if err := serializeLegacyAccount(&acctBuf, acct); err != nil {
+			return err
+		}
+		accountKey := getAccountKey(acct)
+		err := tx.Bucket(accountBucketKey).Put(
+			accountKey, acctBuf.Bytes(),
+		)
+		if err != nil {
+			return err
+		}
+
+		// Stage an update for the account and the ask in a pending
+		// batch.
+		batch := tx.Bucket(batchBucketKey)
+		pendingAccounts, err := batch.CreateBucket(
+			pendingBatchAccountsBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+		err = pendingAccounts.Put(accountKey, acctBuf.Bytes())
+		if err != nil {
+			return err
+		}
+		pendingOrders, err := batch.CreateBucket(
+			pendingBatchOrdersBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(pendingBatchIDKey, testBatchID[:]); err != nil {
+			return err
+		}
+		var txBuf bytes.Buffer
+		if err := WriteElement(&txBuf, testBatchTx); err != nil {
+			return err
+		}
+		if err := batch.Put(pendingBatchTxKey, txBuf.Bytes()); err != nil {
+			return err
+		}
+
+		for _, o := range []order.Order{ask, bid} {
+			var orderBuf bytes.Buffer
+			if err := serializeLegacyOrder(o, &orderBuf); err != nil {
+				return err
+			}
+			err := storeOrderTX(
+				tx.Bucket(ordersBucketKey), o.Nonce(),
+				orderBuf.Bytes(),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		var askBuf bytes.Buffer
+		if err := serializeLegacyOrder(ask, &askBuf); err != nil {
+			return err
+		}
+		err = storeOrderTX(pendingOrders, ask.Nonce(), askBuf.Bytes())
+		if err != nil {
+			return err
+		}
+
+		return setDBVersion(tx.Bucket(metadataBucketKey), 0)
+	})
+}
+
+// TestUpdateFixtures regenerates the fixture databases if the -update-fixtures
+// flag is set.
+func TestUpdateFixtures(t *testing.T) {
+	if !*updateFixtures {
+		t.Skip("fixture update not requested")
+	}
+
+	if err := os.MkdirAll("testdata", 0700); err != nil {
+		t.Fatal(err)
+	}
+	_ = os.Remove(fixtureV0)
+	if err := createFixtureV0(fixtureV0); err != nil {
+		t.Fatalf("unable to create fixture: %v", err)
+	}
+}
+
+// copyFixture copies the given fixture database into a new temporary directory
+// under the default database file name and returns the directory.
+func copyFixture(t *testing.T, fixture string) (string, func()) {
+	t.Helper()
+
+	tempDir, err := ioutil.TempDir("", "client-db-fixture")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	err = copyFile(fixture, filepath.Join(tempDir, dbFilename))
+	if err != nil {
+		os.RemoveAll(tempDir)
+		t.Fatalf("unable to copy fixture: %v", err)
+	}
+
+	return tempDir, func() {
+		os.RemoveAll(tempDir)
+	}
+}
+
+// fixtureVersion returns the database version of the database file at the
+// given path.
+func fixtureVersion(t *testing.T, path string) uint32 {
+	t.Helper()
+
+	db, err := bbolt.Open(path, dbFilePermission, nil)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	defer db.Close()
+
+	var version uint32
+	err = db.View(func(tx *bbolt.Tx) error {
+		var err error
+		version, err = getDBVersion(tx.Bucket(metadataBucketKey))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to read version: %v", err)
+	}
+	return version
+}
+
+// applyMigration is the test harness for writing migrations against fixture
+// databases. It opens a copy of the fixture without running any of the
+// registered migrations, executes beforeMigration, then runs migrationFunc
+// followed by the same verification step the real migration process uses, all
+// within one transaction. If shouldFail is false, afterMigration is executed
+// on the result. Otherwise the migration is expected to fail and the error is
+// checked to contain failMsg.
+func applyMigration(t *testing.T, fixture string,
+	beforeMigration, afterMigration func(tx *bbolt.Tx) error,
+	migrationFunc migration, shouldFail bool, failMsg string) {
+
+	t.Helper()
+
+	dir, cleanup := copyFixture(t, fixture)
+	defer cleanup()
+
+	db, err := bbolt.Open(
+		filepath.Join(dir, dbFilename), dbFilePermission, nil,
+	)
+	if err != nil {
+		t.Fatalf("unable to open fixture: %v", err)
+	}
+	defer db.Close()
+
+	if beforeMigration != nil {
+		if err := db.Update(beforeMigration); err != nil {
+			t.Fatalf("unable to run beforeMigration: %v", err)
+		}
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		if err := migrationFunc(tx); err != nil {
+			return err
+		}
+		return verifyDB(tx)
+	})
+	switch {
+	case shouldFail && err == nil:
+		t.Fatalf("expected migration to fail")
+
+	case shouldFail && !strings.Contains(err.Error(), failMsg):
+		t.Fatalf("expected error containing '%s', got '%v'", failMsg,
+			err)
+
+	case shouldFail:
+		return
+
+	case err != nil:
+		t.Fatalf("unable to apply migration: %v", err)
+	}
+
+	if afterMigration != nil {
+		if err := db.View(afterMigration); err != nil {
+			t.Fatalf("afterMigration failed: %v", err)
+		}
+	}
+}
+
+// TestMigrationVerification makes sure a migration that leaves an unreadable
+// record behind is rejected.
+func TestMigrationVerification(t *testing.T) {
+	t.Parallel()
+
+	corruptAccount := func(tx *bbolt.Tx) error {
+		if err := migrateRecordsToTLV(tx); err != nil {
+			return err
+		}
+		return tx.Bucket(accountBucketKey).Put(
+			getAccountKey(fixtureAccount()), []byte{0xff},
+		)
+	}
+	applyMigration(
+		t, fixtureV0, nil, nil, corruptAccount, true, "invalid account",
+	)
+}
+
+// TestSyncVersionsBackup makes sure a snapshot is taken before the database is
+// migrated and that it can be restored.
+func TestSyncVersionsBackup(t *testing.T) {
+	t.Parallel()
+
+	dir, cleanup := copyFixture(t, fixtureV0)
+	defer cleanup()
+
+	db, err := New(dir)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	if _, err := db.Account(testTraderKey); err != nil {
+		t.Fatalf("unable to read migrated account: %v", err)
+	}
+	db.Close()
+
+	path := filepath.Join(dir, dbFilename)
+	if v := fixtureVersion(t, path); v != latestDBVersion {
+		t.Fatalf("expected version %d, got %d", latestDBVersion, v)
+	}
+
+	backups, err := ListMigrationBackups(dir)
+	if err != nil {
+		t.Fatalf("unable to list backups: %v", err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("expected 1 backup, got %d", len(backups))
+	}
+	if v := fixtureVersion(t, backups[0]); v != 0 {
+		t.Fatalf("expected backup at version 0, got %d", v)
+	}
+
+	// Restore the backup. The migrated database must be kept around.
+	replacedPath, err := RestoreBackup(dir, backups[0])
+	if err != nil {
+		t.Fatalf("unable to restore backup: %v", err)
+	}
+	if v := fixtureVersion(t, path); v != 0 {
+		t.Fatalf("expected restored version 0, got %d", v)
+	}
+	if v := fixtureVersion(t, replacedPath); v != latestDBVersion {
+		t.Fatalf("expected replaced version %d, got %d",
+			latestDBVersion, v)
+	}
+}
+
+// TestDryRunMigration makes sure a dry run leaves the original database
+// untouched.
+func TestDryRunMigration(t *testing.T) {
+	t.Parallel()
+
+	dir, cleanup := copyFixture(t, fixtureV0)
+	defer cleanup()
+
+	if err := DryRunMigration(dir); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+
+	if v := fixtureVersion(t, filepath.Join(dir, dbFilename)); v != 0 {
+		t.Fatalf("expected version 0 after dry run, got %d", v)
+	}
+	backups, err := ListMigrationBackups(dir)
+	if err != nil {
+		t.Fatalf("unable to list backups: %v", err)
+	}
+	if len(backups) != 0 {
+		t.Fatalf("expected no backups after dry run, got %d",
+			len(backups))
+	}
+}
+
+// assertFixtureRecords is an afterMigration check that makes sure all records
+// of the fixture databases can be read and match the expected values.
+func assertFixtureRecords(tx *bbolt.Tx) error {
+	expectedAcct := fixtureAccount()
+	rawAccount := tx.Bucket(accountBucketKey).Get(
+		getAccountKey(expectedAcct),
+	)
+	acct, err := DeserializeAccount(bytes.NewReader(rawAccount))
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(acct, expectedAcct) {
+		return spewMismatch(expectedAcct, acct)
+	}
+
+	ask, bid := fixtureOrders()
+	for _, expected := range []order.Order{ask, bid} {
+		var dbOrder order.Order
+		err := fetchOrderTX(
+			tx.Bucket(ordersBucketKey), expected.Nonce(),
+			func(nonce order.Nonce, rawOrder []byte) error {
+				var err error
+				dbOrder, err = DeserializeOrder(
+					nonce, bytes.NewReader(rawOrder),
+				)
+				return err
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(dbOrder, expected) {
+			return spewMismatch(expected, dbOrder)
+		}
+	}
+
+	return nil
+}
+
+// spewMismatch returns an error describing a mismatch between an expected and
+// an actual value.
+func spewMismatch(expected, actual interface{}) error {
+	return fmt.Errorf("expected: %v\ngot: %v", spew.Sdump(expected),
+		spew.Sdump(actual))
+}
@@ -0,0 +1,218 @@
+package clientdb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/order"
+)
+
+// migrateRecordsToTLV is the migration that re-encodes all accounts and orders,
+// including the staged ones of a pending batch, from the legacy fixed field
+// format into the extensible TLV format.
+func migrateRecordsToTLV(tx *bbolt.Tx) error {
+	accounts, err := getBucket(tx, accountBucketKey)
+	if err != nil {
+		return err
+	}
+	if err := migrateAccountBucket(accounts); err != nil {
+		return err
+	}
+
+	orders, err := getBucket(tx, ordersBucketKey)
+	if err != nil {
+		return err
+	}
+	if err := migrateOrderBucket(orders); err != nil {
+		return err
+	}
+
+	// The staged updates of a pending batch use the same format and
+	// therefore need to be migrated as well, if there are any.
+	batch, err := getBucket(tx, batchBucketKey)
+	if err != nil {
+		return err
+	}
+	pendingAccounts := batch.Bucket(pendingBatchAccountsBucketKey)
+	if pendingAccounts != nil {
+		if err := migrateAccountBucket(pendingAccounts); err != nil {
+			return err
+		}
+	}
+	pendingOrders := batch.Bucket(pendingBatchOrdersBucketKey)
+	if pendingOrders != nil {
+		if err := migrateOrderBucket(pendingOrders); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateAccountBucket re-encodes all accounts stored directly in the given
+// bucket.
+func migrateAccountBucket(bucket *bbolt.Bucket) error {
+	// We can't modify a bucket while iterating over it, so we collect all
+	// new records first.
+	newAccounts := make(map[string][]byte)
+	err := bucket.ForEach(func(k, v []byte) error {
+		// Filter out any keys that are not for accounts.
+		if len(k) != 33 || v == nil {
+			return nil
+		}
+
+		newAccount, err := convertLegacyAccount(v)
+		if err != nil {
+			return fmt.Errorf("unable to migrate account %x: %v",
+				k, err)
+		}
+		newAccounts[string(k)] = newAccount
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for k, v := range newAccounts {
+		if err := bucket.Put([]byte(k), v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateOrderBucket re-encodes all orders stored in the nested per-nonce
+// buckets of the given bucket.
+func migrateOrderBucket(bucket *bbolt.Bucket) error {
+	var nonces []order.Nonce
+	err := bucket.ForEach(func(k, v []byte) error {
+		// Only go into things that we know are sub-bucket keys.
+		var nonce order.Nonce
+		if v != nil || len(k) != len(nonce) {
+			return nil
+		}
+		copy(nonce[:], k)
+		nonces = append(nonces, nonce)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, nonce := range nonces {
+		orderBucket := bucket.Bucket(nonce[:])
+		orderBytes := orderBucket.Get(orderKey)
+		if orderBytes == nil {
+			continue
+		}
+
+		newOrder, err := convertLegacyOrder(nonce, orderBytes)
+		if err != nil {
+			return fmt.Errorf("unable to migrate order %x: %v",
+				nonce[:], err)
+		}
+		if err := orderBucket.Put(orderKey, newOrder); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// convertLegacyAccount re-encodes an account that was serialized with the
+// legacy fixed field format into the TLV format.
+func convertLegacyAccount(rawAccount []byte) ([]byte, error) {
+	a, err := deserializeLegacyAccount(bytes.NewReader(rawAccount))
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := SerializeAccount(&b, a); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// convertLegacyOrder re-encodes an order that was serialized with the legacy
+// fixed field format into the TLV format.
+func convertLegacyOrder(nonce order.Nonce, rawOrder []byte) ([]byte, error) {
+	o, err := deserializeLegacyOrder(nonce, bytes.NewReader(rawOrder))
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := SerializeOrder(o, &b); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// deserializeLegacyAccount deserializes an account from the legacy fixed field
+// format.
+func deserializeLegacyAccount(r io.Reader) (*account.Account, error) {
+	var a account.Account
+	err := ReadElements(
+		r, &a.Value, &a.Expiry, &a.TraderKey, &a.AuctioneerKey,
+		&a.BatchKey, &a.Secret, &a.State, &a.HeightHint, &a.OutPoint,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The close transaction is only found in the following states.
+	if a.State == account.StatePendingClosed ||
+		a.State == account.StateClosed {
+
+		if err := ReadElement(r, &a.CloseTx); err != nil {
+			return nil, err
+		}
+	}
+
+	return &a, nil
+}
+
+// deserializeLegacyOrder deserializes an order from the legacy fixed field
+// format.
+func deserializeLegacyOrder(nonce order.Nonce, r io.Reader) (order.Order,
+	error) {
+
+	var (
+		kit       = order.NewKit(nonce)
+		orderType order.Type
+	)
+
+	err := ReadElements(
+		r, &kit.Preimage, &kit.Version, &orderType, &kit.State,
+		&kit.FixedRate, &kit.Amt, &kit.Units, &kit.MultiSigKeyLocator,
+		&kit.FundingFeeRate, &kit.AcctKey, &kit.UnitsUnfulfilled,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Now read the order type specific fields.
+	switch orderType {
+	case order.TypeAsk:
+		ask := &order.Ask{Kit: *kit}
+		if err := ReadElement(r, &ask.MaxDuration); err != nil {
+			return nil, err
+		}
+		return ask, nil
+
+	case order.TypeBid:
+		bid := &order.Bid{Kit: *kit}
+		if err := ReadElement(r, &bid.MinDuration); err != nil {
+			return nil, err
+		}
+		return bid, nil
+
+	default:
+		return nil, fmt.Errorf("unknown order type: %d", orderType)
+	}
+}
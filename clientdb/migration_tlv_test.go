@@ -0,0 +1,89 @@
+package clientdb
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/order"
+)
+
+// serializeLegacyAccount serializes an account in the legacy fixed field
+// format.
+func serializeLegacyAccount(w io.Writer, a *account.Account) error {
+	err := WriteElements(
+		w, a.Value, a.Expiry, a.TraderKey, a.AuctioneerKey, a.BatchKey,
+		a.Secret, a.State, a.HeightHint, a.OutPoint,
+	)
+	if err != nil {
+		return err
+	}
+
+	if a.State == account.StatePendingClosed ||
+		a.State == account.StateClosed {
+
+		return WriteElement(w, a.CloseTx)
+	}
+	return nil
+}
+
+// serializeLegacyOrder serializes an order in the legacy fixed field format.
+func serializeLegacyOrder(o order.Order, w io.Writer) error {
+	kit := o.Details()
+	err := WriteElements(
+		w, kit.Preimage, kit.Version, o.Type(), kit.State,
+		kit.FixedRate, kit.Amt, kit.Units, kit.MultiSigKeyLocator,
+		kit.FundingFeeRate, kit.AcctKey, kit.UnitsUnfulfilled,
+	)
+	if err != nil {
+		return err
+	}
+
+	switch t := o.(type) {
+	case *order.Ask:
+		return WriteElement(w, t.MaxDuration)
+
+	case *order.Bid:
+		return WriteElement(w, t.MinDuration)
+	}
+	return nil
+}
+
+// TestMigrateRecordsToTLV makes sure accounts and orders stored in the legacy
+// format, including staged batch updates, are readable after the migration.
+func TestMigrateRecordsToTLV(t *testing.T) {
+	t.Parallel()
+
+	afterMigration := func(tx *bbolt.Tx) error {
+		if err := assertFixtureRecords(tx); err != nil {
+			return err
+		}
+
+		// The staged updates of the pending batch must have been
+		// migrated too.
+		batch := tx.Bucket(batchBucketKey)
+		pendingAccounts := batch.Bucket(pendingBatchAccountsBucketKey)
+		_, err := DeserializeAccount(bytes.NewReader(
+			pendingAccounts.Get(getAccountKey(fixtureAccount())),
+		))
+		if err != nil {
+			return err
+		}
+		ask, _ := fixtureOrders()
+		return fetchOrderTX(
+			batch.Bucket(pendingBatchOrdersBucketKey), ask.Nonce(),
+			func(nonce order.Nonce, rawOrder []byte) error {
+				_, err := DeserializeOrder(
+					nonce, bytes.NewReader(rawOrder),
+				)
+				return err
+			},
+		)
+	}
+	applyMigration(
+		t, fixtureV0, nil, afterMigration, migrateRecordsToTLV, false,
+		"",
+	)
+}
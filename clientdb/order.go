@@ -5,9 +5,62 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/coreos/bbolt"
 	"github.com/lightninglabs/llm/order"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+// The TLV types of an order record.
+const (
+	orderPreimageType         tlv.Type = 0
+	orderVersionType          tlv.Type = 2
+	orderTypeType             tlv.Type = 4
+	orderStateType            tlv.Type = 6
+	orderFixedRateType        tlv.Type = 8
+	orderAmtType              tlv.Type = 10
+	orderUnitsType            tlv.Type = 12
+	orderMultiSigKeyLocType   tlv.Type = 14
+	orderFundingFeeRateType   tlv.Type = 16
+	orderAcctKeyType          tlv.Type = 18
+	orderUnitsUnfulfilledType tlv.Type = 20
+	orderMaxDurationType      tlv.Type = 22
+	orderMinDurationType      tlv.Type = 24
+
+	// The expiry of an order is only enforced by the trader itself, so an
+	// older version that doesn't know about it can safely ignore it.
+	orderExpiryHeightType   tlv.Type = 25
+	orderExpiryTimeType     tlv.Type = 27
+	orderExpiryBatchesType  tlv.Type = 29
+	orderBatchesMatchedType tlv.Type = 31
+
+	// The lineage of replaced orders is purely informational.
+	orderReplacesType   tlv.Type = 33
+	orderReplacedByType tlv.Type = 35
+
+	// The details of a failed submission are only kept for the user's
+	// reference.
+	orderFailedAtType   tlv.Type = 37
+	orderFailStringType tlv.Type = 39
+	orderRejectedType   tlv.Type = 41
+	orderFailReasonType tlv.Type = 43
+
+	// The label and tags of an order are only known to the trader.
+	orderLabelType tlv.Type = 45
+	orderTagsType  tlv.Type = 47
+
+	// Order groups are enforced by the trader only.
+	orderGroupIDType    tlv.Type = 49
+	orderCanceledByType tlv.Type = 51
+
+	// The fill constraints are sent to the auctioneer and verified for
+	// every batch, an older version can safely ignore them.
+	orderMinUnitsMatchType tlv.Type = 53
+	orderAllOrNoneType     tlv.Type = 55
+
+	// The batch an order was submitted at is only needed for its expiry.
+	orderSubmittedAtBatchType tlv.Type = 57
 )
 
 var (
@@ -253,107 +306,276 @@ func updateOrder(src, dst *bbolt.Bucket, nonce order.Nonce,
 	modifiers []order.Modifier) error {
 
 	var (
-		o        order.Order
-		err      error
-		callback = func(nonce order.Nonce, rawOrder []byte) error {
-			r := bytes.NewReader(rawOrder)
-			o, err = DeserializeOrder(nonce, r)
+		newOrderBytes []byte
+		callback      = func(nonce order.Nonce, rawOrder []byte) error {
+			var err error
+			_, newOrderBytes, err = ApplyOrderModifiers(
+				nonce, rawOrder, modifiers,
+			)
 			return err
 		}
 	)
-	// Retrieve the order stored in the database.
-	err = fetchOrderTX(src, nonce, callback)
-	if err != nil {
+
+	// Retrieve the order stored in the database, apply the given
+	// modifications to it and store it back.
+	if err := fetchOrderTX(src, nonce, callback); err != nil {
 		return err
 	}
 
-	// Apply the given modifications to it and store it back.
+	return storeOrderTX(dst, nonce, newOrderBytes)
+}
+
+// ApplyOrderModifiers decodes a serialized order, applies the given modifiers
+// to it and returns the modified order together with its new serialization.
+// Any unknown odd TLV records of the original serialization are carried over so
+// they survive the update.
+func ApplyOrderModifiers(nonce order.Nonce, rawOrder []byte,
+	modifiers []order.Modifier) (order.Order, []byte, error) {
+
+	o, unknown, err := deserializeOrder(nonce, bytes.NewReader(rawOrder))
+	if err != nil {
+		return nil, nil, err
+	}
+
 	for _, modifier := range modifiers {
 		modifier(o.Details())
 	}
+
 	var w bytes.Buffer
-	err = SerializeOrder(o, &w)
-	if err != nil {
-		return err
+	if err := serializeOrder(o, &w, unknown); err != nil {
+		return nil, nil, err
 	}
 
-	return storeOrderTX(dst, nonce, w.Bytes())
+	return o, w.Bytes(), nil
 }
 
-// SerializeOrder binary serializes an order to a writer using the common LN
-// wire format.
+// SerializeOrder serializes an order to a writer as a TLV stream.
 func SerializeOrder(o order.Order, w io.Writer) error {
-	kit := o.Details()
+	return serializeOrder(o, w, nil)
+}
+
+// DeserializeOrder deserializes an order from a TLV stream. Unknown odd types
+// are skipped.
+func DeserializeOrder(nonce order.Nonce, r io.Reader) (order.Order, error) {
+	o, _, err := deserializeOrder(nonce, r)
+	return o, err
+}
 
-	// We don't have to deserialize the nonce as it's the sub bucket name.
-	err := WriteElements(
-		w, kit.Preimage, kit.Version, o.Type(), kit.State,
-		kit.FixedRate, kit.Amt, kit.Units, kit.MultiSigKeyLocator,
-		kit.FundingFeeRate, kit.AcctKey, kit.UnitsUnfulfilled,
+// serializeOrder serializes an order and the given unknown records to a writer
+// as a TLV stream.
+func serializeOrder(o order.Order, w io.Writer, unknown tlv.TypeMap) error {
+	var (
+		kit       = o.Details()
+		orderType = o.Type()
 	)
-	if err != nil {
-		return err
+
+	// We don't have to serialize the nonce as it's the sub bucket name.
+	records := []tlv.Record{
+		elementRecord(orderPreimageType, &kit.Preimage),
+		elementRecord(orderVersionType, &kit.Version),
+		elementRecord(orderTypeType, &orderType),
+		elementRecord(orderStateType, &kit.State),
+		elementRecord(orderFixedRateType, &kit.FixedRate),
+		elementRecord(orderAmtType, &kit.Amt),
+		elementRecord(orderUnitsType, &kit.Units),
+		elementRecord(orderMultiSigKeyLocType, &kit.MultiSigKeyLocator),
+		elementRecord(orderFundingFeeRateType, &kit.FundingFeeRate),
+		elementRecord(orderAcctKeyType, &kit.AcctKey),
+		elementRecord(orderUnitsUnfulfilledType, &kit.UnitsUnfulfilled),
 	}
 
-	// Write order type specific fields.
+	// Add the order type specific fields.
 	switch t := o.(type) {
 	case *order.Ask:
-		err := WriteElement(w, t.MaxDuration)
-		if err != nil {
-			return err
-		}
+		records = append(records, elementRecord(
+			orderMaxDurationType, &t.MaxDuration,
+		))
 
 	case *order.Bid:
-		err := WriteElement(w, t.MinDuration)
-		if err != nil {
-			return err
-		}
+		records = append(records, elementRecord(
+			orderMinDurationType, &t.MinDuration,
+		))
+	}
 
-	default:
-		return fmt.Errorf("unknown order type: %d", o.Type())
+	// The optional fields are only added if they are set.
+	if kit.ExpiryHeight != 0 {
+		records = append(records, elementRecord(
+			orderExpiryHeightType, &kit.ExpiryHeight,
+		))
+	}
+	if !kit.ExpiryTime.IsZero() {
+		expiryTime := uint64(kit.ExpiryTime.Unix())
+		records = append(records, elementRecord(
+			orderExpiryTimeType, &expiryTime,
+		))
+	}
+	if kit.ExpiryBatches != 0 {
+		records = append(records, elementRecord(
+			orderExpiryBatchesType, &kit.ExpiryBatches,
+		))
+	}
+	if kit.BatchesMatched != 0 {
+		records = append(records, elementRecord(
+			orderBatchesMatchedType, &kit.BatchesMatched,
+		))
+	}
+	if kit.SubmittedAtBatch != 0 {
+		records = append(records, elementRecord(
+			orderSubmittedAtBatchType, &kit.SubmittedAtBatch,
+		))
+	}
+	if kit.MinUnitsMatch != 0 {
+		records = append(records, elementRecord(
+			orderMinUnitsMatchType, &kit.MinUnitsMatch,
+		))
+	}
+	if kit.AllOrNone {
+		records = append(records, elementRecord(
+			orderAllOrNoneType, &kit.AllOrNone,
+		))
+	}
+	if kit.Replaces != order.ZeroNonce {
+		records = append(records, elementRecord(
+			orderReplacesType, &kit.Replaces,
+		))
+	}
+	if kit.ReplacedBy != order.ZeroNonce {
+		records = append(records, elementRecord(
+			orderReplacedByType, &kit.ReplacedBy,
+		))
+	}
+	if !kit.FailedAt.IsZero() {
+		failedAt := uint64(kit.FailedAt.UnixNano())
+		failString := []byte(kit.FailString)
+		records = append(
+			records, elementRecord(orderFailedAtType, &failedAt),
+			tlv.MakePrimitiveRecord(
+				orderFailStringType, &failString,
+			),
+		)
+	}
+	if kit.Rejected {
+		records = append(
+			records,
+			elementRecord(orderRejectedType, &kit.Rejected),
+			elementRecord(orderFailReasonType, &kit.FailReason),
+		)
+	}
+	if kit.Label != "" {
+		label := []byte(kit.Label)
+		records = append(records, tlv.MakePrimitiveRecord(
+			orderLabelType, &label,
+		))
+	}
+	if len(kit.Tags) > 0 {
+		records = append(
+			records, elementRecord(orderTagsType, &kit.Tags),
+		)
+	}
+	if kit.GroupID != order.ZeroGroupID {
+		records = append(
+			records, elementRecord(orderGroupIDType, &kit.GroupID),
+		)
+	}
+	if kit.CanceledBy != order.ZeroNonce {
+		records = append(records, elementRecord(
+			orderCanceledByType, &kit.CanceledBy,
+		))
 	}
 
-	return nil
+	return encodeTLVStream(w, unknown, records...)
 }
 
-// DeserializeOrder deserializes an order from the binary LN wire format.
-func DeserializeOrder(nonce order.Nonce, r io.Reader) (
-	order.Order, error) {
+// deserializeOrder deserializes an order from a TLV stream and returns it
+// together with all unknown odd records found in the stream.
+func deserializeOrder(nonce order.Nonce, r io.Reader) (order.Order,
+	tlv.TypeMap, error) {
 
 	var (
-		kit       = order.NewKit(nonce)
-		orderType order.Type
+		kit                      = order.NewKit(nonce)
+		orderType                order.Type
+		maxDuration, minDuration uint32
+		expiryTime, failedAt     uint64
+		failString, label        []byte
 	)
 
 	// We don't serialize the nonce as it's part of the bucket name already.
-	err := ReadElements(
-		r, &kit.Preimage, &kit.Version, &orderType, &kit.State,
-		&kit.FixedRate, &kit.Amt, &kit.Units, &kit.MultiSigKeyLocator,
-		&kit.FundingFeeRate, &kit.AcctKey, &kit.UnitsUnfulfilled,
+	parsedTypes, err := decodeTLVStream(
+		r,
+		elementRecord(orderPreimageType, &kit.Preimage),
+		elementRecord(orderVersionType, &kit.Version),
+		elementRecord(orderTypeType, &orderType),
+		elementRecord(orderStateType, &kit.State),
+		elementRecord(orderFixedRateType, &kit.FixedRate),
+		elementRecord(orderAmtType, &kit.Amt),
+		elementRecord(orderUnitsType, &kit.Units),
+		elementRecord(orderMultiSigKeyLocType, &kit.MultiSigKeyLocator),
+		elementRecord(orderFundingFeeRateType, &kit.FundingFeeRate),
+		elementRecord(orderAcctKeyType, &kit.AcctKey),
+		elementRecord(orderUnitsUnfulfilledType, &kit.UnitsUnfulfilled),
+		elementRecord(orderMaxDurationType, &maxDuration),
+		elementRecord(orderMinDurationType, &minDuration),
+		elementRecord(orderExpiryHeightType, &kit.ExpiryHeight),
+		elementRecord(orderExpiryTimeType, &expiryTime),
+		elementRecord(orderExpiryBatchesType, &kit.ExpiryBatches),
+		elementRecord(orderBatchesMatchedType, &kit.BatchesMatched),
+		elementRecord(orderReplacesType, &kit.Replaces),
+		elementRecord(orderReplacedByType, &kit.ReplacedBy),
+		elementRecord(orderFailedAtType, &failedAt),
+		tlv.MakePrimitiveRecord(orderFailStringType, &failString),
+		elementRecord(orderRejectedType, &kit.Rejected),
+		elementRecord(orderFailReasonType, &kit.FailReason),
+		tlv.MakePrimitiveRecord(orderLabelType, &label),
+		elementRecord(orderTagsType, &kit.Tags),
+		elementRecord(orderGroupIDType, &kit.GroupID),
+		elementRecord(orderCanceledByType, &kit.CanceledBy),
+		elementRecord(orderMinUnitsMatchType, &kit.MinUnitsMatch),
+		elementRecord(orderAllOrNoneType, &kit.AllOrNone),
+		elementRecord(orderSubmittedAtBatchType, &kit.SubmittedAtBatch),
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	err = requireTypes(
+		parsedTypes, orderPreimageType, orderVersionType,
+		orderTypeType, orderStateType, orderFixedRateType, orderAmtType,
+		orderUnitsType, orderMultiSigKeyLocType,
+		orderFundingFeeRateType, orderAcctKeyType,
+		orderUnitsUnfulfilledType,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	unknown := unknownRecords(parsedTypes)
+	if failedAt != 0 {
+		kit.FailedAt = time.Unix(0, int64(failedAt))
+		kit.FailString = string(failString)
+	}
+	kit.Label = string(label)
+	if expiryTime != 0 {
+		kit.ExpiryTime = time.Unix(int64(expiryTime), 0)
 	}
 
-	// Now read the order type specific fields.
+	// Now assemble the order type specific struct.
 	switch orderType {
 	case order.TypeAsk:
-		ask := &order.Ask{Kit: *kit}
-		err = ReadElement(r, &ask.MaxDuration)
+		err := requireTypes(parsedTypes, orderMaxDurationType)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		return ask, nil
+		return &order.Ask{Kit: *kit, MaxDuration: maxDuration},
+			unknown, nil
 
 	case order.TypeBid:
-		bid := &order.Bid{Kit: *kit}
-		err = ReadElement(r, &bid.MinDuration)
+		err := requireTypes(parsedTypes, orderMinDurationType)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		return bid, nil
+		return &order.Bid{Kit: *kit, MinDuration: minDuration},
+			unknown, nil
 
 	default:
-		return nil, fmt.Errorf("unknown order type: %d", orderType)
+		return nil, nil, fmt.Errorf("unknown order type: %d",
+			orderType)
 	}
 }
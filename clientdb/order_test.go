@@ -2,11 +2,14 @@ package clientdb
 
 import (
 	"crypto/rand"
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcutil"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightninglabs/llm/clmrpc"
 	"github.com/lightninglabs/llm/order"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lntypes"
@@ -151,6 +154,288 @@ func TestUpdateOrders(t *testing.T) {
 	}
 }
 
+// TestOrderExpiry tests that the optional expiry fields of an order are stored
+// and updated correctly.
+func TestOrderExpiry(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := newTestDB(t)
+	defer cleanup()
+
+	o := &order.Ask{
+		Kit:         *dummyOrder(t, 500000),
+		MaxDuration: 1337,
+	}
+	o.ExpiryHeight = 650_000
+	o.ExpiryTime = time.Unix(1_600_000_000, 0)
+	o.ExpiryBatches = 3
+	o.SubmittedAtBatch = 7
+	if err := store.SubmitOrder(o); err != nil {
+		t.Fatalf("unable to store order: %v", err)
+	}
+
+	// Match the order in a batch and make sure the counter is persisted
+	// together with the expiry.
+	err := store.UpdateOrder(o.Nonce(), order.BatchMatchedModifier())
+	if err != nil {
+		t.Fatalf("unable to update order: %v", err)
+	}
+	o.BatchesMatched = 1
+
+	storedOrder, err := store.GetOrder(o.Nonce())
+	if err != nil {
+		t.Fatalf("unable to retrieve order: %v", err)
+	}
+	if !reflect.DeepEqual(o, storedOrder) {
+		t.Fatalf("expected order: %v\ngot: %v", spew.Sdump(o),
+			spew.Sdump(storedOrder))
+	}
+}
+
+// TestOrderFillConstraints makes sure the fill constraints of an order are
+// stored and retrieved correctly.
+func TestOrderFillConstraints(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := newTestDB(t)
+	defer cleanup()
+
+	o := &order.Bid{
+		Kit:         *dummyOrder(t, 500000),
+		MinDuration: 1337,
+	}
+	o.Version = order.VersionFillConstraints
+	o.MinUnitsMatch = 2
+	o.AllOrNone = true
+	if err := store.SubmitOrder(o); err != nil {
+		t.Fatalf("unable to store order: %v", err)
+	}
+
+	storedOrder, err := store.GetOrder(o.Nonce())
+	if err != nil {
+		t.Fatalf("unable to retrieve order: %v", err)
+	}
+	if !reflect.DeepEqual(o, storedOrder) {
+		t.Fatalf("expected order: %v\ngot: %v", spew.Sdump(o),
+			spew.Sdump(storedOrder))
+	}
+}
+
+// TestOrderReplacement makes sure the lineage between a replaced order and its
+// replacement is stored correctly.
+func TestOrderReplacement(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := newTestDB(t)
+	defer cleanup()
+
+	oldOrder := &order.Ask{
+		Kit:         *dummyOrder(t, 500000),
+		MaxDuration: 1337,
+	}
+	newOrder := &order.Ask{
+		Kit:         *dummyOrder(t, 500000),
+		MaxDuration: 1337,
+	}
+	oldOrder.Label = "standing ask"
+	newOrder.Replaces = oldOrder.Nonce()
+	newOrder.Label = oldOrder.Label
+	for _, o := range []order.Order{oldOrder, newOrder} {
+		if err := store.SubmitOrder(o); err != nil {
+			t.Fatalf("unable to store order: %v", err)
+		}
+	}
+
+	err := store.UpdateOrder(
+		oldOrder.Nonce(), order.StateModifier(order.StateCanceled),
+		order.ReplacedByModifier(newOrder.Nonce()),
+	)
+	if err != nil {
+		t.Fatalf("unable to update order: %v", err)
+	}
+	oldOrder.State = order.StateCanceled
+	oldOrder.ReplacedBy = newOrder.Nonce()
+
+	for _, o := range []order.Order{oldOrder, newOrder} {
+		storedOrder, err := store.GetOrder(o.Nonce())
+		if err != nil {
+			t.Fatalf("unable to retrieve order: %v", err)
+		}
+		if !reflect.DeepEqual(o, storedOrder) {
+			t.Fatalf("expected order: %v\ngot: %v", spew.Sdump(o),
+				spew.Sdump(storedOrder))
+		}
+	}
+}
+
+// TestOrderLabels makes sure the label and tags of an order are stored and can
+// be changed after the order was created.
+func TestOrderLabels(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := newTestDB(t)
+	defer cleanup()
+
+	o := &order.Bid{
+		Kit:         *dummyOrder(t, 500000),
+		MinDuration: 1337,
+	}
+	o.Label = "hedge"
+	o.Tags = map[string]string{"strategy": "twap", "desk": "alpha"}
+	if err := store.SubmitOrder(o); err != nil {
+		t.Fatalf("unable to store order: %v", err)
+	}
+
+	assertOrder := func() {
+		t.Helper()
+
+		storedOrder, err := store.GetOrder(o.Nonce())
+		if err != nil {
+			t.Fatalf("unable to retrieve order: %v", err)
+		}
+		if !reflect.DeepEqual(o, storedOrder) {
+			t.Fatalf("expected order: %v\ngot: %v", spew.Sdump(o),
+				spew.Sdump(storedOrder))
+		}
+	}
+	assertOrder()
+
+	// Relabel the order, change one tag and remove the other one.
+	err := store.UpdateOrder(
+		o.Nonce(), order.LabelModifier("hedge v2"),
+		order.TagsModifier(
+			map[string]string{"strategy": "vwap"},
+			[]string{"desk"},
+		),
+	)
+	if err != nil {
+		t.Fatalf("unable to update order: %v", err)
+	}
+	o.Label = "hedge v2"
+	o.Tags = map[string]string{"strategy": "vwap"}
+	assertOrder()
+
+	// Removing the last tag and the label should clear them completely.
+	err = store.UpdateOrder(
+		o.Nonce(), order.LabelModifier(""),
+		order.TagsModifier(nil, []string{"strategy"}),
+	)
+	if err != nil {
+		t.Fatalf("unable to update order: %v", err)
+	}
+	o.Label = ""
+	o.Tags = nil
+	assertOrder()
+}
+
+// TestOrderGroup makes sure the group of an order and the member that caused it
+// to be canceled are stored correctly.
+func TestOrderGroup(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := newTestDB(t)
+	defer cleanup()
+
+	matched := &order.Bid{
+		Kit:         *dummyOrder(t, 500000),
+		MinDuration: 1337,
+	}
+	canceled := &order.Bid{
+		Kit:         *dummyOrder(t, 300000),
+		MinDuration: 2016,
+	}
+	matched.GroupID = order.GroupID{0x01, 0x02}
+	canceled.GroupID = matched.GroupID
+	for _, o := range []order.Order{matched, canceled} {
+		if err := store.SubmitOrder(o); err != nil {
+			t.Fatalf("unable to store order: %v", err)
+		}
+	}
+
+	err := store.UpdateOrder(
+		canceled.Nonce(), order.GroupCanceledModifier(matched.Nonce()),
+	)
+	if err != nil {
+		t.Fatalf("unable to update order: %v", err)
+	}
+	canceled.State = order.StateCanceled
+	canceled.CanceledBy = matched.Nonce()
+
+	for _, o := range []order.Order{matched, canceled} {
+		storedOrder, err := store.GetOrder(o.Nonce())
+		if err != nil {
+			t.Fatalf("unable to retrieve order: %v", err)
+		}
+		if !reflect.DeepEqual(o, storedOrder) {
+			t.Fatalf("expected order: %v\ngot: %v", spew.Sdump(o),
+				spew.Sdump(storedOrder))
+		}
+	}
+}
+
+// TestOrderFailed makes sure the details of a failed order submission are
+// stored and retrieved correctly.
+func TestOrderFailed(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := newTestDB(t)
+	defer cleanup()
+
+	rejected := &order.Bid{
+		Kit:         *dummyOrder(t, 500000),
+		MinDuration: 1337,
+	}
+	failed := &order.Bid{
+		Kit:         *dummyOrder(t, 500000),
+		MinDuration: 1337,
+	}
+	for _, o := range []order.Order{rejected, failed} {
+		if err := store.SubmitOrder(o); err != nil {
+			t.Fatalf("unable to store order: %v", err)
+		}
+	}
+
+	failedAt := time.Unix(0, 1_600_000_000_123_456_789)
+	userErr := &order.UserError{
+		FailMsg: "invalid amount",
+		Details: &clmrpc.InvalidOrder{
+			FailReason: clmrpc.InvalidOrder_INVALID_AMT,
+			FailString: "invalid amount",
+		},
+	}
+	err := store.UpdateOrder(
+		rejected.Nonce(), order.FailedModifier(failedAt, userErr),
+	)
+	if err != nil {
+		t.Fatalf("unable to update order: %v", err)
+	}
+	rejected.State = order.StateFailed
+	rejected.FailedAt = failedAt
+	rejected.FailString = "invalid amount"
+	rejected.Rejected = true
+
+	err = store.UpdateOrder(failed.Nonce(), order.FailedModifier(
+		failedAt, errors.New("connection refused"),
+	))
+	if err != nil {
+		t.Fatalf("unable to update order: %v", err)
+	}
+	failed.State = order.StateFailed
+	failed.FailedAt = failedAt
+	failed.FailString = "connection refused"
+
+	for _, o := range []order.Order{rejected, failed} {
+		storedOrder, err := store.GetOrder(o.Nonce())
+		if err != nil {
+			t.Fatalf("unable to retrieve order: %v", err)
+		}
+		if !reflect.DeepEqual(o, storedOrder) {
+			t.Fatalf("expected order: %v\ngot: %v", spew.Sdump(o),
+				spew.Sdump(storedOrder))
+		}
+	}
+}
+
 func dummyOrder(t *testing.T, amt btcutil.Amount) *order.Kit {
 	var testPreimage lntypes.Preimage
 	if _, err := rand.Read(testPreimage[:]); err != nil {
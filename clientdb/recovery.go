@@ -0,0 +1,159 @@
+package clientdb
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/coreos/bbolt"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+var (
+	// recoveryBucketKey is the top level bucket that stores all
+	// information needed to speed up and resume account recovery.
+	recoveryBucketKey = []byte("recovery")
+
+	// accountKeyIndexBucketKey is the sub bucket of the recovery bucket
+	// that maps account keys to their derivation index.
+	accountKeyIndexBucketKey = []byte("account-key-index")
+
+	// recoveryProgressKey is the key of the recovery bucket that stores
+	// the progress of the last account recovery.
+	recoveryProgressKey = []byte("progress")
+)
+
+// AddAccountKeyIndexes adds the derivation index of each of the given account
+// keys to the key index cache.
+func (db *DB) AddAccountKeyIndexes(keys []*keychain.KeyDescriptor) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return db.Update(func(tx *bbolt.Tx) error {
+		recovery, err := getBucket(tx, recoveryBucketKey)
+		if err != nil {
+			return err
+		}
+		keyIndexes, err := getNestedBucket(
+			recovery, accountKeyIndexBucketKey, true,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			var index [4]byte
+			byteOrder.PutUint32(index[:], key.Index)
+			err := keyIndexes.Put(
+				key.PubKey.SerializeCompressed(), index[:],
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// AccountKeyIndex looks up the derivation index of the given account key in
+// the key index cache. If it isn't found, account.ErrKeyIndexNotFound is
+// returned.
+func (db *DB) AccountKeyIndex(key *btcec.PublicKey) (uint32, error) {
+	var index uint32
+	err := db.View(func(tx *bbolt.Tx) error {
+		recovery, err := getBucket(tx, recoveryBucketKey)
+		if err != nil {
+			return err
+		}
+		keyIndexes := recovery.Bucket(accountKeyIndexBucketKey)
+		if keyIndexes == nil {
+			return account.ErrKeyIndexNotFound
+		}
+
+		indexBytes := keyIndexes.Get(key.SerializeCompressed())
+		if indexBytes == nil {
+			return account.ErrKeyIndexNotFound
+		}
+		index = byteOrder.Uint32(indexBytes)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return index, nil
+}
+
+// PutRecoveryProgress stores the progress of the current recovery, replacing
+// any previously stored progress.
+func (db *DB) PutRecoveryProgress(progress *account.RecoveryProgress) error {
+	var buf bytes.Buffer
+	if err := SerializeRecoveryProgress(&buf, progress); err != nil {
+		return err
+	}
+
+	return db.Update(func(tx *bbolt.Tx) error {
+		recovery, err := getBucket(tx, recoveryBucketKey)
+		if err != nil {
+			return err
+		}
+		return recovery.Put(recoveryProgressKey, buf.Bytes())
+	})
+}
+
+// RecoveryProgress returns the stored progress of the last recovery. If there
+// is none, account.ErrNoRecoveryProgress is returned.
+func (db *DB) RecoveryProgress() (*account.RecoveryProgress, error) {
+	var progress *account.RecoveryProgress
+	err := db.View(func(tx *bbolt.Tx) error {
+		recovery, err := getBucket(tx, recoveryBucketKey)
+		if err != nil {
+			return err
+		}
+		rawProgress := recovery.Get(recoveryProgressKey)
+		if rawProgress == nil {
+			return account.ErrNoRecoveryProgress
+		}
+
+		progress, err = DeserializeRecoveryProgress(
+			bytes.NewReader(rawProgress),
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return progress, nil
+}
+
+// SerializeRecoveryProgress binary serializes the given recovery progress to
+// the writer.
+func SerializeRecoveryProgress(w io.Writer,
+	progress *account.RecoveryProgress) error {
+
+	return WriteElements(
+		w, progress.StartIndex, progress.NextIndex, progress.Gap,
+		progress.NumUnused, progress.NumRecovered, progress.Complete,
+	)
+}
+
+// DeserializeRecoveryProgress reconstructs a recovery progress from its binary
+// serialization.
+func DeserializeRecoveryProgress(r io.Reader) (*account.RecoveryProgress,
+	error) {
+
+	var progress account.RecoveryProgress
+	err := ReadElements(
+		r, &progress.StartIndex, &progress.NextIndex, &progress.Gap,
+		&progress.NumUnused, &progress.NumRecovered, &progress.Complete,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &progress, nil
+}
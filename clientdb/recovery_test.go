@@ -0,0 +1,76 @@
+package clientdb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// TestAccountKeyIndex makes sure account key indexes can be cached and looked
+// up again.
+func TestAccountKeyIndex(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+
+	_, err := db.AccountKeyIndex(testTraderKey)
+	if err != account.ErrKeyIndexNotFound {
+		t.Fatalf("expected ErrKeyIndexNotFound, got %v", err)
+	}
+
+	keyDesc := &keychain.KeyDescriptor{
+		KeyLocator: keychain.KeyLocator{Index: 1337},
+		PubKey:     testTraderKey,
+	}
+	err = db.AddAccountKeyIndexes([]*keychain.KeyDescriptor{keyDesc})
+	if err != nil {
+		t.Fatalf("unable to add key index: %v", err)
+	}
+
+	index, err := db.AccountKeyIndex(testTraderKey)
+	if err != nil {
+		t.Fatalf("unable to look up key index: %v", err)
+	}
+	if index != keyDesc.Index {
+		t.Fatalf("expected index %d, got %d", keyDesc.Index, index)
+	}
+}
+
+// TestRecoveryProgress makes sure the progress of a recovery can be stored and
+// retrieved.
+func TestRecoveryProgress(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+
+	_, err := db.RecoveryProgress()
+	if err != account.ErrNoRecoveryProgress {
+		t.Fatalf("expected ErrNoRecoveryProgress, got %v", err)
+	}
+
+	progress := &account.RecoveryProgress{
+		StartIndex:   10,
+		NextIndex:    77,
+		Gap:          50,
+		NumUnused:    12,
+		NumRecovered: 3,
+		Complete:     true,
+	}
+	if err := db.PutRecoveryProgress(progress); err != nil {
+		t.Fatalf("unable to store progress: %v", err)
+	}
+
+	dbProgress, err := db.RecoveryProgress()
+	if err != nil {
+		t.Fatalf("unable to fetch progress: %v", err)
+	}
+	if !reflect.DeepEqual(dbProgress, progress) {
+		t.Fatalf("expected progress: %v\ngot: %v", spew.Sdump(progress),
+			spew.Sdump(dbProgress))
+	}
+}
@@ -0,0 +1,149 @@
+package clientdb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+// Accounts and orders are stored as TLV streams. Even types are required to be
+// understood by any reader while odd types can safely be ignored. A new field
+// must therefore use an odd type, otherwise an older version can't read any
+// record that contains it after a downgrade.
+
+// ErrUnknownRequiredType is returned when decoding a record that contains an
+// even TLV type we don't know. Even types must be understood by the reader, so
+// the record was most likely written by a newer version that isn't compatible.
+type ErrUnknownRequiredType tlv.Type
+
+// Error returns a human readable string describing the error.
+func (e ErrUnknownRequiredType) Error() string {
+	return fmt.Sprintf("record contains unknown required type %d",
+		tlv.Type(e))
+}
+
+// ErrMissingRequiredType is returned when decoding a record that doesn't
+// contain a type that is always written. The record is most likely truncated
+// or corrupted.
+type ErrMissingRequiredType tlv.Type
+
+// Error returns a human readable string describing the error.
+func (e ErrMissingRequiredType) Error() string {
+	return fmt.Sprintf("record is missing required type %d", tlv.Type(e))
+}
+
+// elementRecord creates a TLV record for the value the given element pointer
+// points to. The value is encoded and decoded with this package's
+// WriteElement and ReadElement functions so any type known to the codec can be
+// used within a TLV stream.
+func elementRecord(typ tlv.Type, element interface{}) tlv.Record {
+	sizeFunc := func() uint64 {
+		// An encoding error is surfaced by the encoder itself, so we
+		// can ignore it here.
+		var b bytes.Buffer
+		_ = encodeElement(&b, element, nil)
+		return uint64(b.Len())
+	}
+	return tlv.MakeDynamicRecord(
+		typ, element, sizeFunc, encodeElement, decodeElement,
+	)
+}
+
+// encodeElement is a tlv.Encoder that writes the value the element pointer
+// points to using WriteElement.
+func encodeElement(w io.Writer, element interface{}, _ *[8]byte) error {
+	return WriteElement(w, reflect.ValueOf(element).Elem().Interface())
+}
+
+// decodeElement is a tlv.Decoder that reads exactly l bytes into the given
+// element pointer using ReadElement.
+func decodeElement(r io.Reader, element interface{}, _ *[8]byte,
+	l uint64) error {
+
+	lr := &io.LimitedReader{R: r, N: int64(l)}
+	if err := ReadElement(lr, element); err != nil {
+		return err
+	}
+	if lr.N != 0 {
+		return fmt.Errorf("record has %d unexpected trailing bytes",
+			lr.N)
+	}
+	return nil
+}
+
+// encodeTLVStream writes the given records together with any unknown records
+// that were read when the record was last decoded.
+func encodeTLVStream(w io.Writer, unknown tlv.TypeMap,
+	records ...tlv.Record) error {
+
+	for typ, value := range unknown {
+		records = append(records, tlv.MakeStaticRecord(
+			typ, nil, uint64(len(value)), tlv.StubEncoder(value),
+			nil,
+		))
+	}
+	tlv.SortRecords(records)
+
+	stream, err := tlv.NewStream(records...)
+	if err != nil {
+		return err
+	}
+	return stream.Encode(w)
+}
+
+// decodeTLVStream decodes a TLV stream into the given records and returns all
+// types found in the stream. Known types are mapped to a nil value, unknown odd
+// types to their raw value. An unknown even type results in an
+// ErrUnknownRequiredType error.
+func decodeTLVStream(r io.Reader, records ...tlv.Record) (tlv.TypeMap,
+	error) {
+
+	stream, err := tlv.NewStream(records...)
+	if err != nil {
+		return nil, err
+	}
+	parsedTypes, err := stream.DecodeWithParsedTypes(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for typ, value := range parsedTypes {
+		if value != nil && typ%2 == 0 {
+			return nil, ErrUnknownRequiredType(typ)
+		}
+	}
+
+	return parsedTypes, nil
+}
+
+// requireTypes makes sure all the given types were found when decoding a TLV
+// stream.
+func requireTypes(parsedTypes tlv.TypeMap, types ...tlv.Type) error {
+	for _, typ := range types {
+		if _, ok := parsedTypes[typ]; !ok {
+			return ErrMissingRequiredType(typ)
+		}
+	}
+	return nil
+}
+
+// unknownRecords returns the unknown odd records of a decoded TLV stream so
+// they can be written back when the record is stored again.
+func unknownRecords(parsedTypes tlv.TypeMap) tlv.TypeMap {
+	var unknown tlv.TypeMap
+	for typ, value := range parsedTypes {
+		// Known types are marked with a nil value.
+		if value == nil {
+			continue
+		}
+
+		if unknown == nil {
+			unknown = make(tlv.TypeMap)
+		}
+		unknown[typ] = value
+	}
+	return unknown
+}
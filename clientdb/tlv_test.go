@@ -0,0 +1,252 @@
+package clientdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/coreos/bbolt"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/order"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+// appendRecord appends a raw TLV record to the given stream. The type must be
+// larger than any type already in the stream to keep it canonical.
+func appendRecord(t *testing.T, stream []byte, typ tlv.Type,
+	value []byte) []byte {
+
+	var b bytes.Buffer
+	_, _ = b.Write(stream)
+	err := encodeTLVStream(&b, nil, tlv.MakeStaticRecord(
+		typ, nil, uint64(len(value)), tlv.StubEncoder(value), nil,
+	))
+	if err != nil {
+		t.Fatalf("unable to encode record: %v", err)
+	}
+	return b.Bytes()
+}
+
+// TestUnknownTLVTypes makes sure unknown odd types of accounts and orders are
+// preserved when they are updated and that unknown even types are rejected.
+func TestUnknownTLVTypes(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+
+	acct := &account.Account{
+		Value:         btcutil.SatoshiPerBitcoin,
+		Expiry:        1337,
+		TraderKey:     testTraderKeyDesc,
+		AuctioneerKey: testAuctioneerKey,
+		BatchKey:      testBatchKey,
+		Secret:        sharedSecret,
+		State:         account.StateOpen,
+		HeightHint:    1,
+	}
+	bid := &order.Bid{
+		Kit:         *dummyOrder(t, 500000),
+		MinDuration: 1337,
+	}
+	if err := db.AddAccount(acct); err != nil {
+		t.Fatalf("unable to add account: %v", err)
+	}
+	if err := db.SubmitOrder(bid); err != nil {
+		t.Fatalf("unable to submit order: %v", err)
+	}
+
+	// Simulate a newer version of the software that added an optional
+	// field to both records.
+	const newOddType tlv.Type = 1001
+	newValue := []byte("from the future")
+	acctKey := getAccountKey(acct)
+	err := db.Update(func(tx *bbolt.Tx) error {
+		accounts := tx.Bucket(accountBucketKey)
+		rawAccount := appendRecord(
+			t, accounts.Get(acctKey), newOddType, newValue,
+		)
+		if err := accounts.Put(acctKey, rawAccount); err != nil {
+			return err
+		}
+
+		nonce := bid.Nonce()
+		orderBucket := tx.Bucket(ordersBucketKey).Bucket(nonce[:])
+		rawOrder := appendRecord(
+			t, orderBucket.Get(orderKey), newOddType, newValue,
+		)
+		return orderBucket.Put(orderKey, rawOrder)
+	})
+	if err != nil {
+		t.Fatalf("unable to add unknown records: %v", err)
+	}
+
+	// We should still be able to read and update both records.
+	assertAccountExists(t, db, acct)
+	err = db.UpdateAccount(acct, account.StateModifier(account.StateClosed))
+	if err != nil {
+		t.Fatalf("unable to update account: %v", err)
+	}
+	assertAccountExists(t, db, acct)
+	err = db.UpdateOrder(
+		bid.Nonce(), order.StateModifier(order.StateCanceled),
+	)
+	if err != nil {
+		t.Fatalf("unable to update order: %v", err)
+	}
+
+	// The unknown records must have survived the updates.
+	err = db.View(func(tx *bbolt.Tx) error {
+		rawAccount := tx.Bucket(accountBucketKey).Get(acctKey)
+		_, unknown, err := deserializeAccount(
+			bytes.NewReader(rawAccount),
+		)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(unknown[newOddType], newValue) {
+			t.Fatalf("unknown account record not preserved")
+		}
+
+		nonce := bid.Nonce()
+		rawOrder := tx.Bucket(ordersBucketKey).Bucket(nonce[:]).Get(
+			orderKey,
+		)
+		o, unknown, err := deserializeOrder(
+			nonce, bytes.NewReader(rawOrder),
+		)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(unknown[newOddType], newValue) {
+			t.Fatalf("unknown order record not preserved")
+		}
+		if o.Details().State != order.StateCanceled {
+			t.Fatalf("order update not applied")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An unknown even type must not be silently ignored.
+	var b bytes.Buffer
+	if err := SerializeAccount(&b, acct); err != nil {
+		t.Fatalf("unable to serialize account: %v", err)
+	}
+	rawAccount := appendRecord(t, b.Bytes(), newOddType+1, newValue)
+	_, err = DeserializeAccount(bytes.NewReader(rawAccount))
+	if _, ok := err.(ErrUnknownRequiredType); !ok {
+		t.Fatalf("expected ErrUnknownRequiredType, got %v", err)
+	}
+}
+
+// withoutRecord returns the given TLV stream without the record of the given
+// type.
+func withoutRecord(t *testing.T, stream []byte, typ tlv.Type) []byte {
+	t.Helper()
+
+	// A stream without any known records returns all of them as unknown
+	// raw values.
+	decoder, err := tlv.NewStream()
+	if err != nil {
+		t.Fatalf("unable to create stream: %v", err)
+	}
+	records, err := decoder.DecodeWithParsedTypes(bytes.NewReader(stream))
+	if err != nil {
+		t.Fatalf("unable to decode stream: %v", err)
+	}
+	if _, ok := records[typ]; !ok {
+		t.Fatalf("stream doesn't contain type %d", typ)
+	}
+	delete(records, typ)
+
+	var b bytes.Buffer
+	if err := encodeTLVStream(&b, records); err != nil {
+		t.Fatalf("unable to encode stream: %v", err)
+	}
+	return b.Bytes()
+}
+
+// TestMissingRequiredTLVTypes makes sure accounts and orders that miss a field
+// that is always written can't be decoded.
+func TestMissingRequiredTLVTypes(t *testing.T) {
+	t.Parallel()
+
+	acct := &account.Account{
+		Value:         btcutil.SatoshiPerBitcoin,
+		Expiry:        1337,
+		TraderKey:     testTraderKeyDesc,
+		AuctioneerKey: testAuctioneerKey,
+		BatchKey:      testBatchKey,
+		Secret:        sharedSecret,
+		State:         account.StateOpen,
+		HeightHint:    1,
+	}
+	var rawAccount bytes.Buffer
+	if err := SerializeAccount(&rawAccount, acct); err != nil {
+		t.Fatalf("unable to serialize account: %v", err)
+	}
+
+	bid := &order.Bid{
+		Kit:         *dummyOrder(t, 500000),
+		MinDuration: 1337,
+	}
+	var rawBid bytes.Buffer
+	if err := SerializeOrder(bid, &rawBid); err != nil {
+		t.Fatalf("unable to serialize order: %v", err)
+	}
+
+	// The complete records can be decoded.
+	_, err := DeserializeAccount(bytes.NewReader(rawAccount.Bytes()))
+	if err != nil {
+		t.Fatalf("unable to deserialize account: %v", err)
+	}
+	_, err = DeserializeOrder(bid.Nonce(), bytes.NewReader(rawBid.Bytes()))
+	if err != nil {
+		t.Fatalf("unable to deserialize order: %v", err)
+	}
+
+	testCases := []struct {
+		name   string
+		decode func(typ tlv.Type) error
+		typ    tlv.Type
+	}{{
+		name: "account trader key",
+		decode: func(typ tlv.Type) error {
+			raw := withoutRecord(t, rawAccount.Bytes(), typ)
+			_, err := DeserializeAccount(bytes.NewReader(raw))
+			return err
+		},
+		typ: accountTraderKeyType,
+	}, {
+		name: "order amount",
+		decode: func(typ tlv.Type) error {
+			raw := withoutRecord(t, rawBid.Bytes(), typ)
+			_, err := DeserializeOrder(
+				bid.Nonce(), bytes.NewReader(raw),
+			)
+			return err
+		},
+		typ: orderAmtType,
+	}, {
+		name: "bid min duration",
+		decode: func(typ tlv.Type) error {
+			raw := withoutRecord(t, rawBid.Bytes(), typ)
+			_, err := DeserializeOrder(
+				bid.Nonce(), bytes.NewReader(raw),
+			)
+			return err
+		},
+		typ: orderMinDurationType,
+	}}
+
+	for _, tc := range testCases {
+		err := tc.decode(tc.typ)
+		if err != ErrMissingRequiredType(tc.typ) {
+			t.Fatalf("%s: expected missing type %d, got %v",
+				tc.name, tc.typ, err)
+		}
+	}
+}
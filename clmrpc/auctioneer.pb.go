@@ -126,18 +126,38 @@ const (
 	//The client doesn't support the current batch verification version the
 	//server is using.
 	OrderMatchReject_BATCH_VERSION_MISMATCH OrderMatchReject_RejectReason = 2
+	//
+	//Only some of the client's orders in the batch can't be executed. The
+	//rejected orders are listed in rejected_orders, all other orders of the
+	//client would be accepted if the batch is re-planned without them.
+	OrderMatchReject_PARTIAL_REJECT OrderMatchReject_RejectReason = 3
+	//
+	//The client wasn't able to prepare or complete the funding of the
+	//channels in the batch, for example because a matched peer wasn't
+	//reachable.
+	OrderMatchReject_CHANNEL_FUNDING_FAILED OrderMatchReject_RejectReason = 4
+	//
+	//The client rejects the batch or order because of its own local policy
+	//even though the batch is valid.
+	OrderMatchReject_POLICY_REJECT OrderMatchReject_RejectReason = 5
 )
 
 var OrderMatchReject_RejectReason_name = map[int32]string{
 	0: "UNKNOWN",
 	1: "SERVER_MISBEHAVIOR",
 	2: "BATCH_VERSION_MISMATCH",
+	3: "PARTIAL_REJECT",
+	4: "CHANNEL_FUNDING_FAILED",
+	5: "POLICY_REJECT",
 }
 
 var OrderMatchReject_RejectReason_value = map[string]int32{
 	"UNKNOWN":                0,
 	"SERVER_MISBEHAVIOR":     1,
 	"BATCH_VERSION_MISMATCH": 2,
+	"PARTIAL_REJECT":         3,
+	"CHANNEL_FUNDING_FAILED": 4,
+	"POLICY_REJECT":          5,
 }
 
 func (x OrderMatchReject_RejectReason) String() string {
@@ -181,7 +201,7 @@ func (x SubscribeError_Error) String() string {
 }
 
 func (SubscribeError_Error) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{21, 0}
+	return fileDescriptor_f3883418d94ca37f, []int{22, 0}
 }
 
 type AccountDiff_AccountState int32
@@ -212,7 +232,7 @@ func (x AccountDiff_AccountState) String() string {
 }
 
 func (AccountDiff_AccountState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{26, 0}
+	return fileDescriptor_f3883418d94ca37f, []int{27, 0}
 }
 
 type InvalidOrder_FailReason int32
@@ -234,7 +254,7 @@ func (x InvalidOrder_FailReason) String() string {
 }
 
 func (InvalidOrder_FailReason) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{31, 0}
+	return fileDescriptor_f3883418d94ca37f, []int{32, 0}
 }
 
 type ReserveAccountRequest struct {
@@ -1004,10 +1024,15 @@ type OrderMatchReject struct {
 	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	//
 	//The reason as a code.
-	ReasonCode           OrderMatchReject_RejectReason `protobuf:"varint,3,opt,name=reason_code,json=reasonCode,proto3,enum=clmrpc.OrderMatchReject_RejectReason" json:"reason_code,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
-	XXX_unrecognized     []byte                        `json:"-"`
-	XXX_sizecache        int32                         `json:"-"`
+	ReasonCode OrderMatchReject_RejectReason `protobuf:"varint,3,opt,name=reason_code,json=reasonCode,proto3,enum=clmrpc.OrderMatchReject_RejectReason" json:"reason_code,omitempty"`
+	//
+	//The orders of the client that should be excluded from the batch, keyed by
+	//the hex encoded order nonce. If set, the auctioneer can re-plan the batch
+	//without these orders instead of excluding all orders of the client.
+	RejectedOrders       map[string]*OrderReject `protobuf:"bytes,4,rep,name=rejected_orders,json=rejectedOrders,proto3" json:"rejected_orders,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
 }
 
 func (m *OrderMatchReject) Reset()         { *m = OrderMatchReject{} }
@@ -1056,6 +1081,64 @@ func (m *OrderMatchReject) GetReasonCode() OrderMatchReject_RejectReason {
 	return OrderMatchReject_UNKNOWN
 }
 
+func (m *OrderMatchReject) GetRejectedOrders() map[string]*OrderReject {
+	if m != nil {
+		return m.RejectedOrders
+	}
+	return nil
+}
+
+type OrderReject struct {
+	//
+	//The reason/error string for rejecting the order.
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	//
+	//The reason as a code.
+	ReasonCode           OrderMatchReject_RejectReason `protobuf:"varint,2,opt,name=reason_code,json=reasonCode,proto3,enum=clmrpc.OrderMatchReject_RejectReason" json:"reason_code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *OrderReject) Reset()         { *m = OrderReject{} }
+func (m *OrderReject) String() string { return proto.CompactTextString(m) }
+func (*OrderReject) ProtoMessage()    {}
+func (*OrderReject) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f3883418d94ca37f, []int{13}
+}
+
+func (m *OrderReject) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderReject.Unmarshal(m, b)
+}
+func (m *OrderReject) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderReject.Marshal(b, m, deterministic)
+}
+func (m *OrderReject) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderReject.Merge(m, src)
+}
+func (m *OrderReject) XXX_Size() int {
+	return xxx_messageInfo_OrderReject.Size(m)
+}
+func (m *OrderReject) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderReject.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderReject proto.InternalMessageInfo
+
+func (m *OrderReject) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *OrderReject) GetReasonCode() OrderMatchReject_RejectReason {
+	if m != nil {
+		return m.ReasonCode
+	}
+	return OrderMatchReject_UNKNOWN
+}
+
 type OrderMatchSign struct {
 	//
 	//The ID of the batch that the signatures are meant for.
@@ -1075,7 +1158,7 @@ func (m *OrderMatchSign) Reset()         { *m = OrderMatchSign{} }
 func (m *OrderMatchSign) String() string { return proto.CompactTextString(m) }
 func (*OrderMatchSign) ProtoMessage()    {}
 func (*OrderMatchSign) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{13}
+	return fileDescriptor_f3883418d94ca37f, []int{14}
 }
 
 func (m *OrderMatchSign) XXX_Unmarshal(b []byte) error {
@@ -1123,7 +1206,7 @@ func (m *AccountRecovery) Reset()         { *m = AccountRecovery{} }
 func (m *AccountRecovery) String() string { return proto.CompactTextString(m) }
 func (*AccountRecovery) ProtoMessage()    {}
 func (*AccountRecovery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{14}
+	return fileDescriptor_f3883418d94ca37f, []int{15}
 }
 
 func (m *AccountRecovery) XXX_Unmarshal(b []byte) error {
@@ -1170,7 +1253,7 @@ func (m *ServerAuctionMessage) Reset()         { *m = ServerAuctionMessage{} }
 func (m *ServerAuctionMessage) String() string { return proto.CompactTextString(m) }
 func (*ServerAuctionMessage) ProtoMessage()    {}
 func (*ServerAuctionMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{15}
+	return fileDescriptor_f3883418d94ca37f, []int{16}
 }
 
 func (m *ServerAuctionMessage) XXX_Unmarshal(b []byte) error {
@@ -1323,7 +1406,7 @@ func (m *ServerChallenge) Reset()         { *m = ServerChallenge{} }
 func (m *ServerChallenge) String() string { return proto.CompactTextString(m) }
 func (*ServerChallenge) ProtoMessage()    {}
 func (*ServerChallenge) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{16}
+	return fileDescriptor_f3883418d94ca37f, []int{17}
 }
 
 func (m *ServerChallenge) XXX_Unmarshal(b []byte) error {
@@ -1371,7 +1454,7 @@ func (m *SubscribeSuccess) Reset()         { *m = SubscribeSuccess{} }
 func (m *SubscribeSuccess) String() string { return proto.CompactTextString(m) }
 func (*SubscribeSuccess) ProtoMessage()    {}
 func (*SubscribeSuccess) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{17}
+	return fileDescriptor_f3883418d94ca37f, []int{18}
 }
 
 func (m *SubscribeSuccess) XXX_Unmarshal(b []byte) error {
@@ -1446,7 +1529,7 @@ func (m *OrderMatchPrepare) Reset()         { *m = OrderMatchPrepare{} }
 func (m *OrderMatchPrepare) String() string { return proto.CompactTextString(m) }
 func (*OrderMatchPrepare) ProtoMessage()    {}
 func (*OrderMatchPrepare) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{18}
+	return fileDescriptor_f3883418d94ca37f, []int{19}
 }
 
 func (m *OrderMatchPrepare) XXX_Unmarshal(b []byte) error {
@@ -1543,7 +1626,7 @@ func (m *OrderMatchSignBegin) Reset()         { *m = OrderMatchSignBegin{} }
 func (m *OrderMatchSignBegin) String() string { return proto.CompactTextString(m) }
 func (*OrderMatchSignBegin) ProtoMessage()    {}
 func (*OrderMatchSignBegin) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{19}
+	return fileDescriptor_f3883418d94ca37f, []int{20}
 }
 
 func (m *OrderMatchSignBegin) XXX_Unmarshal(b []byte) error {
@@ -1591,7 +1674,7 @@ func (m *OrderMatchFinalize) Reset()         { *m = OrderMatchFinalize{} }
 func (m *OrderMatchFinalize) String() string { return proto.CompactTextString(m) }
 func (*OrderMatchFinalize) ProtoMessage()    {}
 func (*OrderMatchFinalize) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{20}
+	return fileDescriptor_f3883418d94ca37f, []int{21}
 }
 
 func (m *OrderMatchFinalize) XXX_Unmarshal(b []byte) error {
@@ -1654,7 +1737,7 @@ func (m *SubscribeError) Reset()         { *m = SubscribeError{} }
 func (m *SubscribeError) String() string { return proto.CompactTextString(m) }
 func (*SubscribeError) ProtoMessage()    {}
 func (*SubscribeError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{21}
+	return fileDescriptor_f3883418d94ca37f, []int{22}
 }
 
 func (m *SubscribeError) XXX_Unmarshal(b []byte) error {
@@ -1737,7 +1820,7 @@ func (m *AuctionAccount) Reset()         { *m = AuctionAccount{} }
 func (m *AuctionAccount) String() string { return proto.CompactTextString(m) }
 func (*AuctionAccount) ProtoMessage()    {}
 func (*AuctionAccount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{22}
+	return fileDescriptor_f3883418d94ca37f, []int{23}
 }
 
 func (m *AuctionAccount) XXX_Unmarshal(b []byte) error {
@@ -1839,7 +1922,7 @@ func (m *MatchedOrder) Reset()         { *m = MatchedOrder{} }
 func (m *MatchedOrder) String() string { return proto.CompactTextString(m) }
 func (*MatchedOrder) ProtoMessage()    {}
 func (*MatchedOrder) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{23}
+	return fileDescriptor_f3883418d94ca37f, []int{24}
 }
 
 func (m *MatchedOrder) XXX_Unmarshal(b []byte) error {
@@ -1890,7 +1973,7 @@ func (m *MatchedAsk) Reset()         { *m = MatchedAsk{} }
 func (m *MatchedAsk) String() string { return proto.CompactTextString(m) }
 func (*MatchedAsk) ProtoMessage()    {}
 func (*MatchedAsk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{24}
+	return fileDescriptor_f3883418d94ca37f, []int{25}
 }
 
 func (m *MatchedAsk) XXX_Unmarshal(b []byte) error {
@@ -1941,7 +2024,7 @@ func (m *MatchedBid) Reset()         { *m = MatchedBid{} }
 func (m *MatchedBid) String() string { return proto.CompactTextString(m) }
 func (*MatchedBid) ProtoMessage()    {}
 func (*MatchedBid) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{25}
+	return fileDescriptor_f3883418d94ca37f, []int{26}
 }
 
 func (m *MatchedBid) XXX_Unmarshal(b []byte) error {
@@ -2003,7 +2086,7 @@ func (m *AccountDiff) Reset()         { *m = AccountDiff{} }
 func (m *AccountDiff) String() string { return proto.CompactTextString(m) }
 func (*AccountDiff) ProtoMessage()    {}
 func (*AccountDiff) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{26}
+	return fileDescriptor_f3883418d94ca37f, []int{27}
 }
 
 func (m *AccountDiff) XXX_Unmarshal(b []byte) error {
@@ -2069,7 +2152,8 @@ type ServerOrder struct {
 	//Signature of the order's digest, signed with the user's account key. The
 	//signature must be fixed-size LN wire format encoded. Version 0 includes the
 	//fields version, rate_fixed, amt, funding_fee_rate_sat_per_kw and
-	//min/max_duration_blocks in the order digest.
+	//min/max_duration_blocks in the order digest. Version 1 additionally includes
+	//min_units_match and all_or_none.
 	OrderSig []byte `protobuf:"bytes,7,opt,name=order_sig,json=orderSig,proto3" json:"order_sig,omitempty"`
 	//
 	//The multi signature key of the node creating the order, will be used for the
@@ -2087,17 +2171,25 @@ type ServerOrder struct {
 	//
 	//Preferred fee rate to be used for the channel funding transaction, expressed
 	//in satoshis per 1000 weight units (sat/kW).
-	FundingFeeRateSatPerKw uint64   `protobuf:"varint,13,opt,name=funding_fee_rate_sat_per_kw,json=fundingFeeRateSatPerKw,proto3" json:"funding_fee_rate_sat_per_kw,omitempty"`
-	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
-	XXX_unrecognized       []byte   `json:"-"`
-	XXX_sizecache          int32    `json:"-"`
+	FundingFeeRateSatPerKw uint64 `protobuf:"varint,13,opt,name=funding_fee_rate_sat_per_kw,json=fundingFeeRateSatPerKw,proto3" json:"funding_fee_rate_sat_per_kw,omitempty"`
+	//
+	//The minimum number of units a single matched order must fill. Zero means no
+	//minimum. Only valid for order version 1 or later.
+	MinUnitsMatch uint32 `protobuf:"varint,14,opt,name=min_units_match,json=minUnitsMatch,proto3" json:"min_units_match,omitempty"`
+	//
+	//Whether the order can only be filled completely in a single batch. Only
+	//valid for order version 1 or later.
+	AllOrNone            bool     `protobuf:"varint,15,opt,name=all_or_none,json=allOrNone,proto3" json:"all_or_none,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ServerOrder) Reset()         { *m = ServerOrder{} }
 func (m *ServerOrder) String() string { return proto.CompactTextString(m) }
 func (*ServerOrder) ProtoMessage()    {}
 func (*ServerOrder) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{27}
+	return fileDescriptor_f3883418d94ca37f, []int{28}
 }
 
 func (m *ServerOrder) XXX_Unmarshal(b []byte) error {
@@ -2188,6 +2280,20 @@ func (m *ServerOrder) GetFundingFeeRateSatPerKw() uint64 {
 	return 0
 }
 
+func (m *ServerOrder) GetMinUnitsMatch() uint32 {
+	if m != nil {
+		return m.MinUnitsMatch
+	}
+	return 0
+}
+
+func (m *ServerOrder) GetAllOrNone() bool {
+	if m != nil {
+		return m.AllOrNone
+	}
+	return false
+}
+
 type ServerBid struct {
 	//
 	//The common fields shared between both ask and bid order types.
@@ -2209,7 +2315,7 @@ func (m *ServerBid) Reset()         { *m = ServerBid{} }
 func (m *ServerBid) String() string { return proto.CompactTextString(m) }
 func (*ServerBid) ProtoMessage()    {}
 func (*ServerBid) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{28}
+	return fileDescriptor_f3883418d94ca37f, []int{29}
 }
 
 func (m *ServerBid) XXX_Unmarshal(b []byte) error {
@@ -2272,7 +2378,7 @@ func (m *ServerAsk) Reset()         { *m = ServerAsk{} }
 func (m *ServerAsk) String() string { return proto.CompactTextString(m) }
 func (*ServerAsk) ProtoMessage()    {}
 func (*ServerAsk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{29}
+	return fileDescriptor_f3883418d94ca37f, []int{30}
 }
 
 func (m *ServerAsk) XXX_Unmarshal(b []byte) error {
@@ -2325,7 +2431,7 @@ func (m *CancelOrder) Reset()         { *m = CancelOrder{} }
 func (m *CancelOrder) String() string { return proto.CompactTextString(m) }
 func (*CancelOrder) ProtoMessage()    {}
 func (*CancelOrder) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{30}
+	return fileDescriptor_f3883418d94ca37f, []int{31}
 }
 
 func (m *CancelOrder) XXX_Unmarshal(b []byte) error {
@@ -2366,7 +2472,7 @@ func (m *InvalidOrder) Reset()         { *m = InvalidOrder{} }
 func (m *InvalidOrder) String() string { return proto.CompactTextString(m) }
 func (*InvalidOrder) ProtoMessage()    {}
 func (*InvalidOrder) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{31}
+	return fileDescriptor_f3883418d94ca37f, []int{32}
 }
 
 func (m *InvalidOrder) XXX_Unmarshal(b []byte) error {
@@ -2420,7 +2526,7 @@ func (m *ServerInput) Reset()         { *m = ServerInput{} }
 func (m *ServerInput) String() string { return proto.CompactTextString(m) }
 func (*ServerInput) ProtoMessage()    {}
 func (*ServerInput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{32}
+	return fileDescriptor_f3883418d94ca37f, []int{33}
 }
 
 func (m *ServerInput) XXX_Unmarshal(b []byte) error {
@@ -2462,7 +2568,7 @@ func (m *ServerOutput) Reset()         { *m = ServerOutput{} }
 func (m *ServerOutput) String() string { return proto.CompactTextString(m) }
 func (*ServerOutput) ProtoMessage()    {}
 func (*ServerOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{33}
+	return fileDescriptor_f3883418d94ca37f, []int{34}
 }
 
 func (m *ServerOutput) XXX_Unmarshal(b []byte) error {
@@ -2522,7 +2628,7 @@ func (m *ServerModifyAccountRequest) Reset()         { *m = ServerModifyAccountR
 func (m *ServerModifyAccountRequest) String() string { return proto.CompactTextString(m) }
 func (*ServerModifyAccountRequest) ProtoMessage()    {}
 func (*ServerModifyAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{34}
+	return fileDescriptor_f3883418d94ca37f, []int{35}
 }
 
 func (m *ServerModifyAccountRequest) XXX_Unmarshal(b []byte) error {
@@ -2587,7 +2693,7 @@ func (m *ServerModifyAccountRequest_NewAccountParameters) String() string {
 }
 func (*ServerModifyAccountRequest_NewAccountParameters) ProtoMessage() {}
 func (*ServerModifyAccountRequest_NewAccountParameters) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{34, 0}
+	return fileDescriptor_f3883418d94ca37f, []int{35, 0}
 }
 
 func (m *ServerModifyAccountRequest_NewAccountParameters) XXX_Unmarshal(b []byte) error {
@@ -2629,7 +2735,7 @@ func (m *ServerModifyAccountResponse) Reset()         { *m = ServerModifyAccount
 func (m *ServerModifyAccountResponse) String() string { return proto.CompactTextString(m) }
 func (*ServerModifyAccountResponse) ProtoMessage()    {}
 func (*ServerModifyAccountResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{35}
+	return fileDescriptor_f3883418d94ca37f, []int{36}
 }
 
 func (m *ServerModifyAccountResponse) XXX_Unmarshal(b []byte) error {
@@ -2668,7 +2774,7 @@ func (m *ServerOrderStateRequest) Reset()         { *m = ServerOrderStateRequest
 func (m *ServerOrderStateRequest) String() string { return proto.CompactTextString(m) }
 func (*ServerOrderStateRequest) ProtoMessage()    {}
 func (*ServerOrderStateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{36}
+	return fileDescriptor_f3883418d94ca37f, []int{37}
 }
 
 func (m *ServerOrderStateRequest) XXX_Unmarshal(b []byte) error {
@@ -2714,7 +2820,7 @@ func (m *ServerOrderStateResponse) Reset()         { *m = ServerOrderStateRespon
 func (m *ServerOrderStateResponse) String() string { return proto.CompactTextString(m) }
 func (*ServerOrderStateResponse) ProtoMessage()    {}
 func (*ServerOrderStateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{37}
+	return fileDescriptor_f3883418d94ca37f, []int{38}
 }
 
 func (m *ServerOrderStateResponse) XXX_Unmarshal(b []byte) error {
@@ -2759,7 +2865,7 @@ func (m *FeeQuoteRequest) Reset()         { *m = FeeQuoteRequest{} }
 func (m *FeeQuoteRequest) String() string { return proto.CompactTextString(m) }
 func (*FeeQuoteRequest) ProtoMessage()    {}
 func (*FeeQuoteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{38}
+	return fileDescriptor_f3883418d94ca37f, []int{39}
 }
 
 func (m *FeeQuoteRequest) XXX_Unmarshal(b []byte) error {
@@ -2793,7 +2899,7 @@ func (m *FeeQuoteResponse) Reset()         { *m = FeeQuoteResponse{} }
 func (m *FeeQuoteResponse) String() string { return proto.CompactTextString(m) }
 func (*FeeQuoteResponse) ProtoMessage()    {}
 func (*FeeQuoteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{39}
+	return fileDescriptor_f3883418d94ca37f, []int{40}
 }
 
 func (m *FeeQuoteResponse) XXX_Unmarshal(b []byte) error {
@@ -2837,7 +2943,7 @@ func (m *RelevantBatchRequest) Reset()         { *m = RelevantBatchRequest{} }
 func (m *RelevantBatchRequest) String() string { return proto.CompactTextString(m) }
 func (*RelevantBatchRequest) ProtoMessage()    {}
 func (*RelevantBatchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{40}
+	return fileDescriptor_f3883418d94ca37f, []int{41}
 }
 
 func (m *RelevantBatchRequest) XXX_Unmarshal(b []byte) error {
@@ -2904,7 +3010,7 @@ func (m *RelevantBatch) Reset()         { *m = RelevantBatch{} }
 func (m *RelevantBatch) String() string { return proto.CompactTextString(m) }
 func (*RelevantBatch) ProtoMessage()    {}
 func (*RelevantBatch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{41}
+	return fileDescriptor_f3883418d94ca37f, []int{42}
 }
 
 func (m *RelevantBatch) XXX_Unmarshal(b []byte) error {
@@ -2997,7 +3103,7 @@ func (m *ExecutionFee) Reset()         { *m = ExecutionFee{} }
 func (m *ExecutionFee) String() string { return proto.CompactTextString(m) }
 func (*ExecutionFee) ProtoMessage()    {}
 func (*ExecutionFee) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{42}
+	return fileDescriptor_f3883418d94ca37f, []int{43}
 }
 
 func (m *ExecutionFee) XXX_Unmarshal(b []byte) error {
@@ -3044,7 +3150,7 @@ func (m *NodeAddress) Reset()         { *m = NodeAddress{} }
 func (m *NodeAddress) String() string { return proto.CompactTextString(m) }
 func (*NodeAddress) ProtoMessage()    {}
 func (*NodeAddress) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{43}
+	return fileDescriptor_f3883418d94ca37f, []int{44}
 }
 
 func (m *NodeAddress) XXX_Unmarshal(b []byte) error {
@@ -3095,7 +3201,7 @@ func (m *OutPoint) Reset()         { *m = OutPoint{} }
 func (m *OutPoint) String() string { return proto.CompactTextString(m) }
 func (*OutPoint) ProtoMessage()    {}
 func (*OutPoint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f3883418d94ca37f, []int{44}
+	return fileDescriptor_f3883418d94ca37f, []int{45}
 }
 
 func (m *OutPoint) XXX_Unmarshal(b []byte) error {
@@ -3150,6 +3256,8 @@ func init() {
 	proto.RegisterType((*AccountSubscription)(nil), "clmrpc.AccountSubscription")
 	proto.RegisterType((*OrderMatchAccept)(nil), "clmrpc.OrderMatchAccept")
 	proto.RegisterType((*OrderMatchReject)(nil), "clmrpc.OrderMatchReject")
+	proto.RegisterMapType((map[string]*OrderReject)(nil), "clmrpc.OrderMatchReject.RejectedOrdersEntry")
+	proto.RegisterType((*OrderReject)(nil), "clmrpc.OrderReject")
 	proto.RegisterType((*OrderMatchSign)(nil), "clmrpc.OrderMatchSign")
 	proto.RegisterMapType((map[string][]byte)(nil), "clmrpc.OrderMatchSign.AccountSigsEntry")
 	proto.RegisterType((*AccountRecovery)(nil), "clmrpc.AccountRecovery")
@@ -3191,183 +3299,192 @@ func init() {
 func init() { proto.RegisterFile("auctioneer.proto", fileDescriptor_f3883418d94ca37f) }
 
 var fileDescriptor_f3883418d94ca37f = []byte{
-	// 2812 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0xcd, 0x73, 0xdb, 0xc6,
-	0x15, 0x17, 0x3f, 0x24, 0x92, 0x8f, 0xa4, 0x04, 0xad, 0x64, 0x85, 0xa6, 0xac, 0x5a, 0x46, 0x9a,
-	0xa9, 0xc7, 0x71, 0x95, 0x44, 0x69, 0x26, 0x69, 0x9c, 0x66, 0xca, 0x0f, 0xb0, 0xa4, 0x2d, 0x91,
-	0x2c, 0x40, 0x29, 0xce, 0x09, 0x03, 0x12, 0x4b, 0x72, 0x2b, 0x12, 0x60, 0x00, 0xd0, 0x96, 0x3a,
-	0x99, 0xce, 0x74, 0xa6, 0xa7, 0x9e, 0x7b, 0xed, 0x7f, 0x90, 0x4b, 0x4f, 0xbd, 0xb5, 0xe7, 0xfe,
-	0x0b, 0x9d, 0x69, 0x2f, 0xfd, 0x0b, 0x7a, 0xeb, 0xb1, 0xb3, 0x1f, 0x00, 0x01, 0x10, 0xb2, 0x3c,
-	0xe9, 0xf4, 0x22, 0x71, 0xdf, 0xc7, 0xee, 0xdb, 0xb7, 0xef, 0xfd, 0xde, 0xbe, 0x05, 0x48, 0xc6,
-	0x72, 0xe4, 0x11, 0xdb, 0xc2, 0xd8, 0x39, 0x59, 0x38, 0xb6, 0x67, 0xa3, 0xad, 0xd1, 0x6c, 0xee,
-	0x2c, 0x46, 0xd5, 0x07, 0x13, 0xdb, 0x9e, 0xcc, 0xf0, 0x07, 0xc6, 0x82, 0x7c, 0x60, 0x58, 0x96,
-	0xed, 0x19, 0x54, 0xce, 0xe5, 0x52, 0xf2, 0x17, 0x70, 0x4f, 0xc5, 0x2e, 0x76, 0x5e, 0xe1, 0xda,
-	0x68, 0x64, 0x2f, 0x2d, 0x4f, 0xc5, 0xdf, 0x2c, 0xb1, 0xeb, 0xa1, 0x77, 0xa1, 0x6c, 0x70, 0x8a,
-	0xfe, 0xca, 0x98, 0x2d, 0x71, 0x25, 0x75, 0x9c, 0x7a, 0x9c, 0x55, 0x4b, 0x82, 0x78, 0x49, 0x69,
-	0xf2, 0x15, 0x1c, 0xc4, 0xb5, 0xdd, 0x85, 0x6d, 0xb9, 0x18, 0xbd, 0x07, 0xdb, 0x2b, 0x8b, 0xf4,
-	0x2b, 0x7c, 0xc3, 0xf4, 0x4b, 0x6a, 0x79, 0x45, 0x7d, 0x81, 0x6f, 0xd0, 0x13, 0xd8, 0x25, 0x16,
-	0xf1, 0x88, 0x31, 0xd3, 0x87, 0x86, 0x37, 0x9a, 0x32, 0xc9, 0x34, 0x93, 0xdc, 0x11, 0x8c, 0x3a,
-	0xa5, 0xbf, 0xc0, 0x37, 0xf2, 0xbf, 0x52, 0x50, 0xd1, 0xe8, 0x5a, 0x4e, 0xc7, 0x22, 0x5e, 0xcc,
-	0xdc, 0x4f, 0x56, 0xe6, 0x2e, 0x6c, 0x62, 0x79, 0x6c, 0xb9, 0xe2, 0xa9, 0x74, 0xc2, 0xbd, 0x70,
-	0xd2, 0x5b, 0x7a, 0x7d, 0x4a, 0x0f, 0x36, 0xc0, 0x46, 0xcc, 0x4c, 0xa1, 0xe6, 0x8e, 0x1c, 0xb2,
-	0xf0, 0xc4, 0xe2, 0xfe, 0x64, 0x1a, 0x23, 0xae, 0x3b, 0x23, 0xb3, 0xee, 0x8c, 0xf0, 0x5c, 0xf8,
-	0x7a, 0x41, 0x9c, 0x9b, 0x4a, 0xf6, 0x38, 0xf5, 0xb8, 0x1c, 0xcc, 0xa5, 0x30, 0x22, 0x3a, 0x02,
-	0xf0, 0x1c, 0xc3, 0x14, 0x5e, 0xd9, 0x64, 0xcb, 0x15, 0x38, 0x85, 0xee, 0xf2, 0x10, 0xee, 0x27,
-	0x6c, 0x92, 0x7b, 0x55, 0x76, 0x7d, 0x0f, 0x68, 0xcb, 0xe1, 0x9c, 0x78, 0x3d, 0xc7, 0xc4, 0x8e,
-	0xef, 0x81, 0xf7, 0x20, 0x63, 0xb8, 0x57, 0x62, 0xdf, 0xbb, 0xfe, 0xbe, 0xb9, 0x78, 0xcd, 0xbd,
-	0x6a, 0x6f, 0xa8, 0x94, 0x4f, 0xc5, 0x86, 0xc4, 0x64, 0xdb, 0x5c, 0x13, 0xab, 0x13, 0x93, 0x8a,
-	0x0d, 0x89, 0x59, 0x2f, 0x40, 0xce, 0xc4, 0x9e, 0x41, 0x66, 0xae, 0xfc, 0xdb, 0x94, 0x6f, 0x52,
-	0x64, 0x55, 0x71, 0xd0, 0xcf, 0xa0, 0x4c, 0xac, 0x57, 0xc6, 0x8c, 0x98, 0xba, 0x4d, 0x19, 0xc2,
-	0x80, 0x7d, 0x7f, 0xe6, 0x0e, 0x67, 0x32, 0xa5, 0xf6, 0x86, 0x5a, 0x22, 0xa1, 0x31, 0x7a, 0x00,
-	0x79, 0x63, 0x34, 0xc2, 0x0b, 0x0f, 0x73, 0x8b, 0xf2, 0xed, 0x0d, 0x35, 0xa0, 0x84, 0x6d, 0x78,
-	0xe6, 0x6f, 0xbc, 0x61, 0x58, 0x23, 0x3c, 0x8b, 0x6c, 0xfc, 0x21, 0x14, 0xd9, 0xca, 0xba, 0x65,
-	0x5b, 0x23, 0x2c, 0xe2, 0x0c, 0x18, 0xa9, 0x4b, 0x29, 0x2b, 0x97, 0x46, 0x94, 0x85, 0x4b, 0xff,
-	0x9e, 0x86, 0xfd, 0xc6, 0x8c, 0x60, 0xcb, 0xab, 0xf1, 0xc8, 0x3c, 0xc7, 0xae, 0x6b, 0x4c, 0x30,
-	0xfa, 0x18, 0xb6, 0x46, 0xf6, 0x7c, 0x4e, 0xfc, 0x50, 0xba, 0xef, 0xef, 0x48, 0x1c, 0x4a, 0x83,
-	0x31, 0xe7, 0xd8, 0xf2, 0xda, 0x1b, 0xaa, 0x10, 0x45, 0xcf, 0xa0, 0xe0, 0x2e, 0x87, 0x34, 0x94,
-	0x86, 0x58, 0xf8, 0xf8, 0x30, 0xa6, 0xa7, 0x71, 0xfe, 0x82, 0xae, 0xd5, 0xde, 0x50, 0x57, 0xf2,
-	0xe8, 0x14, 0xb6, 0xf8, 0xde, 0x59, 0x78, 0x15, 0x4f, 0x2b, 0x41, 0xf0, 0x52, 0x8b, 0xcf, 0x69,
-	0x1e, 0xd4, 0x18, 0x9f, 0x2e, 0xc8, 0x25, 0xa9, 0x8e, 0x83, 0x7f, 0x85, 0x47, 0x1e, 0x0b, 0xb6,
-	0x44, 0x1d, 0x95, 0xf1, 0xa9, 0x0e, 0x97, 0x44, 0x4f, 0x21, 0xeb, 0x92, 0x89, 0xc5, 0x62, 0xaf,
-	0x78, 0x7a, 0xb0, 0xae, 0xa1, 0x91, 0x09, 0x35, 0x8d, 0x49, 0xa1, 0x8f, 0x21, 0xe7, 0xe0, 0x91,
-	0xfd, 0x0a, 0x3b, 0x95, 0x2d, 0xa6, 0xf0, 0x4e, 0x6c, 0x43, 0x2a, 0xe7, 0xde, 0xb4, 0x37, 0x54,
-	0x5f, 0xb2, 0xbe, 0x09, 0x99, 0xb9, 0x3b, 0x91, 0xbf, 0x86, 0xdd, 0x35, 0x6f, 0xd1, 0xf3, 0xe2,
-	0xde, 0xd2, 0xa7, 0x86, 0x3b, 0xf5, 0xcf, 0x8b, 0x93, 0xda, 0x86, 0x3b, 0xa5, 0xd9, 0xc6, 0xc1,
-	0xe0, 0x15, 0x76, 0x5c, 0x62, 0x5b, 0xcc, 0x91, 0x65, 0xb5, 0xc4, 0x88, 0x97, 0x9c, 0x26, 0x3b,
-	0xb0, 0x97, 0xe0, 0xd0, 0x58, 0x76, 0xa5, 0x62, 0xd9, 0x85, 0x1e, 0x41, 0x49, 0xac, 0xcd, 0x83,
-	0x85, 0x67, 0xbb, 0xb0, 0x87, 0x45, 0x0b, 0xba, 0x0f, 0x79, 0x63, 0xe9, 0x4d, 0x75, 0x97, 0x4c,
-	0xd8, 0x39, 0x94, 0xd4, 0x1c, 0x1d, 0x6b, 0x64, 0x22, 0x77, 0x41, 0x8a, 0x1f, 0xc5, 0x7a, 0xf4,
-	0x65, 0xa2, 0xd1, 0x47, 0xe7, 0xe3, 0xbb, 0x11, 0x59, 0x57, 0x52, 0x73, 0x6c, 0xdc, 0x31, 0xe5,
-	0x7f, 0xa6, 0xc2, 0x13, 0xf2, 0x73, 0x8a, 0xc8, 0xa7, 0x22, 0xf2, 0xe8, 0x80, 0x1e, 0xb6, 0xe1,
-	0x0a, 0x8f, 0x14, 0x54, 0x31, 0x42, 0x2d, 0x28, 0xf2, 0x5f, 0xfa, 0xc8, 0x36, 0x39, 0x38, 0x6d,
-	0x9f, 0xbe, 0x77, 0x5b, 0x24, 0x9c, 0xf0, 0x7f, 0x2a, 0xd3, 0x50, 0x81, 0x6b, 0x36, 0x6c, 0x13,
-	0xcb, 0x3d, 0x28, 0x85, 0x79, 0xa8, 0x08, 0xb9, 0x8b, 0xee, 0x8b, 0x6e, 0xef, 0xab, 0xae, 0xb4,
-	0x81, 0x0e, 0x00, 0x69, 0x8a, 0x7a, 0xa9, 0xa8, 0xfa, 0x79, 0x47, 0xab, 0x2b, 0xed, 0xda, 0x65,
-	0xa7, 0xa7, 0x4a, 0x29, 0x54, 0x85, 0x83, 0x7a, 0x6d, 0xd0, 0x68, 0xeb, 0x97, 0x8a, 0xaa, 0x75,
-	0x7a, 0x5d, 0xca, 0x3e, 0xa7, 0x04, 0x29, 0x2d, 0xff, 0x39, 0x05, 0xdb, 0xd1, 0xb0, 0x7a, 0xd3,
-	0xf6, 0x9e, 0x43, 0x29, 0x00, 0x63, 0x32, 0x71, 0x2b, 0xe9, 0xe3, 0xcc, 0xe3, 0xe2, 0xe9, 0x8f,
-	0x92, 0xe3, 0x33, 0x48, 0x27, 0x32, 0x71, 0x15, 0xcb, 0x73, 0x6e, 0xd4, 0xa2, 0xb1, 0xa2, 0x54,
-	0xbf, 0x04, 0x29, 0x2e, 0x80, 0x24, 0xc8, 0xf8, 0x41, 0x51, 0x50, 0xe9, 0x4f, 0xb4, 0x0f, 0x9b,
-	0x1c, 0xcf, 0xf9, 0xc1, 0xf0, 0xc1, 0xe7, 0xe9, 0xcf, 0x52, 0xf2, 0x87, 0xb0, 0x13, 0x0b, 0xef,
-	0x3b, 0x42, 0x4b, 0xfe, 0x43, 0x06, 0xf6, 0x05, 0xda, 0x46, 0x81, 0xe4, 0x53, 0x28, 0x8c, 0xa6,
-	0xc6, 0x6c, 0x86, 0xad, 0x09, 0x16, 0x58, 0xf2, 0x4e, 0x14, 0x77, 0x1b, 0x3e, 0x9b, 0xe2, 0x41,
-	0x20, 0x8b, 0x7e, 0x02, 0x39, 0x77, 0x39, 0x1a, 0x61, 0xd7, 0x15, 0x50, 0x12, 0x24, 0xb7, 0xe6,
-	0x63, 0x86, 0xc6, 0xf9, 0x34, 0xf5, 0x84, 0x28, 0x3a, 0x81, 0x4d, 0xec, 0x38, 0xb6, 0x23, 0x40,
-	0xe4, 0x60, 0x4d, 0x47, 0xa1, 0xdc, 0xf6, 0x86, 0xca, 0xc5, 0xd0, 0x27, 0x90, 0x5b, 0x38, 0x78,
-	0x61, 0x38, 0x58, 0x40, 0xc8, 0xfd, 0x75, 0x87, 0xf7, 0xb9, 0x00, 0x5d, 0x46, 0xc8, 0xa2, 0x8f,
-	0x22, 0x20, 0x72, 0x98, 0x7c, 0x48, 0x75, 0x3c, 0x21, 0x2b, 0x24, 0xf9, 0x0c, 0xf2, 0x63, 0x62,
-	0x19, 0x33, 0xf2, 0x6b, 0x2c, 0xa0, 0xa4, 0xba, 0xae, 0xd6, 0x12, 0x12, 0xb4, 0x12, 0xf8, 0xd2,
-	0xe8, 0x14, 0x72, 0xe2, 0x70, 0x2b, 0xb9, 0xe8, 0xae, 0x84, 0xaf, 0xc5, 0x59, 0x51, 0x03, 0x85,
-	0xa0, 0x0f, 0x41, 0x7d, 0xd8, 0x89, 0x39, 0x19, 0x3d, 0x88, 0x1f, 0x48, 0x29, 0xec, 0xf5, 0x18,
-	0x3c, 0xa5, 0xe3, 0xf0, 0x24, 0x7f, 0x04, 0x52, 0xdc, 0xff, 0x77, 0xc5, 0xc6, 0x77, 0x59, 0xd8,
-	0x5d, 0xf3, 0x26, 0xd2, 0x60, 0x7b, 0x4e, 0xc7, 0x58, 0x94, 0x4e, 0x97, 0xa1, 0x47, 0xf1, 0xf4,
-	0xe9, 0xad, 0x07, 0x70, 0x72, 0xce, 0xe5, 0x19, 0x43, 0x84, 0x7d, 0x79, 0x1e, 0xa6, 0xa1, 0x13,
-	0xd8, 0x1b, 0xcd, 0xb0, 0xe1, 0x10, 0x6b, 0xa2, 0x2f, 0x1c, 0x32, 0xc2, 0xba, 0x63, 0x78, 0x58,
-	0x40, 0xe8, 0xae, 0xcf, 0xea, 0x53, 0x8e, 0x6a, 0x78, 0x18, 0x7d, 0x09, 0xd2, 0x68, 0x6a, 0x38,
-	0x13, 0x6c, 0xea, 0xc2, 0x73, 0x6e, 0x25, 0xc3, 0xcc, 0xd8, 0x8b, 0xe1, 0x7c, 0x93, 0x8c, 0xc7,
-	0xea, 0x8e, 0x10, 0x16, 0x34, 0x17, 0xfd, 0x14, 0xca, 0xf8, 0x1a, 0x8f, 0x96, 0xf4, 0x14, 0xf4,
-	0x31, 0xf6, 0x83, 0x28, 0xa8, 0xff, 0x8a, 0xcf, 0x6c, 0x61, 0xac, 0x96, 0x70, 0x68, 0x84, 0xde,
-	0x87, 0x5d, 0x0e, 0x05, 0x9e, 0x63, 0x58, 0xae, 0xc1, 0x0e, 0x52, 0x5c, 0x88, 0x24, 0xc6, 0x18,
-	0xac, 0xe8, 0xe8, 0x29, 0xec, 0x8d, 0x31, 0xdf, 0x8c, 0xee, 0x1a, 0x9e, 0xbe, 0xa0, 0xbe, 0x7e,
-	0xcd, 0xe2, 0x28, 0xab, 0xee, 0x8c, 0x31, 0xdb, 0x8d, 0x66, 0x78, 0x7d, 0xec, 0xbc, 0x78, 0x8d,
-	0x7e, 0x08, 0xdb, 0x4c, 0x1a, 0x0f, 0x85, 0x3c, 0x8b, 0x9b, 0xac, 0x5a, 0xa2, 0x82, 0x8c, 0xa8,
-	0x19, 0x51, 0xa8, 0xcd, 0x47, 0xb1, 0x68, 0xad, 0x06, 0x15, 0xd6, 0x6b, 0x50, 0xf5, 0x12, 0xd0,
-	0xfa, 0x81, 0x24, 0xc0, 0xcc, 0x93, 0x30, 0xcc, 0x84, 0x7c, 0x13, 0x56, 0x8e, 0x82, 0xcf, 0x5e,
-	0x42, 0x1e, 0xbd, 0x01, 0x3a, 0x65, 0x1b, 0xd0, 0x7a, 0x0a, 0xbd, 0x09, 0x6b, 0x8f, 0x00, 0x84,
-	0xef, 0xaf, 0x83, 0xba, 0x54, 0xe0, 0x4e, 0xbf, 0x26, 0x26, 0x4d, 0x82, 0x29, 0x26, 0x93, 0xa9,
-	0xa7, 0x4f, 0xe9, 0x65, 0x3a, 0xc3, 0x36, 0x0f, 0x9c, 0xd4, 0x26, 0x96, 0x27, 0xff, 0x2d, 0x05,
-	0xdb, 0x51, 0x44, 0xa1, 0x60, 0xca, 0x81, 0x87, 0xef, 0x5c, 0xc0, 0xcb, 0x33, 0x00, 0xf6, 0x83,
-	0x97, 0xa6, 0x34, 0x2b, 0x4d, 0x0f, 0x92, 0x31, 0xe9, 0x84, 0xfd, 0x55, 0x0b, 0x4c, 0x9e, 0x16,
-	0xa4, 0x58, 0x5a, 0x65, 0xe2, 0x69, 0xa5, 0xc0, 0x26, 0x5f, 0x3a, 0x52, 0xa8, 0xf6, 0x60, 0x47,
-	0x14, 0x2a, 0xad, 0x7d, 0x31, 0x68, 0x52, 0x22, 0xab, 0x52, 0xb5, 0x46, 0xa3, 0x77, 0xd1, 0x1d,
-	0xe8, 0xcd, 0x9e, 0xa2, 0xe9, 0xdd, 0xde, 0x40, 0x57, 0x5e, 0x76, 0xb4, 0x81, 0x94, 0x96, 0xff,
-	0x92, 0x86, 0xed, 0x28, 0x8e, 0xac, 0x0a, 0x03, 0xef, 0x7a, 0xf8, 0x80, 0xd6, 0x5f, 0x71, 0xb3,
-	0xe7, 0xe9, 0x24, 0x46, 0x77, 0x98, 0x99, 0xd0, 0x0b, 0x65, 0x93, 0x7a, 0xa1, 0x43, 0x28, 0xac,
-	0x7a, 0x20, 0x9e, 0x06, 0xfc, 0xf8, 0x28, 0xf3, 0x23, 0xd8, 0x74, 0x3d, 0x9a, 0xc8, 0x5b, 0xcc,
-	0x83, 0x87, 0xc9, 0xf8, 0xa7, 0x51, 0x11, 0x95, 0x4b, 0xc6, 0xcf, 0x30, 0x17, 0x3f, 0x43, 0xf4,
-	0x14, 0xf2, 0xf6, 0xd2, 0xe3, 0xed, 0x52, 0xfe, 0x96, 0x76, 0x29, 0x90, 0xa0, 0xc1, 0x34, 0x9a,
-	0xd9, 0x2e, 0xd6, 0xbd, 0x6b, 0x96, 0x0c, 0x25, 0x35, 0xc7, 0xc6, 0x83, 0x6b, 0xf9, 0x5b, 0x28,
-	0x85, 0x43, 0x19, 0x7d, 0x02, 0x25, 0x1f, 0xd8, 0x86, 0xc4, 0xf4, 0x61, 0x0d, 0xc5, 0xc2, 0xbe,
-	0x4e, 0x4c, 0xb5, 0x38, 0x0f, 0x7e, 0xbb, 0x61, 0x35, 0xc3, 0xbd, 0xf2, 0xeb, 0x7f, 0x5c, 0xad,
-	0xe6, 0x5e, 0x05, 0x6a, 0x35, 0xf7, 0xca, 0x95, 0x07, 0x00, 0x2b, 0x16, 0x7a, 0xf7, 0xcd, 0x6d,
-	0x10, 0x6f, 0x82, 0x1e, 0x41, 0x69, 0x69, 0x11, 0xcf, 0xd5, 0xc7, 0x64, 0x36, 0x13, 0xbd, 0x47,
-	0x59, 0x2d, 0x32, 0x5a, 0x8b, 0x91, 0x42, 0xb3, 0xd6, 0x09, 0x85, 0x03, 0xd6, 0x35, 0xa5, 0x6e,
-	0xe9, 0x9a, 0x58, 0xcf, 0xf4, 0x36, 0xb3, 0xfe, 0x35, 0x0d, 0xc5, 0x10, 0x9c, 0xd2, 0xd0, 0xc0,
-	0x96, 0x49, 0xb1, 0x7a, 0x68, 0xcc, 0x0c, 0xbf, 0x7d, 0xc9, 0xaa, 0x65, 0x4e, 0xad, 0x73, 0x22,
-	0x6a, 0x40, 0x49, 0x88, 0xf1, 0x20, 0xe0, 0x69, 0x74, 0x9c, 0x00, 0xd0, 0x27, 0x91, 0x48, 0x28,
-	0x72, 0x2d, 0x36, 0xa0, 0x6b, 0xf9, 0x87, 0xa9, 0x13, 0xcb, 0xc4, 0xd7, 0x2c, 0x52, 0x37, 0xd5,
-	0xb2, 0x4f, 0xed, 0x50, 0x62, 0x2c, 0x98, 0xb3, 0xf1, 0x9c, 0xfb, 0x0d, 0x94, 0xc2, 0x4b, 0xa0,
-	0x7d, 0x90, 0x7a, 0x17, 0x83, 0xfe, 0xc5, 0x40, 0x57, 0x95, 0x86, 0xaa, 0xd4, 0x06, 0x4a, 0x53,
-	0xda, 0x40, 0x8f, 0xe0, 0x48, 0x50, 0x9b, 0x17, 0x1a, 0xcd, 0xb4, 0x81, 0xd2, 0x6d, 0x2a, 0x4d,
-	0xbd, 0xd7, 0x6a, 0x35, 0xda, 0xb5, 0x0e, 0xcd, 0xc8, 0x23, 0xb8, 0x1f, 0x16, 0xa9, 0x35, 0x29,
-	0x7f, 0xd0, 0xd3, 0x5b, 0x8a, 0xa2, 0x49, 0x69, 0x7a, 0xdd, 0x14, 0xec, 0xd6, 0xc5, 0xd9, 0xd9,
-	0xd7, 0xba, 0xd6, 0x57, 0xba, 0x03, 0x29, 0x23, 0xff, 0x27, 0x0d, 0x45, 0xee, 0x77, 0x1e, 0x6b,
-	0x77, 0x5c, 0xf8, 0x8f, 0x00, 0x58, 0xc9, 0x18, 0x93, 0xeb, 0xe0, 0x44, 0x0a, 0x94, 0xd2, 0xa2,
-	0x04, 0x8a, 0xd5, 0xc6, 0xdc, 0x13, 0xed, 0x3c, 0xfd, 0x19, 0xbf, 0xcf, 0x6f, 0xc5, 0xbb, 0x49,
-	0x9a, 0xa6, 0x5c, 0x80, 0x36, 0x08, 0x39, 0x9e, 0xa6, 0x8c, 0xa0, 0x91, 0x09, 0x92, 0xa1, 0x3c,
-	0x5f, 0xce, 0x3c, 0x42, 0x99, 0xcc, 0x20, 0x5e, 0x56, 0x8a, 0x8c, 0xa8, 0x91, 0x09, 0x35, 0xe9,
-	0x3e, 0xe4, 0x2d, 0xdb, 0xc4, 0xfa, 0x62, 0x39, 0xf4, 0x13, 0x89, 0x8e, 0xfb, 0xcb, 0x21, 0xfa,
-	0x10, 0x0a, 0x8c, 0x65, 0x98, 0xa6, 0x53, 0x81, 0x68, 0x15, 0xee, 0xda, 0x26, 0xae, 0x99, 0xa6,
-	0x83, 0x5d, 0x57, 0x65, 0x13, 0xd0, 0x01, 0xb5, 0x66, 0x34, 0x35, 0x2c, 0xdd, 0xbb, 0x59, 0xe0,
-	0x4a, 0x89, 0x6d, 0x2f, 0x4f, 0x09, 0x83, 0x9b, 0x05, 0xed, 0xcd, 0x0f, 0xc7, 0x4b, 0x1e, 0x37,
-	0x49, 0xb5, 0xb3, 0xcc, 0x76, 0x7d, 0x20, 0x44, 0x5a, 0xd1, 0x12, 0xfa, 0x3c, 0x9b, 0xcf, 0x4a,
-	0x9b, 0xcf, 0xb3, 0xf9, 0x4d, 0x69, 0xeb, 0x79, 0x36, 0x5f, 0x94, 0x4a, 0xf2, 0xef, 0x53, 0x50,
-	0x08, 0x42, 0x1e, 0xfd, 0x38, 0xe8, 0xce, 0x45, 0x5a, 0xec, 0x45, 0xd3, 0x82, 0x57, 0x35, 0x5f,
-	0x86, 0xde, 0x4b, 0xe6, 0xc4, 0xd2, 0xcd, 0xa5, 0xc3, 0xde, 0x9f, 0xf4, 0xe1, 0xcc, 0x1e, 0x5d,
-	0xb9, 0xfe, 0xbd, 0x64, 0x4e, 0xac, 0xa6, 0xe0, 0xd4, 0x19, 0x03, 0x55, 0x20, 0xe7, 0x97, 0x5e,
-	0xfe, 0x8c, 0xe2, 0x0f, 0x9f, 0x67, 0xf3, 0x19, 0x29, 0x2b, 0xff, 0x2e, 0x30, 0x86, 0x66, 0xfd,
-	0xf7, 0x30, 0xc6, 0xb8, 0x5e, 0x33, 0x26, 0x2b, 0x8c, 0x31, 0xae, 0x6f, 0x37, 0x66, 0x33, 0x62,
-	0x8c, 0x7c, 0x02, 0xc5, 0xd0, 0xab, 0xc2, 0xdd, 0x6f, 0x11, 0x7f, 0x4a, 0x41, 0x29, 0xfc, 0x24,
-	0x72, 0xa7, 0x06, 0xfa, 0x39, 0x14, 0xc7, 0x06, 0x99, 0xe9, 0xa1, 0xce, 0x6f, 0xfb, 0xf4, 0x61,
-	0xd2, 0xf3, 0xca, 0x49, 0xcb, 0x20, 0x33, 0xbf, 0xad, 0x1b, 0x07, 0xbf, 0xe9, 0x12, 0x6c, 0x06,
-	0xd7, 0xa3, 0x57, 0x3f, 0x16, 0xec, 0x05, 0x2e, 0xa0, 0x31, 0x8a, 0x7c, 0x04, 0xb0, 0x52, 0x45,
-	0x3b, 0x50, 0xec, 0x74, 0x2f, 0x6b, 0x67, 0x9d, 0xa6, 0x5e, 0x3b, 0x1f, 0x48, 0x1b, 0xf2, 0x33,
-	0x3f, 0xe3, 0x3a, 0xd6, 0x62, 0x19, 0x2d, 0x1b, 0xa9, 0xbb, 0xca, 0x86, 0xfc, 0x05, 0x94, 0xc4,
-	0x11, 0x2c, 0x3d, 0xaa, 0x7d, 0x6b, 0x65, 0x8d, 0xbc, 0xbf, 0x89, 0x91, 0xfc, 0x5d, 0x1a, 0xaa,
-	0x5c, 0xfd, 0xdc, 0x36, 0xc9, 0xf8, 0x26, 0xf6, 0xea, 0x77, 0x47, 0xf2, 0x9f, 0x02, 0x58, 0xf8,
-	0xb5, 0x4e, 0xa8, 0xd9, 0x7e, 0x39, 0x89, 0x05, 0x06, 0xdb, 0x92, 0x5a, 0xb0, 0xf0, 0x6b, 0xf6,
-	0x8b, 0x16, 0xa1, 0x22, 0xd5, 0xb1, 0x99, 0xb5, 0xfe, 0x55, 0x78, 0x3f, 0x16, 0x4d, 0x8c, 0xa9,
-	0xd2, 0xc9, 0xf9, 0x4f, 0x17, 0x5d, 0xf2, 0xa5, 0x16, 0x86, 0x63, 0xcc, 0x5d, 0x71, 0x07, 0xfe,
-	0x34, 0xaa, 0x95, 0xb4, 0x83, 0x93, 0x2e, 0x7e, 0x2d, 0x28, 0x7d, 0xaa, 0x8a, 0x3d, 0xec, 0xb8,
-	0xcc, 0x1c, 0x36, 0x74, 0xab, 0x4f, 0x61, 0x3f, 0x49, 0x24, 0xd9, 0x8d, 0xf2, 0x97, 0x70, 0x98,
-	0xb8, 0x96, 0x78, 0xab, 0x7b, 0x08, 0xc5, 0x50, 0x83, 0xed, 0xc7, 0xda, 0xaa, 0x6d, 0x96, 0x3f,
-	0x87, 0x77, 0x42, 0xf9, 0xc2, 0x6b, 0xc8, 0xdb, 0xbe, 0xb2, 0x7d, 0xe3, 0x3f, 0xd1, 0x85, 0x75,
-	0xc5, 0xc2, 0x8f, 0xfd, 0xdb, 0x4b, 0x8a, 0x45, 0x2f, 0x8a, 0x34, 0x38, 0x91, 0x4b, 0xcb, 0xfb,
-	0xb0, 0xcb, 0x6b, 0xe8, 0xd2, 0x1a, 0x2f, 0x67, 0x91, 0x42, 0x2a, 0x31, 0xc6, 0xc5, 0x8a, 0x2e,
-	0xef, 0xc2, 0x4e, 0x0b, 0xe3, 0x5f, 0x2e, 0xed, 0xc0, 0x4c, 0xf9, 0x1c, 0xa4, 0x15, 0x49, 0xac,
-	0xbe, 0xd6, 0xa2, 0xa4, 0xde, 0xb6, 0x45, 0x91, 0xeb, 0xb0, 0xaf, 0xe2, 0x19, 0x7e, 0x65, 0x58,
-	0x5e, 0x9d, 0xbf, 0xa0, 0x70, 0x6f, 0x6c, 0x43, 0x3a, 0xb8, 0x53, 0xa7, 0x89, 0x89, 0xaa, 0xec,
-	0x21, 0x93, 0x77, 0x4f, 0x69, 0xf6, 0x04, 0x14, 0x8c, 0xe5, 0x7f, 0x64, 0xa0, 0x1c, 0x99, 0x24,
-	0x0c, 0x27, 0xa9, 0x08, 0x9c, 0x88, 0x79, 0xd3, 0xc1, 0xbc, 0xff, 0x6b, 0x77, 0xd6, 0x5b, 0x6b,
-	0x31, 0xb3, 0x4c, 0xfb, 0xb1, 0xaf, 0x1d, 0x31, 0xec, 0xfb, 0xb7, 0x97, 0x9b, 0xb7, 0xb5, 0x97,
-	0x6b, 0xbe, 0xdf, 0x7a, 0xeb, 0xf6, 0xf0, 0x18, 0x8a, 0xe1, 0xc6, 0x90, 0x97, 0xda, 0x30, 0xe9,
-	0xb6, 0x9e, 0x30, 0x9f, 0xd8, 0x13, 0xfe, 0xdf, 0xba, 0xb5, 0x26, 0x94, 0xc2, 0xbb, 0xe0, 0x5d,
-	0x97, 0x8b, 0x83, 0x48, 0xcb, 0xd2, 0xae, 0xcb, 0xc5, 0x82, 0xe5, 0x1b, 0xcc, 0x66, 0xcf, 0xaa,
-	0x39, 0x61, 0x25, 0x05, 0xd9, 0x50, 0x85, 0xa7, 0x21, 0x62, 0x61, 0xef, 0xb5, 0xed, 0x5c, 0x09,
-	0xd3, 0xfc, 0x21, 0x42, 0x90, 0x65, 0xd7, 0x03, 0xfe, 0x04, 0xc8, 0x7e, 0xcb, 0x35, 0xc8, 0xfb,
-	0xd0, 0x4b, 0xf9, 0xac, 0xa7, 0xe3, 0xc1, 0xc9, 0x7e, 0xd3, 0x9b, 0x29, 0x07, 0x34, 0x71, 0xf1,
-	0x13, 0x37, 0x53, 0x4e, 0x63, 0xd7, 0xbe, 0x27, 0xdf, 0xc2, 0x5e, 0x42, 0x2f, 0xc1, 0x5e, 0xfd,
-	0x06, 0xb5, 0x81, 0xa2, 0xf7, 0x95, 0x6e, 0xb3, 0xd3, 0xfd, 0x85, 0xde, 0xeb, 0x2b, 0xb4, 0xc9,
-	0xda, 0x06, 0xe0, 0x74, 0x36, 0x4e, 0xa1, 0x5d, 0x28, 0xf3, 0xb1, 0xf2, 0xb2, 0xdf, 0x51, 0x95,
-	0xa6, 0x94, 0x46, 0x15, 0xd8, 0x8f, 0xaa, 0x5e, 0xf4, 0x9b, 0xb5, 0x81, 0x22, 0x65, 0x90, 0x04,
-	0x25, 0xce, 0x69, 0x9c, 0xf5, 0x34, 0xa5, 0x29, 0x65, 0x9f, 0xfc, 0x31, 0x05, 0xb0, 0x02, 0x03,
-	0xda, 0xc2, 0xf5, 0xd4, 0x26, 0xed, 0xe0, 0x2e, 0xea, 0xe7, 0x9d, 0x01, 0xbf, 0x53, 0xee, 0x42,
-	0x99, 0x13, 0x1b, 0x67, 0x4a, 0x8d, 0x2e, 0xc1, 0xba, 0x3a, 0x4e, 0xea, 0xd7, 0xd4, 0x41, 0xa7,
-	0x46, 0xef, 0x89, 0xad, 0xce, 0xd9, 0x19, 0x5b, 0x1e, 0xc1, 0x36, 0xe7, 0x29, 0x2f, 0x95, 0xc6,
-	0x05, 0x9d, 0x22, 0xb3, 0xa2, 0x35, 0x6a, 0xdd, 0x86, 0x42, 0xe5, 0xb2, 0xab, 0x69, 0x7d, 0xcb,
-	0x37, 0xa9, 0x7d, 0x9c, 0xd4, 0xaa, 0x75, 0xa8, 0xd0, 0xd6, 0xe9, 0xbf, 0x37, 0x61, 0xb7, 0x31,
-	0x35, 0x2c, 0x0b, 0xcf, 0x6a, 0x41, 0xd3, 0x46, 0xb3, 0x2b, 0xfa, 0xf9, 0x0b, 0x1d, 0xad, 0xf2,
-	0x2a, 0xe1, 0xa3, 0x5a, 0xf5, 0x07, 0xb7, 0xb1, 0x05, 0x52, 0xa9, 0x50, 0x0c, 0x7d, 0xf6, 0x41,
-	0xc7, 0xf1, 0x5a, 0x15, 0xff, 0xec, 0x55, 0x7d, 0xf4, 0x06, 0x09, 0x31, 0xe7, 0x4b, 0x28, 0x47,
-	0xaa, 0x01, 0x92, 0xef, 0x2e, 0x4b, 0xd5, 0x77, 0xdf, 0x28, 0xb3, 0xb2, 0x36, 0xf4, 0x45, 0x28,
-	0x6e, 0xed, 0xfa, 0x27, 0xaa, 0xb8, 0xb5, 0x49, 0x9f, 0x93, 0xd4, 0xe8, 0x7d, 0x2a, 0x36, 0xe7,
-	0xfa, 0xd7, 0x9f, 0xf8, 0x9c, 0x09, 0x9f, 0x78, 0x50, 0x2f, 0x12, 0x5b, 0x0f, 0x13, 0x6e, 0x86,
-	0xe1, 0x4a, 0x57, 0x3d, 0xbe, 0x5d, 0x40, 0x4c, 0xf8, 0x15, 0xdc, 0x0b, 0x5e, 0x2e, 0x18, 0x70,
-	0x8a, 0x98, 0x40, 0xc1, 0xc3, 0x46, 0xd2, 0x17, 0xa5, 0xea, 0x83, 0x58, 0x37, 0x1a, 0xe1, 0x3e,
-	0x4e, 0x7d, 0x98, 0x42, 0x3f, 0x83, 0xbc, 0x5f, 0xbd, 0x50, 0xf0, 0x46, 0x1c, 0x2b, 0x71, 0xd5,
-	0xca, 0x3a, 0x43, 0xd8, 0x75, 0x06, 0xf7, 0x22, 0x78, 0xae, 0x59, 0xc6, 0xc2, 0x9d, 0xda, 0xde,
-	0xca, 0xae, 0xa4, 0x62, 0x56, 0xbd, 0x97, 0xc8, 0x1d, 0x6e, 0xb1, 0x2f, 0xc4, 0x1f, 0xff, 0x37,
-	0x00, 0x00, 0xff, 0xff, 0xe8, 0x81, 0xa0, 0x78, 0x5b, 0x1e, 0x00, 0x00,
+	// 2946 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x39, 0x4b, 0x73, 0xdb, 0xd6,
+	0xd5, 0xe2, 0x4b, 0x24, 0x0f, 0x1f, 0x82, 0xae, 0x64, 0x87, 0xa6, 0xec, 0x58, 0x46, 0xbe, 0x7c,
+	0x9f, 0x3f, 0xc7, 0x55, 0x12, 0xa5, 0x99, 0xa4, 0x71, 0x9a, 0x29, 0x1f, 0x60, 0x49, 0x5b, 0x22,
+	0x59, 0x80, 0x54, 0xec, 0x15, 0x06, 0x22, 0xaf, 0xa8, 0x5b, 0x81, 0x00, 0x03, 0x80, 0xb6, 0xd4,
+	0xc9, 0x74, 0xa6, 0x33, 0x5d, 0x75, 0xd9, 0xe9, 0xb6, 0xff, 0x20, 0x9b, 0xae, 0xba, 0x6b, 0xbb,
+	0xed, 0xb2, 0xdb, 0xce, 0x74, 0xd5, 0x5f, 0xd0, 0x7f, 0xd0, 0xb9, 0x0f, 0x80, 0x00, 0x08, 0x49,
+	0x9e, 0x74, 0xba, 0x91, 0x70, 0xcf, 0xe3, 0xde, 0x73, 0xcf, 0xf3, 0x9e, 0x43, 0x90, 0x8c, 0xe5,
+	0xc4, 0x23, 0xb6, 0x85, 0xb1, 0x73, 0xb0, 0x70, 0x6c, 0xcf, 0x46, 0x9b, 0x13, 0x73, 0xee, 0x2c,
+	0x26, 0xf5, 0xfb, 0x33, 0xdb, 0x9e, 0x99, 0xf8, 0x43, 0x63, 0x41, 0x3e, 0x34, 0x2c, 0xcb, 0xf6,
+	0x0c, 0x4a, 0xe7, 0x72, 0x2a, 0xf9, 0x4b, 0xb8, 0xa3, 0x62, 0x17, 0x3b, 0xaf, 0x71, 0x63, 0x32,
+	0xb1, 0x97, 0x96, 0xa7, 0xe2, 0x6f, 0x96, 0xd8, 0xf5, 0xd0, 0x7b, 0x50, 0x31, 0x38, 0x44, 0x7f,
+	0x6d, 0x98, 0x4b, 0x5c, 0x4b, 0xed, 0xa7, 0x1e, 0x67, 0xd5, 0xb2, 0x00, 0x9e, 0x50, 0x98, 0x7c,
+	0x01, 0x77, 0xe3, 0xdc, 0xee, 0xc2, 0xb6, 0x5c, 0x8c, 0xde, 0x87, 0xea, 0x4a, 0x22, 0xfd, 0x02,
+	0x5f, 0x31, 0xfe, 0xb2, 0x5a, 0x59, 0x41, 0x5f, 0xe0, 0x2b, 0xf4, 0x04, 0xb6, 0x89, 0x45, 0x3c,
+	0x62, 0x98, 0xfa, 0xa9, 0xe1, 0x4d, 0xce, 0x19, 0x65, 0x9a, 0x51, 0x6e, 0x09, 0x44, 0x93, 0xc2,
+	0x5f, 0xe0, 0x2b, 0xf9, 0x9f, 0x29, 0xa8, 0x69, 0xf4, 0x2c, 0xa7, 0x67, 0x11, 0x2f, 0x26, 0xee,
+	0xa7, 0x2b, 0x71, 0x17, 0x36, 0xb1, 0x3c, 0x76, 0x5c, 0xe9, 0x50, 0x3a, 0xe0, 0x5a, 0x38, 0x18,
+	0x2c, 0xbd, 0x21, 0x85, 0x07, 0x17, 0x60, 0x2b, 0x26, 0xa6, 0x60, 0x73, 0x27, 0x0e, 0x59, 0x78,
+	0xe2, 0x70, 0x7f, 0x33, 0x8d, 0x01, 0xd7, 0x95, 0x91, 0x59, 0x57, 0x46, 0x78, 0x2f, 0x7c, 0xb9,
+	0x20, 0xce, 0x55, 0x2d, 0xbb, 0x9f, 0x7a, 0x5c, 0x09, 0xf6, 0x52, 0x18, 0x10, 0x3d, 0x00, 0xf0,
+	0x1c, 0x63, 0x2a, 0xb4, 0x92, 0x63, 0xc7, 0x15, 0x39, 0x84, 0xde, 0x72, 0x0f, 0xee, 0x25, 0x5c,
+	0x92, 0x6b, 0x55, 0x76, 0x7d, 0x0d, 0x68, 0xcb, 0xd3, 0x39, 0xf1, 0x06, 0xce, 0x14, 0x3b, 0xbe,
+	0x06, 0xde, 0x87, 0x8c, 0xe1, 0x5e, 0x88, 0x7b, 0x6f, 0xfb, 0xf7, 0xe6, 0xe4, 0x0d, 0xf7, 0xa2,
+	0xbb, 0xa1, 0x52, 0x3c, 0x25, 0x3b, 0x25, 0xd3, 0x5a, 0x3a, 0x89, 0xac, 0x49, 0xa6, 0x94, 0xec,
+	0x94, 0x4c, 0x9b, 0x45, 0xc8, 0x4f, 0xb1, 0x67, 0x10, 0xd3, 0x95, 0x7f, 0x95, 0x82, 0x7b, 0x09,
+	0xa7, 0x0a, 0x43, 0x3f, 0x83, 0x0a, 0xb1, 0x5e, 0x1b, 0x26, 0x99, 0xea, 0x36, 0x45, 0x08, 0x01,
+	0x76, 0xfd, 0x9d, 0x7b, 0x1c, 0xc9, 0x98, 0xba, 0x1b, 0x6a, 0x99, 0x84, 0xd6, 0xe8, 0x3e, 0x14,
+	0x8c, 0xc9, 0x04, 0x2f, 0x3c, 0xcc, 0x25, 0x2a, 0x74, 0x37, 0xd4, 0x00, 0x12, 0x96, 0xe1, 0x99,
+	0x7f, 0xf1, 0x96, 0x61, 0x4d, 0xb0, 0x19, 0xb9, 0xf8, 0x43, 0x28, 0xb1, 0x93, 0x75, 0xcb, 0xb6,
+	0x26, 0x58, 0xf8, 0x19, 0x30, 0x50, 0x9f, 0x42, 0x56, 0x2a, 0x8d, 0x30, 0x0b, 0x95, 0xfe, 0x3d,
+	0x0d, 0xbb, 0x2d, 0x93, 0x60, 0xcb, 0x6b, 0x70, 0xcf, 0x3c, 0xc6, 0xae, 0x6b, 0xcc, 0x30, 0xfa,
+	0x04, 0x36, 0x27, 0xf6, 0x7c, 0x4e, 0x7c, 0x57, 0xba, 0xe7, 0xdf, 0x48, 0x18, 0xa5, 0xc5, 0x90,
+	0x73, 0x6c, 0x79, 0xdd, 0x0d, 0x55, 0x90, 0xa2, 0x67, 0x50, 0x74, 0x97, 0xa7, 0xd4, 0x95, 0x4e,
+	0xb1, 0xd0, 0xf1, 0x5e, 0x8c, 0x4f, 0xe3, 0xf8, 0x05, 0x3d, 0xab, 0xbb, 0xa1, 0xae, 0xe8, 0xd1,
+	0x21, 0x6c, 0xf2, 0xbb, 0x33, 0xf7, 0x2a, 0x1d, 0xd6, 0x02, 0xe7, 0xa5, 0x12, 0x1f, 0xd3, 0x38,
+	0x68, 0x30, 0x3c, 0x3d, 0x90, 0x53, 0x52, 0x1e, 0x07, 0xff, 0x1c, 0x4f, 0xbc, 0x5a, 0xf6, 0x3a,
+	0x1e, 0x95, 0xe1, 0x29, 0x0f, 0xa7, 0x44, 0x4f, 0x21, 0xeb, 0x92, 0x99, 0xc5, 0x7c, 0xaf, 0x74,
+	0x78, 0x77, 0x9d, 0x43, 0x23, 0x33, 0x2a, 0x1a, 0xa3, 0x42, 0x9f, 0x40, 0xde, 0xc1, 0x13, 0xfb,
+	0x35, 0x76, 0x6a, 0x9b, 0x8c, 0xe1, 0x9d, 0xd8, 0x85, 0x54, 0x8e, 0xbd, 0xea, 0x6e, 0xa8, 0x3e,
+	0x65, 0x33, 0x07, 0x99, 0xb9, 0x3b, 0x93, 0x5f, 0xc1, 0xf6, 0x9a, 0xb6, 0xa8, 0xbd, 0xb8, 0xb6,
+	0xf4, 0x73, 0xc3, 0x3d, 0xf7, 0xed, 0xc5, 0x41, 0x5d, 0xc3, 0x3d, 0xa7, 0xd1, 0xc6, 0x93, 0xc1,
+	0x6b, 0xec, 0xb8, 0xc4, 0xb6, 0x98, 0x22, 0x2b, 0x6a, 0x99, 0x01, 0x4f, 0x38, 0x4c, 0x76, 0x60,
+	0x27, 0x41, 0xa1, 0xb1, 0xe8, 0x4a, 0xc5, 0xa2, 0x0b, 0x3d, 0x82, 0xb2, 0x38, 0x9b, 0x3b, 0x0b,
+	0x8f, 0x76, 0x21, 0x0f, 0xf3, 0x16, 0x74, 0x0f, 0x0a, 0xc6, 0xd2, 0x3b, 0xd7, 0x5d, 0x32, 0x63,
+	0x76, 0x28, 0xab, 0x79, 0xba, 0xd6, 0xc8, 0x4c, 0xee, 0x83, 0x14, 0x37, 0xc5, 0xba, 0xf7, 0x65,
+	0xa2, 0xde, 0x47, 0xf7, 0xe3, 0xb7, 0x11, 0x51, 0x57, 0x56, 0xf3, 0x6c, 0xdd, 0x9b, 0xca, 0x7f,
+	0xcb, 0x80, 0x14, 0xb7, 0x53, 0x84, 0x3e, 0x15, 0xa1, 0x47, 0x77, 0xa9, 0xb1, 0x0d, 0x57, 0x68,
+	0xa4, 0xa8, 0x8a, 0x15, 0xea, 0x40, 0x89, 0x7f, 0xe9, 0x13, 0x7b, 0xca, 0x93, 0x53, 0xf5, 0xf0,
+	0xfd, 0xeb, 0x3c, 0xe1, 0x80, 0xff, 0x53, 0x19, 0x87, 0x0a, 0x9c, 0xb3, 0x65, 0x4f, 0x31, 0x1a,
+	0xc3, 0x16, 0x77, 0x11, 0x2c, 0x82, 0xd9, 0xad, 0x65, 0xf7, 0x33, 0x8f, 0x4b, 0x87, 0x4f, 0x6f,
+	0xd9, 0x0b, 0xf3, 0x78, 0x76, 0x15, 0xcb, 0x73, 0xae, 0xd4, 0xaa, 0x13, 0x01, 0xd6, 0x4f, 0x60,
+	0x27, 0x81, 0x0c, 0x49, 0x90, 0xf1, 0x6d, 0x54, 0x54, 0xe9, 0x27, 0xfa, 0x7f, 0xc8, 0xf1, 0xf4,
+	0xca, 0x23, 0x67, 0x27, 0x72, 0xaa, 0x90, 0x9a, 0x53, 0x7c, 0x91, 0xfe, 0x3c, 0x25, 0xff, 0x36,
+	0x05, 0xe5, 0xf0, 0x5d, 0x50, 0x09, 0xf2, 0xe3, 0xfe, 0x8b, 0xfe, 0xe0, 0xeb, 0xbe, 0xb4, 0x81,
+	0xee, 0x02, 0xd2, 0x14, 0xf5, 0x44, 0x51, 0xf5, 0xe3, 0x9e, 0xd6, 0x54, 0xba, 0x8d, 0x93, 0xde,
+	0x40, 0x95, 0x52, 0xa8, 0x0e, 0x77, 0x9b, 0x8d, 0x51, 0xab, 0xab, 0x9f, 0x28, 0xaa, 0xd6, 0x1b,
+	0xf4, 0x29, 0xfa, 0x98, 0x02, 0xa4, 0x34, 0x42, 0x50, 0x1d, 0x36, 0xd4, 0x51, 0xaf, 0x71, 0xa4,
+	0xab, 0xca, 0x73, 0xa5, 0x35, 0x92, 0x32, 0x94, 0xbe, 0xd5, 0x6d, 0xf4, 0xfb, 0xca, 0x91, 0xde,
+	0x19, 0xf7, 0xdb, 0xbd, 0xfe, 0x4f, 0xf5, 0x4e, 0xa3, 0x77, 0xa4, 0xb4, 0xa5, 0x2c, 0xda, 0x86,
+	0xca, 0x70, 0x70, 0xd4, 0x6b, 0xbd, 0xf2, 0xc9, 0x73, 0xf2, 0x1c, 0x4a, 0x21, 0x71, 0x43, 0x26,
+	0x4b, 0xdd, 0x64, 0xb2, 0xf4, 0xf7, 0x34, 0x99, 0xfc, 0xc7, 0x14, 0x54, 0xa3, 0x81, 0x7b, 0x93,
+	0x03, 0x3d, 0x87, 0x72, 0x50, 0xee, 0xc8, 0xcc, 0xad, 0xa5, 0x99, 0x75, 0xff, 0x2f, 0x39, 0x03,
+	0x04, 0x09, 0x8b, 0xcc, 0x84, 0x61, 0x4b, 0xc6, 0x0a, 0x52, 0xff, 0x0a, 0xa4, 0x38, 0x41, 0x82,
+	0x49, 0x77, 0xc3, 0x26, 0x2d, 0x87, 0xad, 0xf7, 0x11, 0x6c, 0xc5, 0x12, 0xc8, 0x2d, 0xc1, 0x2b,
+	0xff, 0x2e, 0x03, 0xbb, 0xa2, 0x9e, 0x45, 0x53, 0xf5, 0x67, 0x50, 0x9c, 0x9c, 0x1b, 0xa6, 0x89,
+	0xad, 0x19, 0xae, 0xa5, 0xa2, 0x49, 0x4a, 0x64, 0x7e, 0x1f, 0x4d, 0x33, 0x6e, 0x40, 0x8b, 0x7e,
+	0x08, 0x79, 0x77, 0x39, 0x99, 0x60, 0xd7, 0xad, 0xa5, 0xa3, 0xe9, 0x53, 0xf3, 0xb3, 0xb2, 0xc6,
+	0xf1, 0x34, 0xb9, 0x09, 0x52, 0x74, 0x00, 0x39, 0xec, 0x38, 0xb6, 0x53, 0xcb, 0x44, 0x13, 0x68,
+	0xc0, 0xa3, 0x50, 0x6c, 0x77, 0x43, 0xe5, 0x64, 0xe8, 0x53, 0xc8, 0x2f, 0x1c, 0xbc, 0x30, 0x1c,
+	0x5c, 0xcb, 0x46, 0x4b, 0xc9, 0x4a, 0xe1, 0x43, 0x4e, 0x40, 0x8f, 0x11, 0xb4, 0xe8, 0xe3, 0x48,
+	0x9a, 0xde, 0x4b, 0x36, 0x52, 0x13, 0xcf, 0xc8, 0x2a, 0x57, 0x7f, 0x0e, 0x85, 0x33, 0x62, 0x19,
+	0x26, 0xf9, 0x05, 0x16, 0xc9, 0xba, 0xbe, 0xce, 0xd6, 0x11, 0x14, 0xb4, 0xd6, 0xfa, 0xd4, 0xe8,
+	0x10, 0xf2, 0xc2, 0xb8, 0xb5, 0x7c, 0xf4, 0x56, 0x42, 0xd7, 0xc2, 0x56, 0x54, 0x40, 0x41, 0xe8,
+	0x27, 0xf9, 0x21, 0x6c, 0xc5, 0x94, 0x8c, 0xee, 0xc7, 0x0d, 0x52, 0x0e, 0x6b, 0x3d, 0x56, 0x00,
+	0xd2, 0xf1, 0x02, 0x20, 0x7f, 0x0c, 0x52, 0x5c, 0xff, 0xb7, 0xf9, 0xc6, 0x77, 0x59, 0xd8, 0x5e,
+	0xd3, 0x26, 0xd2, 0xa0, 0x3a, 0xa7, 0xeb, 0x55, 0x3e, 0x4b, 0x5d, 0x97, 0xcf, 0x04, 0xcb, 0xc1,
+	0x31, 0xa7, 0x0f, 0xe7, 0xb3, 0xca, 0x3c, 0x0c, 0x43, 0x07, 0xb0, 0x33, 0x31, 0xb1, 0xe1, 0x10,
+	0x6b, 0xa6, 0x2f, 0x1c, 0x32, 0xc1, 0xba, 0x63, 0x78, 0x58, 0x14, 0xa9, 0x6d, 0x1f, 0x35, 0xa4,
+	0x18, 0xd5, 0xf0, 0x30, 0xfa, 0x0a, 0xa4, 0xc9, 0xb9, 0xe1, 0xcc, 0xf0, 0x54, 0x17, 0x9a, 0x73,
+	0x6b, 0x99, 0xfd, 0x4c, 0x38, 0xc1, 0x09, 0xe5, 0xb6, 0xc9, 0xd9, 0x99, 0xba, 0x25, 0x88, 0x05,
+	0xcc, 0x45, 0x3f, 0x82, 0x0a, 0xbe, 0xc4, 0x93, 0x25, 0xb5, 0x82, 0x7e, 0x86, 0x7d, 0x27, 0x0a,
+	0x5e, 0x58, 0x8a, 0x8f, 0xec, 0x60, 0xac, 0x96, 0x71, 0x68, 0x85, 0x3e, 0x80, 0x6d, 0x9e, 0x0a,
+	0x3c, 0xc7, 0xb0, 0x5c, 0x83, 0x19, 0x52, 0x3c, 0x39, 0x25, 0x86, 0x18, 0xad, 0xe0, 0xe8, 0x29,
+	0xec, 0x9c, 0x61, 0x7e, 0x19, 0xdd, 0x35, 0x3c, 0x7d, 0x41, 0x75, 0xfd, 0x86, 0xf9, 0x51, 0x56,
+	0xdd, 0x3a, 0xc3, 0xec, 0x36, 0x9a, 0xe1, 0x0d, 0xb1, 0xf3, 0xe2, 0x0d, 0xfa, 0x1f, 0xa8, 0x32,
+	0x6a, 0x7c, 0x2a, 0xe8, 0x99, 0xdf, 0x64, 0xd5, 0x32, 0x25, 0x64, 0x40, 0xcd, 0x88, 0x16, 0xb3,
+	0x42, 0x34, 0x17, 0xad, 0x55, 0xf9, 0xe2, 0x7a, 0x95, 0xaf, 0x9f, 0x00, 0x5a, 0x37, 0x48, 0x42,
+	0x9a, 0x79, 0x12, 0xad, 0x1c, 0x81, 0x6e, 0xc2, 0xcc, 0xd1, 0xe4, 0xb3, 0x93, 0x10, 0x47, 0x37,
+	0xa4, 0x4e, 0xd9, 0x06, 0xb4, 0x1e, 0x42, 0x37, 0x30, 0x50, 0x87, 0xe5, 0x28, 0xef, 0x32, 0xa8,
+	0xfc, 0x45, 0xae, 0xf4, 0x4b, 0x32, 0xa5, 0x41, 0x70, 0x8e, 0xc9, 0xec, 0xdc, 0xd3, 0xcf, 0x69,
+	0xbb, 0x92, 0x61, 0x97, 0x07, 0x0e, 0xea, 0x12, 0xcb, 0x93, 0xff, 0x9a, 0x82, 0x6a, 0x34, 0xa3,
+	0xd0, 0x64, 0xca, 0x13, 0x0f, 0xbf, 0x39, 0x5f, 0xa0, 0x67, 0x00, 0xec, 0x23, 0x5c, 0x49, 0xee,
+	0x27, 0xe7, 0xa4, 0x03, 0xf6, 0x57, 0x2d, 0x32, 0x7a, 0x56, 0xf2, 0xa3, 0x61, 0x95, 0x89, 0x87,
+	0x95, 0x02, 0x39, 0x7e, 0x74, 0xa4, 0xb4, 0xee, 0xc0, 0x96, 0x28, 0xad, 0x5a, 0x77, 0x3c, 0x6a,
+	0x53, 0x20, 0xab, 0xab, 0x8d, 0x56, 0x6b, 0x30, 0xee, 0x8f, 0xf4, 0xf6, 0x40, 0xd1, 0xf4, 0xfe,
+	0x60, 0xa4, 0x2b, 0x2f, 0x7b, 0xda, 0x48, 0x4a, 0xcb, 0x7f, 0x4a, 0x43, 0x35, 0x9a, 0x47, 0x56,
+	0x85, 0x81, 0xf7, 0x95, 0x7c, 0x41, 0xcb, 0xa5, 0xe8, 0x9d, 0x78, 0x38, 0x89, 0xd5, 0x2d, 0x62,
+	0x26, 0x74, 0x9b, 0xd9, 0xa4, 0x6e, 0x73, 0x0f, 0x8a, 0xab, 0x2e, 0x93, 0x87, 0x01, 0x37, 0x1f,
+	0x45, 0x7e, 0x0c, 0x39, 0xd7, 0xa3, 0x81, 0xbc, 0xc9, 0x34, 0xb8, 0x97, 0x9c, 0xff, 0x34, 0x4a,
+	0xa2, 0x72, 0xca, 0xb8, 0x0d, 0xf3, 0x71, 0x1b, 0xa2, 0xa7, 0x50, 0xb0, 0x97, 0x1e, 0x6f, 0x48,
+	0x0b, 0xd7, 0x34, 0xa4, 0x01, 0x05, 0x75, 0xa6, 0x89, 0x69, 0xbb, 0x58, 0xf7, 0x2e, 0x59, 0x30,
+	0x94, 0xd5, 0x3c, 0x5b, 0x8f, 0x2e, 0xe5, 0x6f, 0xa1, 0x1c, 0x76, 0x65, 0xf4, 0x29, 0x94, 0xfd,
+	0xc4, 0x76, 0x4a, 0xa6, 0x7e, 0x5a, 0x43, 0x31, 0xb7, 0x6f, 0x92, 0xa9, 0x5a, 0x9a, 0x07, 0xdf,
+	0x6e, 0x98, 0xcd, 0x70, 0x2f, 0xfc, 0xfa, 0x1f, 0x67, 0x6b, 0xb8, 0x17, 0x01, 0x5b, 0xc3, 0xbd,
+	0x70, 0xe5, 0x11, 0xc0, 0x0a, 0x85, 0xde, 0xbb, 0xb9, 0xd1, 0xe4, 0x6d, 0xe6, 0x23, 0x28, 0x2f,
+	0x2d, 0xe2, 0xb9, 0xfa, 0x19, 0x31, 0x4d, 0xd1, 0xdd, 0x55, 0xd4, 0x12, 0x83, 0x75, 0x18, 0x28,
+	0xb4, 0x6b, 0x93, 0xd0, 0x74, 0xc0, 0xfa, 0xd2, 0xd4, 0x35, 0x7d, 0x29, 0xeb, 0x4a, 0xdf, 0x66,
+	0xd7, 0x3f, 0xa7, 0xa1, 0x14, 0x4a, 0xa7, 0xd4, 0x35, 0xb0, 0x35, 0xa5, 0xb9, 0xfa, 0xd4, 0x30,
+	0x0d, 0xbf, 0x41, 0xcc, 0xaa, 0x15, 0x0e, 0x6d, 0x72, 0x20, 0x6a, 0x41, 0x59, 0x90, 0x71, 0x27,
+	0xe0, 0x61, 0xb4, 0x9f, 0x90, 0xa0, 0x0f, 0x22, 0x9e, 0x50, 0xe2, 0x5c, 0x6c, 0x41, 0xcf, 0xf2,
+	0x8d, 0xa9, 0x13, 0x6b, 0x8a, 0x2f, 0x99, 0xa7, 0xe6, 0xd4, 0x8a, 0x0f, 0xed, 0x51, 0x60, 0xcc,
+	0x99, 0xb3, 0xf1, 0x98, 0xfb, 0x25, 0x94, 0xc3, 0x47, 0xa0, 0x5d, 0x90, 0x06, 0xe3, 0xd1, 0x70,
+	0x3c, 0xd2, 0x55, 0xa5, 0xa5, 0x2a, 0x8d, 0x91, 0xd2, 0x96, 0x36, 0xd0, 0x23, 0x78, 0x20, 0xa0,
+	0xed, 0xb1, 0x46, 0x23, 0x6d, 0xa4, 0xf4, 0xdb, 0x4a, 0x5b, 0x1f, 0x74, 0x3a, 0xad, 0x6e, 0xa3,
+	0x47, 0x23, 0xf2, 0x01, 0xdc, 0x0b, 0x93, 0x34, 0xda, 0x14, 0x3f, 0x1a, 0xe8, 0x1d, 0x45, 0xd1,
+	0xa4, 0x34, 0x7d, 0x20, 0x0b, 0x74, 0x67, 0x7c, 0x74, 0xf4, 0x4a, 0xd7, 0x86, 0x4a, 0x7f, 0x24,
+	0x65, 0xe4, 0xbf, 0x64, 0xa0, 0xc4, 0xf5, 0xce, 0x7d, 0xed, 0x96, 0x96, 0xea, 0x01, 0x00, 0x2b,
+	0x19, 0x67, 0xe4, 0x32, 0xb0, 0x48, 0x91, 0x42, 0x3a, 0x14, 0x40, 0x73, 0xb5, 0x31, 0xf7, 0xc4,
+	0xc0, 0x84, 0x7e, 0xc6, 0x3b, 0xa6, 0xcd, 0x78, 0xbf, 0x4e, 0xc3, 0x94, 0x13, 0xd0, 0x16, 0x2c,
+	0xcf, 0xc3, 0x94, 0x01, 0x34, 0x32, 0x43, 0x32, 0x54, 0xe6, 0x4b, 0xd3, 0x23, 0x14, 0xc9, 0x04,
+	0xe2, 0x65, 0xa5, 0xc4, 0x80, 0x1a, 0x99, 0x51, 0x91, 0xee, 0x41, 0xc1, 0xb2, 0xa7, 0x58, 0x5f,
+	0x2c, 0x4f, 0xfd, 0x40, 0xa2, 0xeb, 0xe1, 0xf2, 0x14, 0x7d, 0x04, 0x45, 0x86, 0x32, 0xa6, 0x53,
+	0xa7, 0x06, 0xd1, 0x2a, 0xdc, 0xb7, 0xa7, 0xb8, 0x31, 0x9d, 0x3a, 0xd8, 0x75, 0xd5, 0x82, 0x25,
+	0x16, 0x54, 0x9a, 0xc9, 0xb9, 0x61, 0xe9, 0xde, 0xd5, 0x02, 0xd7, 0xca, 0xec, 0x7a, 0x05, 0x0a,
+	0x18, 0x5d, 0x2d, 0xe8, 0xf4, 0x63, 0xef, 0x6c, 0xc9, 0xfd, 0x26, 0xa9, 0x76, 0x56, 0xd8, 0xad,
+	0xef, 0x0a, 0x92, 0x4e, 0xac, 0x84, 0xfe, 0x2f, 0x6c, 0xcd, 0x89, 0xa5, 0x73, 0x8f, 0x66, 0xf1,
+	0x56, 0xab, 0xf2, 0x89, 0xd1, 0x9c, 0x58, 0x63, 0x0a, 0x65, 0xf1, 0x81, 0xde, 0x85, 0x92, 0x61,
+	0x9a, 0xba, 0xcd, 0x34, 0x86, 0x6b, 0x5b, 0x74, 0x50, 0xa2, 0x16, 0x0d, 0xd3, 0x1c, 0x50, 0x85,
+	0xe1, 0xe7, 0xd9, 0x42, 0x56, 0xca, 0x3d, 0xcf, 0x16, 0x72, 0xd2, 0xe6, 0xf3, 0x6c, 0xa1, 0x24,
+	0x95, 0xe5, 0xdf, 0xa4, 0xa0, 0x18, 0x84, 0x0e, 0xfa, 0x41, 0x30, 0x47, 0x11, 0xe1, 0xb5, 0x13,
+	0x0d, 0x2f, 0x5e, 0x1d, 0x7d, 0x1a, 0xfa, 0xbe, 0xa1, 0x62, 0x4d, 0x97, 0x0e, 0x9b, 0x14, 0xea,
+	0xa7, 0xa6, 0x3d, 0xb9, 0x70, 0xfd, 0xf7, 0xcd, 0x9c, 0x58, 0x6d, 0x81, 0x69, 0x32, 0x04, 0xaa,
+	0x41, 0xde, 0x2f, 0xe1, 0x7c, 0xe0, 0xe5, 0x2f, 0x9f, 0x67, 0x0b, 0x19, 0x29, 0x2b, 0xff, 0x3a,
+	0x10, 0x86, 0x66, 0x8f, 0xef, 0x21, 0x8c, 0x71, 0xb9, 0x26, 0x4c, 0x56, 0x08, 0x63, 0x5c, 0x5e,
+	0x2f, 0x4c, 0x2e, 0x22, 0x8c, 0x7c, 0x00, 0xa5, 0xd0, 0xfc, 0xe7, 0xf6, 0xa9, 0xd1, 0x1f, 0x52,
+	0x50, 0x0e, 0x0f, 0xaf, 0x6e, 0xe5, 0x40, 0x3f, 0x81, 0xd2, 0x99, 0x41, 0x4c, 0x3d, 0xd4, 0xa3,
+	0x57, 0x0f, 0x1f, 0x26, 0x0d, 0xc2, 0x0e, 0x3a, 0x06, 0x31, 0xfd, 0x6e, 0xee, 0x2c, 0xf8, 0xa6,
+	0x47, 0xb0, 0x1d, 0x5c, 0x8f, 0x3e, 0x21, 0x59, 0xd0, 0x14, 0x39, 0x81, 0xc6, 0x20, 0xf2, 0x03,
+	0x80, 0x15, 0x2b, 0xda, 0x82, 0x52, 0xaf, 0x7f, 0xd2, 0x38, 0xea, 0xb5, 0xf5, 0xc6, 0xf1, 0x48,
+	0xda, 0x90, 0x9f, 0xf9, 0x91, 0xdb, 0xb3, 0x16, 0xcb, 0x68, 0xf9, 0x49, 0xdd, 0x56, 0x7e, 0xe4,
+	0x2f, 0xa1, 0x2c, 0x4c, 0xb0, 0xf4, 0x16, 0xcb, 0x1b, 0x2a, 0x74, 0x64, 0x52, 0x2a, 0x56, 0xf2,
+	0x77, 0x69, 0xa8, 0x73, 0xf6, 0x63, 0x7b, 0x4a, 0xce, 0xae, 0x62, 0xf3, 0xd9, 0x5b, 0x92, 0xc8,
+	0x21, 0x80, 0x85, 0xdf, 0xe8, 0x84, 0x8a, 0xed, 0x97, 0xa5, 0x98, 0x63, 0xb0, 0x2b, 0xa9, 0x45,
+	0x0b, 0xbf, 0x61, 0x5f, 0xb4, 0x98, 0x95, 0x28, 0x8f, 0xcd, 0xa4, 0xf5, 0x9f, 0xd4, 0xbb, 0x31,
+	0x6f, 0x62, 0x48, 0x95, 0x6e, 0xce, 0x3f, 0x5d, 0x74, 0xc2, 0x8f, 0x5a, 0x18, 0x8e, 0x31, 0x77,
+	0xc5, 0x5b, 0xfa, 0xb3, 0x28, 0x57, 0xd2, 0x0d, 0x0e, 0xfa, 0xf8, 0x8d, 0x80, 0x0c, 0x29, 0x2b,
+	0xf6, 0xb0, 0xe3, 0x32, 0x71, 0xd8, 0xd2, 0xad, 0x3f, 0x85, 0xdd, 0x24, 0x92, 0x64, 0x35, 0xca,
+	0x5f, 0xc1, 0x5e, 0xe2, 0x59, 0x62, 0xaa, 0xfa, 0x10, 0x4a, 0xa1, 0x46, 0xdd, 0xf7, 0xb5, 0x55,
+	0xfb, 0x2d, 0x7f, 0x01, 0xef, 0x84, 0xe2, 0x85, 0xd7, 0xa2, 0xb7, 0x9d, 0x87, 0x7e, 0x03, 0xb5,
+	0x75, 0x5e, 0x71, 0xf0, 0x63, 0xff, 0x15, 0x94, 0x62, 0xde, 0x8b, 0x22, 0x8d, 0x52, 0xe4, 0xf1,
+	0xf3, 0x01, 0x6c, 0xf3, 0xcc, 0xb5, 0xb4, 0xce, 0x96, 0x66, 0xa4, 0x20, 0x4b, 0x0c, 0x31, 0x5e,
+	0xc1, 0xe5, 0x6d, 0xd8, 0xea, 0x60, 0xfc, 0xb3, 0xa5, 0x1d, 0x88, 0x29, 0x1f, 0x83, 0xb4, 0x02,
+	0x89, 0xd3, 0xd7, 0x5a, 0x9d, 0xd4, 0xdb, 0xb6, 0x3a, 0x72, 0x13, 0x76, 0x55, 0x6c, 0xe2, 0xd7,
+	0x86, 0xe5, 0x35, 0xf9, 0xe0, 0x84, 0x6b, 0xa3, 0x0a, 0xe9, 0xe0, 0x6d, 0x9e, 0x26, 0x53, 0x54,
+	0x87, 0x82, 0x50, 0x23, 0xf7, 0xb3, 0xb2, 0x1a, 0xac, 0xe5, 0x7f, 0x64, 0xa0, 0x12, 0xd9, 0x24,
+	0x9c, 0x4e, 0x52, 0x91, 0x74, 0x22, 0xf6, 0x4d, 0x07, 0xfb, 0xfe, 0xa7, 0x5d, 0xde, 0x60, 0xad,
+	0x55, 0xe5, 0xa3, 0xb7, 0xc7, 0x3e, 0x77, 0x44, 0xb0, 0xef, 0xdf, 0xa6, 0xe6, 0xae, 0x6b, 0x53,
+	0xd7, 0x74, 0xbf, 0xf9, 0xd6, 0x6d, 0xe6, 0x3e, 0x94, 0xc2, 0x0d, 0x26, 0x2f, 0xd9, 0x25, 0xef,
+	0xf6, 0xde, 0xb2, 0x90, 0xd8, 0x5b, 0xfe, 0xd7, 0xba, 0xbe, 0x36, 0x94, 0xc3, 0xb7, 0xe0, 0xdd,
+	0x9b, 0x8b, 0x03, 0x4f, 0xcb, 0xd2, 0xee, 0xcd, 0xc5, 0x02, 0xe5, 0x0b, 0xcc, 0x76, 0xcf, 0xaa,
+	0x79, 0x21, 0x25, 0x4d, 0xb2, 0xa1, 0x97, 0x02, 0x75, 0x11, 0x0b, 0x7b, 0x6f, 0x6c, 0xe7, 0x42,
+	0x88, 0xe6, 0x2f, 0x11, 0x82, 0x2c, 0x7b, 0x66, 0xf0, 0x61, 0x2d, 0xfb, 0x96, 0x1b, 0x50, 0xf0,
+	0x53, 0x2f, 0xc5, 0xb3, 0xde, 0x90, 0x3b, 0x27, 0xfb, 0xa6, 0x2f, 0x5c, 0x9e, 0xd0, 0xc4, 0x03,
+	0x52, 0xbc, 0x70, 0x39, 0x8c, 0x3d, 0x1f, 0x9f, 0x7c, 0x0b, 0x3b, 0x09, 0x3d, 0x09, 0x9b, 0x77,
+	0x8e, 0x1a, 0x23, 0x45, 0x1f, 0x2a, 0x7c, 0x4a, 0x39, 0x18, 0x2a, 0xb4, 0x59, 0xab, 0x02, 0x70,
+	0x38, 0x5b, 0xa7, 0xe8, 0xcc, 0x92, 0xaf, 0x95, 0x97, 0xc3, 0x9e, 0xaa, 0xb4, 0xa5, 0x34, 0xaa,
+	0xc1, 0x6e, 0x94, 0x75, 0x3c, 0x6c, 0x37, 0x46, 0x8a, 0x94, 0x41, 0x12, 0x94, 0x39, 0xa6, 0x75,
+	0x34, 0xd0, 0xe8, 0xc8, 0xf3, 0xc9, 0xef, 0x53, 0x00, 0xab, 0x64, 0x40, 0x5b, 0xc1, 0x81, 0xda,
+	0xa6, 0x9d, 0xe0, 0xb8, 0x79, 0xdc, 0x1b, 0xf1, 0xb7, 0xe9, 0x36, 0x54, 0x38, 0xb0, 0x75, 0xa4,
+	0x34, 0xe8, 0x11, 0xac, 0x3b, 0xe4, 0x20, 0x31, 0x5f, 0x3d, 0x7a, 0xa5, 0x77, 0x7a, 0x47, 0x47,
+	0xec, 0x78, 0x04, 0x55, 0x8e, 0x53, 0x5e, 0x2a, 0xad, 0x31, 0xdd, 0x22, 0xb3, 0x82, 0xb5, 0x1a,
+	0xfd, 0x96, 0x12, 0x4c, 0x5b, 0x7d, 0x3a, 0x2e, 0x79, 0x8e, 0xca, 0xc7, 0x41, 0x62, 0x24, 0xbb,
+	0x79, 0xf8, 0xaf, 0x1c, 0x6c, 0xb7, 0xce, 0x0d, 0xcb, 0xc2, 0x66, 0x23, 0x68, 0xfe, 0x68, 0x74,
+	0x45, 0x7f, 0xa8, 0x44, 0x0f, 0x56, 0x71, 0x95, 0xf0, 0xf3, 0x67, 0xfd, 0xdd, 0xeb, 0xd0, 0x22,
+	0x53, 0xa9, 0x50, 0x0a, 0xfd, 0x40, 0x87, 0xf6, 0xe3, 0xb5, 0x2a, 0xfe, 0x03, 0x65, 0xfd, 0xd1,
+	0x0d, 0x14, 0x62, 0xcf, 0x97, 0x50, 0x89, 0x54, 0x03, 0x24, 0xdf, 0x5e, 0x96, 0xea, 0xef, 0xdd,
+	0x48, 0xb3, 0x92, 0x36, 0xf4, 0xdb, 0x5d, 0x5c, 0xda, 0xf5, 0x1f, 0x13, 0xeb, 0x8f, 0x6e, 0xa0,
+	0x58, 0xed, 0x19, 0x7e, 0x4f, 0xc5, 0xf6, 0x5c, 0xff, 0x9d, 0xae, 0xfe, 0xe8, 0x06, 0x0a, 0xb1,
+	0xe7, 0x20, 0xe2, 0x5b, 0x0f, 0x13, 0x5e, 0x86, 0xe1, 0x4a, 0x57, 0xdf, 0xbf, 0x9e, 0x40, 0x6c,
+	0xf8, 0x35, 0xdc, 0x09, 0x26, 0x20, 0x2c, 0x71, 0x0a, 0x9f, 0x40, 0xc1, 0x80, 0x24, 0xe9, 0xb7,
+	0xbf, 0xfa, 0xfd, 0x58, 0x57, 0x1b, 0xc1, 0x3e, 0x4e, 0x7d, 0x94, 0x42, 0x3f, 0x86, 0x82, 0x5f,
+	0xbd, 0x50, 0x30, 0x6b, 0x8e, 0x95, 0xb8, 0x7a, 0x6d, 0x1d, 0x21, 0xe4, 0x3a, 0x82, 0x3b, 0x91,
+	0x7c, 0xae, 0x59, 0xc6, 0xc2, 0x3d, 0xb7, 0xbd, 0x95, 0x5c, 0x49, 0xc5, 0xac, 0x7e, 0x27, 0x11,
+	0x7b, 0xba, 0xc9, 0x7e, 0xcb, 0xff, 0xe4, 0xdf, 0x03, 0x00, 0x3c, 0x55, 0x2d, 0xe2, 0x05, 0x20,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
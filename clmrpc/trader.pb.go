@@ -75,12 +75,76 @@ func (AccountState) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_b8f61804588c75fe, []int{0}
 }
 
+type ListOrdersRequest_TypeFilter int32
+
+const (
+	ListOrdersRequest_ALL_TYPES ListOrdersRequest_TypeFilter = 0
+	ListOrdersRequest_ASKS_ONLY ListOrdersRequest_TypeFilter = 1
+	ListOrdersRequest_BIDS_ONLY ListOrdersRequest_TypeFilter = 2
+)
+
+var ListOrdersRequest_TypeFilter_name = map[int32]string{
+	0: "ALL_TYPES",
+	1: "ASKS_ONLY",
+	2: "BIDS_ONLY",
+}
+
+var ListOrdersRequest_TypeFilter_value = map[string]int32{
+	"ALL_TYPES": 0,
+	"ASKS_ONLY": 1,
+	"BIDS_ONLY": 2,
+}
+
+func (x ListOrdersRequest_TypeFilter) String() string {
+	return proto.EnumName(ListOrdersRequest_TypeFilter_name, int32(x))
+}
+
+func (ListOrdersRequest_TypeFilter) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{14, 0}
+}
+
+type OrderAction_ActionType int32
+
+const (
+	OrderAction_SUBMIT  OrderAction_ActionType = 0
+	OrderAction_CANCEL  OrderAction_ActionType = 1
+	OrderAction_REPLACE OrderAction_ActionType = 2
+)
+
+var OrderAction_ActionType_name = map[int32]string{
+	0: "SUBMIT",
+	1: "CANCEL",
+	2: "REPLACE",
+}
+
+var OrderAction_ActionType_value = map[string]int32{
+	"SUBMIT":  0,
+	"CANCEL":  1,
+	"REPLACE": 2,
+}
+
+func (x OrderAction_ActionType) String() string {
+	return proto.EnumName(OrderAction_ActionType_name, int32(x))
+}
+
+func (OrderAction_ActionType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{22, 0}
+}
+
 type InitAccountRequest struct {
-	AccountValue         uint64   `protobuf:"varint,1,opt,name=account_value,json=accountValue,proto3" json:"account_value,omitempty"`
-	AccountExpiry        uint32   `protobuf:"varint,2,opt,name=account_expiry,json=accountExpiry,proto3" json:"account_expiry,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	AccountValue  uint64 `protobuf:"varint,1,opt,name=account_value,json=accountValue,proto3" json:"account_value,omitempty"`
+	AccountExpiry uint32 `protobuf:"varint,2,opt,name=account_expiry,json=accountExpiry,proto3" json:"account_expiry,omitempty"`
+	//
+	//An optional user-defined name of the account. The label is only known to
+	//the trader and never sent to the auction server.
+	Label string `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	//
+	//Optional user-defined key/value pairs to attach to the account. Just like
+	//the label, they are only known to the trader.
+	Tags                 map[string]string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *InitAccountRequest) Reset()         { *m = InitAccountRequest{} }
@@ -122,10 +186,31 @@ func (m *InitAccountRequest) GetAccountExpiry() uint32 {
 	return 0
 }
 
+func (m *InitAccountRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *InitAccountRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
 type ListAccountsRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	//
+	//Only list accounts with the given label.
+	Label string `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	//
+	//Only list accounts that have all of the given tags. A tag with an empty
+	//value matches any value of that key.
+	Tags                 map[string]string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ListAccountsRequest) Reset()         { *m = ListAccountsRequest{} }
@@ -153,6 +238,20 @@ func (m *ListAccountsRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ListAccountsRequest proto.InternalMessageInfo
 
+func (m *ListAccountsRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *ListAccountsRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
 type ListAccountsResponse struct {
 	Accounts             []*Account `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
@@ -241,6 +340,84 @@ func (m *Output) GetAddress() string {
 	return ""
 }
 
+type LabelAccountRequest struct {
+	// The trader key associated with the account to label.
+	TraderKey []byte `protobuf:"bytes,1,opt,name=trader_key,json=traderKey,proto3" json:"trader_key,omitempty"`
+	//
+	//The new label of the account. The label is only changed if it's set or if
+	//clear_label is true.
+	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// Remove the label of the account.
+	ClearLabel bool `protobuf:"varint,3,opt,name=clear_label,json=clearLabel,proto3" json:"clear_label,omitempty"`
+	// The tags to add to the account or to overwrite the value of.
+	SetTags map[string]string `protobuf:"bytes,4,rep,name=set_tags,json=setTags,proto3" json:"set_tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The keys of the tags to remove from the account.
+	RemoveTags           []string `protobuf:"bytes,5,rep,name=remove_tags,json=removeTags,proto3" json:"remove_tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LabelAccountRequest) Reset()         { *m = LabelAccountRequest{} }
+func (m *LabelAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*LabelAccountRequest) ProtoMessage()    {}
+func (*LabelAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{4}
+}
+
+func (m *LabelAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LabelAccountRequest.Unmarshal(m, b)
+}
+func (m *LabelAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LabelAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *LabelAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelAccountRequest.Merge(m, src)
+}
+func (m *LabelAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_LabelAccountRequest.Size(m)
+}
+func (m *LabelAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelAccountRequest proto.InternalMessageInfo
+
+func (m *LabelAccountRequest) GetTraderKey() []byte {
+	if m != nil {
+		return m.TraderKey
+	}
+	return nil
+}
+
+func (m *LabelAccountRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *LabelAccountRequest) GetClearLabel() bool {
+	if m != nil {
+		return m.ClearLabel
+	}
+	return false
+}
+
+func (m *LabelAccountRequest) GetSetTags() map[string]string {
+	if m != nil {
+		return m.SetTags
+	}
+	return nil
+}
+
+func (m *LabelAccountRequest) GetRemoveTags() []string {
+	if m != nil {
+		return m.RemoveTags
+	}
+	return nil
+}
+
 type CloseAccountRequest struct {
 	// The trader key associated with the account that will be closed.
 	TraderKey []byte `protobuf:"bytes,1,opt,name=trader_key,json=traderKey,proto3" json:"trader_key,omitempty"`
@@ -258,7 +435,7 @@ func (m *CloseAccountRequest) Reset()         { *m = CloseAccountRequest{} }
 func (m *CloseAccountRequest) String() string { return proto.CompactTextString(m) }
 func (*CloseAccountRequest) ProtoMessage()    {}
 func (*CloseAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{4}
+	return fileDescriptor_b8f61804588c75fe, []int{5}
 }
 
 func (m *CloseAccountRequest) XXX_Unmarshal(b []byte) error {
@@ -305,7 +482,7 @@ func (m *CloseAccountResponse) Reset()         { *m = CloseAccountResponse{} }
 func (m *CloseAccountResponse) String() string { return proto.CompactTextString(m) }
 func (*CloseAccountResponse) ProtoMessage()    {}
 func (*CloseAccountResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{5}
+	return fileDescriptor_b8f61804588c75fe, []int{6}
 }
 
 func (m *CloseAccountResponse) XXX_Unmarshal(b []byte) error {
@@ -352,7 +529,7 @@ func (m *WithdrawAccountRequest) Reset()         { *m = WithdrawAccountRequest{}
 func (m *WithdrawAccountRequest) String() string { return proto.CompactTextString(m) }
 func (*WithdrawAccountRequest) ProtoMessage()    {}
 func (*WithdrawAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{6}
+	return fileDescriptor_b8f61804588c75fe, []int{7}
 }
 
 func (m *WithdrawAccountRequest) XXX_Unmarshal(b []byte) error {
@@ -408,7 +585,7 @@ func (m *WithdrawAccountResponse) Reset()         { *m = WithdrawAccountResponse
 func (m *WithdrawAccountResponse) String() string { return proto.CompactTextString(m) }
 func (*WithdrawAccountResponse) ProtoMessage()    {}
 func (*WithdrawAccountResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{7}
+	return fileDescriptor_b8f61804588c75fe, []int{8}
 }
 
 func (m *WithdrawAccountResponse) XXX_Unmarshal(b []byte) error {
@@ -462,7 +639,7 @@ func (m *DepositAccountRequest) Reset()         { *m = DepositAccountRequest{} }
 func (m *DepositAccountRequest) String() string { return proto.CompactTextString(m) }
 func (*DepositAccountRequest) ProtoMessage()    {}
 func (*DepositAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{8}
+	return fileDescriptor_b8f61804588c75fe, []int{9}
 }
 
 func (m *DepositAccountRequest) XXX_Unmarshal(b []byte) error {
@@ -518,7 +695,7 @@ func (m *DepositAccountResponse) Reset()         { *m = DepositAccountResponse{}
 func (m *DepositAccountResponse) String() string { return proto.CompactTextString(m) }
 func (*DepositAccountResponse) ProtoMessage()    {}
 func (*DepositAccountResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{9}
+	return fileDescriptor_b8f61804588c75fe, []int{10}
 }
 
 func (m *DepositAccountResponse) XXX_Unmarshal(b []byte) error {
@@ -569,17 +746,21 @@ type Account struct {
 	// The current state of the account.
 	State AccountState `protobuf:"varint,5,opt,name=state,proto3,enum=clmrpc.AccountState" json:"state,omitempty"`
 	// The hash of the account's closing transaction, if any.
-	CloseTxid            []byte   `protobuf:"bytes,6,opt,name=close_txid,json=closeTxid,proto3" json:"close_txid,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	CloseTxid []byte `protobuf:"bytes,6,opt,name=close_txid,json=closeTxid,proto3" json:"close_txid,omitempty"`
+	// The user-defined name of the account, if any.
+	Label string `protobuf:"bytes,7,opt,name=label,proto3" json:"label,omitempty"`
+	// The user-defined key/value pairs attached to the account.
+	Tags                 map[string]string `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *Account) Reset()         { *m = Account{} }
 func (m *Account) String() string { return proto.CompactTextString(m) }
 func (*Account) ProtoMessage()    {}
 func (*Account) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{10}
+	return fileDescriptor_b8f61804588c75fe, []int{11}
 }
 
 func (m *Account) XXX_Unmarshal(b []byte) error {
@@ -642,6 +823,20 @@ func (m *Account) GetCloseTxid() []byte {
 	return nil
 }
 
+func (m *Account) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *Account) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
 type SubmitOrderRequest struct {
 	// Types that are valid to be assigned to Details:
 	//	*SubmitOrderRequest_Ask
@@ -656,7 +851,7 @@ func (m *SubmitOrderRequest) Reset()         { *m = SubmitOrderRequest{} }
 func (m *SubmitOrderRequest) String() string { return proto.CompactTextString(m) }
 func (*SubmitOrderRequest) ProtoMessage()    {}
 func (*SubmitOrderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{11}
+	return fileDescriptor_b8f61804588c75fe, []int{12}
 }
 
 func (m *SubmitOrderRequest) XXX_Unmarshal(b []byte) error {
@@ -736,7 +931,7 @@ func (m *SubmitOrderResponse) Reset()         { *m = SubmitOrderResponse{} }
 func (m *SubmitOrderResponse) String() string { return proto.CompactTextString(m) }
 func (*SubmitOrderResponse) ProtoMessage()    {}
 func (*SubmitOrderResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{12}
+	return fileDescriptor_b8f61804588c75fe, []int{13}
 }
 
 func (m *SubmitOrderResponse) XXX_Unmarshal(b []byte) error {
@@ -803,16 +998,49 @@ func (*SubmitOrderResponse) XXX_OneofWrappers() []interface{} {
 }
 
 type ListOrdersRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	//
+	//Also list the orders that failed to be submitted to the auction server.
+	IncludeFailed bool `protobuf:"varint,1,opt,name=include_failed,json=includeFailed,proto3" json:"include_failed,omitempty"`
+	//
+	//Only list orders of the given type.
+	TypeFilter ListOrdersRequest_TypeFilter `protobuf:"varint,2,opt,name=type_filter,json=typeFilter,proto3,enum=clmrpc.ListOrdersRequest_TypeFilter" json:"type_filter,omitempty"`
+	//
+	//Only list orders that are in one of the given states. All states are listed
+	//if empty.
+	States []OrderState `protobuf:"varint,3,rep,packed,name=states,proto3,enum=clmrpc.OrderState" json:"states,omitempty"`
+	//
+	//Only list orders of the account with the given trader key.
+	TraderKey []byte `protobuf:"bytes,4,opt,name=trader_key,json=traderKey,proto3" json:"trader_key,omitempty"`
+	//
+	//Only list orders that can still be matched.
+	ActiveOnly bool `protobuf:"varint,5,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	//
+	//The number of matching orders to skip, ordered by their nonce.
+	Offset uint32 `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	//
+	//The maximum number of orders to return. Zero means no limit.
+	Limit uint32 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	//
+	//Don't contact the auction server and return the state we last stored
+	//locally for each order.
+	LocalOnly bool `protobuf:"varint,8,opt,name=local_only,json=localOnly,proto3" json:"local_only,omitempty"`
+	//
+	//Only list orders with the given label.
+	Label string `protobuf:"bytes,9,opt,name=label,proto3" json:"label,omitempty"`
+	//
+	//Only list orders that have all of the given tags. A tag with an empty value
+	//matches any value of that key.
+	Tags                 map[string]string `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ListOrdersRequest) Reset()         { *m = ListOrdersRequest{} }
 func (m *ListOrdersRequest) String() string { return proto.CompactTextString(m) }
 func (*ListOrdersRequest) ProtoMessage()    {}
 func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{13}
+	return fileDescriptor_b8f61804588c75fe, []int{14}
 }
 
 func (m *ListOrdersRequest) XXX_Unmarshal(b []byte) error {
@@ -833,9 +1061,83 @@ func (m *ListOrdersRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ListOrdersRequest proto.InternalMessageInfo
 
+func (m *ListOrdersRequest) GetIncludeFailed() bool {
+	if m != nil {
+		return m.IncludeFailed
+	}
+	return false
+}
+
+func (m *ListOrdersRequest) GetTypeFilter() ListOrdersRequest_TypeFilter {
+	if m != nil {
+		return m.TypeFilter
+	}
+	return ListOrdersRequest_ALL_TYPES
+}
+
+func (m *ListOrdersRequest) GetStates() []OrderState {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
+func (m *ListOrdersRequest) GetTraderKey() []byte {
+	if m != nil {
+		return m.TraderKey
+	}
+	return nil
+}
+
+func (m *ListOrdersRequest) GetActiveOnly() bool {
+	if m != nil {
+		return m.ActiveOnly
+	}
+	return false
+}
+
+func (m *ListOrdersRequest) GetOffset() uint32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ListOrdersRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListOrdersRequest) GetLocalOnly() bool {
+	if m != nil {
+		return m.LocalOnly
+	}
+	return false
+}
+
+func (m *ListOrdersRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *ListOrdersRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
 type ListOrdersResponse struct {
-	Asks                 []*Ask   `protobuf:"bytes,1,rep,name=asks,proto3" json:"asks,omitempty"`
-	Bids                 []*Bid   `protobuf:"bytes,2,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks []*Ask `protobuf:"bytes,1,rep,name=asks,proto3" json:"asks,omitempty"`
+	Bids []*Bid `protobuf:"bytes,2,rep,name=bids,proto3" json:"bids,omitempty"`
+	//
+	//The total number of orders that match the filters, without taking offset
+	//and limit into account.
+	TotalOrders          uint32   `protobuf:"varint,3,opt,name=total_orders,json=totalOrders,proto3" json:"total_orders,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -845,7 +1147,7 @@ func (m *ListOrdersResponse) Reset()         { *m = ListOrdersResponse{} }
 func (m *ListOrdersResponse) String() string { return proto.CompactTextString(m) }
 func (*ListOrdersResponse) ProtoMessage()    {}
 func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{14}
+	return fileDescriptor_b8f61804588c75fe, []int{15}
 }
 
 func (m *ListOrdersResponse) XXX_Unmarshal(b []byte) error {
@@ -880,6 +1182,13 @@ func (m *ListOrdersResponse) GetBids() []*Bid {
 	return nil
 }
 
+func (m *ListOrdersResponse) GetTotalOrders() uint32 {
+	if m != nil {
+		return m.TotalOrders
+	}
+	return 0
+}
+
 type CancelOrderRequest struct {
 	OrderNonce           []byte   `protobuf:"bytes,1,opt,name=order_nonce,json=orderNonce,proto3" json:"order_nonce,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -891,7 +1200,7 @@ func (m *CancelOrderRequest) Reset()         { *m = CancelOrderRequest{} }
 func (m *CancelOrderRequest) String() string { return proto.CompactTextString(m) }
 func (*CancelOrderRequest) ProtoMessage()    {}
 func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{15}
+	return fileDescriptor_b8f61804588c75fe, []int{16}
 }
 
 func (m *CancelOrderRequest) XXX_Unmarshal(b []byte) error {
@@ -929,7 +1238,7 @@ func (m *CancelOrderResponse) Reset()         { *m = CancelOrderResponse{} }
 func (m *CancelOrderResponse) String() string { return proto.CompactTextString(m) }
 func (*CancelOrderResponse) ProtoMessage()    {}
 func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{16}
+	return fileDescriptor_b8f61804588c75fe, []int{17}
 }
 
 func (m *CancelOrderResponse) XXX_Unmarshal(b []byte) error {
@@ -950,323 +1259,2056 @@ func (m *CancelOrderResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CancelOrderResponse proto.InternalMessageInfo
 
-type Order struct {
-	//
-	//The trader's account key of the account that is used for the order.
-	TraderKey []byte `protobuf:"bytes,1,opt,name=trader_key,json=traderKey,proto3" json:"trader_key,omitempty"`
-	//
-	//Fixed order rate in parts per million.
-	RateFixed uint32 `protobuf:"varint,2,opt,name=rate_fixed,json=rateFixed,proto3" json:"rate_fixed,omitempty"`
-	//
-	//Order amount in satoshis.
-	Amt uint64 `protobuf:"varint,3,opt,name=amt,proto3" json:"amt,omitempty"`
-	//
-	//Preferred fee rate to be used for the channel funding transaction, expressed
-	//in satoshis per 1000 weight units (sat/kW).
-	FundingFeeRate uint64 `protobuf:"varint,4,opt,name=funding_fee_rate,json=fundingFeeRate,proto3" json:"funding_fee_rate,omitempty"`
-	//
-	//Order nonce, acts as unique order identifier.
-	OrderNonce []byte `protobuf:"bytes,5,opt,name=order_nonce,json=orderNonce,proto3" json:"order_nonce,omitempty"`
-	//
-	//The state the order currently is in.
-	State OrderState `protobuf:"varint,6,opt,name=state,proto3,enum=clmrpc.OrderState" json:"state,omitempty"`
-	//
-	//The number of order units the amount corresponds to.
-	Units uint32 `protobuf:"varint,7,opt,name=units,proto3" json:"units,omitempty"`
+type LabelOrderRequest struct {
+	// The nonce of the order to label.
+	OrderNonce []byte `protobuf:"bytes,1,opt,name=order_nonce,json=orderNonce,proto3" json:"order_nonce,omitempty"`
 	//
-	//The number of currently unfilled units of this order. This will be equal to
-	//the total amount of units until the order has reached the state PARTIAL_FILL
-	//or EXECUTED.
-	UnitsUnfulfilled     uint32   `protobuf:"varint,8,opt,name=units_unfulfilled,json=unitsUnfulfilled,proto3" json:"units_unfulfilled,omitempty"`
+	//The new label of the order. The label is only changed if it's set or if
+	//clear_label is true.
+	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// Remove the label of the order.
+	ClearLabel bool `protobuf:"varint,3,opt,name=clear_label,json=clearLabel,proto3" json:"clear_label,omitempty"`
+	// The tags to add to the order or to overwrite the value of.
+	SetTags map[string]string `protobuf:"bytes,4,rep,name=set_tags,json=setTags,proto3" json:"set_tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The keys of the tags to remove from the order.
+	RemoveTags           []string `protobuf:"bytes,5,rep,name=remove_tags,json=removeTags,proto3" json:"remove_tags,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Order) Reset()         { *m = Order{} }
-func (m *Order) String() string { return proto.CompactTextString(m) }
-func (*Order) ProtoMessage()    {}
-func (*Order) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{17}
+func (m *LabelOrderRequest) Reset()         { *m = LabelOrderRequest{} }
+func (m *LabelOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*LabelOrderRequest) ProtoMessage()    {}
+func (*LabelOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{18}
 }
 
-func (m *Order) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Order.Unmarshal(m, b)
+func (m *LabelOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LabelOrderRequest.Unmarshal(m, b)
 }
-func (m *Order) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Order.Marshal(b, m, deterministic)
+func (m *LabelOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LabelOrderRequest.Marshal(b, m, deterministic)
 }
-func (m *Order) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Order.Merge(m, src)
+func (m *LabelOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelOrderRequest.Merge(m, src)
 }
-func (m *Order) XXX_Size() int {
-	return xxx_messageInfo_Order.Size(m)
+func (m *LabelOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_LabelOrderRequest.Size(m)
 }
-func (m *Order) XXX_DiscardUnknown() {
-	xxx_messageInfo_Order.DiscardUnknown(m)
+func (m *LabelOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelOrderRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Order proto.InternalMessageInfo
+var xxx_messageInfo_LabelOrderRequest proto.InternalMessageInfo
 
-func (m *Order) GetTraderKey() []byte {
+func (m *LabelOrderRequest) GetOrderNonce() []byte {
 	if m != nil {
-		return m.TraderKey
+		return m.OrderNonce
 	}
 	return nil
 }
 
-func (m *Order) GetRateFixed() uint32 {
+func (m *LabelOrderRequest) GetLabel() string {
 	if m != nil {
-		return m.RateFixed
+		return m.Label
 	}
-	return 0
+	return ""
 }
 
-func (m *Order) GetAmt() uint64 {
+func (m *LabelOrderRequest) GetClearLabel() bool {
 	if m != nil {
-		return m.Amt
+		return m.ClearLabel
 	}
-	return 0
+	return false
 }
 
-func (m *Order) GetFundingFeeRate() uint64 {
+func (m *LabelOrderRequest) GetSetTags() map[string]string {
 	if m != nil {
-		return m.FundingFeeRate
+		return m.SetTags
+	}
+	return nil
+}
+
+func (m *LabelOrderRequest) GetRemoveTags() []string {
+	if m != nil {
+		return m.RemoveTags
+	}
+	return nil
+}
+
+type LabelOrderResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LabelOrderResponse) Reset()         { *m = LabelOrderResponse{} }
+func (m *LabelOrderResponse) String() string { return proto.CompactTextString(m) }
+func (*LabelOrderResponse) ProtoMessage()    {}
+func (*LabelOrderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{19}
+}
+
+func (m *LabelOrderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LabelOrderResponse.Unmarshal(m, b)
+}
+func (m *LabelOrderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LabelOrderResponse.Marshal(b, m, deterministic)
+}
+func (m *LabelOrderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelOrderResponse.Merge(m, src)
+}
+func (m *LabelOrderResponse) XXX_Size() int {
+	return xxx_messageInfo_LabelOrderResponse.Size(m)
+}
+func (m *LabelOrderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelOrderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelOrderResponse proto.InternalMessageInfo
+
+type ApplyOrdersRequest struct {
+	//
+	//The trader keys of the accounts whose orders are fully reconciled. All
+	//active orders of these accounts that aren't desired are canceled, including
+	//unlabeled ones. Of all other accounts, only the orders with the label of a
+	//desired order are touched.
+	TraderKeys [][]byte `protobuf:"bytes,1,rep,name=trader_keys,json=traderKeys,proto3" json:"trader_keys,omitempty"`
+	//
+	//The desired asks. Each desired order must have a label that is unique
+	//within its account and is used to find the existing order it corresponds
+	//to.
+	Asks []*Ask `protobuf:"bytes,2,rep,name=asks,proto3" json:"asks,omitempty"`
+	//
+	//The desired bids, identified by their label the same way as the asks.
+	Bids []*Bid `protobuf:"bytes,3,rep,name=bids,proto3" json:"bids,omitempty"`
+	//
+	//Only return the actions that would be taken without executing them.
+	PlanOnly             bool     `protobuf:"varint,4,opt,name=plan_only,json=planOnly,proto3" json:"plan_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplyOrdersRequest) Reset()         { *m = ApplyOrdersRequest{} }
+func (m *ApplyOrdersRequest) String() string { return proto.CompactTextString(m) }
+func (*ApplyOrdersRequest) ProtoMessage()    {}
+func (*ApplyOrdersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{20}
+}
+
+func (m *ApplyOrdersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplyOrdersRequest.Unmarshal(m, b)
+}
+func (m *ApplyOrdersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplyOrdersRequest.Marshal(b, m, deterministic)
+}
+func (m *ApplyOrdersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyOrdersRequest.Merge(m, src)
+}
+func (m *ApplyOrdersRequest) XXX_Size() int {
+	return xxx_messageInfo_ApplyOrdersRequest.Size(m)
+}
+func (m *ApplyOrdersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyOrdersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplyOrdersRequest proto.InternalMessageInfo
+
+func (m *ApplyOrdersRequest) GetTraderKeys() [][]byte {
+	if m != nil {
+		return m.TraderKeys
+	}
+	return nil
+}
+
+func (m *ApplyOrdersRequest) GetAsks() []*Ask {
+	if m != nil {
+		return m.Asks
+	}
+	return nil
+}
+
+func (m *ApplyOrdersRequest) GetBids() []*Bid {
+	if m != nil {
+		return m.Bids
+	}
+	return nil
+}
+
+func (m *ApplyOrdersRequest) GetPlanOnly() bool {
+	if m != nil {
+		return m.PlanOnly
+	}
+	return false
+}
+
+type ApplyOrdersResponse struct {
+	//
+	//The actions that were taken, or would be taken if plan_only was set, to
+	//reach the desired set of orders.
+	Actions              []*OrderAction `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ApplyOrdersResponse) Reset()         { *m = ApplyOrdersResponse{} }
+func (m *ApplyOrdersResponse) String() string { return proto.CompactTextString(m) }
+func (*ApplyOrdersResponse) ProtoMessage()    {}
+func (*ApplyOrdersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{21}
+}
+
+func (m *ApplyOrdersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplyOrdersResponse.Unmarshal(m, b)
+}
+func (m *ApplyOrdersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplyOrdersResponse.Marshal(b, m, deterministic)
+}
+func (m *ApplyOrdersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyOrdersResponse.Merge(m, src)
+}
+func (m *ApplyOrdersResponse) XXX_Size() int {
+	return xxx_messageInfo_ApplyOrdersResponse.Size(m)
+}
+func (m *ApplyOrdersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyOrdersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplyOrdersResponse proto.InternalMessageInfo
+
+func (m *ApplyOrdersResponse) GetActions() []*OrderAction {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+type OrderAction struct {
+	//
+	//The action to take.
+	Action OrderAction_ActionType `protobuf:"varint,1,opt,name=action,proto3,enum=clmrpc.OrderAction_ActionType" json:"action,omitempty"`
+	//
+	//The trader key of the account of the order.
+	TraderKey []byte `protobuf:"bytes,2,opt,name=trader_key,json=traderKey,proto3" json:"trader_key,omitempty"`
+	//
+	//The label of the order.
+	Label string `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	//
+	//The nonce of the existing order that is canceled or replaced.
+	OrderNonce []byte `protobuf:"bytes,4,opt,name=order_nonce,json=orderNonce,proto3" json:"order_nonce,omitempty"`
+	//
+	//The nonce of the new order that was submitted.
+	NewOrderNonce []byte `protobuf:"bytes,5,opt,name=new_order_nonce,json=newOrderNonce,proto3" json:"new_order_nonce,omitempty"`
+	//
+	//A human readable description of the action.
+	Description string `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	//
+	//The error that occurred while executing the action, if any.
+	Error                string   `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderAction) Reset()         { *m = OrderAction{} }
+func (m *OrderAction) String() string { return proto.CompactTextString(m) }
+func (*OrderAction) ProtoMessage()    {}
+func (*OrderAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{22}
+}
+
+func (m *OrderAction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderAction.Unmarshal(m, b)
+}
+func (m *OrderAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderAction.Marshal(b, m, deterministic)
+}
+func (m *OrderAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderAction.Merge(m, src)
+}
+func (m *OrderAction) XXX_Size() int {
+	return xxx_messageInfo_OrderAction.Size(m)
+}
+func (m *OrderAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderAction proto.InternalMessageInfo
+
+func (m *OrderAction) GetAction() OrderAction_ActionType {
+	if m != nil {
+		return m.Action
+	}
+	return OrderAction_SUBMIT
+}
+
+func (m *OrderAction) GetTraderKey() []byte {
+	if m != nil {
+		return m.TraderKey
+	}
+	return nil
+}
+
+func (m *OrderAction) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *OrderAction) GetOrderNonce() []byte {
+	if m != nil {
+		return m.OrderNonce
+	}
+	return nil
+}
+
+func (m *OrderAction) GetNewOrderNonce() []byte {
+	if m != nil {
+		return m.NewOrderNonce
+	}
+	return nil
+}
+
+func (m *OrderAction) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *OrderAction) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type PruneFailedOrdersRequest struct {
+	//
+	//Only remove failed orders that failed more than the given number of
+	//seconds ago. Zero removes all failed orders.
+	OlderThanSeconds     uint64   `protobuf:"varint,1,opt,name=older_than_seconds,json=olderThanSeconds,proto3" json:"older_than_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneFailedOrdersRequest) Reset()         { *m = PruneFailedOrdersRequest{} }
+func (m *PruneFailedOrdersRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneFailedOrdersRequest) ProtoMessage()    {}
+func (*PruneFailedOrdersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{23}
+}
+
+func (m *PruneFailedOrdersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneFailedOrdersRequest.Unmarshal(m, b)
+}
+func (m *PruneFailedOrdersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneFailedOrdersRequest.Marshal(b, m, deterministic)
+}
+func (m *PruneFailedOrdersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneFailedOrdersRequest.Merge(m, src)
+}
+func (m *PruneFailedOrdersRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneFailedOrdersRequest.Size(m)
+}
+func (m *PruneFailedOrdersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneFailedOrdersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneFailedOrdersRequest proto.InternalMessageInfo
+
+func (m *PruneFailedOrdersRequest) GetOlderThanSeconds() uint64 {
+	if m != nil {
+		return m.OlderThanSeconds
+	}
+	return 0
+}
+
+type PruneFailedOrdersResponse struct {
+	//
+	//The number of failed orders that were removed.
+	NumPruned            uint32   `protobuf:"varint,1,opt,name=num_pruned,json=numPruned,proto3" json:"num_pruned,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneFailedOrdersResponse) Reset()         { *m = PruneFailedOrdersResponse{} }
+func (m *PruneFailedOrdersResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneFailedOrdersResponse) ProtoMessage()    {}
+func (*PruneFailedOrdersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{24}
+}
+
+func (m *PruneFailedOrdersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneFailedOrdersResponse.Unmarshal(m, b)
+}
+func (m *PruneFailedOrdersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneFailedOrdersResponse.Marshal(b, m, deterministic)
+}
+func (m *PruneFailedOrdersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneFailedOrdersResponse.Merge(m, src)
+}
+func (m *PruneFailedOrdersResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneFailedOrdersResponse.Size(m)
+}
+func (m *PruneFailedOrdersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneFailedOrdersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneFailedOrdersResponse proto.InternalMessageInfo
+
+func (m *PruneFailedOrdersResponse) GetNumPruned() uint32 {
+	if m != nil {
+		return m.NumPruned
+	}
+	return 0
+}
+
+type ReplaceOrderRequest struct {
+	//
+	//The nonce of the order to replace.
+	OrderNonce []byte `protobuf:"bytes,1,opt,name=order_nonce,json=orderNonce,proto3" json:"order_nonce,omitempty"`
+	//
+	//The new order. It must be of the same type as the order it replaces and is
+	//submitted with a new nonce.
+	//
+	// Types that are valid to be assigned to Details:
+	//	*ReplaceOrderRequest_Ask
+	//	*ReplaceOrderRequest_Bid
+	Details              isReplaceOrderRequest_Details `protobuf_oneof:"details"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *ReplaceOrderRequest) Reset()         { *m = ReplaceOrderRequest{} }
+func (m *ReplaceOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplaceOrderRequest) ProtoMessage()    {}
+func (*ReplaceOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{25}
+}
+
+func (m *ReplaceOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplaceOrderRequest.Unmarshal(m, b)
+}
+func (m *ReplaceOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplaceOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *ReplaceOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplaceOrderRequest.Merge(m, src)
+}
+func (m *ReplaceOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_ReplaceOrderRequest.Size(m)
+}
+func (m *ReplaceOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplaceOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplaceOrderRequest proto.InternalMessageInfo
+
+func (m *ReplaceOrderRequest) GetOrderNonce() []byte {
+	if m != nil {
+		return m.OrderNonce
+	}
+	return nil
+}
+
+type isReplaceOrderRequest_Details interface {
+	isReplaceOrderRequest_Details()
+}
+
+type ReplaceOrderRequest_Ask struct {
+	Ask *Ask `protobuf:"bytes,2,opt,name=ask,proto3,oneof"`
+}
+
+type ReplaceOrderRequest_Bid struct {
+	Bid *Bid `protobuf:"bytes,3,opt,name=bid,proto3,oneof"`
+}
+
+func (*ReplaceOrderRequest_Ask) isReplaceOrderRequest_Details() {}
+
+func (*ReplaceOrderRequest_Bid) isReplaceOrderRequest_Details() {}
+
+func (m *ReplaceOrderRequest) GetDetails() isReplaceOrderRequest_Details {
+	if m != nil {
+		return m.Details
+	}
+	return nil
+}
+
+func (m *ReplaceOrderRequest) GetAsk() *Ask {
+	if x, ok := m.GetDetails().(*ReplaceOrderRequest_Ask); ok {
+		return x.Ask
+	}
+	return nil
+}
+
+func (m *ReplaceOrderRequest) GetBid() *Bid {
+	if x, ok := m.GetDetails().(*ReplaceOrderRequest_Bid); ok {
+		return x.Bid
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ReplaceOrderRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ReplaceOrderRequest_Ask)(nil),
+		(*ReplaceOrderRequest_Bid)(nil),
+	}
+}
+
+type ReplaceOrderResponse struct {
+	// Types that are valid to be assigned to Details:
+	//	*ReplaceOrderResponse_InvalidOrder
+	//	*ReplaceOrderResponse_AcceptedOrderNonce
+	Details              isReplaceOrderResponse_Details `protobuf_oneof:"details"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *ReplaceOrderResponse) Reset()         { *m = ReplaceOrderResponse{} }
+func (m *ReplaceOrderResponse) String() string { return proto.CompactTextString(m) }
+func (*ReplaceOrderResponse) ProtoMessage()    {}
+func (*ReplaceOrderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{26}
+}
+
+func (m *ReplaceOrderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplaceOrderResponse.Unmarshal(m, b)
+}
+func (m *ReplaceOrderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplaceOrderResponse.Marshal(b, m, deterministic)
+}
+func (m *ReplaceOrderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplaceOrderResponse.Merge(m, src)
+}
+func (m *ReplaceOrderResponse) XXX_Size() int {
+	return xxx_messageInfo_ReplaceOrderResponse.Size(m)
+}
+func (m *ReplaceOrderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplaceOrderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplaceOrderResponse proto.InternalMessageInfo
+
+type isReplaceOrderResponse_Details interface {
+	isReplaceOrderResponse_Details()
+}
+
+type ReplaceOrderResponse_InvalidOrder struct {
+	InvalidOrder *InvalidOrder `protobuf:"bytes,1,opt,name=invalid_order,json=invalidOrder,proto3,oneof"`
+}
+
+type ReplaceOrderResponse_AcceptedOrderNonce struct {
+	AcceptedOrderNonce []byte `protobuf:"bytes,2,opt,name=accepted_order_nonce,json=acceptedOrderNonce,proto3,oneof"`
+}
+
+func (*ReplaceOrderResponse_InvalidOrder) isReplaceOrderResponse_Details() {}
+
+func (*ReplaceOrderResponse_AcceptedOrderNonce) isReplaceOrderResponse_Details() {}
+
+func (m *ReplaceOrderResponse) GetDetails() isReplaceOrderResponse_Details {
+	if m != nil {
+		return m.Details
+	}
+	return nil
+}
+
+func (m *ReplaceOrderResponse) GetInvalidOrder() *InvalidOrder {
+	if x, ok := m.GetDetails().(*ReplaceOrderResponse_InvalidOrder); ok {
+		return x.InvalidOrder
+	}
+	return nil
+}
+
+func (m *ReplaceOrderResponse) GetAcceptedOrderNonce() []byte {
+	if x, ok := m.GetDetails().(*ReplaceOrderResponse_AcceptedOrderNonce); ok {
+		return x.AcceptedOrderNonce
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ReplaceOrderResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ReplaceOrderResponse_InvalidOrder)(nil),
+		(*ReplaceOrderResponse_AcceptedOrderNonce)(nil),
+	}
+}
+
+type Order struct {
+	//
+	//The trader's account key of the account that is used for the order.
+	TraderKey []byte `protobuf:"bytes,1,opt,name=trader_key,json=traderKey,proto3" json:"trader_key,omitempty"`
+	//
+	//Fixed order rate in parts per million.
+	RateFixed uint32 `protobuf:"varint,2,opt,name=rate_fixed,json=rateFixed,proto3" json:"rate_fixed,omitempty"`
+	//
+	//Order amount in satoshis.
+	Amt uint64 `protobuf:"varint,3,opt,name=amt,proto3" json:"amt,omitempty"`
+	//
+	//Preferred fee rate to be used for the channel funding transaction, expressed
+	//in satoshis per 1000 weight units (sat/kW).
+	FundingFeeRate uint64 `protobuf:"varint,4,opt,name=funding_fee_rate,json=fundingFeeRate,proto3" json:"funding_fee_rate,omitempty"`
+	//
+	//Order nonce, acts as unique order identifier.
+	OrderNonce []byte `protobuf:"bytes,5,opt,name=order_nonce,json=orderNonce,proto3" json:"order_nonce,omitempty"`
+	//
+	//The state the order currently is in.
+	State OrderState `protobuf:"varint,6,opt,name=state,proto3,enum=clmrpc.OrderState" json:"state,omitempty"`
+	//
+	//The number of order units the amount corresponds to.
+	Units uint32 `protobuf:"varint,7,opt,name=units,proto3" json:"units,omitempty"`
+	//
+	//The number of currently unfilled units of this order. This will be equal to
+	//the total amount of units until the order has reached the state PARTIAL_FILL
+	//or EXECUTED.
+	UnitsUnfulfilled uint32 `protobuf:"varint,8,opt,name=units_unfulfilled,json=unitsUnfulfilled,proto3" json:"units_unfulfilled,omitempty"`
+	//
+	//The optional block height at which the order is canceled automatically by
+	//the trader. Zero means no limit.
+	ExpiryHeight uint32 `protobuf:"varint,9,opt,name=expiry_height,json=expiryHeight,proto3" json:"expiry_height,omitempty"`
+	//
+	//The optional unix timestamp in seconds after which the order is canceled
+	//automatically by the trader. Zero means no limit.
+	ExpiryTimestamp int64 `protobuf:"varint,10,opt,name=expiry_timestamp,json=expiryTimestamp,proto3" json:"expiry_timestamp,omitempty"`
+	//
+	//The optional number of batches that can be completed after the order was
+	//submitted before the rest of it is canceled automatically by the trader.
+	//Every batch the trader takes part in counts, whether or not the order was
+	//matched in it. Zero means no limit.
+	ExpiryBatches uint32 `protobuf:"varint,11,opt,name=expiry_batches,json=expiryBatches,proto3" json:"expiry_batches,omitempty"`
+	//
+	//The number of batches the order was matched in so far.
+	BatchesMatched uint32 `protobuf:"varint,12,opt,name=batches_matched,json=batchesMatched,proto3" json:"batches_matched,omitempty"`
+	//
+	//The minimum number of units a single matched order must fill. Zero means no
+	//minimum. Requires order version 1 or later.
+	MinUnitsMatch uint32 `protobuf:"varint,13,opt,name=min_units_match,json=minUnitsMatch,proto3" json:"min_units_match,omitempty"`
+	//
+	//Whether the order can only be filled completely in a single batch. Requires
+	//order version 1 or later.
+	AllOrNone bool `protobuf:"varint,14,opt,name=all_or_none,json=allOrNone,proto3" json:"all_or_none,omitempty"`
+	//
+	//The nonce of the order this order replaced, if any.
+	ReplacesOrderNonce []byte `protobuf:"bytes,15,opt,name=replaces_order_nonce,json=replacesOrderNonce,proto3" json:"replaces_order_nonce,omitempty"`
+	//
+	//The nonce of the order this order was replaced by, if any.
+	ReplacedByOrderNonce []byte `protobuf:"bytes,16,opt,name=replaced_by_order_nonce,json=replacedByOrderNonce,proto3" json:"replaced_by_order_nonce,omitempty"`
+	//
+	//The unix timestamp in nanoseconds at which the submission of the order
+	//failed. Only set for orders in the state ORDER_FAILED.
+	FailTimestamp int64 `protobuf:"varint,17,opt,name=fail_timestamp,json=failTimestamp,proto3" json:"fail_timestamp,omitempty"`
+	//
+	//The description of why the submission of the order failed.
+	FailString string `protobuf:"bytes,18,opt,name=fail_string,json=failString,proto3" json:"fail_string,omitempty"`
+	//
+	//Whether the auction server rejected the order because of its details, in
+	//which case fail_reason is set.
+	Rejected bool `protobuf:"varint,19,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	//
+	//The reason the auction server gave for rejecting the order.
+	FailReason InvalidOrder_FailReason `protobuf:"varint,20,opt,name=fail_reason,json=failReason,proto3,enum=clmrpc.InvalidOrder_FailReason" json:"fail_reason,omitempty"`
+	//
+	//Whether the state is the one we last stored locally because it wasn't
+	//queried from the auction server.
+	StateCached bool `protobuf:"varint,21,opt,name=state_cached,json=stateCached,proto3" json:"state_cached,omitempty"`
+	//
+	//An optional user-defined name of the order. The label is only known to the
+	//trader and never sent to the auction server.
+	Label string `protobuf:"bytes,22,opt,name=label,proto3" json:"label,omitempty"`
+	//
+	//Optional user-defined key/value pairs attached to the order. Just like the
+	//label, they are only known to the trader.
+	Tags map[string]string `protobuf:"bytes,23,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	//
+	//The optional one-cancels-other group of the order. As soon as any order of
+	//a group is matched in a batch, all other orders of the group are canceled.
+	//The group is only known to the trader.
+	GroupId []byte `protobuf:"bytes,24,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	//
+	//The nonce of the order of the same group that was matched and caused this
+	//order to be canceled, if any.
+	CanceledByOrderNonce []byte   `protobuf:"bytes,25,opt,name=canceled_by_order_nonce,json=canceledByOrderNonce,proto3" json:"canceled_by_order_nonce,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Order) Reset()         { *m = Order{} }
+func (m *Order) String() string { return proto.CompactTextString(m) }
+func (*Order) ProtoMessage()    {}
+func (*Order) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{27}
+}
+
+func (m *Order) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Order.Unmarshal(m, b)
+}
+func (m *Order) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Order.Marshal(b, m, deterministic)
+}
+func (m *Order) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Order.Merge(m, src)
+}
+func (m *Order) XXX_Size() int {
+	return xxx_messageInfo_Order.Size(m)
+}
+func (m *Order) XXX_DiscardUnknown() {
+	xxx_messageInfo_Order.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Order proto.InternalMessageInfo
+
+func (m *Order) GetTraderKey() []byte {
+	if m != nil {
+		return m.TraderKey
+	}
+	return nil
+}
+
+func (m *Order) GetRateFixed() uint32 {
+	if m != nil {
+		return m.RateFixed
+	}
+	return 0
+}
+
+func (m *Order) GetAmt() uint64 {
+	if m != nil {
+		return m.Amt
+	}
+	return 0
+}
+
+func (m *Order) GetFundingFeeRate() uint64 {
+	if m != nil {
+		return m.FundingFeeRate
+	}
+	return 0
+}
+
+func (m *Order) GetOrderNonce() []byte {
+	if m != nil {
+		return m.OrderNonce
+	}
+	return nil
+}
+
+func (m *Order) GetState() OrderState {
+	if m != nil {
+		return m.State
+	}
+	return OrderState_ORDER_SUBMITTED
+}
+
+func (m *Order) GetUnits() uint32 {
+	if m != nil {
+		return m.Units
+	}
+	return 0
+}
+
+func (m *Order) GetUnitsUnfulfilled() uint32 {
+	if m != nil {
+		return m.UnitsUnfulfilled
+	}
+	return 0
+}
+
+func (m *Order) GetExpiryHeight() uint32 {
+	if m != nil {
+		return m.ExpiryHeight
+	}
+	return 0
+}
+
+func (m *Order) GetExpiryTimestamp() int64 {
+	if m != nil {
+		return m.ExpiryTimestamp
+	}
+	return 0
+}
+
+func (m *Order) GetExpiryBatches() uint32 {
+	if m != nil {
+		return m.ExpiryBatches
+	}
+	return 0
+}
+
+func (m *Order) GetBatchesMatched() uint32 {
+	if m != nil {
+		return m.BatchesMatched
+	}
+	return 0
+}
+
+func (m *Order) GetMinUnitsMatch() uint32 {
+	if m != nil {
+		return m.MinUnitsMatch
+	}
+	return 0
+}
+
+func (m *Order) GetAllOrNone() bool {
+	if m != nil {
+		return m.AllOrNone
+	}
+	return false
+}
+
+func (m *Order) GetReplacesOrderNonce() []byte {
+	if m != nil {
+		return m.ReplacesOrderNonce
+	}
+	return nil
+}
+
+func (m *Order) GetReplacedByOrderNonce() []byte {
+	if m != nil {
+		return m.ReplacedByOrderNonce
+	}
+	return nil
+}
+
+func (m *Order) GetFailTimestamp() int64 {
+	if m != nil {
+		return m.FailTimestamp
+	}
+	return 0
+}
+
+func (m *Order) GetFailString() string {
+	if m != nil {
+		return m.FailString
+	}
+	return ""
+}
+
+func (m *Order) GetRejected() bool {
+	if m != nil {
+		return m.Rejected
+	}
+	return false
+}
+
+func (m *Order) GetFailReason() InvalidOrder_FailReason {
+	if m != nil {
+		return m.FailReason
+	}
+	return InvalidOrder_INVALID_AMT
+}
+
+func (m *Order) GetStateCached() bool {
+	if m != nil {
+		return m.StateCached
+	}
+	return false
+}
+
+func (m *Order) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *Order) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Order) GetGroupId() []byte {
+	if m != nil {
+		return m.GroupId
+	}
+	return nil
+}
+
+func (m *Order) GetCanceledByOrderNonce() []byte {
+	if m != nil {
+		return m.CanceledByOrderNonce
+	}
+	return nil
+}
+
+type Bid struct {
+	//
+	//The common fields shared between both ask and bid order types.
+	Details *Order `protobuf:"bytes,1,opt,name=details,proto3" json:"details,omitempty"`
+	//
+	//Required minimum number of blocks that a channel opened as a result of this
+	//bid should be kept open.
+	MinDurationBlocks uint32 `protobuf:"varint,2,opt,name=min_duration_blocks,json=minDurationBlocks,proto3" json:"min_duration_blocks,omitempty"`
+	//
+	//The version of the order format that is used. Will be increased once new
+	//features are added.
+	Version              uint32   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Bid) Reset()         { *m = Bid{} }
+func (m *Bid) String() string { return proto.CompactTextString(m) }
+func (*Bid) ProtoMessage()    {}
+func (*Bid) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{28}
+}
+
+func (m *Bid) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Bid.Unmarshal(m, b)
+}
+func (m *Bid) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Bid.Marshal(b, m, deterministic)
+}
+func (m *Bid) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Bid.Merge(m, src)
+}
+func (m *Bid) XXX_Size() int {
+	return xxx_messageInfo_Bid.Size(m)
+}
+func (m *Bid) XXX_DiscardUnknown() {
+	xxx_messageInfo_Bid.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Bid proto.InternalMessageInfo
+
+func (m *Bid) GetDetails() *Order {
+	if m != nil {
+		return m.Details
+	}
+	return nil
+}
+
+func (m *Bid) GetMinDurationBlocks() uint32 {
+	if m != nil {
+		return m.MinDurationBlocks
+	}
+	return 0
+}
+
+func (m *Bid) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type Ask struct {
+	//
+	//The common fields shared between both ask and bid order types.
+	Details *Order `protobuf:"bytes,1,opt,name=details,proto3" json:"details,omitempty"`
+	//
+	//The maximum number of blocks the liquidity provider is willing to provide
+	//the channel funds for.
+	MaxDurationBlocks uint32 `protobuf:"varint,2,opt,name=max_duration_blocks,json=maxDurationBlocks,proto3" json:"max_duration_blocks,omitempty"`
+	//
+	//The version of the order format that is used. Will be increased once new
+	//features are added.
+	Version              uint32   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Ask) Reset()         { *m = Ask{} }
+func (m *Ask) String() string { return proto.CompactTextString(m) }
+func (*Ask) ProtoMessage()    {}
+func (*Ask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{29}
+}
+
+func (m *Ask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ask.Unmarshal(m, b)
+}
+func (m *Ask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ask.Marshal(b, m, deterministic)
+}
+func (m *Ask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ask.Merge(m, src)
+}
+func (m *Ask) XXX_Size() int {
+	return xxx_messageInfo_Ask.Size(m)
+}
+func (m *Ask) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ask proto.InternalMessageInfo
+
+func (m *Ask) GetDetails() *Order {
+	if m != nil {
+		return m.Details
+	}
+	return nil
+}
+
+func (m *Ask) GetMaxDurationBlocks() uint32 {
+	if m != nil {
+		return m.MaxDurationBlocks
+	}
+	return 0
+}
+
+func (m *Ask) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type RecoverAccountsRequest struct {
+	//
+	//Whether the accounts should be recovered by scanning the chain instead of
+	//asking the auctioneer. This only requires the connected lnd node and the
+	//auctioneer's static keys below.
+	ChainScan bool `protobuf:"varint,1,opt,name=chain_scan,json=chainScan,proto3" json:"chain_scan,omitempty"`
+	//
+	//The auctioneer's static base key, required for a chain scan.
+	AuctioneerKey []byte `protobuf:"bytes,2,opt,name=auctioneer_key,json=auctioneerKey,proto3" json:"auctioneer_key,omitempty"`
+	//
+	//The auctioneer's initial batch key, required for a chain scan.
+	InitialBatchKey []byte `protobuf:"bytes,3,opt,name=initial_batch_key,json=initialBatchKey,proto3" json:"initial_batch_key,omitempty"`
+	//
+	//The earliest height any of the accounts could have been funded at. If
+	//zero, a height one maximum account lifetime before the current height is
+	//used.
+	StartHeight uint32 `protobuf:"varint,4,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	//
+	//The number of account keys to derive and check during a chain scan. If
+	//zero, a default value is used.
+	NumKeys uint32 `protobuf:"varint,5,opt,name=num_keys,json=numKeys,proto3" json:"num_keys,omitempty"`
+	//
+	//The number of batch keys, starting at the initial batch key, that are
+	//tried for each possible account output during a chain scan. If zero, a
+	//default value is used.
+	BatchKeyWindow uint32 `protobuf:"varint,6,opt,name=batch_key_window,json=batchKeyWindow,proto3" json:"batch_key_window,omitempty"`
+	//
+	//The index of the first account key to check. Only keys from this index on
+	//are derived and sent to the auctioneer or checked during a chain scan.
+	StartIndex uint32 `protobuf:"varint,7,opt,name=start_index,json=startIndex,proto3" json:"start_index,omitempty"`
+	//
+	//The number of successive account keys the auctioneer doesn't know of
+	//after which the recovery stops. If zero, a default value is used.
+	Gap uint32 `protobuf:"varint,8,opt,name=gap,proto3" json:"gap,omitempty"`
+	//
+	//Whether a previously interrupted recovery should be resumed from its
+	//stored progress instead of starting a new one. The start index and gap of
+	//the interrupted recovery are used.
+	Resume               bool     `protobuf:"varint,9,opt,name=resume,proto3" json:"resume,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecoverAccountsRequest) Reset()         { *m = RecoverAccountsRequest{} }
+func (m *RecoverAccountsRequest) String() string { return proto.CompactTextString(m) }
+func (*RecoverAccountsRequest) ProtoMessage()    {}
+func (*RecoverAccountsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{30}
+}
+
+func (m *RecoverAccountsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecoverAccountsRequest.Unmarshal(m, b)
+}
+func (m *RecoverAccountsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecoverAccountsRequest.Marshal(b, m, deterministic)
+}
+func (m *RecoverAccountsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecoverAccountsRequest.Merge(m, src)
+}
+func (m *RecoverAccountsRequest) XXX_Size() int {
+	return xxx_messageInfo_RecoverAccountsRequest.Size(m)
+}
+func (m *RecoverAccountsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecoverAccountsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecoverAccountsRequest proto.InternalMessageInfo
+
+func (m *RecoverAccountsRequest) GetChainScan() bool {
+	if m != nil {
+		return m.ChainScan
+	}
+	return false
+}
+
+func (m *RecoverAccountsRequest) GetAuctioneerKey() []byte {
+	if m != nil {
+		return m.AuctioneerKey
+	}
+	return nil
+}
+
+func (m *RecoverAccountsRequest) GetInitialBatchKey() []byte {
+	if m != nil {
+		return m.InitialBatchKey
+	}
+	return nil
+}
+
+func (m *RecoverAccountsRequest) GetStartHeight() uint32 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *RecoverAccountsRequest) GetNumKeys() uint32 {
+	if m != nil {
+		return m.NumKeys
+	}
+	return 0
+}
+
+func (m *RecoverAccountsRequest) GetBatchKeyWindow() uint32 {
+	if m != nil {
+		return m.BatchKeyWindow
+	}
+	return 0
+}
+
+func (m *RecoverAccountsRequest) GetStartIndex() uint32 {
+	if m != nil {
+		return m.StartIndex
+	}
+	return 0
+}
+
+func (m *RecoverAccountsRequest) GetGap() uint32 {
+	if m != nil {
+		return m.Gap
+	}
+	return 0
+}
+
+func (m *RecoverAccountsRequest) GetResume() bool {
+	if m != nil {
+		return m.Resume
+	}
+	return false
+}
+
+type RecoverAccountsResponse struct {
+	// The number of accounts that were recovered.
+	NumRecoveredAccounts uint32 `protobuf:"varint,1,opt,name=num_recovered_accounts,json=numRecoveredAccounts,proto3" json:"num_recovered_accounts,omitempty"`
+	// The index of the first account key that was checked.
+	StartIndex uint32 `protobuf:"varint,2,opt,name=start_index,json=startIndex,proto3" json:"start_index,omitempty"`
+	// The index of the account key that would be checked next.
+	NextIndex uint32 `protobuf:"varint,3,opt,name=next_index,json=nextIndex,proto3" json:"next_index,omitempty"`
+	//
+	//The number of successive account keys at the end of the checked range the
+	//auctioneer didn't know of.
+	NumUnusedKeys uint32 `protobuf:"varint,4,opt,name=num_unused_keys,json=numUnusedKeys,proto3" json:"num_unused_keys,omitempty"`
+	//
+	//Whether the recovery is complete. An incomplete recovery can be resumed
+	//by setting the resume flag.
+	Complete             bool     `protobuf:"varint,5,opt,name=complete,proto3" json:"complete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecoverAccountsResponse) Reset()         { *m = RecoverAccountsResponse{} }
+func (m *RecoverAccountsResponse) String() string { return proto.CompactTextString(m) }
+func (*RecoverAccountsResponse) ProtoMessage()    {}
+func (*RecoverAccountsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{31}
+}
+
+func (m *RecoverAccountsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecoverAccountsResponse.Unmarshal(m, b)
+}
+func (m *RecoverAccountsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecoverAccountsResponse.Marshal(b, m, deterministic)
+}
+func (m *RecoverAccountsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecoverAccountsResponse.Merge(m, src)
+}
+func (m *RecoverAccountsResponse) XXX_Size() int {
+	return xxx_messageInfo_RecoverAccountsResponse.Size(m)
+}
+func (m *RecoverAccountsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecoverAccountsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecoverAccountsResponse proto.InternalMessageInfo
+
+func (m *RecoverAccountsResponse) GetNumRecoveredAccounts() uint32 {
+	if m != nil {
+		return m.NumRecoveredAccounts
+	}
+	return 0
+}
+
+func (m *RecoverAccountsResponse) GetStartIndex() uint32 {
+	if m != nil {
+		return m.StartIndex
+	}
+	return 0
+}
+
+func (m *RecoverAccountsResponse) GetNextIndex() uint32 {
+	if m != nil {
+		return m.NextIndex
+	}
+	return 0
+}
+
+func (m *RecoverAccountsResponse) GetNumUnusedKeys() uint32 {
+	if m != nil {
+		return m.NumUnusedKeys
+	}
+	return 0
+}
+
+func (m *RecoverAccountsResponse) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+type RestoreAccountBackupRequest struct {
+	// The content of the encrypted static account backup file.
+	Backup               []byte   `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreAccountBackupRequest) Reset()         { *m = RestoreAccountBackupRequest{} }
+func (m *RestoreAccountBackupRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreAccountBackupRequest) ProtoMessage()    {}
+func (*RestoreAccountBackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{32}
+}
+
+func (m *RestoreAccountBackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreAccountBackupRequest.Unmarshal(m, b)
+}
+func (m *RestoreAccountBackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreAccountBackupRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreAccountBackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreAccountBackupRequest.Merge(m, src)
+}
+func (m *RestoreAccountBackupRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreAccountBackupRequest.Size(m)
+}
+func (m *RestoreAccountBackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreAccountBackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreAccountBackupRequest proto.InternalMessageInfo
+
+func (m *RestoreAccountBackupRequest) GetBackup() []byte {
+	if m != nil {
+		return m.Backup
+	}
+	return nil
+}
+
+type RestoreAccountBackupResponse struct {
+	// The number of accounts that were restored from the backup.
+	NumRestoredAccounts  uint32   `protobuf:"varint,1,opt,name=num_restored_accounts,json=numRestoredAccounts,proto3" json:"num_restored_accounts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreAccountBackupResponse) Reset()         { *m = RestoreAccountBackupResponse{} }
+func (m *RestoreAccountBackupResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreAccountBackupResponse) ProtoMessage()    {}
+func (*RestoreAccountBackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{33}
+}
+
+func (m *RestoreAccountBackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreAccountBackupResponse.Unmarshal(m, b)
+}
+func (m *RestoreAccountBackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreAccountBackupResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreAccountBackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreAccountBackupResponse.Merge(m, src)
+}
+func (m *RestoreAccountBackupResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreAccountBackupResponse.Size(m)
+}
+func (m *RestoreAccountBackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreAccountBackupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreAccountBackupResponse proto.InternalMessageInfo
+
+func (m *RestoreAccountBackupResponse) GetNumRestoredAccounts() uint32 {
+	if m != nil {
+		return m.NumRestoredAccounts
+	}
+	return 0
+}
+
+type BackupDBRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupDBRequest) Reset()         { *m = BackupDBRequest{} }
+func (m *BackupDBRequest) String() string { return proto.CompactTextString(m) }
+func (*BackupDBRequest) ProtoMessage()    {}
+func (*BackupDBRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{34}
+}
+
+func (m *BackupDBRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupDBRequest.Unmarshal(m, b)
+}
+func (m *BackupDBRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupDBRequest.Marshal(b, m, deterministic)
+}
+func (m *BackupDBRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupDBRequest.Merge(m, src)
+}
+func (m *BackupDBRequest) XXX_Size() int {
+	return xxx_messageInfo_BackupDBRequest.Size(m)
+}
+func (m *BackupDBRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupDBRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupDBRequest proto.InternalMessageInfo
+
+type BackupDBResponse struct {
+	//
+	//The version of the database the snapshot was taken of. Only set in the
+	//final message of the stream, which doesn't contain a chunk.
+	DbVersion uint32 `protobuf:"varint,1,opt,name=db_version,json=dbVersion,proto3" json:"db_version,omitempty"`
+	// The next chunk of the consistent database snapshot.
+	Chunk                []byte   `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupDBResponse) Reset()         { *m = BackupDBResponse{} }
+func (m *BackupDBResponse) String() string { return proto.CompactTextString(m) }
+func (*BackupDBResponse) ProtoMessage()    {}
+func (*BackupDBResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{35}
+}
+
+func (m *BackupDBResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupDBResponse.Unmarshal(m, b)
+}
+func (m *BackupDBResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupDBResponse.Marshal(b, m, deterministic)
+}
+func (m *BackupDBResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupDBResponse.Merge(m, src)
+}
+func (m *BackupDBResponse) XXX_Size() int {
+	return xxx_messageInfo_BackupDBResponse.Size(m)
+}
+func (m *BackupDBResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupDBResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupDBResponse proto.InternalMessageInfo
+
+func (m *BackupDBResponse) GetDbVersion() uint32 {
+	if m != nil {
+		return m.DbVersion
+	}
+	return 0
+}
+
+func (m *BackupDBResponse) GetChunk() []byte {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
+type AuctionConnectionRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuctionConnectionRequest) Reset()         { *m = AuctionConnectionRequest{} }
+func (m *AuctionConnectionRequest) String() string { return proto.CompactTextString(m) }
+func (*AuctionConnectionRequest) ProtoMessage()    {}
+func (*AuctionConnectionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{36}
+}
+
+func (m *AuctionConnectionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuctionConnectionRequest.Unmarshal(m, b)
+}
+func (m *AuctionConnectionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuctionConnectionRequest.Marshal(b, m, deterministic)
+}
+func (m *AuctionConnectionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuctionConnectionRequest.Merge(m, src)
+}
+func (m *AuctionConnectionRequest) XXX_Size() int {
+	return xxx_messageInfo_AuctionConnectionRequest.Size(m)
+}
+func (m *AuctionConnectionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuctionConnectionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuctionConnectionRequest proto.InternalMessageInfo
+
+type ConnectionEvent struct {
+	// The unix timestamp in nanoseconds the event happened at.
+	TimestampNs int64 `protobuf:"varint,1,opt,name=timestamp_ns,json=timestampNs,proto3" json:"timestamp_ns,omitempty"`
+	//
+	//The type of the event, one of Connected, ConnectFailed, Disconnected,
+	//KeepaliveFailed or Failover.
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	//
+	//The auction server address the event relates to. For a failover, this is
+	//the address that was switched to.
+	Endpoint string `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// The error that caused the event, if any.
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConnectionEvent) Reset()         { *m = ConnectionEvent{} }
+func (m *ConnectionEvent) String() string { return proto.CompactTextString(m) }
+func (*ConnectionEvent) ProtoMessage()    {}
+func (*ConnectionEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{37}
+}
+
+func (m *ConnectionEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnectionEvent.Unmarshal(m, b)
+}
+func (m *ConnectionEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnectionEvent.Marshal(b, m, deterministic)
+}
+func (m *ConnectionEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectionEvent.Merge(m, src)
+}
+func (m *ConnectionEvent) XXX_Size() int {
+	return xxx_messageInfo_ConnectionEvent.Size(m)
+}
+func (m *ConnectionEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectionEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectionEvent proto.InternalMessageInfo
+
+func (m *ConnectionEvent) GetTimestampNs() int64 {
+	if m != nil {
+		return m.TimestampNs
+	}
+	return 0
+}
+
+func (m *ConnectionEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ConnectionEvent) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *ConnectionEvent) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type AuctionConnectionResponse struct {
+	// The auction server address currently in use.
+	CurrentEndpoint string `protobuf:"bytes,1,opt,name=current_endpoint,json=currentEndpoint,proto3" json:"current_endpoint,omitempty"`
+	// All configured auction server addresses, starting with the primary one.
+	Endpoints []string `protobuf:"bytes,2,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	// Whether the stream to the auction server is currently open.
+	Connected bool `protobuf:"varint,3,opt,name=connected,proto3" json:"connected,omitempty"`
+	// The last connection related error, if any.
+	LastError string `protobuf:"bytes,4,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// The unix timestamp in nanoseconds the last error happened at.
+	LastErrorTimestampNs int64 `protobuf:"varint,5,opt,name=last_error_timestamp_ns,json=lastErrorTimestampNs,proto3" json:"last_error_timestamp_ns,omitempty"`
+	// The history of the connection, oldest event first.
+	Events               []*ConnectionEvent `protobuf:"bytes,6,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *AuctionConnectionResponse) Reset()         { *m = AuctionConnectionResponse{} }
+func (m *AuctionConnectionResponse) String() string { return proto.CompactTextString(m) }
+func (*AuctionConnectionResponse) ProtoMessage()    {}
+func (*AuctionConnectionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{38}
+}
+
+func (m *AuctionConnectionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuctionConnectionResponse.Unmarshal(m, b)
+}
+func (m *AuctionConnectionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuctionConnectionResponse.Marshal(b, m, deterministic)
+}
+func (m *AuctionConnectionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuctionConnectionResponse.Merge(m, src)
+}
+func (m *AuctionConnectionResponse) XXX_Size() int {
+	return xxx_messageInfo_AuctionConnectionResponse.Size(m)
+}
+func (m *AuctionConnectionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuctionConnectionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuctionConnectionResponse proto.InternalMessageInfo
+
+func (m *AuctionConnectionResponse) GetCurrentEndpoint() string {
+	if m != nil {
+		return m.CurrentEndpoint
+	}
+	return ""
+}
+
+func (m *AuctionConnectionResponse) GetEndpoints() []string {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+func (m *AuctionConnectionResponse) GetConnected() bool {
+	if m != nil {
+		return m.Connected
+	}
+	return false
+}
+
+func (m *AuctionConnectionResponse) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *AuctionConnectionResponse) GetLastErrorTimestampNs() int64 {
+	if m != nil {
+		return m.LastErrorTimestampNs
+	}
+	return 0
+}
+
+func (m *AuctionConnectionResponse) GetEvents() []*ConnectionEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type LsatToken struct {
+	// The base macaroon of the token as baked by the auction server.
+	BaseMacaroon []byte `protobuf:"bytes,1,opt,name=base_macaroon,json=baseMacaroon,proto3" json:"base_macaroon,omitempty"`
+	// The payment hash of the invoice that was paid for the token.
+	PaymentHash []byte `protobuf:"bytes,2,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	//
+	//The preimage of the payment, proving the token was paid for. Empty if the
+	//payment is still pending.
+	PaymentPreimage []byte `protobuf:"bytes,3,opt,name=payment_preimage,json=paymentPreimage,proto3" json:"payment_preimage,omitempty"`
+	// The amount paid for the token in milli satoshis, excluding routing fees.
+	AmountPaidMsat uint64 `protobuf:"varint,4,opt,name=amount_paid_msat,json=amountPaidMsat,proto3" json:"amount_paid_msat,omitempty"`
+	// The routing fee paid for the token in milli satoshis.
+	RoutingFeePaidMsat uint64 `protobuf:"varint,5,opt,name=routing_fee_paid_msat,json=routingFeePaidMsat,proto3" json:"routing_fee_paid_msat,omitempty"`
+	// The unix timestamp in seconds the token was created at.
+	TimeCreated int64 `protobuf:"varint,6,opt,name=time_created,json=timeCreated,proto3" json:"time_created,omitempty"`
+	//
+	//Whether the token can be used to authenticate, which means it is paid,
+	//not expired and not revoked.
+	Valid bool `protobuf:"varint,7,opt,name=valid,proto3" json:"valid,omitempty"`
+	// Whether the payment of the token is still in flight.
+	Pending bool `protobuf:"varint,8,opt,name=pending,proto3" json:"pending,omitempty"`
+	// Whether the token was revoked.
+	Revoked bool `protobuf:"varint,9,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	// The name of the file the token is stored in.
+	StorageName          string   `protobuf:"bytes,10,opt,name=storage_name,json=storageName,proto3" json:"storage_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LsatToken) Reset()         { *m = LsatToken{} }
+func (m *LsatToken) String() string { return proto.CompactTextString(m) }
+func (*LsatToken) ProtoMessage()    {}
+func (*LsatToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{39}
+}
+
+func (m *LsatToken) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LsatToken.Unmarshal(m, b)
+}
+func (m *LsatToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LsatToken.Marshal(b, m, deterministic)
+}
+func (m *LsatToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LsatToken.Merge(m, src)
+}
+func (m *LsatToken) XXX_Size() int {
+	return xxx_messageInfo_LsatToken.Size(m)
+}
+func (m *LsatToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_LsatToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LsatToken proto.InternalMessageInfo
+
+func (m *LsatToken) GetBaseMacaroon() []byte {
+	if m != nil {
+		return m.BaseMacaroon
+	}
+	return nil
+}
+
+func (m *LsatToken) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *LsatToken) GetPaymentPreimage() []byte {
+	if m != nil {
+		return m.PaymentPreimage
+	}
+	return nil
+}
+
+func (m *LsatToken) GetAmountPaidMsat() uint64 {
+	if m != nil {
+		return m.AmountPaidMsat
+	}
+	return 0
+}
+
+func (m *LsatToken) GetRoutingFeePaidMsat() uint64 {
+	if m != nil {
+		return m.RoutingFeePaidMsat
+	}
+	return 0
+}
+
+func (m *LsatToken) GetTimeCreated() int64 {
+	if m != nil {
+		return m.TimeCreated
 	}
 	return 0
 }
 
-func (m *Order) GetOrderNonce() []byte {
+func (m *LsatToken) GetValid() bool {
+	if m != nil {
+		return m.Valid
+	}
+	return false
+}
+
+func (m *LsatToken) GetPending() bool {
+	if m != nil {
+		return m.Pending
+	}
+	return false
+}
+
+func (m *LsatToken) GetRevoked() bool {
+	if m != nil {
+		return m.Revoked
+	}
+	return false
+}
+
+func (m *LsatToken) GetStorageName() string {
+	if m != nil {
+		return m.StorageName
+	}
+	return ""
+}
+
+type ListLsatTokensRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListLsatTokensRequest) Reset()         { *m = ListLsatTokensRequest{} }
+func (m *ListLsatTokensRequest) String() string { return proto.CompactTextString(m) }
+func (*ListLsatTokensRequest) ProtoMessage()    {}
+func (*ListLsatTokensRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{40}
+}
+
+func (m *ListLsatTokensRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListLsatTokensRequest.Unmarshal(m, b)
+}
+func (m *ListLsatTokensRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListLsatTokensRequest.Marshal(b, m, deterministic)
+}
+func (m *ListLsatTokensRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListLsatTokensRequest.Merge(m, src)
+}
+func (m *ListLsatTokensRequest) XXX_Size() int {
+	return xxx_messageInfo_ListLsatTokensRequest.Size(m)
+}
+func (m *ListLsatTokensRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListLsatTokensRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListLsatTokensRequest proto.InternalMessageInfo
+
+type ListLsatTokensResponse struct {
+	// All stored tokens, including revoked ones, oldest first.
+	Tokens               []*LsatToken `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListLsatTokensResponse) Reset()         { *m = ListLsatTokensResponse{} }
+func (m *ListLsatTokensResponse) String() string { return proto.CompactTextString(m) }
+func (*ListLsatTokensResponse) ProtoMessage()    {}
+func (*ListLsatTokensResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{41}
+}
+
+func (m *ListLsatTokensResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListLsatTokensResponse.Unmarshal(m, b)
+}
+func (m *ListLsatTokensResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListLsatTokensResponse.Marshal(b, m, deterministic)
+}
+func (m *ListLsatTokensResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListLsatTokensResponse.Merge(m, src)
+}
+func (m *ListLsatTokensResponse) XXX_Size() int {
+	return xxx_messageInfo_ListLsatTokensResponse.Size(m)
+}
+func (m *ListLsatTokensResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListLsatTokensResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListLsatTokensResponse proto.InternalMessageInfo
+
+func (m *ListLsatTokensResponse) GetTokens() []*LsatToken {
+	if m != nil {
+		return m.Tokens
+	}
+	return nil
+}
+
+type GetLsatTokenRequest struct {
+	// The payment hash of the token to return.
+	PaymentHash          []byte   `protobuf:"bytes,1,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLsatTokenRequest) Reset()         { *m = GetLsatTokenRequest{} }
+func (m *GetLsatTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLsatTokenRequest) ProtoMessage()    {}
+func (*GetLsatTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{42}
+}
+
+func (m *GetLsatTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLsatTokenRequest.Unmarshal(m, b)
+}
+func (m *GetLsatTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLsatTokenRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLsatTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLsatTokenRequest.Merge(m, src)
+}
+func (m *GetLsatTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLsatTokenRequest.Size(m)
+}
+func (m *GetLsatTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLsatTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLsatTokenRequest proto.InternalMessageInfo
+
+func (m *GetLsatTokenRequest) GetPaymentHash() []byte {
 	if m != nil {
-		return m.OrderNonce
+		return m.PaymentHash
 	}
 	return nil
 }
 
-func (m *Order) GetState() OrderState {
-	if m != nil {
-		return m.State
-	}
-	return OrderState_ORDER_SUBMITTED
+type RevokeLsatTokenRequest struct {
+	//
+	//The payment hash of the token to revoke. A new token is obtained with the
+	//next request to the auction server.
+	PaymentHash          []byte   `protobuf:"bytes,1,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Order) GetUnits() uint32 {
-	if m != nil {
-		return m.Units
-	}
-	return 0
+func (m *RevokeLsatTokenRequest) Reset()         { *m = RevokeLsatTokenRequest{} }
+func (m *RevokeLsatTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeLsatTokenRequest) ProtoMessage()    {}
+func (*RevokeLsatTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{43}
 }
 
-func (m *Order) GetUnitsUnfulfilled() uint32 {
+func (m *RevokeLsatTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeLsatTokenRequest.Unmarshal(m, b)
+}
+func (m *RevokeLsatTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeLsatTokenRequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeLsatTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeLsatTokenRequest.Merge(m, src)
+}
+func (m *RevokeLsatTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeLsatTokenRequest.Size(m)
+}
+func (m *RevokeLsatTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeLsatTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeLsatTokenRequest proto.InternalMessageInfo
+
+func (m *RevokeLsatTokenRequest) GetPaymentHash() []byte {
 	if m != nil {
-		return m.UnitsUnfulfilled
+		return m.PaymentHash
 	}
-	return 0
+	return nil
 }
 
-type Bid struct {
-	//
-	//The common fields shared between both ask and bid order types.
-	Details *Order `protobuf:"bytes,1,opt,name=details,proto3" json:"details,omitempty"`
-	//
-	//Required minimum number of blocks that a channel opened as a result of this
-	//bid should be kept open.
-	MinDurationBlocks uint32 `protobuf:"varint,2,opt,name=min_duration_blocks,json=minDurationBlocks,proto3" json:"min_duration_blocks,omitempty"`
-	//
-	//The version of the order format that is used. Will be increased once new
-	//features are added.
-	Version              uint32   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+type RevokeLsatTokenResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Bid) Reset()         { *m = Bid{} }
-func (m *Bid) String() string { return proto.CompactTextString(m) }
-func (*Bid) ProtoMessage()    {}
-func (*Bid) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{18}
+func (m *RevokeLsatTokenResponse) Reset()         { *m = RevokeLsatTokenResponse{} }
+func (m *RevokeLsatTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeLsatTokenResponse) ProtoMessage()    {}
+func (*RevokeLsatTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{44}
 }
 
-func (m *Bid) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Bid.Unmarshal(m, b)
+func (m *RevokeLsatTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeLsatTokenResponse.Unmarshal(m, b)
 }
-func (m *Bid) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Bid.Marshal(b, m, deterministic)
+func (m *RevokeLsatTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeLsatTokenResponse.Marshal(b, m, deterministic)
 }
-func (m *Bid) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Bid.Merge(m, src)
+func (m *RevokeLsatTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeLsatTokenResponse.Merge(m, src)
 }
-func (m *Bid) XXX_Size() int {
-	return xxx_messageInfo_Bid.Size(m)
+func (m *RevokeLsatTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokeLsatTokenResponse.Size(m)
 }
-func (m *Bid) XXX_DiscardUnknown() {
-	xxx_messageInfo_Bid.DiscardUnknown(m)
+func (m *RevokeLsatTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeLsatTokenResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Bid proto.InternalMessageInfo
+var xxx_messageInfo_RevokeLsatTokenResponse proto.InternalMessageInfo
 
-func (m *Bid) GetDetails() *Order {
-	if m != nil {
-		return m.Details
-	}
-	return nil
+type LsatLedgerRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Bid) GetMinDurationBlocks() uint32 {
-	if m != nil {
-		return m.MinDurationBlocks
-	}
-	return 0
+func (m *LsatLedgerRequest) Reset()         { *m = LsatLedgerRequest{} }
+func (m *LsatLedgerRequest) String() string { return proto.CompactTextString(m) }
+func (*LsatLedgerRequest) ProtoMessage()    {}
+func (*LsatLedgerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{45}
 }
 
-func (m *Bid) GetVersion() uint32 {
-	if m != nil {
-		return m.Version
-	}
-	return 0
+func (m *LsatLedgerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LsatLedgerRequest.Unmarshal(m, b)
+}
+func (m *LsatLedgerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LsatLedgerRequest.Marshal(b, m, deterministic)
+}
+func (m *LsatLedgerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LsatLedgerRequest.Merge(m, src)
+}
+func (m *LsatLedgerRequest) XXX_Size() int {
+	return xxx_messageInfo_LsatLedgerRequest.Size(m)
+}
+func (m *LsatLedgerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LsatLedgerRequest.DiscardUnknown(m)
 }
 
-type Ask struct {
-	//
-	//The common fields shared between both ask and bid order types.
-	Details *Order `protobuf:"bytes,1,opt,name=details,proto3" json:"details,omitempty"`
-	//
-	//The maximum number of blocks the liquidity provider is willing to provide
-	//the channel funds for.
-	MaxDurationBlocks uint32 `protobuf:"varint,2,opt,name=max_duration_blocks,json=maxDurationBlocks,proto3" json:"max_duration_blocks,omitempty"`
-	//
-	//The version of the order format that is used. Will be increased once new
-	//features are added.
-	Version              uint32   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+var xxx_messageInfo_LsatLedgerRequest proto.InternalMessageInfo
+
+type LsatLedgerEntry struct {
+	// The payment hash of the token that was paid for.
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	// The amount paid for the token in milli satoshis, excluding routing fees.
+	AmountPaidMsat uint64 `protobuf:"varint,2,opt,name=amount_paid_msat,json=amountPaidMsat,proto3" json:"amount_paid_msat,omitempty"`
+	// The routing fee paid for the token in milli satoshis.
+	RoutingFeePaidMsat uint64 `protobuf:"varint,3,opt,name=routing_fee_paid_msat,json=routingFeePaidMsat,proto3" json:"routing_fee_paid_msat,omitempty"`
+	// The unix timestamp in seconds the payment was recorded at.
+	TimePaid             int64    `protobuf:"varint,4,opt,name=time_paid,json=timePaid,proto3" json:"time_paid,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Ask) Reset()         { *m = Ask{} }
-func (m *Ask) String() string { return proto.CompactTextString(m) }
-func (*Ask) ProtoMessage()    {}
-func (*Ask) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{19}
+func (m *LsatLedgerEntry) Reset()         { *m = LsatLedgerEntry{} }
+func (m *LsatLedgerEntry) String() string { return proto.CompactTextString(m) }
+func (*LsatLedgerEntry) ProtoMessage()    {}
+func (*LsatLedgerEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{46}
 }
 
-func (m *Ask) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Ask.Unmarshal(m, b)
+func (m *LsatLedgerEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LsatLedgerEntry.Unmarshal(m, b)
 }
-func (m *Ask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Ask.Marshal(b, m, deterministic)
+func (m *LsatLedgerEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LsatLedgerEntry.Marshal(b, m, deterministic)
 }
-func (m *Ask) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Ask.Merge(m, src)
+func (m *LsatLedgerEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LsatLedgerEntry.Merge(m, src)
 }
-func (m *Ask) XXX_Size() int {
-	return xxx_messageInfo_Ask.Size(m)
+func (m *LsatLedgerEntry) XXX_Size() int {
+	return xxx_messageInfo_LsatLedgerEntry.Size(m)
 }
-func (m *Ask) XXX_DiscardUnknown() {
-	xxx_messageInfo_Ask.DiscardUnknown(m)
+func (m *LsatLedgerEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LsatLedgerEntry.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Ask proto.InternalMessageInfo
+var xxx_messageInfo_LsatLedgerEntry proto.InternalMessageInfo
 
-func (m *Ask) GetDetails() *Order {
+func (m *LsatLedgerEntry) GetPaymentHash() []byte {
 	if m != nil {
-		return m.Details
+		return m.PaymentHash
 	}
 	return nil
 }
 
-func (m *Ask) GetMaxDurationBlocks() uint32 {
+func (m *LsatLedgerEntry) GetAmountPaidMsat() uint64 {
 	if m != nil {
-		return m.MaxDurationBlocks
+		return m.AmountPaidMsat
 	}
 	return 0
 }
 
-func (m *Ask) GetVersion() uint32 {
+func (m *LsatLedgerEntry) GetRoutingFeePaidMsat() uint64 {
 	if m != nil {
-		return m.Version
+		return m.RoutingFeePaidMsat
 	}
 	return 0
 }
 
-type RecoverAccountsRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *LsatLedgerEntry) GetTimePaid() int64 {
+	if m != nil {
+		return m.TimePaid
+	}
+	return 0
 }
 
-func (m *RecoverAccountsRequest) Reset()         { *m = RecoverAccountsRequest{} }
-func (m *RecoverAccountsRequest) String() string { return proto.CompactTextString(m) }
-func (*RecoverAccountsRequest) ProtoMessage()    {}
-func (*RecoverAccountsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{20}
+type LsatLedgerResponse struct {
+	// All recorded LSAT payments, oldest first.
+	Entries []*LsatLedgerEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	//
+	//The total amount paid for all tokens in milli satoshis, excluding routing
+	//fees.
+	TotalAmountPaidMsat uint64 `protobuf:"varint,2,opt,name=total_amount_paid_msat,json=totalAmountPaidMsat,proto3" json:"total_amount_paid_msat,omitempty"`
+	// The total routing fee paid for all tokens in milli satoshis.
+	TotalRoutingFeePaidMsat uint64   `protobuf:"varint,3,opt,name=total_routing_fee_paid_msat,json=totalRoutingFeePaidMsat,proto3" json:"total_routing_fee_paid_msat,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
 }
 
-func (m *RecoverAccountsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RecoverAccountsRequest.Unmarshal(m, b)
+func (m *LsatLedgerResponse) Reset()         { *m = LsatLedgerResponse{} }
+func (m *LsatLedgerResponse) String() string { return proto.CompactTextString(m) }
+func (*LsatLedgerResponse) ProtoMessage()    {}
+func (*LsatLedgerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b8f61804588c75fe, []int{47}
 }
-func (m *RecoverAccountsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RecoverAccountsRequest.Marshal(b, m, deterministic)
+
+func (m *LsatLedgerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LsatLedgerResponse.Unmarshal(m, b)
 }
-func (m *RecoverAccountsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RecoverAccountsRequest.Merge(m, src)
+func (m *LsatLedgerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LsatLedgerResponse.Marshal(b, m, deterministic)
 }
-func (m *RecoverAccountsRequest) XXX_Size() int {
-	return xxx_messageInfo_RecoverAccountsRequest.Size(m)
+func (m *LsatLedgerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LsatLedgerResponse.Merge(m, src)
 }
-func (m *RecoverAccountsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_RecoverAccountsRequest.DiscardUnknown(m)
+func (m *LsatLedgerResponse) XXX_Size() int {
+	return xxx_messageInfo_LsatLedgerResponse.Size(m)
 }
-
-var xxx_messageInfo_RecoverAccountsRequest proto.InternalMessageInfo
-
-type RecoverAccountsResponse struct {
-	// The number of accounts that were recovered.
-	NumRecoveredAccounts uint32   `protobuf:"varint,1,opt,name=num_recovered_accounts,json=numRecoveredAccounts,proto3" json:"num_recovered_accounts,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *LsatLedgerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LsatLedgerResponse.DiscardUnknown(m)
 }
 
-func (m *RecoverAccountsResponse) Reset()         { *m = RecoverAccountsResponse{} }
-func (m *RecoverAccountsResponse) String() string { return proto.CompactTextString(m) }
-func (*RecoverAccountsResponse) ProtoMessage()    {}
-func (*RecoverAccountsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b8f61804588c75fe, []int{21}
-}
+var xxx_messageInfo_LsatLedgerResponse proto.InternalMessageInfo
 
-func (m *RecoverAccountsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RecoverAccountsResponse.Unmarshal(m, b)
-}
-func (m *RecoverAccountsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RecoverAccountsResponse.Marshal(b, m, deterministic)
-}
-func (m *RecoverAccountsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RecoverAccountsResponse.Merge(m, src)
-}
-func (m *RecoverAccountsResponse) XXX_Size() int {
-	return xxx_messageInfo_RecoverAccountsResponse.Size(m)
-}
-func (m *RecoverAccountsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_RecoverAccountsResponse.DiscardUnknown(m)
+func (m *LsatLedgerResponse) GetEntries() []*LsatLedgerEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
 }
 
-var xxx_messageInfo_RecoverAccountsResponse proto.InternalMessageInfo
+func (m *LsatLedgerResponse) GetTotalAmountPaidMsat() uint64 {
+	if m != nil {
+		return m.TotalAmountPaidMsat
+	}
+	return 0
+}
 
-func (m *RecoverAccountsResponse) GetNumRecoveredAccounts() uint32 {
+func (m *LsatLedgerResponse) GetTotalRoutingFeePaidMsat() uint64 {
 	if m != nil {
-		return m.NumRecoveredAccounts
+		return m.TotalRoutingFeePaidMsat
 	}
 	return 0
 }
 
 func init() {
 	proto.RegisterEnum("clmrpc.AccountState", AccountState_name, AccountState_value)
+	proto.RegisterEnum("clmrpc.ListOrdersRequest_TypeFilter", ListOrdersRequest_TypeFilter_name, ListOrdersRequest_TypeFilter_value)
+	proto.RegisterEnum("clmrpc.OrderAction_ActionType", OrderAction_ActionType_name, OrderAction_ActionType_value)
 	proto.RegisterType((*InitAccountRequest)(nil), "clmrpc.InitAccountRequest")
+	proto.RegisterMapType((map[string]string)(nil), "clmrpc.InitAccountRequest.TagsEntry")
 	proto.RegisterType((*ListAccountsRequest)(nil), "clmrpc.ListAccountsRequest")
+	proto.RegisterMapType((map[string]string)(nil), "clmrpc.ListAccountsRequest.TagsEntry")
 	proto.RegisterType((*ListAccountsResponse)(nil), "clmrpc.ListAccountsResponse")
 	proto.RegisterType((*Output)(nil), "clmrpc.Output")
+	proto.RegisterType((*LabelAccountRequest)(nil), "clmrpc.LabelAccountRequest")
+	proto.RegisterMapType((map[string]string)(nil), "clmrpc.LabelAccountRequest.SetTagsEntry")
 	proto.RegisterType((*CloseAccountRequest)(nil), "clmrpc.CloseAccountRequest")
 	proto.RegisterType((*CloseAccountResponse)(nil), "clmrpc.CloseAccountResponse")
 	proto.RegisterType((*WithdrawAccountRequest)(nil), "clmrpc.WithdrawAccountRequest")
@@ -1274,101 +3316,259 @@ func init() {
 	proto.RegisterType((*DepositAccountRequest)(nil), "clmrpc.DepositAccountRequest")
 	proto.RegisterType((*DepositAccountResponse)(nil), "clmrpc.DepositAccountResponse")
 	proto.RegisterType((*Account)(nil), "clmrpc.Account")
+	proto.RegisterMapType((map[string]string)(nil), "clmrpc.Account.TagsEntry")
 	proto.RegisterType((*SubmitOrderRequest)(nil), "clmrpc.SubmitOrderRequest")
 	proto.RegisterType((*SubmitOrderResponse)(nil), "clmrpc.SubmitOrderResponse")
 	proto.RegisterType((*ListOrdersRequest)(nil), "clmrpc.ListOrdersRequest")
+	proto.RegisterMapType((map[string]string)(nil), "clmrpc.ListOrdersRequest.TagsEntry")
 	proto.RegisterType((*ListOrdersResponse)(nil), "clmrpc.ListOrdersResponse")
 	proto.RegisterType((*CancelOrderRequest)(nil), "clmrpc.CancelOrderRequest")
 	proto.RegisterType((*CancelOrderResponse)(nil), "clmrpc.CancelOrderResponse")
+	proto.RegisterType((*LabelOrderRequest)(nil), "clmrpc.LabelOrderRequest")
+	proto.RegisterMapType((map[string]string)(nil), "clmrpc.LabelOrderRequest.SetTagsEntry")
+	proto.RegisterType((*LabelOrderResponse)(nil), "clmrpc.LabelOrderResponse")
+	proto.RegisterType((*ApplyOrdersRequest)(nil), "clmrpc.ApplyOrdersRequest")
+	proto.RegisterType((*ApplyOrdersResponse)(nil), "clmrpc.ApplyOrdersResponse")
+	proto.RegisterType((*OrderAction)(nil), "clmrpc.OrderAction")
+	proto.RegisterType((*PruneFailedOrdersRequest)(nil), "clmrpc.PruneFailedOrdersRequest")
+	proto.RegisterType((*PruneFailedOrdersResponse)(nil), "clmrpc.PruneFailedOrdersResponse")
+	proto.RegisterType((*ReplaceOrderRequest)(nil), "clmrpc.ReplaceOrderRequest")
+	proto.RegisterType((*ReplaceOrderResponse)(nil), "clmrpc.ReplaceOrderResponse")
 	proto.RegisterType((*Order)(nil), "clmrpc.Order")
+	proto.RegisterMapType((map[string]string)(nil), "clmrpc.Order.TagsEntry")
 	proto.RegisterType((*Bid)(nil), "clmrpc.Bid")
 	proto.RegisterType((*Ask)(nil), "clmrpc.Ask")
 	proto.RegisterType((*RecoverAccountsRequest)(nil), "clmrpc.RecoverAccountsRequest")
 	proto.RegisterType((*RecoverAccountsResponse)(nil), "clmrpc.RecoverAccountsResponse")
+	proto.RegisterType((*RestoreAccountBackupRequest)(nil), "clmrpc.RestoreAccountBackupRequest")
+	proto.RegisterType((*RestoreAccountBackupResponse)(nil), "clmrpc.RestoreAccountBackupResponse")
+	proto.RegisterType((*BackupDBRequest)(nil), "clmrpc.BackupDBRequest")
+	proto.RegisterType((*BackupDBResponse)(nil), "clmrpc.BackupDBResponse")
+	proto.RegisterType((*AuctionConnectionRequest)(nil), "clmrpc.AuctionConnectionRequest")
+	proto.RegisterType((*ConnectionEvent)(nil), "clmrpc.ConnectionEvent")
+	proto.RegisterType((*AuctionConnectionResponse)(nil), "clmrpc.AuctionConnectionResponse")
+	proto.RegisterType((*LsatToken)(nil), "clmrpc.LsatToken")
+	proto.RegisterType((*ListLsatTokensRequest)(nil), "clmrpc.ListLsatTokensRequest")
+	proto.RegisterType((*ListLsatTokensResponse)(nil), "clmrpc.ListLsatTokensResponse")
+	proto.RegisterType((*GetLsatTokenRequest)(nil), "clmrpc.GetLsatTokenRequest")
+	proto.RegisterType((*RevokeLsatTokenRequest)(nil), "clmrpc.RevokeLsatTokenRequest")
+	proto.RegisterType((*RevokeLsatTokenResponse)(nil), "clmrpc.RevokeLsatTokenResponse")
+	proto.RegisterType((*LsatLedgerRequest)(nil), "clmrpc.LsatLedgerRequest")
+	proto.RegisterType((*LsatLedgerEntry)(nil), "clmrpc.LsatLedgerEntry")
+	proto.RegisterType((*LsatLedgerResponse)(nil), "clmrpc.LsatLedgerResponse")
 }
 
 func init() { proto.RegisterFile("trader.proto", fileDescriptor_b8f61804588c75fe) }
 
 var fileDescriptor_b8f61804588c75fe = []byte{
-	// 1247 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xdd, 0x6e, 0x1b, 0x45,
-	0x14, 0x8e, 0xe3, 0xbf, 0xf8, 0xf8, 0xa7, 0xdb, 0xb1, 0x9b, 0x6e, 0x9d, 0xa6, 0x0d, 0x5b, 0x10,
-	0x26, 0x45, 0xb1, 0x08, 0xf4, 0x06, 0x2e, 0x50, 0x13, 0xbb, 0x24, 0xa2, 0x4a, 0xac, 0x4d, 0x7f,
-	0x90, 0x90, 0x58, 0xc6, 0xbb, 0xe3, 0x74, 0xe4, 0xf5, 0xae, 0xd9, 0x9d, 0x75, 0x1d, 0x21, 0x6e,
-	0x90, 0xb8, 0x46, 0x88, 0x37, 0xe2, 0x15, 0x78, 0x85, 0x3e, 0x08, 0x9a, 0xd9, 0x19, 0x7b, 0xbd,
-	0xde, 0x10, 0x7a, 0xc1, 0x9d, 0xf7, 0x7c, 0x67, 0xce, 0x77, 0xce, 0x9c, 0xbf, 0x31, 0xd4, 0x58,
-	0x80, 0x1d, 0x12, 0x1c, 0x4c, 0x03, 0x9f, 0xf9, 0xa8, 0x64, 0xbb, 0x93, 0x60, 0x6a, 0xb7, 0xef,
-	0x5f, 0xfa, 0xfe, 0xa5, 0x4b, 0xba, 0x78, 0x4a, 0xbb, 0xd8, 0xf3, 0x7c, 0x86, 0x19, 0xf5, 0xbd,
-	0x30, 0xd6, 0x6a, 0x6b, 0x38, 0xb2, 0xf9, 0x37, 0x51, 0xe7, 0x8c, 0x1f, 0x01, 0x9d, 0x7a, 0x94,
-	0x3d, 0xb5, 0x6d, 0x3f, 0xf2, 0x98, 0x49, 0x7e, 0x8a, 0x48, 0xc8, 0xd0, 0x23, 0xa8, 0xe3, 0x58,
-	0x62, 0xcd, 0xb0, 0x1b, 0x11, 0x3d, 0xb7, 0x97, 0xeb, 0x14, 0xcc, 0x9a, 0x14, 0xbe, 0xe2, 0x32,
-	0xf4, 0x11, 0x34, 0x94, 0x12, 0x99, 0x4f, 0x69, 0x70, 0xa5, 0x6f, 0xee, 0xe5, 0x3a, 0x75, 0x53,
-	0x1d, 0xed, 0x0b, 0xa1, 0x71, 0x07, 0x9a, 0xcf, 0x69, 0xa8, 0x18, 0x42, 0x49, 0x61, 0x1c, 0x43,
-	0x6b, 0x55, 0x1c, 0x4e, 0x7d, 0x2f, 0x24, 0xe8, 0x31, 0x6c, 0xc9, 0xf3, 0xa1, 0x9e, 0xdb, 0xcb,
-	0x77, 0xaa, 0x87, 0xb7, 0x0e, 0xe2, 0xd8, 0x0e, 0x94, 0x93, 0x0b, 0x05, 0xe3, 0x6b, 0x28, 0x9d,
-	0x47, 0x6c, 0x1a, 0x31, 0xb4, 0x03, 0x15, 0xe1, 0xa9, 0x15, 0x62, 0x26, 0xbd, 0xdd, 0x12, 0x82,
-	0x0b, 0xcc, 0x90, 0x0e, 0x65, 0xec, 0x38, 0x01, 0x09, 0x43, 0xe1, 0x62, 0xc5, 0x54, 0x9f, 0xc6,
-	0x0f, 0xd0, 0x3c, 0x76, 0xfd, 0x90, 0xa4, 0xe2, 0xdf, 0x05, 0x88, 0x6f, 0xd7, 0x1a, 0x93, 0x2b,
-	0x61, 0xae, 0x66, 0x56, 0x62, 0xc9, 0xb7, 0xe4, 0x0a, 0x75, 0xa0, 0xec, 0x0b, 0x5a, 0x6e, 0x8f,
-	0xbb, 0xd8, 0x50, 0x2e, 0xc6, 0xde, 0x98, 0x0a, 0x36, 0x9e, 0x40, 0x6b, 0xd5, 0xbe, 0x8c, 0x72,
-	0x17, 0xc0, 0xe6, 0x72, 0x8b, 0xcd, 0xa9, 0xa3, 0x08, 0x84, 0xe4, 0xc5, 0x9c, 0x3a, 0xc6, 0x6f,
-	0x39, 0xd8, 0x7e, 0x4d, 0xd9, 0x1b, 0x27, 0xc0, 0x6f, 0xff, 0x27, 0xd7, 0x90, 0x01, 0xf5, 0x10,
-	0x33, 0x6b, 0x4a, 0x02, 0x6b, 0x36, 0xbc, 0x62, 0x44, 0xcf, 0x8b, 0x5b, 0xab, 0x86, 0x98, 0x0d,
-	0x48, 0xf0, 0x8a, 0x8b, 0x0c, 0x0a, 0x77, 0xd7, 0xdc, 0x90, 0x11, 0x7c, 0x02, 0x65, 0x99, 0x06,
-	0xe1, 0x44, 0x46, 0x9a, 0x14, 0xce, 0xab, 0xe9, 0xad, 0xb4, 0x12, 0xc7, 0xbb, 0x29, 0xbc, 0xae,
-	0x29, 0xa1, 0x08, 0xf9, 0x0a, 0xee, 0xf4, 0xc8, 0xd4, 0x0f, 0xd7, 0x6a, 0xf1, 0x86, 0x80, 0x77,
-	0x01, 0xf0, 0x44, 0x14, 0x21, 0xcf, 0xfc, 0xa6, 0x88, 0xa1, 0x12, 0x4b, 0x78, 0xea, 0x33, 0xa3,
-	0xac, 0xaf, 0x46, 0x39, 0x82, 0xed, 0x34, 0xf5, 0xfb, 0x07, 0xf9, 0x01, 0xd4, 0x9c, 0xd8, 0x48,
-	0x32, 0xc6, 0xaa, 0x94, 0x89, 0x10, 0xdf, 0xe5, 0xa0, 0x2c, 0xcf, 0xdd, 0x14, 0xd5, 0xa7, 0xb0,
-	0xc5, 0xf3, 0xe4, 0x53, 0x2f, 0x8e, 0xa9, 0x7a, 0xa8, 0x25, 0xf2, 0x38, 0xe0, 0x72, 0x73, 0xa1,
-	0x81, 0x5a, 0x50, 0x8c, 0xdb, 0x34, 0x4e, 0x61, 0xfc, 0x81, 0x1e, 0xc3, 0x6d, 0xd1, 0x97, 0x62,
-	0x02, 0x58, 0x6f, 0x08, 0xbd, 0x7c, 0xc3, 0xf4, 0x82, 0x08, 0x5f, 0x5b, 0x02, 0x27, 0x42, 0x8e,
-	0xf6, 0xa1, 0x18, 0x32, 0xcc, 0x88, 0x5e, 0xdc, 0xcb, 0x75, 0x1a, 0x87, 0xad, 0x54, 0x9c, 0x17,
-	0x1c, 0x33, 0x63, 0x95, 0x54, 0xf1, 0x96, 0xd2, 0xc5, 0x8b, 0x01, 0x5d, 0x44, 0xc3, 0x09, 0x65,
-	0xe7, 0x81, 0x43, 0x02, 0x95, 0xc6, 0x87, 0x90, 0xc7, 0xe1, 0x58, 0x5e, 0x63, 0x75, 0x61, 0x3e,
-	0x1c, 0x9f, 0x6c, 0x98, 0x1c, 0xe1, 0x0a, 0x43, 0x79, 0x6f, 0x09, 0x85, 0x23, 0xea, 0x70, 0x85,
-	0x21, 0x75, 0x8e, 0x2a, 0x50, 0x76, 0x08, 0xc3, 0xd4, 0x0d, 0x8d, 0x3f, 0x72, 0xd0, 0x5c, 0xe1,
-	0x90, 0xf9, 0xfa, 0x0a, 0xea, 0xd4, 0x9b, 0x61, 0x97, 0x3a, 0x96, 0xcf, 0x01, 0x49, 0xb7, 0x88,
-	0xe6, 0x34, 0x06, 0xc5, 0xa1, 0x93, 0x0d, 0xb3, 0x46, 0x13, 0xdf, 0xe8, 0x10, 0x5a, 0xd8, 0xb6,
-	0xc9, 0x94, 0x11, 0x79, 0xda, 0xf2, 0x7c, 0xcf, 0x26, 0x71, 0x26, 0x4f, 0x36, 0x4c, 0xa4, 0x50,
-	0xa1, 0x7e, 0xc6, 0xb1, 0xa4, 0x4f, 0x4d, 0xb8, 0xcd, 0x07, 0x9a, 0x00, 0x17, 0x53, 0xee, 0x15,
-	0xa0, 0xa4, 0x50, 0xba, 0xf9, 0x10, 0x0a, 0x38, 0x1c, 0xab, 0xf9, 0x96, 0xbc, 0x0c, 0x53, 0x00,
-	0x5c, 0x61, 0x48, 0x1d, 0xd5, 0xc2, 0xc9, 0xcb, 0x30, 0x05, 0x60, 0x3c, 0x01, 0x74, 0x8c, 0x3d,
-	0x9b, 0xb8, 0xa9, 0x3b, 0xae, 0x26, 0x1d, 0x8f, 0xab, 0x0a, 0xfc, 0x85, 0xbb, 0x7c, 0x16, 0xaf,
-	0x1c, 0x8b, 0xfd, 0x31, 0x7e, 0xdf, 0x84, 0x62, 0x7c, 0x07, 0x37, 0x37, 0x5b, 0x80, 0x19, 0xb1,
-	0x46, 0x74, 0x4e, 0x1c, 0x39, 0xee, 0x2b, 0x5c, 0xf2, 0x8c, 0x0b, 0x90, 0x06, 0x79, 0x3c, 0x61,
-	0xb2, 0x0a, 0xf9, 0x4f, 0xd4, 0x01, 0x6d, 0x14, 0x79, 0x0e, 0xf5, 0x2e, 0xad, 0x11, 0x21, 0x16,
-	0x57, 0x15, 0x25, 0x58, 0x30, 0x1b, 0x52, 0xfe, 0x8c, 0x10, 0x93, 0x17, 0x55, 0xca, 0xf7, 0x62,
-	0xda, 0x77, 0xd4, 0x51, 0x15, 0x5a, 0x12, 0x15, 0x8a, 0x16, 0xfd, 0xc0, 0x55, 0x56, 0xea, 0xb3,
-	0x05, 0xc5, 0xc8, 0xa3, 0x2c, 0xd4, 0xcb, 0xc2, 0xc1, 0xf8, 0x83, 0xb7, 0x83, 0xf8, 0x61, 0x45,
-	0xde, 0x28, 0x72, 0x47, 0xd4, 0x75, 0x89, 0xa3, 0x6f, 0xc5, 0xed, 0x20, 0x80, 0x97, 0x4b, 0xb9,
-	0x31, 0x87, 0xfc, 0x11, 0x75, 0xd0, 0xc7, 0x8b, 0xf4, 0xca, 0x4a, 0xaa, 0xaf, 0xb0, 0x9a, 0x0a,
-	0x45, 0x07, 0xd0, 0x9c, 0x50, 0xcf, 0x72, 0x22, 0xd9, 0x6d, 0x43, 0xd7, 0xb7, 0xc7, 0xa1, 0xbc,
-	0xa1, 0xdb, 0x13, 0xea, 0xf5, 0x24, 0x72, 0x24, 0x00, 0xbe, 0x91, 0x66, 0x24, 0x08, 0xa9, 0xef,
-	0xc9, 0x81, 0xa4, 0x3e, 0x39, 0xf3, 0xd3, 0x70, 0xfc, 0x7e, 0xcc, 0x78, 0x7e, 0x2d, 0x33, 0x9e,
-	0xff, 0x67, 0x66, 0x1d, 0xb6, 0x4d, 0x62, 0xfb, 0x33, 0x12, 0xa4, 0x77, 0xf5, 0x39, 0xdc, 0x5d,
-	0x43, 0x64, 0x29, 0x7f, 0x01, 0xdb, 0x5e, 0x34, 0xb1, 0x82, 0x18, 0x26, 0x8e, 0x95, 0x58, 0xde,
-	0xdc, 0x7a, 0xcb, 0x8b, 0x26, 0xa6, 0x02, 0xd5, 0xe9, 0xfd, 0x31, 0xd4, 0x92, 0x83, 0x05, 0x69,
-	0x50, 0x1b, 0xf4, 0xcf, 0x7a, 0xa7, 0x67, 0xdf, 0x58, 0xe7, 0x83, 0xfe, 0x99, 0xb6, 0x81, 0x10,
-	0x34, 0x94, 0xe4, 0xe5, 0xa0, 0xf7, 0xf4, 0x45, 0x5f, 0xcb, 0xa1, 0x2d, 0x28, 0x08, 0x74, 0x13,
-	0x55, 0xa1, 0xdc, 0xff, 0x6e, 0x70, 0x6a, 0xf6, 0x7b, 0x5a, 0x3e, 0xa9, 0x7a, 0xfc, 0xfc, 0xfc,
-	0xa2, 0xdf, 0xd3, 0x0a, 0x08, 0xa0, 0x24, 0x7f, 0x17, 0x0f, 0xff, 0x2a, 0x43, 0xe9, 0x85, 0x28,
-	0x61, 0xf4, 0x1a, 0xaa, 0x89, 0xd7, 0x0e, 0x6a, 0x2f, 0xe7, 0x42, 0x7a, 0xed, 0xb4, 0xd3, 0x93,
-	0xde, 0xd8, 0xf9, 0xf5, 0xef, 0x77, 0x7f, 0x6e, 0xde, 0x31, 0xb4, 0xee, 0xec, 0xb3, 0xae, 0xed,
-	0x4e, 0xba, 0x2a, 0xd2, 0x2f, 0x73, 0xfb, 0xc8, 0x86, 0x5a, 0xf2, 0x35, 0x83, 0x76, 0xd4, 0xe9,
-	0x8c, 0xa7, 0x4f, 0xfb, 0x7e, 0x36, 0x28, 0x9b, 0x51, 0x17, 0x3c, 0x08, 0xad, 0xf1, 0x70, 0x92,
-	0xe4, 0x63, 0x62, 0x49, 0x92, 0xf1, 0x84, 0x59, 0x92, 0x64, 0xbd, 0x3f, 0x14, 0xc9, 0xfe, 0x3a,
-	0xc9, 0x1c, 0x6e, 0xa5, 0x56, 0x3e, 0x7a, 0xa0, 0x4c, 0x65, 0x3f, 0x49, 0xda, 0x0f, 0xaf, 0xc5,
-	0x25, 0xdb, 0x87, 0x82, 0xed, 0x81, 0x71, 0x2f, 0xcd, 0xd6, 0x55, 0x4f, 0x00, 0x7e, 0x87, 0x0c,
-	0x1a, 0xab, 0x6b, 0x18, 0xed, 0x2a, 0xc3, 0x99, 0x2f, 0x83, 0xf6, 0x83, 0xeb, 0x60, 0x49, 0xfb,
-	0x48, 0xd0, 0xee, 0x1a, 0xfa, 0x1a, 0xad, 0xdc, 0xca, 0x9c, 0xf5, 0x2d, 0xdc, 0x4a, 0xd5, 0xf6,
-	0x32, 0xde, 0xec, 0x76, 0x58, 0xc6, 0x7b, 0x4d, 0x53, 0xfc, 0x0b, 0xb1, 0xec, 0x13, 0x4e, 0x8c,
-	0xa1, 0x9a, 0x58, 0x61, 0xcb, 0x5a, 0x5c, 0xdf, 0x9d, 0xed, 0x9d, 0x4c, 0x4c, 0x92, 0xdd, 0x13,
-	0x64, 0x4d, 0xa3, 0xa1, 0xc8, 0xc4, 0xcc, 0x14, 0x55, 0xf9, 0x3d, 0xc0, 0x72, 0xfb, 0xa0, 0x7b,
-	0xc9, 0xb2, 0x5b, 0x59, 0x53, 0xed, 0x76, 0x16, 0x24, 0xed, 0x6f, 0x0b, 0xfb, 0x1a, 0x4a, 0xd9,
-	0x47, 0x2e, 0x54, 0x13, 0xbb, 0x64, 0xe9, 0xff, 0xfa, 0x5e, 0x5a, 0xfa, 0x9f, 0xb5, 0x7c, 0x64,
-	0x71, 0xec, 0xdf, 0x5f, 0xb5, 0xdf, 0xfd, 0x39, 0xb1, 0x0e, 0x7e, 0x19, 0x96, 0xc4, 0xdf, 0x95,
-	0xcf, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0xd4, 0xfd, 0xa2, 0x2b, 0xf6, 0x0c, 0x00, 0x00,
+	// 3310 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5a, 0x5b, 0x6f, 0x1b, 0xc7,
+	0x15, 0x36, 0x49, 0x89, 0x22, 0x0f, 0x2f, 0xa2, 0x86, 0xba, 0x50, 0x2b, 0xdb, 0x92, 0xd7, 0x76,
+	0x22, 0x2b, 0x89, 0x14, 0x2b, 0x75, 0x93, 0x38, 0x05, 0x5a, 0x5d, 0xe8, 0xd8, 0x88, 0x2c, 0x0b,
+	0x2b, 0x39, 0x6e, 0x50, 0xa0, 0xdb, 0xe1, 0xee, 0x48, 0xda, 0x68, 0xb9, 0xcb, 0xee, 0x2e, 0x65,
+	0x31, 0x41, 0xfa, 0xd0, 0xa2, 0x0f, 0x45, 0x5b, 0x14, 0xbd, 0xbc, 0xf7, 0x1f, 0xe4, 0x07, 0x14,
+	0xe8, 0xaf, 0xc8, 0x3f, 0x28, 0xfa, 0x52, 0xf4, 0x07, 0xf4, 0xa9, 0x40, 0x31, 0x67, 0x66, 0x76,
+	0x97, 0x37, 0x39, 0x0a, 0x52, 0xb4, 0x4f, 0xe2, 0x7c, 0xe7, 0xcc, 0xb9, 0xcd, 0x99, 0x33, 0x67,
+	0x66, 0x05, 0xe5, 0x28, 0xa0, 0x36, 0x0b, 0xd6, 0x3b, 0x81, 0x1f, 0xf9, 0x24, 0x6f, 0xb9, 0xed,
+	0xa0, 0x63, 0x69, 0xd7, 0x4f, 0x7c, 0xff, 0xc4, 0x65, 0x1b, 0xb4, 0xe3, 0x6c, 0x50, 0xcf, 0xf3,
+	0x23, 0x1a, 0x39, 0xbe, 0x17, 0x0a, 0x2e, 0xad, 0x46, 0xbb, 0x16, 0x1f, 0x33, 0x35, 0x4f, 0xff,
+	0x47, 0x06, 0xc8, 0x13, 0xcf, 0x89, 0xb6, 0x2c, 0xcb, 0xef, 0x7a, 0x91, 0xc1, 0x7e, 0xda, 0x65,
+	0x61, 0x44, 0x6e, 0x43, 0x85, 0x0a, 0xc4, 0x3c, 0xa7, 0x6e, 0x97, 0x35, 0x32, 0x2b, 0x99, 0xd5,
+	0x09, 0xa3, 0x2c, 0xc1, 0x8f, 0x39, 0x46, 0xee, 0x42, 0x55, 0x31, 0xb1, 0x8b, 0x8e, 0x13, 0xf4,
+	0x1a, 0xd9, 0x95, 0xcc, 0x6a, 0xc5, 0x50, 0x53, 0x9b, 0x08, 0x92, 0x59, 0x98, 0x74, 0x69, 0x8b,
+	0xb9, 0x8d, 0xdc, 0x4a, 0x66, 0xb5, 0x68, 0x88, 0x01, 0x79, 0x0f, 0x26, 0x22, 0x7a, 0x12, 0x36,
+	0x26, 0x56, 0x72, 0xab, 0xa5, 0xcd, 0x3b, 0xeb, 0xc2, 0xfe, 0xf5, 0x61, 0x5b, 0xd6, 0x8f, 0xe8,
+	0x49, 0xd8, 0xf4, 0xa2, 0xa0, 0x67, 0xe0, 0x0c, 0xed, 0x5d, 0x28, 0xc6, 0x10, 0xa9, 0x41, 0xee,
+	0x8c, 0xf5, 0xd0, 0xbc, 0xa2, 0xc1, 0x7f, 0x72, 0x75, 0xc2, 0xe4, 0xac, 0x50, 0x87, 0x83, 0x87,
+	0xd9, 0xf7, 0x32, 0xfa, 0x9f, 0x33, 0x50, 0xdf, 0x73, 0x42, 0x25, 0x3f, 0x54, 0xce, 0xc6, 0x06,
+	0x66, 0xd2, 0x06, 0xbe, 0x2f, 0x0d, 0xcc, 0xa2, 0x81, 0x77, 0x95, 0x81, 0x23, 0x04, 0x7c, 0x7b,
+	0x16, 0xee, 0xc0, 0x6c, 0xbf, 0xfc, 0xb0, 0xe3, 0x7b, 0x21, 0x23, 0x6f, 0x40, 0x41, 0xc6, 0x34,
+	0x6c, 0x64, 0xd0, 0x9e, 0x69, 0x65, 0x8f, 0x0a, 0x56, 0xcc, 0xa0, 0x7f, 0x1f, 0xf2, 0xcf, 0xba,
+	0x51, 0xa7, 0x1b, 0x91, 0x25, 0x28, 0xa2, 0x6c, 0x33, 0xa4, 0x91, 0x5c, 0xc1, 0x02, 0x02, 0x87,
+	0x34, 0x22, 0x0d, 0x98, 0xa2, 0xb6, 0x1d, 0xb0, 0x30, 0x94, 0x76, 0xa8, 0xa1, 0xfe, 0xdb, 0x2c,
+	0xd4, 0xf7, 0x78, 0x0c, 0x06, 0x92, 0xe2, 0x06, 0x80, 0xc8, 0x39, 0x53, 0x39, 0x54, 0x36, 0x8a,
+	0x02, 0xf9, 0x88, 0xa5, 0xd6, 0x39, 0x9b, 0x0e, 0xe3, 0x32, 0x94, 0x2c, 0x97, 0xd1, 0xc0, 0x4c,
+	0x72, 0xa0, 0x60, 0x00, 0x42, 0xa8, 0x83, 0xec, 0x40, 0x21, 0x64, 0x91, 0x99, 0x4a, 0x86, 0xd5,
+	0x38, 0xd6, 0xc3, 0x46, 0xac, 0x1f, 0xb2, 0x28, 0x09, 0xf7, 0x54, 0x28, 0x46, 0x5c, 0x4b, 0xc0,
+	0xda, 0xfe, 0x39, 0x13, 0x72, 0x26, 0x57, 0x72, 0xab, 0x45, 0x03, 0x04, 0xc4, 0x19, 0xb4, 0x87,
+	0x50, 0x4e, 0xcf, 0xbc, 0xd2, 0xaa, 0xfc, 0x18, 0xea, 0x3b, 0xae, 0x1f, 0xb2, 0xab, 0x85, 0x63,
+	0x15, 0xa6, 0x7c, 0x5c, 0x06, 0x95, 0x42, 0x55, 0xe5, 0x96, 0x58, 0x1d, 0x43, 0x91, 0xf5, 0x07,
+	0x30, 0xdb, 0x2f, 0x5f, 0xae, 0xfa, 0x0d, 0x00, 0x8b, 0xe3, 0x66, 0x74, 0xe1, 0xd8, 0x4a, 0x01,
+	0x22, 0x47, 0x17, 0x8e, 0xad, 0xff, 0x32, 0x03, 0xf3, 0x2f, 0x9c, 0xe8, 0xd4, 0x0e, 0xe8, 0xcb,
+	0xff, 0x92, 0x69, 0x44, 0x87, 0x4a, 0x48, 0x23, 0xb3, 0xc3, 0x02, 0xf3, 0xbc, 0xd5, 0x8b, 0x18,
+	0xae, 0xdf, 0x84, 0x51, 0x0a, 0x69, 0x74, 0xc0, 0x82, 0x8f, 0x39, 0xa4, 0x3b, 0xb0, 0x30, 0x64,
+	0x86, 0xf4, 0xe0, 0x1e, 0x4c, 0xc9, 0xb4, 0x44, 0x23, 0x46, 0xa4, 0xad, 0xa2, 0xf3, 0x8a, 0xf3,
+	0x52, 0x4a, 0x11, 0xfe, 0x66, 0xd1, 0xea, 0xb2, 0x02, 0xd1, 0xe5, 0x1e, 0xcc, 0xed, 0xb2, 0x8e,
+	0x1f, 0x3a, 0xd1, 0xd5, 0x1c, 0xbe, 0x01, 0x40, 0xdb, 0x58, 0xa8, 0xf8, 0x4e, 0xc8, 0xa2, 0x0f,
+	0x45, 0x81, 0xf0, 0xad, 0x30, 0xd2, 0xcb, 0x4a, 0xbf, 0x97, 0xc7, 0x30, 0x3f, 0xa8, 0xfa, 0xea,
+	0x4e, 0xde, 0x82, 0xb2, 0x2d, 0x84, 0xa4, 0x7d, 0x2c, 0x49, 0x0c, 0x5d, 0xfc, 0x5b, 0x16, 0xa6,
+	0xe4, 0xbc, 0x57, 0x79, 0xf5, 0x26, 0x14, 0xf8, 0x3a, 0xf9, 0x8e, 0x27, 0x7c, 0x2a, 0x6d, 0xd6,
+	0x52, 0xeb, 0x78, 0xc0, 0x71, 0x23, 0xe6, 0x48, 0xf2, 0x5b, 0x2c, 0xa1, 0x18, 0x90, 0x37, 0x60,
+	0x06, 0x6b, 0x37, 0x1e, 0x13, 0xe6, 0x29, 0x73, 0x4e, 0x4e, 0xa3, 0xc6, 0x04, 0xba, 0x5f, 0x4b,
+	0x08, 0x8f, 0x11, 0x27, 0x6b, 0x30, 0x19, 0x46, 0x34, 0x62, 0x8d, 0xc9, 0x95, 0xcc, 0x6a, 0x75,
+	0x73, 0x76, 0xc0, 0xcf, 0x43, 0x4e, 0x33, 0x04, 0xcb, 0x40, 0xf2, 0xe6, 0x07, 0x92, 0x37, 0x29,
+	0x16, 0x53, 0xe9, 0x62, 0xf1, 0x96, 0xac, 0xb9, 0x05, 0xcc, 0xca, 0xc5, 0x01, 0xf9, 0xdf, 0x5e,
+	0x9d, 0xa5, 0x40, 0x0e, 0xbb, 0xad, 0xb6, 0x13, 0x3d, 0x0b, 0x6c, 0x16, 0xa8, 0x24, 0x5a, 0x86,
+	0x1c, 0x0d, 0xcf, 0xe4, 0x22, 0x96, 0x62, 0xe5, 0xe1, 0xd9, 0xe3, 0x6b, 0x06, 0xa7, 0x70, 0x86,
+	0x96, 0x5c, 0xb5, 0x14, 0xc3, 0xb6, 0x63, 0x73, 0x86, 0x96, 0x63, 0x6f, 0x17, 0x61, 0xca, 0x66,
+	0x11, 0x75, 0xdc, 0x50, 0xff, 0x7d, 0x06, 0xea, 0x7d, 0x3a, 0x64, 0xb6, 0x7c, 0x00, 0x15, 0xc7,
+	0x3b, 0xa7, 0xae, 0x63, 0x9b, 0x3e, 0x27, 0x48, 0x75, 0xb3, 0xc9, 0x01, 0x88, 0x44, 0x9c, 0xf4,
+	0xf8, 0x9a, 0x51, 0x76, 0x52, 0x63, 0xb2, 0x09, 0xb3, 0xd4, 0xb2, 0x58, 0x27, 0x62, 0x72, 0xb6,
+	0xe9, 0xf9, 0x9e, 0x25, 0x1c, 0x2c, 0x3f, 0xbe, 0x66, 0x10, 0x45, 0x45, 0xf6, 0x7d, 0x4e, 0x4b,
+	0xdb, 0xf4, 0xef, 0x1c, 0xcc, 0xf0, 0xf3, 0x05, 0xa9, 0xf1, 0xf1, 0x77, 0x17, 0xaa, 0x8e, 0x67,
+	0xb9, 0x5d, 0x9b, 0x99, 0xc7, 0xd4, 0x71, 0x99, 0x28, 0x35, 0x05, 0xa3, 0x22, 0xd1, 0x47, 0x08,
+	0x92, 0x26, 0x94, 0xa2, 0x5e, 0x87, 0x99, 0xc7, 0x8e, 0x1b, 0xb1, 0x00, 0x55, 0x56, 0x93, 0x73,
+	0x7b, 0x48, 0xec, 0xfa, 0x51, 0xaf, 0xc3, 0x1e, 0x21, 0xaf, 0x01, 0x51, 0xfc, 0x9b, 0xac, 0x41,
+	0x1e, 0x13, 0x24, 0x6c, 0xe4, 0x56, 0x72, 0xab, 0xd5, 0x4d, 0x12, 0xa7, 0x2c, 0x9f, 0x2d, 0x52,
+	0x48, 0x72, 0x0c, 0xe4, 0xff, 0xc4, 0x60, 0xfe, 0x2f, 0x43, 0x89, 0x5a, 0x91, 0x73, 0xce, 0x4c,
+	0xdf, 0x73, 0x7b, 0x98, 0x94, 0x05, 0x03, 0x04, 0xf4, 0xcc, 0x73, 0x7b, 0x64, 0x1e, 0xf2, 0xfe,
+	0xf1, 0x71, 0xc8, 0x22, 0xcc, 0xbf, 0x8a, 0x21, 0x47, 0x98, 0x7c, 0x4e, 0xdb, 0x89, 0x30, 0xf9,
+	0x2a, 0x86, 0x18, 0x70, 0x6d, 0xae, 0x6f, 0x51, 0x57, 0x48, 0x2b, 0xa0, 0xb4, 0x22, 0x22, 0x28,
+	0x2c, 0xce, 0xd8, 0x62, 0x3a, 0x63, 0xdf, 0x95, 0x19, 0x0b, 0x98, 0xb1, 0xb7, 0x2f, 0x09, 0xc7,
+	0xb7, 0x95, 0xbb, 0xef, 0x03, 0x24, 0xa1, 0x25, 0x15, 0x28, 0x6e, 0xed, 0xed, 0x99, 0x47, 0x9f,
+	0x1c, 0x34, 0x0f, 0x6b, 0xd7, 0x70, 0x78, 0xf8, 0xd1, 0xa1, 0xf9, 0x6c, 0x7f, 0xef, 0x93, 0x5a,
+	0x86, 0x0f, 0xb7, 0x9f, 0xec, 0xca, 0x61, 0x56, 0xef, 0x01, 0x49, 0x1b, 0x26, 0x33, 0x72, 0x19,
+	0x26, 0x68, 0x78, 0xa6, 0x1a, 0x8b, 0x74, 0xde, 0x1b, 0x48, 0xe0, 0x0c, 0x2d, 0xc7, 0x56, 0x67,
+	0x45, 0x3a, 0xef, 0x0d, 0x24, 0xf0, 0xb2, 0x16, 0xf9, 0x11, 0x8f, 0x1c, 0x4a, 0x56, 0xe5, 0x13,
+	0x31, 0xa1, 0x4c, 0x7f, 0x00, 0x64, 0x87, 0x7a, 0x16, 0x73, 0x07, 0x76, 0x5c, 0x29, 0x9d, 0xc6,
+	0xa2, 0xc2, 0x81, 0x1f, 0x27, 0xaf, 0x3e, 0x07, 0xf5, 0xbe, 0x69, 0xc2, 0x64, 0xfd, 0xd7, 0x59,
+	0x98, 0xc1, 0xe6, 0xe0, 0x4a, 0xd2, 0xbe, 0x69, 0x87, 0xb2, 0x35, 0xd4, 0xa1, 0xbc, 0xd6, 0xd7,
+	0xa1, 0xa4, 0x8d, 0xf8, 0x5f, 0xf4, 0x27, 0xb3, 0x40, 0xd2, 0x76, 0xc8, 0x18, 0xfd, 0x29, 0x03,
+	0x64, 0xab, 0xd3, 0x71, 0x7b, 0xfd, 0xbb, 0x7d, 0x19, 0x4a, 0xc9, 0x9e, 0x12, 0x8b, 0x5e, 0x36,
+	0x20, 0xde, 0x54, 0x61, 0x9c, 0x0e, 0xd9, 0x57, 0xa5, 0x43, 0x6e, 0x5c, 0x3a, 0x2c, 0x41, 0xb1,
+	0xe3, 0x52, 0x4f, 0xec, 0xa3, 0x09, 0x0c, 0x67, 0x81, 0x03, 0x7c, 0x1b, 0xe9, 0xbb, 0x50, 0xef,
+	0xb3, 0x4a, 0x26, 0xe1, 0x5b, 0xfc, 0x10, 0xc5, 0xab, 0x8a, 0xcc, 0xc3, 0x7a, 0x5f, 0x5d, 0xd8,
+	0x42, 0x9a, 0xa1, 0x78, 0xf4, 0x2f, 0xb3, 0x50, 0x4a, 0x11, 0xc8, 0x77, 0x21, 0x2f, 0x48, 0x18,
+	0xb1, 0xea, 0xe6, 0xcd, 0x11, 0xb3, 0xd7, 0xc5, 0x1f, 0xbe, 0x79, 0x0c, 0xc9, 0x3d, 0x50, 0x61,
+	0xb2, 0x63, 0x5b, 0xda, 0xdc, 0x40, 0xc2, 0xa4, 0xf3, 0x6c, 0x62, 0x28, 0xcf, 0x5e, 0x83, 0x69,
+	0x8f, 0xbd, 0xec, 0xab, 0xd0, 0x93, 0xc8, 0x54, 0xf1, 0xd8, 0xcb, 0xa4, 0x34, 0x93, 0x15, 0x28,
+	0xd9, 0x2c, 0xb4, 0x02, 0xa7, 0x83, 0xa6, 0xe7, 0x51, 0x49, 0x1a, 0xe2, 0x06, 0xb0, 0x20, 0xf0,
+	0x03, 0x75, 0x4c, 0xe2, 0x40, 0xbf, 0x0f, 0x90, 0xf8, 0x42, 0x00, 0xf2, 0x87, 0xcf, 0xb7, 0x9f,
+	0x3e, 0x39, 0xaa, 0x5d, 0xe3, 0xbf, 0x77, 0xb6, 0xf6, 0x77, 0x9a, 0x7b, 0xb5, 0x0c, 0x29, 0xc1,
+	0x94, 0xd1, 0x3c, 0xd8, 0xdb, 0xda, 0x69, 0xd6, 0xb2, 0xfa, 0x63, 0x68, 0x1c, 0x04, 0x5d, 0x4f,
+	0x16, 0xf3, 0xfe, 0x94, 0x78, 0x13, 0x88, 0xef, 0x72, 0x53, 0xa3, 0x53, 0xea, 0x99, 0x21, 0xb3,
+	0x7c, 0xcf, 0x0e, 0xe5, 0x7d, 0xa1, 0x86, 0x94, 0xa3, 0x53, 0xea, 0x1d, 0x0a, 0x5c, 0x7f, 0x08,
+	0x8b, 0x23, 0x24, 0x25, 0x2d, 0xab, 0xd7, 0x6d, 0x9b, 0x1d, 0xce, 0x20, 0xce, 0x91, 0x8a, 0x51,
+	0xf4, 0xba, 0x6d, 0x9c, 0x61, 0xeb, 0xbf, 0xc8, 0x40, 0xdd, 0x60, 0x1d, 0x97, 0x5a, 0xec, 0x6a,
+	0x3b, 0x57, 0x1e, 0xcd, 0xd9, 0x57, 0x1d, 0xcd, 0xb9, 0xaf, 0x73, 0x34, 0xff, 0x21, 0x03, 0xb3,
+	0xfd, 0x56, 0xfc, 0x1f, 0x9c, 0xcd, 0xff, 0x9a, 0x82, 0x49, 0x21, 0xe8, 0xd5, 0xbd, 0x6c, 0x40,
+	0x23, 0x7e, 0x0e, 0x5f, 0x30, 0x5b, 0xde, 0xb8, 0x8b, 0x1c, 0x79, 0xc4, 0x01, 0x5e, 0x38, 0x68,
+	0x3b, 0x92, 0x4d, 0x1e, 0xff, 0x49, 0x56, 0xa1, 0x76, 0xdc, 0xf5, 0x6c, 0xc7, 0x3b, 0x31, 0x8f,
+	0x19, 0x33, 0x39, 0x2b, 0xe6, 0xec, 0x84, 0x51, 0x95, 0xf8, 0x23, 0xc6, 0x0c, 0xde, 0xb3, 0x0d,
+	0x2c, 0xc3, 0xe4, 0xd0, 0x32, 0xac, 0xaa, 0x06, 0x30, 0xbf, 0x92, 0x19, 0x73, 0x76, 0x0b, 0x06,
+	0x9e, 0xb8, 0x5d, 0xcf, 0x89, 0x42, 0x75, 0xc4, 0xe2, 0x80, 0x77, 0x9b, 0xf8, 0xc3, 0xec, 0x7a,
+	0xc7, 0x5d, 0xf7, 0xd8, 0x71, 0x79, 0xb7, 0x51, 0x10, 0xdd, 0x26, 0x12, 0x9e, 0x27, 0x38, 0xbf,
+	0x11, 0x88, 0x67, 0x05, 0xd5, 0x96, 0x16, 0x91, 0xb1, 0x2c, 0x40, 0xd9, 0x92, 0xde, 0x83, 0x9a,
+	0x64, 0x8a, 0x9c, 0x36, 0x0b, 0x23, 0xda, 0xee, 0x34, 0x60, 0x25, 0xb3, 0x9a, 0x33, 0xa6, 0x05,
+	0x7e, 0xa4, 0x60, 0xde, 0xe7, 0x48, 0xd6, 0x16, 0x8d, 0xac, 0x53, 0x16, 0x36, 0x4a, 0xe2, 0xb9,
+	0x42, 0xa0, 0xdb, 0x02, 0x24, 0xaf, 0xc3, 0xb4, 0xa4, 0x9b, 0x6d, 0xfc, 0x6b, 0x37, 0xca, 0xc8,
+	0x57, 0x95, 0xf0, 0x53, 0x81, 0xf2, 0x5d, 0xde, 0x76, 0x3c, 0x53, 0x38, 0x84, 0xac, 0x8d, 0x8a,
+	0x10, 0xd8, 0x76, 0xbc, 0xe7, 0x1c, 0x45, 0x4e, 0x72, 0x13, 0x4a, 0xd4, 0xe5, 0x67, 0x23, 0x0f,
+	0x2b, 0x6b, 0x54, 0x45, 0x63, 0x41, 0x5d, 0xf7, 0x19, 0x8f, 0x2a, 0x23, 0x6f, 0xc3, 0x6c, 0x20,
+	0xb2, 0x31, 0xec, 0x4b, 0x9c, 0x69, 0x0c, 0x3f, 0x51, 0xb4, 0x54, 0xdd, 0x78, 0x00, 0x0b, 0x12,
+	0xb5, 0xcd, 0x56, 0xaf, 0x6f, 0x52, 0x0d, 0x27, 0x29, 0x81, 0xf6, 0x76, 0x2f, 0x35, 0xed, 0x2e,
+	0x54, 0x79, 0x83, 0x97, 0x8a, 0xd4, 0x0c, 0x46, 0xaa, 0xc2, 0xd1, 0x24, 0x4e, 0xcb, 0x50, 0x42,
+	0xb6, 0x30, 0x0a, 0x1c, 0xef, 0xa4, 0x41, 0xb0, 0xf2, 0x00, 0x87, 0x0e, 0x11, 0x21, 0x1a, 0x14,
+	0x02, 0xf6, 0x29, 0xb3, 0x22, 0x66, 0x37, 0xea, 0xa2, 0xbc, 0xab, 0x31, 0xf9, 0x81, 0x9c, 0x1c,
+	0x30, 0x1a, 0xfa, 0x5e, 0x63, 0x16, 0xf3, 0x64, 0x79, 0xd4, 0x06, 0x5a, 0xe7, 0x05, 0xc4, 0x40,
+	0x36, 0x21, 0x5d, 0xfc, 0xe6, 0xcd, 0x04, 0xa6, 0x90, 0x69, 0x51, 0x0c, 0xfe, 0x1c, 0x6a, 0x28,
+	0x21, 0xb6, 0x83, 0x50, 0x52, 0x96, 0xe7, 0xd3, 0x65, 0xf9, 0x0d, 0xd9, 0x8a, 0x2d, 0xe0, 0xf9,
+	0xb1, 0xd0, 0x97, 0x9b, 0x83, 0xed, 0x17, 0x59, 0x84, 0xc2, 0x49, 0xe0, 0x77, 0x3b, 0xa6, 0x63,
+	0x37, 0x1a, 0x18, 0xb3, 0x29, 0x1c, 0x3f, 0xb1, 0x79, 0x74, 0x2d, 0xec, 0x39, 0x86, 0xa3, 0xbb,
+	0x28, 0xa2, 0xab, 0xc8, 0xe9, 0xe8, 0x7e, 0xf3, 0x86, 0xee, 0x02, 0x72, 0xdb, 0x8e, 0x4d, 0x5e,
+	0x8f, 0x6b, 0x81, 0x2c, 0x3b, 0x95, 0x3e, 0x0f, 0x0c, 0x45, 0x25, 0xeb, 0x50, 0xe7, 0x79, 0x67,
+	0x77, 0xe5, 0xa5, 0xad, 0xe5, 0xfa, 0xd6, 0x59, 0x28, 0x2b, 0xc1, 0x4c, 0xdb, 0xf1, 0x76, 0x25,
+	0x65, 0x1b, 0x09, 0xfc, 0xa1, 0xe7, 0x9c, 0x05, 0x21, 0x3f, 0x61, 0x44, 0x63, 0xa6, 0x86, 0x5c,
+	0xf3, 0x56, 0x78, 0x76, 0x35, 0xcd, 0xf4, 0x62, 0xac, 0x66, 0x7a, 0xf1, 0xb5, 0x35, 0xff, 0x35,
+	0x0b, 0xf3, 0x06, 0xb3, 0xfc, 0x73, 0x16, 0xc8, 0xcb, 0x5d, 0x98, 0xba, 0xca, 0x5b, 0xa7, 0xd4,
+	0xf1, 0xcc, 0xd0, 0xa2, 0x9e, 0xbc, 0x8a, 0x14, 0x11, 0x39, 0xb4, 0xa8, 0x87, 0x8f, 0x8e, 0xf1,
+	0x23, 0x66, 0xea, 0xd4, 0xae, 0x24, 0x28, 0xaf, 0x92, 0x6b, 0x30, 0xe3, 0x78, 0x4e, 0xe4, 0x50,
+	0x57, 0xec, 0x76, 0xe4, 0xcc, 0x21, 0xe7, 0xb4, 0x24, 0xe0, 0x86, 0xe7, 0xbc, 0x22, 0xe3, 0x82,
+	0xa8, 0xff, 0xfa, 0x5b, 0x42, 0x4c, 0x96, 0x99, 0x45, 0x28, 0xf0, 0x73, 0x0d, 0x5b, 0xa6, 0x49,
+	0xe1, 0x8a, 0xd7, 0x6d, 0x63, 0xbf, 0xb4, 0x0a, 0xb5, 0x58, 0x83, 0xf9, 0xd2, 0xf1, 0x6c, 0xff,
+	0x65, 0x23, 0x9f, 0x2a, 0x18, 0x1f, 0xb1, 0xde, 0x0b, 0x44, 0xf9, 0xc6, 0x12, 0x7a, 0x1c, 0xcf,
+	0x66, 0x17, 0xb2, 0x32, 0x02, 0x42, 0x4f, 0x38, 0xc2, 0xb3, 0xe6, 0x84, 0x76, 0x64, 0x41, 0xe4,
+	0x3f, 0xf9, 0x0d, 0x26, 0x60, 0x61, 0xb7, 0xcd, 0xb0, 0xf8, 0x15, 0x0c, 0x39, 0xd2, 0xbf, 0xca,
+	0xc0, 0xc2, 0x50, 0xfc, 0xe4, 0x29, 0xf6, 0x1d, 0x98, 0xe7, 0xb6, 0x06, 0x82, 0xcc, 0x6c, 0x33,
+	0xf5, 0x74, 0xc8, 0x05, 0xcf, 0x7a, 0xdd, 0xb6, 0xa1, 0x88, 0x6a, 0xf6, 0xa0, 0x71, 0xd9, 0x21,
+	0xe3, 0xf8, 0xd1, 0xce, 0x2e, 0x14, 0x3d, 0x27, 0x8f, 0x76, 0x76, 0x21, 0xc9, 0xbc, 0xe7, 0xe9,
+	0xb6, 0xcd, 0xae, 0xd7, 0x0d, 0x99, 0x2d, 0x02, 0x25, 0xe2, 0x58, 0xf1, 0xba, 0xed, 0xe7, 0x88,
+	0x62, 0xb8, 0x34, 0x28, 0x58, 0x7e, 0xbb, 0xe3, 0x32, 0xf9, 0x8c, 0x50, 0x30, 0xe2, 0xb1, 0xfe,
+	0x00, 0x96, 0x0c, 0x16, 0x46, 0x7e, 0xa0, 0x9e, 0xc2, 0xb6, 0xa9, 0x75, 0xd6, 0xed, 0xa8, 0xcc,
+	0x98, 0x87, 0x7c, 0x0b, 0x01, 0x79, 0x28, 0xca, 0x91, 0x6e, 0xc0, 0xf5, 0xd1, 0xd3, 0x64, 0x40,
+	0x36, 0x61, 0x4e, 0x04, 0x04, 0x79, 0x86, 0xe2, 0x51, 0xc7, 0x78, 0x08, 0x9a, 0x0a, 0x87, 0x3e,
+	0x03, 0xd3, 0x42, 0xca, 0xee, 0xb6, 0x54, 0xaf, 0x7f, 0x08, 0xb5, 0x04, 0x4a, 0xfa, 0x1d, 0xbb,
+	0x65, 0xaa, 0x24, 0x97, 0xfd, 0x8e, 0xdd, 0xfa, 0x58, 0x00, 0x7c, 0xd3, 0x5b, 0xa7, 0x5d, 0xef,
+	0x4c, 0xe6, 0xa8, 0x18, 0xe8, 0x1a, 0x34, 0xb6, 0x44, 0xb2, 0xee, 0xf8, 0x9e, 0xc7, 0xf0, 0x97,
+	0x52, 0xf2, 0x19, 0x4c, 0x27, 0x60, 0xf3, 0x9c, 0x89, 0x47, 0xa3, 0xb8, 0x62, 0x9b, 0x9e, 0xb0,
+	0x3a, 0x67, 0x94, 0x62, 0x6c, 0x3f, 0x24, 0x04, 0x26, 0xf8, 0x15, 0x5b, 0xd6, 0x16, 0xfc, 0xcd,
+	0x03, 0xcd, 0x3c, 0x5b, 0xbc, 0x0e, 0x89, 0xf6, 0x35, 0x1e, 0x27, 0x6d, 0xe5, 0x44, 0xba, 0xad,
+	0xfc, 0x55, 0x16, 0x16, 0x47, 0x18, 0x16, 0x3f, 0x73, 0xd5, 0xac, 0x6e, 0x10, 0x30, 0xfe, 0xda,
+	0xaf, 0xe4, 0x8a, 0xfa, 0x36, 0x2d, 0xf1, 0xa6, 0x12, 0x7f, 0x1d, 0x8a, 0x8a, 0x45, 0xdc, 0x23,
+	0x8a, 0x46, 0x02, 0x70, 0xaa, 0x25, 0xc4, 0x33, 0x5b, 0xde, 0xb6, 0x12, 0x00, 0x6f, 0xe1, 0x34,
+	0x8c, 0xcc, 0xb4, 0x7d, 0x45, 0x8e, 0x34, 0x39, 0xc0, 0x8b, 0x73, 0x42, 0x36, 0xfb, 0xe2, 0x32,
+	0x89, 0x71, 0x99, 0x8d, 0x79, 0x8f, 0x52, 0x01, 0xda, 0x80, 0x3c, 0xe3, 0xc1, 0x0c, 0x1b, 0xf9,
+	0xfe, 0xd3, 0x61, 0x20, 0xd8, 0x86, 0x64, 0xd3, 0xff, 0x99, 0x85, 0xe2, 0x5e, 0x48, 0xa3, 0x23,
+	0xff, 0x8c, 0x79, 0xbc, 0x15, 0x69, 0xd1, 0x90, 0x99, 0x6d, 0x6a, 0xd1, 0xc0, 0x97, 0x2b, 0x5d,
+	0x36, 0xca, 0x1c, 0x7c, 0x2a, 0x31, 0xbe, 0x4e, 0x1d, 0xda, 0x6b, 0xf3, 0x00, 0x9d, 0xd2, 0xf0,
+	0x54, 0x3d, 0xee, 0x49, 0xec, 0x31, 0x0d, 0x4f, 0x79, 0x0c, 0x15, 0x4b, 0x27, 0x60, 0x4e, 0x9b,
+	0x9e, 0x30, 0x55, 0x94, 0x24, 0x7e, 0x20, 0x61, 0x5e, 0x56, 0xe4, 0x93, 0x65, 0x87, 0x3a, 0xb6,
+	0xd9, 0xe6, 0x0f, 0x97, 0xb2, 0x6b, 0x13, 0xf8, 0x01, 0x75, 0xec, 0xa7, 0x21, 0x8d, 0xc8, 0x7d,
+	0x98, 0x0b, 0xfc, 0x6e, 0xa4, 0xfa, 0xbb, 0x84, 0x7d, 0x12, 0xd9, 0x89, 0x24, 0x3e, 0x62, 0x2c,
+	0x9e, 0x22, 0x53, 0xca, 0xb4, 0x02, 0x46, 0xf9, 0x2a, 0xe4, 0x93, 0x94, 0xda, 0x11, 0x90, 0x3c,
+	0xaf, 0x1c, 0x1b, 0xcb, 0x54, 0xc1, 0x10, 0x03, 0x5e, 0xd1, 0x3b, 0x0c, 0x7b, 0x46, 0xf9, 0x40,
+	0xa2, 0x86, 0x9c, 0x12, 0xb0, 0x73, 0xff, 0x8c, 0xd9, 0xb2, 0x54, 0xa9, 0xa1, 0x28, 0xaf, 0x7e,
+	0x40, 0x4f, 0x98, 0xe9, 0xd1, 0x36, 0xc3, 0xf6, 0xac, 0x68, 0x94, 0x24, 0xb6, 0x4f, 0xdb, 0x4c,
+	0x5f, 0x80, 0x39, 0xfe, 0x30, 0x11, 0x07, 0x5c, 0x1d, 0x06, 0xfa, 0x0e, 0xcc, 0x0f, 0x12, 0xe2,
+	0x74, 0xcc, 0x47, 0x88, 0xc8, 0xfb, 0xe2, 0x4c, 0x7c, 0x25, 0x57, 0xbc, 0x86, 0x64, 0xd0, 0xdf,
+	0x83, 0xfa, 0x87, 0x2c, 0x91, 0xa1, 0xca, 0xc9, 0xe0, 0x7a, 0x65, 0x86, 0xd6, 0x4b, 0xff, 0x80,
+	0x9f, 0x52, 0xdc, 0x8b, 0x6f, 0x32, 0x79, 0x11, 0x16, 0x86, 0x26, 0xcb, 0xbb, 0x79, 0x1d, 0x66,
+	0x38, 0xb8, 0xc7, 0xec, 0x93, 0xf8, 0x12, 0xa4, 0x7f, 0x99, 0x81, 0xe9, 0x04, 0x15, 0x7d, 0xc4,
+	0xab, 0xd5, 0x8c, 0x4c, 0x94, 0xec, 0xd5, 0x12, 0x25, 0x37, 0x36, 0x51, 0x96, 0xa0, 0x88, 0x89,
+	0xc2, 0x79, 0x31, 0xfd, 0x72, 0x46, 0x81, 0x03, 0x9c, 0x41, 0xff, 0x4b, 0x06, 0x48, 0xda, 0x0d,
+	0xb9, 0x32, 0xf7, 0x61, 0x8a, 0x79, 0x51, 0xe0, 0x30, 0xb5, 0x34, 0x0b, 0xe9, 0xa5, 0x49, 0x79,
+	0x67, 0x28, 0x3e, 0xf2, 0x0e, 0xcc, 0x8b, 0x07, 0xa4, 0x31, 0x9e, 0xd4, 0x91, 0xba, 0xd5, 0xef,
+	0xce, 0xf7, 0x60, 0x49, 0x4c, 0xba, 0xcc, 0xa9, 0x05, 0x64, 0x31, 0x86, 0x3c, 0x5b, 0x3b, 0x83,
+	0x72, 0xfa, 0xd9, 0x9a, 0xd4, 0xa0, 0x7c, 0xd0, 0xdc, 0xdf, 0x7d, 0xb2, 0xff, 0xa1, 0xf9, 0xec,
+	0xa0, 0xb9, 0x5f, 0xbb, 0x46, 0x08, 0x54, 0x15, 0xf2, 0xfc, 0x60, 0x77, 0xeb, 0xa8, 0x59, 0xcb,
+	0x90, 0x02, 0x4c, 0x20, 0x35, 0xcb, 0x6f, 0xd7, 0xcd, 0x1f, 0x1e, 0x3c, 0x31, 0x9a, 0xbb, 0xb5,
+	0x5c, 0x9a, 0x75, 0x67, 0xef, 0xd9, 0x61, 0x73, 0xb7, 0x36, 0x81, 0x57, 0x71, 0xf1, 0x7b, 0x72,
+	0xf3, 0x77, 0x04, 0xf2, 0x47, 0x78, 0x83, 0x23, 0x2f, 0xa0, 0x94, 0xfa, 0xc6, 0x49, 0xb4, 0xf1,
+	0x1f, 0x3e, 0xb5, 0xc1, 0xef, 0x08, 0xfa, 0xd2, 0xcf, 0xbf, 0xfa, 0xfb, 0x1f, 0xb3, 0x73, 0x7a,
+	0x6d, 0xe3, 0xfc, 0xfe, 0x86, 0xe5, 0xb6, 0x37, 0xd4, 0x49, 0xf6, 0x30, 0xb3, 0x46, 0x2c, 0x28,
+	0xa7, 0xbf, 0x1d, 0x92, 0xa5, 0x4b, 0xbe, 0x58, 0x6a, 0xd7, 0x47, 0x13, 0x65, 0x7a, 0x36, 0x50,
+	0x0f, 0x21, 0x43, 0x7a, 0x88, 0x09, 0xe5, 0xf4, 0x47, 0xb9, 0x94, 0x92, 0xe1, 0x4f, 0x75, 0xc3,
+	0xf6, 0xdf, 0x42, 0xb9, 0x4b, 0xfa, 0xfc, 0xa0, 0xdc, 0x0d, 0xec, 0xe0, 0xa5, 0x17, 0xe9, 0x6f,
+	0x61, 0x89, 0x82, 0x11, 0x5f, 0xe0, 0xb4, 0xeb, 0xa3, 0x89, 0xfd, 0x5e, 0xac, 0x0d, 0x7b, 0x71,
+	0x01, 0xd3, 0x03, 0x5f, 0xac, 0x48, 0xfc, 0x60, 0x34, 0xfa, 0x8b, 0x9a, 0xb6, 0x3c, 0x96, 0x2e,
+	0xb5, 0xdd, 0x41, 0x6d, 0x37, 0xf5, 0xc5, 0x21, 0xdf, 0xd4, 0x17, 0x2c, 0xee, 0x5e, 0x04, 0xd5,
+	0xfe, 0xaf, 0x48, 0xe4, 0x86, 0x12, 0x3c, 0xf2, 0xc3, 0x96, 0x76, 0x73, 0x1c, 0x59, 0xaa, 0xbd,
+	0x8d, 0x6a, 0x6f, 0xe8, 0x8d, 0x21, 0xb5, 0xf2, 0xa3, 0x12, 0xd7, 0xfa, 0x12, 0xa6, 0x07, 0x9a,
+	0xc5, 0xc4, 0xdf, 0xd1, 0x5d, 0xb8, 0xb6, 0x3c, 0x96, 0xfe, 0x4a, 0xc5, 0xb2, 0xf1, 0xe4, 0x8a,
+	0x7f, 0x83, 0x2f, 0x2d, 0xc3, 0xad, 0x19, 0xb9, 0x9d, 0x88, 0x1f, 0xdb, 0xef, 0x69, 0x77, 0x2e,
+	0x67, 0x92, 0x86, 0xdc, 0x43, 0x43, 0x6e, 0xeb, 0x37, 0x47, 0x18, 0x82, 0xd3, 0x44, 0x97, 0xc8,
+	0xcd, 0xa1, 0x50, 0x4a, 0x7d, 0x92, 0x49, 0xf6, 0xde, 0xf0, 0xb7, 0x20, 0x6d, 0x69, 0x24, 0x4d,
+	0xaa, 0x5c, 0x44, 0x95, 0x75, 0xbd, 0xaa, 0x54, 0x8a, 0x77, 0x6f, 0xae, 0xe2, 0x47, 0x00, 0xc9,
+	0x13, 0x3b, 0x59, 0x1c, 0xfb, 0x3d, 0x40, 0xd3, 0x46, 0x91, 0xa4, 0xfc, 0x79, 0x94, 0x5f, 0x23,
+	0x03, 0xf2, 0x89, 0x0b, 0xa5, 0xd4, 0x6b, 0x78, 0x62, 0xff, 0xf0, 0xcb, 0xba, 0xb6, 0x34, 0x92,
+	0xd6, 0x9f, 0xab, 0x6b, 0xd7, 0xfb, 0xe5, 0x6f, 0x7c, 0x9e, 0xba, 0xeb, 0x7e, 0x41, 0x02, 0x80,
+	0xe4, 0x59, 0x39, 0xe5, 0xca, 0xe0, 0x93, 0xb7, 0xa6, 0x8d, 0x22, 0x49, 0x55, 0x6f, 0xa1, 0xaa,
+	0xd7, 0x75, 0xfd, 0x32, 0x55, 0xc9, 0xf6, 0xff, 0x0c, 0xca, 0xe9, 0x97, 0xb9, 0x64, 0xfb, 0x8f,
+	0x78, 0x35, 0xd4, 0xae, 0x8f, 0x26, 0x4a, 0xcd, 0x1b, 0xa8, 0xf9, 0x9e, 0x7e, 0xe7, 0x52, 0xcd,
+	0xf2, 0xa1, 0x84, 0xeb, 0x3e, 0x81, 0x52, 0xea, 0x65, 0x3a, 0x89, 0xee, 0xf0, 0x23, 0xba, 0xb6,
+	0x34, 0x92, 0x26, 0x15, 0x2f, 0xa3, 0xe2, 0x45, 0x7d, 0x76, 0x40, 0x31, 0xe5, 0xbc, 0x62, 0x3b,
+	0xce, 0x0c, 0xbd, 0xa0, 0x92, 0x15, 0x25, 0x72, 0xdc, 0x33, 0xad, 0x76, 0xeb, 0x12, 0x0e, 0xa9,
+	0xfa, 0x06, 0xaa, 0x5e, 0x58, 0x9b, 0x1b, 0x50, 0x2d, 0xbe, 0xeb, 0x91, 0x17, 0x50, 0x50, 0x37,
+	0x18, 0x12, 0x1f, 0xca, 0x03, 0xd7, 0x1c, 0xad, 0x31, 0x4c, 0x18, 0x97, 0x96, 0x62, 0x67, 0xbd,
+	0x9d, 0x21, 0x11, 0xcc, 0x0c, 0x5d, 0x1c, 0x12, 0x8f, 0xc6, 0x5d, 0x76, 0xb4, 0x5b, 0x97, 0x70,
+	0x48, 0x9d, 0x1a, 0xea, 0x9c, 0x25, 0x44, 0xe9, 0xb4, 0x12, 0x05, 0x2e, 0x54, 0xfb, 0x9b, 0xc3,
+	0xa4, 0x98, 0x8e, 0xec, 0x26, 0xb5, 0x9b, 0xe3, 0xc8, 0x52, 0x99, 0x3c, 0x5f, 0x49, 0x5d, 0x29,
+	0x73, 0x43, 0x1a, 0x6d, 0x88, 0x2e, 0x92, 0x7c, 0x0a, 0xe5, 0x74, 0x17, 0x99, 0xa4, 0xe6, 0x88,
+	0xde, 0x52, 0x1b, 0xee, 0x46, 0xf5, 0x35, 0x14, 0x7e, 0x87, 0xe8, 0x23, 0x84, 0x6f, 0x7c, 0x9e,
+	0xee, 0xf2, 0xbe, 0x20, 0x3f, 0x83, 0xe9, 0x81, 0xd6, 0x31, 0x5d, 0xb0, 0x47, 0x35, 0xa4, 0xda,
+	0xf2, 0x58, 0xba, 0x74, 0x4e, 0xea, 0x5f, 0xfb, 0x3a, 0xfa, 0x7f, 0x02, 0x90, 0xf4, 0x6a, 0xa9,
+	0xad, 0x3f, 0xd8, 0xb3, 0x6a, 0xda, 0x28, 0xd2, 0xa5, 0xd1, 0x74, 0x91, 0xa9, 0x95, 0xc7, 0x7f,
+	0x3f, 0x7b, 0xe7, 0x3f, 0x03, 0x00, 0xea, 0xf3, 0x84, 0x6a, 0xc6, 0x26, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1385,98 +3585,241 @@ const _ = grpc.SupportPackageIsVersion4
 type TraderClient interface {
 	InitAccount(ctx context.Context, in *InitAccountRequest, opts ...grpc.CallOption) (*Account, error)
 	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	LabelAccount(ctx context.Context, in *LabelAccountRequest, opts ...grpc.CallOption) (*Account, error)
 	CloseAccount(ctx context.Context, in *CloseAccountRequest, opts ...grpc.CallOption) (*CloseAccountResponse, error)
 	WithdrawAccount(ctx context.Context, in *WithdrawAccountRequest, opts ...grpc.CallOption) (*WithdrawAccountResponse, error)
 	DepositAccount(ctx context.Context, in *DepositAccountRequest, opts ...grpc.CallOption) (*DepositAccountResponse, error)
 	RecoverAccounts(ctx context.Context, in *RecoverAccountsRequest, opts ...grpc.CallOption) (*RecoverAccountsResponse, error)
+	RestoreAccountBackup(ctx context.Context, in *RestoreAccountBackupRequest, opts ...grpc.CallOption) (*RestoreAccountBackupResponse, error)
 	SubmitOrder(ctx context.Context, in *SubmitOrderRequest, opts ...grpc.CallOption) (*SubmitOrderResponse, error)
 	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
 	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
+	LabelOrder(ctx context.Context, in *LabelOrderRequest, opts ...grpc.CallOption) (*LabelOrderResponse, error)
+	ReplaceOrder(ctx context.Context, in *ReplaceOrderRequest, opts ...grpc.CallOption) (*ReplaceOrderResponse, error)
+	ApplyOrders(ctx context.Context, in *ApplyOrdersRequest, opts ...grpc.CallOption) (*ApplyOrdersResponse, error)
+	PruneFailedOrders(ctx context.Context, in *PruneFailedOrdersRequest, opts ...grpc.CallOption) (*PruneFailedOrdersResponse, error)
+	BackupDB(ctx context.Context, in *BackupDBRequest, opts ...grpc.CallOption) (Trader_BackupDBClient, error)
+	AuctionConnection(ctx context.Context, in *AuctionConnectionRequest, opts ...grpc.CallOption) (*AuctionConnectionResponse, error)
+	ListLsatTokens(ctx context.Context, in *ListLsatTokensRequest, opts ...grpc.CallOption) (*ListLsatTokensResponse, error)
+	GetLsatToken(ctx context.Context, in *GetLsatTokenRequest, opts ...grpc.CallOption) (*LsatToken, error)
+	RevokeLsatToken(ctx context.Context, in *RevokeLsatTokenRequest, opts ...grpc.CallOption) (*RevokeLsatTokenResponse, error)
+	LsatLedger(ctx context.Context, in *LsatLedgerRequest, opts ...grpc.CallOption) (*LsatLedgerResponse, error)
+}
+
+type traderClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTraderClient(cc *grpc.ClientConn) TraderClient {
+	return &traderClient{cc}
+}
+
+func (c *traderClient) InitAccount(ctx context.Context, in *InitAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/InitAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/ListAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) LabelAccount(ctx context.Context, in *LabelAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/LabelAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) CloseAccount(ctx context.Context, in *CloseAccountRequest, opts ...grpc.CallOption) (*CloseAccountResponse, error) {
+	out := new(CloseAccountResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/CloseAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) WithdrawAccount(ctx context.Context, in *WithdrawAccountRequest, opts ...grpc.CallOption) (*WithdrawAccountResponse, error) {
+	out := new(WithdrawAccountResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/WithdrawAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) DepositAccount(ctx context.Context, in *DepositAccountRequest, opts ...grpc.CallOption) (*DepositAccountResponse, error) {
+	out := new(DepositAccountResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/DepositAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) RecoverAccounts(ctx context.Context, in *RecoverAccountsRequest, opts ...grpc.CallOption) (*RecoverAccountsResponse, error) {
+	out := new(RecoverAccountsResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/RecoverAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) RestoreAccountBackup(ctx context.Context, in *RestoreAccountBackupRequest, opts ...grpc.CallOption) (*RestoreAccountBackupResponse, error) {
+	out := new(RestoreAccountBackupResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/RestoreAccountBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) SubmitOrder(ctx context.Context, in *SubmitOrderRequest, opts ...grpc.CallOption) (*SubmitOrderResponse, error) {
+	out := new(SubmitOrderResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/SubmitOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type traderClient struct {
-	cc *grpc.ClientConn
+func (c *traderClient) ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
+	out := new(ListOrdersResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/ListOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewTraderClient(cc *grpc.ClientConn) TraderClient {
-	return &traderClient{cc}
+func (c *traderClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
+	out := new(CancelOrderResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/CancelOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *traderClient) InitAccount(ctx context.Context, in *InitAccountRequest, opts ...grpc.CallOption) (*Account, error) {
-	out := new(Account)
-	err := c.cc.Invoke(ctx, "/clmrpc.Trader/InitAccount", in, out, opts...)
+func (c *traderClient) LabelOrder(ctx context.Context, in *LabelOrderRequest, opts ...grpc.CallOption) (*LabelOrderResponse, error) {
+	out := new(LabelOrderResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/LabelOrder", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *traderClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
-	out := new(ListAccountsResponse)
-	err := c.cc.Invoke(ctx, "/clmrpc.Trader/ListAccounts", in, out, opts...)
+func (c *traderClient) ReplaceOrder(ctx context.Context, in *ReplaceOrderRequest, opts ...grpc.CallOption) (*ReplaceOrderResponse, error) {
+	out := new(ReplaceOrderResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/ReplaceOrder", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *traderClient) CloseAccount(ctx context.Context, in *CloseAccountRequest, opts ...grpc.CallOption) (*CloseAccountResponse, error) {
-	out := new(CloseAccountResponse)
-	err := c.cc.Invoke(ctx, "/clmrpc.Trader/CloseAccount", in, out, opts...)
+func (c *traderClient) ApplyOrders(ctx context.Context, in *ApplyOrdersRequest, opts ...grpc.CallOption) (*ApplyOrdersResponse, error) {
+	out := new(ApplyOrdersResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/ApplyOrders", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *traderClient) WithdrawAccount(ctx context.Context, in *WithdrawAccountRequest, opts ...grpc.CallOption) (*WithdrawAccountResponse, error) {
-	out := new(WithdrawAccountResponse)
-	err := c.cc.Invoke(ctx, "/clmrpc.Trader/WithdrawAccount", in, out, opts...)
+func (c *traderClient) PruneFailedOrders(ctx context.Context, in *PruneFailedOrdersRequest, opts ...grpc.CallOption) (*PruneFailedOrdersResponse, error) {
+	out := new(PruneFailedOrdersResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/PruneFailedOrders", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *traderClient) DepositAccount(ctx context.Context, in *DepositAccountRequest, opts ...grpc.CallOption) (*DepositAccountResponse, error) {
-	out := new(DepositAccountResponse)
-	err := c.cc.Invoke(ctx, "/clmrpc.Trader/DepositAccount", in, out, opts...)
+func (c *traderClient) BackupDB(ctx context.Context, in *BackupDBRequest, opts ...grpc.CallOption) (Trader_BackupDBClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Trader_serviceDesc.Streams[0], "/clmrpc.Trader/BackupDB", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &traderBackupDBClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Trader_BackupDBClient interface {
+	Recv() (*BackupDBResponse, error)
+	grpc.ClientStream
+}
+
+type traderBackupDBClient struct {
+	grpc.ClientStream
+}
+
+func (x *traderBackupDBClient) Recv() (*BackupDBResponse, error) {
+	m := new(BackupDBResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *traderClient) AuctionConnection(ctx context.Context, in *AuctionConnectionRequest, opts ...grpc.CallOption) (*AuctionConnectionResponse, error) {
+	out := new(AuctionConnectionResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/AuctionConnection", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *traderClient) RecoverAccounts(ctx context.Context, in *RecoverAccountsRequest, opts ...grpc.CallOption) (*RecoverAccountsResponse, error) {
-	out := new(RecoverAccountsResponse)
-	err := c.cc.Invoke(ctx, "/clmrpc.Trader/RecoverAccounts", in, out, opts...)
+func (c *traderClient) ListLsatTokens(ctx context.Context, in *ListLsatTokensRequest, opts ...grpc.CallOption) (*ListLsatTokensResponse, error) {
+	out := new(ListLsatTokensResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/ListLsatTokens", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *traderClient) SubmitOrder(ctx context.Context, in *SubmitOrderRequest, opts ...grpc.CallOption) (*SubmitOrderResponse, error) {
-	out := new(SubmitOrderResponse)
-	err := c.cc.Invoke(ctx, "/clmrpc.Trader/SubmitOrder", in, out, opts...)
+func (c *traderClient) GetLsatToken(ctx context.Context, in *GetLsatTokenRequest, opts ...grpc.CallOption) (*LsatToken, error) {
+	out := new(LsatToken)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/GetLsatToken", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *traderClient) ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
-	out := new(ListOrdersResponse)
-	err := c.cc.Invoke(ctx, "/clmrpc.Trader/ListOrders", in, out, opts...)
+func (c *traderClient) RevokeLsatToken(ctx context.Context, in *RevokeLsatTokenRequest, opts ...grpc.CallOption) (*RevokeLsatTokenResponse, error) {
+	out := new(RevokeLsatTokenResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/RevokeLsatToken", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *traderClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
-	out := new(CancelOrderResponse)
-	err := c.cc.Invoke(ctx, "/clmrpc.Trader/CancelOrder", in, out, opts...)
+func (c *traderClient) LsatLedger(ctx context.Context, in *LsatLedgerRequest, opts ...grpc.CallOption) (*LsatLedgerResponse, error) {
+	out := new(LsatLedgerResponse)
+	err := c.cc.Invoke(ctx, "/clmrpc.Trader/LsatLedger", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1487,13 +3830,25 @@ func (c *traderClient) CancelOrder(ctx context.Context, in *CancelOrderRequest,
 type TraderServer interface {
 	InitAccount(context.Context, *InitAccountRequest) (*Account, error)
 	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	LabelAccount(context.Context, *LabelAccountRequest) (*Account, error)
 	CloseAccount(context.Context, *CloseAccountRequest) (*CloseAccountResponse, error)
 	WithdrawAccount(context.Context, *WithdrawAccountRequest) (*WithdrawAccountResponse, error)
 	DepositAccount(context.Context, *DepositAccountRequest) (*DepositAccountResponse, error)
 	RecoverAccounts(context.Context, *RecoverAccountsRequest) (*RecoverAccountsResponse, error)
+	RestoreAccountBackup(context.Context, *RestoreAccountBackupRequest) (*RestoreAccountBackupResponse, error)
 	SubmitOrder(context.Context, *SubmitOrderRequest) (*SubmitOrderResponse, error)
 	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
 	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
+	LabelOrder(context.Context, *LabelOrderRequest) (*LabelOrderResponse, error)
+	ReplaceOrder(context.Context, *ReplaceOrderRequest) (*ReplaceOrderResponse, error)
+	ApplyOrders(context.Context, *ApplyOrdersRequest) (*ApplyOrdersResponse, error)
+	PruneFailedOrders(context.Context, *PruneFailedOrdersRequest) (*PruneFailedOrdersResponse, error)
+	BackupDB(*BackupDBRequest, Trader_BackupDBServer) error
+	AuctionConnection(context.Context, *AuctionConnectionRequest) (*AuctionConnectionResponse, error)
+	ListLsatTokens(context.Context, *ListLsatTokensRequest) (*ListLsatTokensResponse, error)
+	GetLsatToken(context.Context, *GetLsatTokenRequest) (*LsatToken, error)
+	RevokeLsatToken(context.Context, *RevokeLsatTokenRequest) (*RevokeLsatTokenResponse, error)
+	LsatLedger(context.Context, *LsatLedgerRequest) (*LsatLedgerResponse, error)
 }
 
 // UnimplementedTraderServer can be embedded to have forward compatible implementations.
@@ -1506,6 +3861,9 @@ func (*UnimplementedTraderServer) InitAccount(ctx context.Context, req *InitAcco
 func (*UnimplementedTraderServer) ListAccounts(ctx context.Context, req *ListAccountsRequest) (*ListAccountsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
 }
+func (*UnimplementedTraderServer) LabelAccount(ctx context.Context, req *LabelAccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LabelAccount not implemented")
+}
 func (*UnimplementedTraderServer) CloseAccount(ctx context.Context, req *CloseAccountRequest) (*CloseAccountResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CloseAccount not implemented")
 }
@@ -1518,6 +3876,9 @@ func (*UnimplementedTraderServer) DepositAccount(ctx context.Context, req *Depos
 func (*UnimplementedTraderServer) RecoverAccounts(ctx context.Context, req *RecoverAccountsRequest) (*RecoverAccountsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RecoverAccounts not implemented")
 }
+func (*UnimplementedTraderServer) RestoreAccountBackup(ctx context.Context, req *RestoreAccountBackupRequest) (*RestoreAccountBackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreAccountBackup not implemented")
+}
 func (*UnimplementedTraderServer) SubmitOrder(ctx context.Context, req *SubmitOrderRequest) (*SubmitOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SubmitOrder not implemented")
 }
@@ -1527,6 +3888,36 @@ func (*UnimplementedTraderServer) ListOrders(ctx context.Context, req *ListOrder
 func (*UnimplementedTraderServer) CancelOrder(ctx context.Context, req *CancelOrderRequest) (*CancelOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
 }
+func (*UnimplementedTraderServer) LabelOrder(ctx context.Context, req *LabelOrderRequest) (*LabelOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LabelOrder not implemented")
+}
+func (*UnimplementedTraderServer) ReplaceOrder(ctx context.Context, req *ReplaceOrderRequest) (*ReplaceOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplaceOrder not implemented")
+}
+func (*UnimplementedTraderServer) ApplyOrders(ctx context.Context, req *ApplyOrdersRequest) (*ApplyOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyOrders not implemented")
+}
+func (*UnimplementedTraderServer) PruneFailedOrders(ctx context.Context, req *PruneFailedOrdersRequest) (*PruneFailedOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneFailedOrders not implemented")
+}
+func (*UnimplementedTraderServer) BackupDB(req *BackupDBRequest, srv Trader_BackupDBServer) error {
+	return status.Errorf(codes.Unimplemented, "method BackupDB not implemented")
+}
+func (*UnimplementedTraderServer) AuctionConnection(ctx context.Context, req *AuctionConnectionRequest) (*AuctionConnectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuctionConnection not implemented")
+}
+func (*UnimplementedTraderServer) ListLsatTokens(ctx context.Context, req *ListLsatTokensRequest) (*ListLsatTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLsatTokens not implemented")
+}
+func (*UnimplementedTraderServer) GetLsatToken(ctx context.Context, req *GetLsatTokenRequest) (*LsatToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLsatToken not implemented")
+}
+func (*UnimplementedTraderServer) RevokeLsatToken(ctx context.Context, req *RevokeLsatTokenRequest) (*RevokeLsatTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeLsatToken not implemented")
+}
+func (*UnimplementedTraderServer) LsatLedger(ctx context.Context, req *LsatLedgerRequest) (*LsatLedgerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LsatLedger not implemented")
+}
 
 func RegisterTraderServer(s *grpc.Server, srv TraderServer) {
 	s.RegisterService(&_Trader_serviceDesc, srv)
@@ -1568,6 +3959,24 @@ func _Trader_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Trader_LabelAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).LabelAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clmrpc.Trader/LabelAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).LabelAccount(ctx, req.(*LabelAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Trader_CloseAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CloseAccountRequest)
 	if err := dec(in); err != nil {
@@ -1640,6 +4049,24 @@ func _Trader_RecoverAccounts_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Trader_RestoreAccountBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreAccountBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).RestoreAccountBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clmrpc.Trader/RestoreAccountBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).RestoreAccountBackup(ctx, req.(*RestoreAccountBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Trader_SubmitOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SubmitOrderRequest)
 	if err := dec(in); err != nil {
@@ -1694,6 +4121,189 @@ func _Trader_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Trader_LabelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).LabelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clmrpc.Trader/LabelOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).LabelOrder(ctx, req.(*LabelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_ReplaceOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).ReplaceOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clmrpc.Trader/ReplaceOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).ReplaceOrder(ctx, req.(*ReplaceOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_ApplyOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).ApplyOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clmrpc.Trader/ApplyOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).ApplyOrders(ctx, req.(*ApplyOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_PruneFailedOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneFailedOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).PruneFailedOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clmrpc.Trader/PruneFailedOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).PruneFailedOrders(ctx, req.(*PruneFailedOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_BackupDB_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BackupDBRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TraderServer).BackupDB(m, &traderBackupDBServer{stream})
+}
+
+type Trader_BackupDBServer interface {
+	Send(*BackupDBResponse) error
+	grpc.ServerStream
+}
+
+type traderBackupDBServer struct {
+	grpc.ServerStream
+}
+
+func (x *traderBackupDBServer) Send(m *BackupDBResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Trader_AuctionConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuctionConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).AuctionConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clmrpc.Trader/AuctionConnection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).AuctionConnection(ctx, req.(*AuctionConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_ListLsatTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLsatTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).ListLsatTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clmrpc.Trader/ListLsatTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).ListLsatTokens(ctx, req.(*ListLsatTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_GetLsatToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLsatTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).GetLsatToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clmrpc.Trader/GetLsatToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).GetLsatToken(ctx, req.(*GetLsatTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_RevokeLsatToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeLsatTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).RevokeLsatToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clmrpc.Trader/RevokeLsatToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).RevokeLsatToken(ctx, req.(*RevokeLsatTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_LsatLedger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LsatLedgerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).LsatLedger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clmrpc.Trader/LsatLedger",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).LsatLedger(ctx, req.(*LsatLedgerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Trader_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "clmrpc.Trader",
 	HandlerType: (*TraderServer)(nil),
@@ -1706,6 +4316,10 @@ var _Trader_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListAccounts",
 			Handler:    _Trader_ListAccounts_Handler,
 		},
+		{
+			MethodName: "LabelAccount",
+			Handler:    _Trader_LabelAccount_Handler,
+		},
 		{
 			MethodName: "CloseAccount",
 			Handler:    _Trader_CloseAccount_Handler,
@@ -1722,6 +4336,10 @@ var _Trader_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RecoverAccounts",
 			Handler:    _Trader_RecoverAccounts_Handler,
 		},
+		{
+			MethodName: "RestoreAccountBackup",
+			Handler:    _Trader_RestoreAccountBackup_Handler,
+		},
 		{
 			MethodName: "SubmitOrder",
 			Handler:    _Trader_SubmitOrder_Handler,
@@ -1734,7 +4352,49 @@ var _Trader_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CancelOrder",
 			Handler:    _Trader_CancelOrder_Handler,
 		},
+		{
+			MethodName: "LabelOrder",
+			Handler:    _Trader_LabelOrder_Handler,
+		},
+		{
+			MethodName: "ReplaceOrder",
+			Handler:    _Trader_ReplaceOrder_Handler,
+		},
+		{
+			MethodName: "ApplyOrders",
+			Handler:    _Trader_ApplyOrders_Handler,
+		},
+		{
+			MethodName: "PruneFailedOrders",
+			Handler:    _Trader_PruneFailedOrders_Handler,
+		},
+		{
+			MethodName: "AuctionConnection",
+			Handler:    _Trader_AuctionConnection_Handler,
+		},
+		{
+			MethodName: "ListLsatTokens",
+			Handler:    _Trader_ListLsatTokens_Handler,
+		},
+		{
+			MethodName: "GetLsatToken",
+			Handler:    _Trader_GetLsatToken_Handler,
+		},
+		{
+			MethodName: "RevokeLsatToken",
+			Handler:    _Trader_RevokeLsatToken_Handler,
+		},
+		{
+			MethodName: "LsatLedger",
+			Handler:    _Trader_LsatLedger_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BackupDB",
+			Handler:       _Trader_BackupDB_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "trader.proto",
 }
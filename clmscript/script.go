@@ -125,6 +125,81 @@ func AccountScript(expiry uint32, traderKey, auctioneerKey,
 	return input.WitnessScriptHash(witnessScript)
 }
 
+// AccountScriptTemplate computes the output scripts of an account for different
+// expiries. All key tweaks only depend on the keys and the secret, so they are
+// only computed once. This makes it feasible to find the expiry of an account
+// output by trying all possible values.
+type AccountScriptTemplate struct {
+	// prefix is the part of the witness script in front of the expiry.
+	prefix []byte
+}
+
+// NewAccountScriptTemplate creates a new template for the account scripts of
+// the given keys and secret.
+func NewAccountScriptTemplate(traderKey, auctioneerKey,
+	batchKey *btcec.PublicKey, secret [32]byte) *AccountScriptTemplate {
+
+	traderKeyTweak := TraderKeyTweak(batchKey, secret, traderKey)
+	tweakedTraderKey := input.TweakPubKeyWithTweak(traderKey, traderKeyTweak)
+	tweakedAuctioneerKey := input.TweakPubKey(auctioneerKey, tweakedTraderKey)
+
+	prefix := make([]byte, 0, AccountWitnessScriptSize)
+	prefix = append(prefix, txscript.OP_DATA_33)
+	prefix = append(prefix, tweakedTraderKey.SerializeCompressed()...)
+	prefix = append(prefix, txscript.OP_CHECKSIGVERIFY, txscript.OP_DATA_33)
+	prefix = append(prefix, tweakedAuctioneerKey.SerializeCompressed()...)
+	prefix = append(
+		prefix, txscript.OP_CHECKSIG, txscript.OP_IFDUP,
+		txscript.OP_NOTIF,
+	)
+
+	return &AccountScriptTemplate{
+		prefix: prefix,
+	}
+}
+
+// Script returns the account output script for the given expiry. The result is
+// identical to calling AccountScript with the same parameters.
+func (t *AccountScriptTemplate) Script(expiry uint32) []byte {
+	witnessScript := make([]byte, 0, len(t.prefix)+8)
+	witnessScript = append(witnessScript, t.prefix...)
+	witnessScript = appendScriptNum(witnessScript, expiry)
+	witnessScript = append(
+		witnessScript, txscript.OP_CHECKLOCKTIMEVERIFY,
+		txscript.OP_ENDIF,
+	)
+
+	scriptHash := sha256.Sum256(witnessScript)
+	pkScript := make([]byte, 0, 34)
+	pkScript = append(pkScript, txscript.OP_0, txscript.OP_DATA_32)
+	return append(pkScript, scriptHash[:]...)
+}
+
+// appendScriptNum appends the minimal push of the given number to the script,
+// the same way txscript.ScriptBuilder.AddInt64 does.
+func appendScriptNum(script []byte, num uint32) []byte {
+	switch {
+	case num == 0:
+		return append(script, txscript.OP_0)
+
+	case num <= 16:
+		return append(script, byte(txscript.OP_1-1+num))
+	}
+
+	// Numbers are encoded in little endian with the most significant bit
+	// of the last byte being the sign bit.
+	var numBytes []byte
+	for n := num; n > 0; n >>= 8 {
+		numBytes = append(numBytes, byte(n))
+	}
+	if numBytes[len(numBytes)-1]&0x80 != 0 {
+		numBytes = append(numBytes, 0x00)
+	}
+
+	script = append(script, byte(len(numBytes)))
+	return append(script, numBytes...)
+}
+
 // SpendExpiry returns the witness required to spend an account through the
 // expiration script path.
 func SpendExpiry(witnessScript, traderSig []byte) wire.TxWitness {
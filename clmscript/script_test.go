@@ -0,0 +1,58 @@
+package clmscript
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+var (
+	testRawTraderKey, _ = hex.DecodeString("036b51e0cc2d9e5988ee4967e0ba67ef3727bb633fea21a0af58e0c9395446ba09")
+	testTraderKey, _    = btcec.ParsePubKey(testRawTraderKey, btcec.S256())
+
+	testRawAuctioneerKey, _ = hex.DecodeString("02187d1a0e30f4e5016fc1137363ee9e7ed5dde1e6c50f367422336df7a108b716")
+	testAuctioneerKey, _    = btcec.ParsePubKey(testRawAuctioneerKey, btcec.S256())
+
+	testRawBatchKey, _ = hex.DecodeString("02824d0cbac65e01712124c50ff2cc74ce22851d7b444c1bf2ae66afefb8eaf27f")
+	testBatchKey, _    = btcec.ParsePubKey(testRawBatchKey, btcec.S256())
+
+	testSecret = [32]byte{0x73, 0x65, 0x63, 0x72, 0x65, 0x74}
+)
+
+// TestAccountScriptTemplate makes sure the account script template produces
+// the same output scripts as AccountScript, especially around the boundaries
+// of the minimal number encoding.
+func TestAccountScriptTemplate(t *testing.T) {
+	t.Parallel()
+
+	template := NewAccountScriptTemplate(
+		testTraderKey, testAuctioneerKey, testBatchKey, testSecret,
+	)
+
+	expiries := []uint32{
+		0, 1, 16, 17, 127, 128, 255, 256, 32767, 32768, 65535, 65536,
+		8388607, 8388608, 1<<24 - 1, 1 << 24, 1<<31 - 1, 1 << 31,
+		1<<32 - 1,
+	}
+	for expiry := uint32(600000); expiry < 600100; expiry++ {
+		expiries = append(expiries, expiry)
+	}
+
+	for _, expiry := range expiries {
+		expected, err := AccountScript(
+			expiry, testTraderKey, testAuctioneerKey, testBatchKey,
+			testSecret,
+		)
+		if err != nil {
+			t.Fatalf("unable to create account script: %v", err)
+		}
+
+		script := template.Script(expiry)
+		if !bytes.Equal(script, expected) {
+			t.Fatalf("script mismatch for expiry %d, expected %x, "+
+				"got %x", expiry, expected, script)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btclog"
+	"github.com/btcsuite/btcutil"
+	"github.com/jessevdk/go-flags"
+	"github.com/lightninglabs/llm"
+	"github.com/lightninglabs/llm/fakeauctioneer"
+	"github.com/lightninglabs/loop/lndclient"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/lightningnetwork/lnd/signal"
+)
+
+const (
+	defaultRPCListen     = "localhost:12009"
+	defaultNetwork       = "regtest"
+	defaultLndHost       = "localhost:10009"
+	defaultBatchInterval = 30 * time.Second
+)
+
+// config contains all options of the fake auctioneer.
+type config struct {
+	RPCListen string `long:"rpclisten" description:"Address to listen on for gRPC clients"`
+	Network   string `long:"network" description:"network to run on" choice:"regtest" choice:"testnet" choice:"mainnet" choice:"simnet"`
+
+	AuctioneerKey string `long:"auctioneerkey" description:"Hex encoded private key of the auctioneer, a random key is used if empty"`
+
+	BaseFee       int64         `long:"basefee" description:"The base fee in satoshis charged per matched order"`
+	FeeRate       int64         `long:"feerate" description:"The execution fee rate in parts per million"`
+	BatchFeeRate  int64         `long:"batchfeerate" description:"The fee rate of the batch transaction in sat/kw"`
+	BatchInterval time.Duration `long:"batchinterval" description:"The interval in which batches are attempted"`
+
+	NoLnd bool `long:"nolnd" description:"Don't connect to lnd, accounts never expire and batch transactions are not published"`
+
+	DebugLevel string `long:"debuglevel" description:"Logging level for all subsystems" choice:"trace" choice:"debug" choice:"info" choice:"warn" choice:"error" choice:"critical"`
+
+	Lnd *llm.LndConfig `group:"lnd" namespace:"lnd"`
+}
+
+func main() {
+	if err := start(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func start() error {
+	cfg := config{
+		RPCListen:     defaultRPCListen,
+		Network:       defaultNetwork,
+		BaseFee:       int64(fakeauctioneer.DefaultBaseFee),
+		FeeRate:       int64(fakeauctioneer.DefaultFeeRate),
+		BatchFeeRate:  int64(fakeauctioneer.DefaultBatchFeeRate),
+		BatchInterval: defaultBatchInterval,
+		DebugLevel:    "info",
+		Lnd: &llm.LndConfig{
+			Host: defaultLndHost,
+		},
+	}
+	if _, err := flags.Parse(&cfg); err != nil {
+		if e, ok := err.(*flags.Error); ok && e.Type == flags.ErrHelp {
+			return nil
+		}
+		return err
+	}
+
+	logger := btclog.NewBackend(os.Stdout).Logger(fakeauctioneer.Subsystem)
+	level, _ := btclog.LevelFromString(cfg.DebugLevel)
+	logger.SetLevel(level)
+	fakeauctioneer.UseLogger(logger)
+
+	serverCfg := &fakeauctioneer.Config{
+		BaseFee:      btcutil.Amount(cfg.BaseFee),
+		FeeRate:      btcutil.Amount(cfg.FeeRate),
+		BatchFeeRate: chainfee.SatPerKWeight(cfg.BatchFeeRate),
+	}
+	if cfg.AuctioneerKey != "" {
+		rawKey, err := hex.DecodeString(cfg.AuctioneerKey)
+		if err != nil {
+			return fmt.Errorf("invalid auctioneer key: %v", err)
+		}
+		serverCfg.AuctioneerKey, _ = btcec.PrivKeyFromBytes(
+			btcec.S256(), rawKey,
+		)
+	}
+
+	// Use lnd to learn about the current block height and to publish the
+	// batch transactions.
+	if !cfg.NoLnd {
+		lnd, err := lndclient.NewLndServices(
+			&lndclient.LndServicesConfig{
+				LndAddress:  cfg.Lnd.Host,
+				Network:     cfg.Network,
+				MacaroonDir: cfg.Lnd.MacaroonDir,
+				TLSPath:     cfg.Lnd.TLSPath,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("unable to connect to lnd: %v", err)
+		}
+		defer lnd.Close()
+
+		serverCfg.BestHeight = func() (uint32, error) {
+			info, err := lnd.Client.GetInfo(context.Background())
+			if err != nil {
+				return 0, err
+			}
+			return info.BlockHeight, nil
+		}
+		serverCfg.PublishTransaction = func(tx *wire.MsgTx) error {
+			return lnd.WalletKit.PublishTransaction(
+				context.Background(), tx,
+			)
+		}
+	}
+
+	server, err := fakeauctioneer.New(serverCfg)
+	if err != nil {
+		return err
+	}
+	lis, err := net.Listen("tcp", cfg.RPCListen)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %v: %v", cfg.RPCListen,
+			err)
+	}
+
+	signal.Intercept()
+	if err := server.Start(lis); err != nil {
+		return err
+	}
+	defer server.Stop()
+
+	ticker := time.NewTicker(cfg.BatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			tx, err := server.RunBatch(context.Background())
+			switch {
+			case err == fakeauctioneer.ErrNoMatch:
+				logger.Debugf("No orders matched")
+
+			case err != nil:
+				logger.Errorf("Unable to run batch: %v", err)
+
+			default:
+				logger.Infof("Executed batch in tx %v",
+					tx.TxHash())
+			}
+
+		case <-signal.ShutdownChannel():
+			return nil
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/lightninglabs/llm/clmrpc"
@@ -19,21 +20,25 @@ var accountsCommands = []cli.Command{
 		Subcommands: []cli.Command{
 			newAccountCommand,
 			listAccountsCommand,
+			labelAccountCommand,
 			depositAccountCommand,
 			withdrawAccountCommand,
 			closeAccountCommand,
 			recoverAccountsCommand,
+			restoreAccountBackupCommand,
 		},
 	},
 }
 
 type Account struct {
-	TraderKey        string `json:"trader_key"`
-	OutPoint         string `json:"outpoint"`
-	Value            uint64 `json:"value"`
-	ExpirationHeight uint32 `json:"expiration_height"`
-	State            string `json:"state"`
-	CloseTxid        string `json:"close_txid"`
+	TraderKey        string            `json:"trader_key"`
+	OutPoint         string            `json:"outpoint"`
+	Value            uint64            `json:"value"`
+	ExpirationHeight uint32            `json:"expiration_height"`
+	State            string            `json:"state"`
+	CloseTxid        string            `json:"close_txid"`
+	Label            string            `json:"label,omitempty"`
+	Tags             map[string]string `json:"tags,omitempty"`
 }
 
 // NewAccountFromProto creates a display Account from its proto.
@@ -49,6 +54,8 @@ func NewAccountFromProto(a *clmrpc.Account) *Account {
 		ExpirationHeight: a.ExpirationHeight,
 		State:            a.State.String(),
 		CloseTxid:        closeTxHash.String(),
+		Label:            a.Label,
+		Tags:             a.Tags,
 	}
 }
 
@@ -70,6 +77,17 @@ var newAccountCommand = cli.Command{
 			Usage: "the block height at which this account should " +
 				"expire at",
 		},
+		cli.StringFlag{
+			Name: "label",
+			Usage: "an optional name for the account that is " +
+				"only stored locally",
+		},
+		cli.StringSliceFlag{
+			Name: "tag",
+			Usage: "an optional tag in the format key=value that " +
+				"is only stored locally, can be specified " +
+				"multiple times",
+		},
 	},
 	Action: newAccount,
 }
@@ -85,6 +103,10 @@ func newAccount(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	tags, err := parseTags(ctx.StringSlice("tag"))
+	if err != nil {
+		return err
+	}
 
 	client, cleanup, err := getClient(ctx)
 	if err != nil {
@@ -96,6 +118,8 @@ func newAccount(ctx *cli.Context) error {
 		&clmrpc.InitAccountRequest{
 			AccountValue:  amt,
 			AccountExpiry: uint32(expiry),
+			Label:         ctx.String("label"),
+			Tags:          tags,
 		},
 	)
 	if err != nil {
@@ -112,10 +136,28 @@ var listAccountsCommand = cli.Command{
 	ShortName:   "l",
 	Usage:       "list all existing accounts",
 	Description: `List all existing accounts.`,
-	Action:      listAccounts,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "only list accounts with the given label",
+		},
+		cli.StringSliceFlag{
+			Name: "tag",
+			Usage: "only list accounts with the given tag in the " +
+				"format key=value, a tag without value " +
+				"matches any value, can be specified " +
+				"multiple times",
+		},
+	},
+	Action: listAccounts,
 }
 
 func listAccounts(ctx *cli.Context) error {
+	tags, err := parseTags(ctx.StringSlice("tag"))
+	if err != nil {
+		return err
+	}
+
 	client, cleanup, err := getClient(ctx)
 	if err != nil {
 		return err
@@ -123,7 +165,10 @@ func listAccounts(ctx *cli.Context) error {
 	defer cleanup()
 
 	resp, err := client.ListAccounts(
-		context.Background(), &clmrpc.ListAccountsRequest{},
+		context.Background(), &clmrpc.ListAccountsRequest{
+			Label: ctx.String("label"),
+			Tags:  tags,
+		},
 	)
 	if err != nil {
 		return err
@@ -144,6 +189,77 @@ func listAccounts(ctx *cli.Context) error {
 	return nil
 }
 
+var labelAccountCommand = cli.Command{
+	Name:      "label",
+	Usage:     "change the label and tags of an account",
+	ArgsUsage: "trader_key",
+	Description: `
+	Change the label of an existing account and add, change or remove its
+	tags. The label and tags are only stored locally.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "trader_key",
+			Usage: "the hex-encoded trader key of the account to " +
+				"label",
+		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "the new label of the account",
+		},
+		cli.BoolFlag{
+			Name:  "clear_label",
+			Usage: "remove the label of the account",
+		},
+		cli.StringSliceFlag{
+			Name: "tag",
+			Usage: "a tag in the format key=value to add or " +
+				"change, can be specified multiple times",
+		},
+		cli.StringSliceFlag{
+			Name: "remove_tag",
+			Usage: "the key of a tag to remove, can be specified " +
+				"multiple times",
+		},
+	},
+	Action: labelAccount,
+}
+
+func labelAccount(ctx *cli.Context) error {
+	cmd := "label"
+	traderKey, err := parseHexStr(ctx, 0, "trader_key", cmd)
+	if err != nil {
+		return err
+	}
+	tags, err := parseTags(ctx.StringSlice("tag"))
+	if err != nil {
+		return err
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.LabelAccount(
+		context.Background(), &clmrpc.LabelAccountRequest{
+			TraderKey:  traderKey,
+			Label:      ctx.String("label"),
+			ClearLabel: ctx.Bool("clear_label"),
+			SetTags:    tags,
+			RemoveTags: ctx.StringSlice("remove_tag"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printJSON(NewAccountFromProto(resp))
+
+	return nil
+}
+
 var depositAccountCommand = cli.Command{
 	Name:      "deposit",
 	ShortName: "d",
@@ -375,7 +491,63 @@ var recoverAccountsCommand = cli.Command{
 	if there already are open accounts in the trader's database.
 	All open or pending orders of any recovered account will be canceled on
 	the auctioneer's side and won't be restored in the trader's database.
+
+	Account keys are checked one after the other, starting at --start_index,
+	until --gap successive keys are unknown to the auctioneer. If the
+	recovery is interrupted, it can be continued with the --resume flag.
+
+	If the auctioneer isn't reachable, the --chain_scan flag can be used to
+	find the accounts by scanning the chain instead. This requires the
+	auctioneer's static key and initial batch key. Accounts recovered this
+	way don't have any orders and can be closed through their expiration
+	path without the auctioneer.
 	`,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name:  "start_index",
+			Usage: "the index of the first account key to check",
+		},
+		cli.Uint64Flag{
+			Name: "gap",
+			Usage: "the number of successive unused account keys " +
+				"after which the recovery stops",
+		},
+		cli.BoolFlag{
+			Name: "resume",
+			Usage: "resume a previously interrupted recovery " +
+				"from its stored progress",
+		},
+		cli.BoolFlag{
+			Name: "chain_scan",
+			Usage: "recover the accounts by scanning the chain " +
+				"instead of asking the auctioneer",
+		},
+		cli.StringFlag{
+			Name: "auctioneer_key",
+			Usage: "the auctioneer's static key, required for " +
+				"a chain scan",
+		},
+		cli.StringFlag{
+			Name: "initial_batch_key",
+			Usage: "the auctioneer's initial batch key, " +
+				"required for a chain scan",
+		},
+		cli.Uint64Flag{
+			Name: "start_height",
+			Usage: "the earliest height any account could " +
+				"have been funded at, defaults to one year " +
+				"before the current height",
+		},
+		cli.Uint64Flag{
+			Name:  "num_keys",
+			Usage: "the number of account keys to check",
+		},
+		cli.Uint64Flag{
+			Name: "batch_key_window",
+			Usage: "the number of batch keys to try for each " +
+				"account output",
+		},
+	},
 	Action: recoverAccounts,
 }
 
@@ -386,8 +558,86 @@ func recoverAccounts(ctx *cli.Context) error {
 	}
 	defer cleanup()
 
-	resp, err := client.RecoverAccounts(
-		context.Background(), &clmrpc.RecoverAccountsRequest{},
+	req := &clmrpc.RecoverAccountsRequest{
+		ChainScan:      ctx.Bool("chain_scan"),
+		StartIndex:     uint32(ctx.Uint64("start_index")),
+		Gap:            uint32(ctx.Uint64("gap")),
+		Resume:         ctx.Bool("resume"),
+		StartHeight:    uint32(ctx.Uint64("start_height")),
+		NumKeys:        uint32(ctx.Uint64("num_keys")),
+		BatchKeyWindow: uint32(ctx.Uint64("batch_key_window")),
+	}
+	if req.ChainScan {
+		req.AuctioneerKey, err = hex.DecodeString(
+			ctx.String("auctioneer_key"),
+		)
+		if err != nil {
+			return fmt.Errorf("invalid auctioneer key: %v", err)
+		}
+		req.InitialBatchKey, err = hex.DecodeString(
+			ctx.String("initial_batch_key"),
+		)
+		if err != nil {
+			return fmt.Errorf("invalid initial batch key: %v", err)
+		}
+	}
+
+	resp, err := client.RecoverAccounts(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var restoreAccountBackupCommand = cli.Command{
+	Name:      "restorebackup",
+	Usage:     "restore accounts from the static account backup file",
+	ArgsUsage: "backup_file",
+	Description: `
+	In case the data directory of the trader was corrupted or lost, this
+	command can be used to restore all accounts from the encrypted static
+	account backup file (accounts.backup in the network directory by
+	default) without the help of the auction server. The connected lnd
+	node must be running with the same seed as when the accounts were
+	created.
+
+	The backup doesn't contain the latest state of the accounts, so the
+	restored accounts are watched on-chain until they are spent. Accounts
+	that are past their expiry can be closed through the expiration path.
+	Accounts that are already known to the trader database are skipped.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "backup_file",
+			Usage: "the static account backup file to restore",
+		},
+	},
+	Action: restoreAccountBackup,
+}
+
+func restoreAccountBackup(ctx *cli.Context) error {
+	backupFile, err := parseStr(ctx, 0, "backup_file", "restorebackup")
+	if err != nil {
+		return err
+	}
+	backup, err := ioutil.ReadFile(backupFile)
+	if err != nil {
+		return fmt.Errorf("unable to read backup file: %v", err)
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.RestoreAccountBackup(
+		context.Background(), &clmrpc.RestoreAccountBackupRequest{
+			Backup: backup,
+		},
 	)
 	if err != nil {
 		return err
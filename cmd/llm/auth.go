@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/lightninglabs/llm/clmrpc"
+	"github.com/urfave/cli"
+)
+
+var authCommands = []cli.Command{
+	{
+		Name:     "auth",
+		Usage:    "Manage the LSAT tokens used to authenticate.",
+		Category: "Auth",
+		Subcommands: []cli.Command{
+			{
+				Name:  "tokens",
+				Usage: "Interact with stored LSAT tokens.",
+				Subcommands: []cli.Command{
+					listTokensCommand,
+					showTokenCommand,
+					revokeTokenCommand,
+				},
+			},
+			ledgerCommand,
+		},
+	},
+}
+
+type LsatToken struct {
+	BaseMacaroon       string `json:"base_macaroon,omitempty"`
+	PaymentHash        string `json:"payment_hash"`
+	PaymentPreimage    string `json:"payment_preimage,omitempty"`
+	AmountPaidMsat     uint64 `json:"amount_paid_msat"`
+	RoutingFeePaidMsat uint64 `json:"routing_fee_paid_msat"`
+	TimeCreated        string `json:"time_created"`
+	Valid              bool   `json:"valid"`
+	Pending            bool   `json:"pending"`
+	Revoked            bool   `json:"revoked"`
+	StorageName        string `json:"storage_name"`
+}
+
+// NewLsatTokenFromProto creates a display LsatToken from its proto. The base
+// macaroon and preimage are only included if details are requested.
+func NewLsatTokenFromProto(t *clmrpc.LsatToken, details bool) *LsatToken {
+	token := &LsatToken{
+		PaymentHash:        hex.EncodeToString(t.PaymentHash),
+		AmountPaidMsat:     t.AmountPaidMsat,
+		RoutingFeePaidMsat: t.RoutingFeePaidMsat,
+		TimeCreated:        formatUnix(t.TimeCreated),
+		Valid:              t.Valid,
+		Pending:            t.Pending,
+		Revoked:            t.Revoked,
+		StorageName:        t.StorageName,
+	}
+	if details {
+		token.BaseMacaroon = hex.EncodeToString(t.BaseMacaroon)
+		token.PaymentPreimage = hex.EncodeToString(t.PaymentPreimage)
+	}
+	return token
+}
+
+var listTokensCommand = cli.Command{
+	Name:        "list",
+	ShortName:   "l",
+	Usage:       "list all LSAT tokens",
+	Description: `List all stored LSAT tokens, including revoked ones.`,
+	Action:      listTokens,
+}
+
+func listTokens(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.ListLsatTokens(
+		context.Background(), &clmrpc.ListLsatTokensRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	var listTokensResp = struct {
+		Tokens []*LsatToken `json:"tokens"`
+	}{
+		Tokens: make([]*LsatToken, 0, len(resp.Tokens)),
+	}
+	for _, protoToken := range resp.Tokens {
+		t := NewLsatTokenFromProto(protoToken, false)
+		listTokensResp.Tokens = append(listTokensResp.Tokens, t)
+	}
+
+	printJSON(listTokensResp)
+
+	return nil
+}
+
+var showTokenCommand = cli.Command{
+	Name:      "show",
+	ShortName: "s",
+	Usage:     "show the details of an LSAT token",
+	Description: `
+	Show all details of an LSAT token, including its base macaroon and the
+	preimage of the payment.`,
+	ArgsUsage: "payment_hash",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "payment_hash",
+			Usage: "the payment hash of the token",
+		},
+	},
+	Action: showToken,
+}
+
+func showToken(ctx *cli.Context) error {
+	cmd := "show"
+	paymentHash, err := parseHexStr(ctx, 0, "payment_hash", cmd)
+	if err != nil {
+		return err
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.GetLsatToken(
+		context.Background(), &clmrpc.GetLsatTokenRequest{
+			PaymentHash: paymentHash,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printJSON(NewLsatTokenFromProto(resp, true))
+
+	return nil
+}
+
+var revokeTokenCommand = cli.Command{
+	Name:      "revoke",
+	ShortName: "r",
+	Usage:     "revoke an LSAT token",
+	Description: `
+	Revoke an LSAT token so it's no longer used to authenticate with the
+	auction server. A new token is paid for with the next request to the
+	auction server. The revoked token is still listed.`,
+	ArgsUsage: "payment_hash",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "payment_hash",
+			Usage: "the payment hash of the token",
+		},
+	},
+	Action: revokeToken,
+}
+
+func revokeToken(ctx *cli.Context) error {
+	cmd := "revoke"
+	paymentHash, err := parseHexStr(ctx, 0, "payment_hash", cmd)
+	if err != nil {
+		return err
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.RevokeLsatToken(
+		context.Background(), &clmrpc.RevokeLsatTokenRequest{
+			PaymentHash: paymentHash,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+type LsatLedgerEntry struct {
+	PaymentHash        string `json:"payment_hash"`
+	AmountPaidMsat     uint64 `json:"amount_paid_msat"`
+	RoutingFeePaidMsat uint64 `json:"routing_fee_paid_msat"`
+	TimePaid           string `json:"time_paid"`
+}
+
+var ledgerCommand = cli.Command{
+	Name:  "ledger",
+	Usage: "show all LSAT payments",
+	Description: `
+	Show all payments made for LSAT tokens and the total amount spent on
+	them.`,
+	Action: lsatLedger,
+}
+
+func lsatLedger(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.LsatLedger(
+		context.Background(), &clmrpc.LsatLedgerRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]*LsatLedgerEntry, 0, len(resp.Entries))
+	for _, entry := range resp.Entries {
+		paymentHash := hex.EncodeToString(entry.PaymentHash)
+		entries = append(entries, &LsatLedgerEntry{
+			PaymentHash:        paymentHash,
+			AmountPaidMsat:     entry.AmountPaidMsat,
+			RoutingFeePaidMsat: entry.RoutingFeePaidMsat,
+			TimePaid:           formatUnix(entry.TimePaid),
+		})
+	}
+
+	var ledgerResp = struct {
+		Entries                 []*LsatLedgerEntry `json:"entries"`
+		TotalAmountPaidMsat     uint64             `json:"total_amount_paid_msat"`
+		TotalRoutingFeePaidMsat uint64             `json:"total_routing_fee_paid_msat"`
+	}{
+		Entries:                 entries,
+		TotalAmountPaidMsat:     resp.TotalAmountPaidMsat,
+		TotalRoutingFeePaidMsat: resp.TotalRoutingFeePaidMsat,
+	}
+
+	printJSON(ledgerResp)
+
+	return nil
+}
+
+// formatUnix formats a unix timestamp in seconds as RFC3339 string.
+func formatUnix(timestamp int64) string {
+	return time.Unix(timestamp, 0).Format(time.RFC3339)
+}
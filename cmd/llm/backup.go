@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/lightninglabs/llm/clmrpc"
+	"github.com/urfave/cli"
+)
+
+const (
+	// defaultBackupFile is the file the database snapshot is written to if
+	// no other file is specified.
+	defaultBackupFile = "llm.db.backup"
+)
+
+var backupCommand = cli.Command{
+	Name:      "backup",
+	Usage:     "back up the trader database while llmd is running",
+	ArgsUsage: "output",
+	Description: `
+	Write a consistent snapshot of the trader database to the given file.
+	The snapshot can be restored with the restoredb command of llmd while
+	llmd isn't running.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output",
+			Usage: "the file to write the snapshot to",
+			Value: defaultBackupFile,
+		},
+	},
+	Action: backupDB,
+}
+
+func backupDB(ctx *cli.Context) error {
+	outputFile := ctx.String("output")
+	if ctx.Args().First() != "" {
+		outputFile = ctx.Args().First()
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	stream, err := client.BackupDB(
+		context.Background(), &clmrpc.BackupDBRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so we never leave a truncated
+	// snapshot behind under the final name.
+	tempFile := outputFile + ".tmp"
+	f, err := os.OpenFile(
+		tempFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600,
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tempFile)
+	}()
+
+	var (
+		version uint32
+		written int
+	)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			_ = f.Close()
+			return err
+		}
+
+		if resp.DbVersion != 0 {
+			version = resp.DbVersion
+		}
+		n, err := f.Write(resp.Chunk)
+		if err != nil {
+			_ = f.Close()
+			return err
+		}
+		written += n
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tempFile, outputFile); err != nil {
+		return err
+	}
+
+	printJSON(struct {
+		File      string `json:"file"`
+		Size      int    `json:"size"`
+		DBVersion uint32 `json:"db_version"`
+	}{
+		File:      outputFile,
+		Size:      written,
+		DBVersion: version,
+	})
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+
+	"github.com/lightninglabs/llm/clmrpc"
+	"github.com/urfave/cli"
+)
+
+var connectionCommand = cli.Command{
+	Name:  "connection",
+	Usage: "show the state of the connection to the auction server",
+	Description: `
+	Show the auction server address currently in use, whether the stream
+	to the auction server is open and the recent connection history,
+	including failovers to backup auction servers.
+	`,
+	Action: auctionConnection,
+}
+
+func auctionConnection(ctx *cli.Context) error {
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.AuctionConnection(
+		context.Background(), &clmrpc.AuctionConnectionRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
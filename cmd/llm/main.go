@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/btcsuite/btcutil"
 	"github.com/lightninglabs/llm"
@@ -65,6 +66,9 @@ func main() {
 	}
 	app.Commands = append(app.Commands, accountsCommands...)
 	app.Commands = append(app.Commands, ordersCommands...)
+	app.Commands = append(app.Commands, backupCommand)
+	app.Commands = append(app.Commands, connectionCommand)
+	app.Commands = append(app.Commands, authCommands...)
 
 	err := app.Run(os.Args)
 	if err != nil {
@@ -94,6 +98,29 @@ func parseAmt(text string) (btcutil.Amount, error) {
 	return btcutil.Amount(amtInt64), nil
 }
 
+// parseTags parses a list of tags in the format key=value. A tag without a
+// value is parsed as a key with an empty value.
+func parseTags(rawTags []string) (map[string]string, error) {
+	if len(rawTags) == 0 {
+		return nil, nil
+	}
+
+	tags := make(map[string]string, len(rawTags))
+	for _, rawTag := range rawTags {
+		parts := strings.SplitN(rawTag, "=", 2)
+		if parts[0] == "" {
+			return nil, fmt.Errorf("invalid tag %q, must be in "+
+				"format key=value", rawTag)
+		}
+
+		tags[parts[0]] = ""
+		if len(parts) == 2 {
+			tags[parts[0]] = parts[1]
+		}
+	}
+	return tags, nil
+}
+
 func getClientConn(address string) (*grpc.ClientConn, error) {
 	opts := []grpc.DialOption{
 		grpc.WithInsecure(),
@@ -1,15 +1,22 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/btcsuite/btcutil"
 	"github.com/lightninglabs/llm/clmrpc"
 	"github.com/lightninglabs/llm/order"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -29,6 +36,10 @@ var ordersCommands = []cli.Command{
 		Subcommands: []cli.Command{
 			ordersListCommand,
 			ordersCancelCommand,
+			ordersLabelCommand,
+			ordersAmendCommand,
+			ordersPruneCommand,
+			ordersApplyCommand,
 			{
 				Name:    "submit",
 				Aliases: []string{"s"},
@@ -42,13 +53,69 @@ var ordersCommands = []cli.Command{
 	},
 }
 
+// amtUsage is the description of the format of all order amount flags.
+const amtUsage = "either in satoshis or with one of the suffixes sat, btc " +
+	"or units (e.g. 0.01btc or 10units), one unit is 100k satoshis"
+
+// roundFlag is the flag that allows an order amount that is not a multiple of
+// the supply unit to be rounded before it is sent to the daemon.
+var roundFlag = cli.StringFlag{
+	Name: "round",
+	Usage: "round an amount that is not a multiple of 100k satoshis " +
+		"'up' or 'down' instead of rejecting it",
+}
+
 var sharedFlags = []cli.Flag{
+	roundFlag,
 	cli.Uint64Flag{
 		Name: "funding_fee_rate",
 		Usage: "the fee rate (sat/vByte) to use to publish " +
 			"the funding transaction",
 		Value: uint64(defaultFundingFeeRate),
 	},
+	cli.Uint64Flag{
+		Name: "expiry_height",
+		Usage: "the block height at which the order is canceled " +
+			"automatically",
+	},
+	cli.StringFlag{
+		Name: "expiry_time",
+		Usage: "the time after which the order is canceled " +
+			"automatically, either as RFC3339 timestamp or as " +
+			"duration from now (e.g. 12h)",
+	},
+	cli.Uint64Flag{
+		Name: "expiry_batches",
+		Usage: "the number of batches that can be completed after " +
+			"the order was submitted before the rest of it is " +
+			"canceled automatically",
+	},
+	cli.Uint64Flag{
+		Name: "min_units_match",
+		Usage: "the minimum number of units a single matched order " +
+			"must fill, each unit is 100k satoshis",
+	},
+	cli.BoolFlag{
+		Name: "all_or_none",
+		Usage: "only match the order if it can be filled " +
+			"completely in a single batch",
+	},
+	cli.StringFlag{
+		Name: "label",
+		Usage: "an optional name for the order that is only stored " +
+			"locally",
+	},
+	cli.StringSliceFlag{
+		Name: "tag",
+		Usage: "an optional tag in the format key=value that is only " +
+			"stored locally, can be specified multiple times",
+	},
+	cli.StringFlag{
+		Name: "group",
+		Usage: "the name of an optional one-cancels-other group, " +
+			"as soon as an order of a group is matched all " +
+			"other orders with the same group name are canceled",
+	},
 }
 
 // parseCommonParams tries to read the common order parameters from the command
@@ -63,17 +130,18 @@ func parseCommonParams(ctx *cli.Context) (*clmrpc.Order, error) {
 		params = &clmrpc.Order{}
 	)
 
+	round := ctx.String("round")
 	switch {
 	case ctx.IsSet("amt"):
-		params.Amt = ctx.Uint64("amt")
+		amt, err = parseOrderAmt(ctx.String("amt"), round)
 	case args.Present():
-		amt, err = parseAmt(args.First())
-		params.Amt = uint64(amt)
+		amt, err = parseOrderAmt(args.First(), round)
 		args = args.Tail()
 	}
 	if err != nil {
 		return nil, fmt.Errorf("unable to decode amount: %v", err)
 	}
+	params.Amt = uint64(amt)
 
 	params.TraderKey, err = parseAccountKey(ctx, args)
 	if err != nil {
@@ -83,9 +151,124 @@ func parseCommonParams(ctx *cli.Context) (*clmrpc.Order, error) {
 	params.FundingFeeRate = ctx.Uint64("funding_fee_rate")
 	params.RateFixed = uint32(ctx.Uint64("rate_fixed"))
 
+	params.ExpiryHeight = uint32(ctx.Uint64("expiry_height"))
+	params.ExpiryBatches = uint32(ctx.Uint64("expiry_batches"))
+	if ctx.IsSet("expiry_time") {
+		expiry, err := parseExpiryTime(ctx.String("expiry_time"))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse expiry_time: "+
+				"%v", err)
+		}
+		params.ExpiryTimestamp = expiry.Unix()
+	}
+
+	params.MinUnitsMatch = uint32(ctx.Uint64("min_units_match"))
+	params.AllOrNone = ctx.Bool("all_or_none")
+	params.Label = ctx.String("label")
+	params.Tags, err = parseTags(ctx.StringSlice("tag"))
+	if err != nil {
+		return nil, err
+	}
+	if ctx.IsSet("group") {
+		groupID := sha256.Sum256([]byte(ctx.String("group")))
+		params.GroupId = groupID[:]
+	}
+
 	return params, nil
 }
 
+// orderVersion returns the lowest order version that supports all parameters
+// of the given order.
+func orderVersion(params *clmrpc.Order) uint32 {
+	if params.MinUnitsMatch != 0 || params.AllOrNone {
+		return uint32(order.VersionFillConstraints)
+	}
+	return uint32(order.VersionDefault)
+}
+
+// parseExpiryTime parses an order expiry that is either given as an RFC3339
+// timestamp or as a duration relative to now.
+func parseExpiryTime(expiry string) (time.Time, error) {
+	if d, err := time.ParseDuration(expiry); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("duration must be " +
+				"positive")
+		}
+		return time.Now().Add(d), nil
+	}
+	return time.Parse(time.RFC3339, expiry)
+}
+
+// parseOrderAmt parses an order amount that is either given in satoshis or
+// with one of the suffixes sat, btc or units. If the amount isn't a multiple
+// of the supply unit, it is rounded according to the given rounding mode,
+// which is either "up", "down" or empty to reject the amount.
+func parseOrderAmt(text, round string) (btcutil.Amount, error) {
+	var mode order.RoundingMode
+	switch strings.ToLower(round) {
+	case "":
+		mode = order.RoundingStrict
+	case "up":
+		mode = order.RoundingUp
+	case "down":
+		mode = order.RoundingDown
+	default:
+		return 0, fmt.Errorf("unknown rounding mode %q, must be up "+
+			"or down", round)
+	}
+
+	value := strings.ToLower(strings.TrimSpace(text))
+	amt, err := parseAmtWithSuffix(value)
+	if err != nil {
+		return 0, err
+	}
+
+	units, err := order.NewSupplyFromSatsRounded(amt, mode)
+	if err != nil {
+		return 0, err
+	}
+	return units.ToSatoshis(), nil
+}
+
+// parseAmtWithSuffix parses an amount in satoshis, BTC or supply units,
+// depending on the suffix of the given lower case value.
+func parseAmtWithSuffix(value string) (btcutil.Amount, error) {
+	switch {
+	case strings.HasSuffix(value, "btc"):
+		btc, err := strconv.ParseFloat(
+			strings.TrimSuffix(value, "btc"), 64,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("invalid btc value: %v", err)
+		}
+		return btcutil.NewAmount(btc)
+
+	case strings.HasSuffix(value, "unit"),
+		strings.HasSuffix(value, "units"):
+
+		value = strings.TrimSuffix(value, "s")
+		units, err := strconv.ParseUint(
+			strings.TrimSuffix(value, "unit"), 10, 64,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("invalid units value: %v", err)
+		}
+		if units > uint64(order.NewSupplyFromSats(btcutil.MaxSatoshi)) {
+			return 0, fmt.Errorf("units value %d too large", units)
+		}
+		return order.SupplyUnit(units).ToSatoshis(), nil
+
+	case strings.HasSuffix(value, "sat"),
+		strings.HasSuffix(value, "sats"):
+
+		value = strings.TrimSuffix(value, "s")
+		return parseAmt(strings.TrimSuffix(value, "sat"))
+
+	default:
+		return parseAmt(value)
+	}
+}
+
 // parseAccountKey tries to read the account key parameter from the command
 // line positional arguments and/or flags.
 func parseAccountKey(ctx *cli.Context, args cli.Args) ([]byte, error) {
@@ -123,10 +306,10 @@ var ordersSubmitAskCommand = cli.Command{
 				"in parts per million",
 			Value: defaultAskRate,
 		},
-		cli.Uint64Flag{
+		cli.StringFlag{
 			Name: "amt",
-			Usage: "the amount to offer for channel creation in" +
-				"satoshis",
+			Usage: "the amount to offer for channel creation, " +
+				amtUsage,
 		},
 		cli.StringFlag{
 			Name: "acct_key",
@@ -157,7 +340,7 @@ func ordersSubmitAsk(ctx *cli.Context) error { // nolint: dupl
 	ask := &clmrpc.Ask{
 		Details:           params,
 		MaxDurationBlocks: uint32(ctx.Uint64("max_duration_blocks")),
-		Version:           uint32(order.VersionDefault),
+		Version:           orderVersion(params),
 	}
 
 	client, cleanup, err := getClient(ctx)
@@ -197,10 +380,10 @@ var ordersSubmitBidCommand = cli.Command{
 				"participant for lending the funds out",
 			Value: defaultBidRate,
 		},
-		cli.Uint64Flag{
+		cli.StringFlag{
 			Name: "amt",
-			Usage: "the amount of inbound liquidity in satoshis " +
-				"to request",
+			Usage: "the amount of inbound liquidity to request, " +
+				amtUsage,
 		},
 		cli.StringFlag{
 			Name: "acct_key",
@@ -231,7 +414,7 @@ func ordersSubmitBid(ctx *cli.Context) error { // nolint: dupl
 	bid := &clmrpc.Bid{
 		Details:           params,
 		MinDurationBlocks: uint32(ctx.Uint64("min_duration_blocks")),
-		Version:           uint32(order.VersionDefault),
+		Version:           orderVersion(params),
 	}
 
 	client, cleanup, err := getClient(ctx)
@@ -260,21 +443,113 @@ var ordersListCommand = cli.Command{
 	Aliases: []string{"l"},
 	Usage:   "list all existing orders",
 	Description: `
-	List all orders that are stored in the local order database`,
-	Flags:  []cli.Flag{},
+	List all orders that are stored in the local order database and match
+	the given filters. The state of all orders that can still change is
+	refreshed from the auction server unless --local_only is set.`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "show_failed",
+			Usage: "also list orders that failed to be submitted " +
+				"to the auction server",
+		},
+		cli.StringFlag{
+			Name: "type",
+			Usage: "only list orders of the given type (ask or " +
+				"bid)",
+		},
+		cli.StringSliceFlag{
+			Name: "state",
+			Usage: "only list orders in the given state (e.g. " +
+				"submitted or partially_filled), can be " +
+				"specified multiple times",
+		},
+		cli.StringFlag{
+			Name:  "acct_key",
+			Usage: "only list orders of the given account",
+		},
+		cli.BoolFlag{
+			Name:  "active_only",
+			Usage: "only list orders that can still be matched",
+		},
+		cli.Uint64Flag{
+			Name:  "offset",
+			Usage: "the number of matching orders to skip",
+		},
+		cli.Uint64Flag{
+			Name:  "limit",
+			Usage: "the maximum number of orders to list",
+		},
+		cli.BoolFlag{
+			Name: "local_only",
+			Usage: "don't contact the auction server and show " +
+				"the last known state of each order",
+		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "only list orders with the given label",
+		},
+		cli.StringSliceFlag{
+			Name: "tag",
+			Usage: "only list orders with the given tag in the " +
+				"format key=value, a tag without value " +
+				"matches any value, can be specified " +
+				"multiple times",
+		},
+	},
 	Action: ordersList,
 }
 
 func ordersList(ctx *cli.Context) error {
+	req := &clmrpc.ListOrdersRequest{
+		IncludeFailed: ctx.Bool("show_failed"),
+		ActiveOnly:    ctx.Bool("active_only"),
+		Offset:        uint32(ctx.Uint64("offset")),
+		Limit:         uint32(ctx.Uint64("limit")),
+		LocalOnly:     ctx.Bool("local_only"),
+		Label:         ctx.String("label"),
+	}
+
+	var err error
+	req.Tags, err = parseTags(ctx.StringSlice("tag"))
+	if err != nil {
+		return err
+	}
+
+	switch ctx.String("type") {
+	case "":
+	case "ask":
+		req.TypeFilter = clmrpc.ListOrdersRequest_ASKS_ONLY
+	case "bid":
+		req.TypeFilter = clmrpc.ListOrdersRequest_BIDS_ONLY
+	default:
+		return fmt.Errorf("invalid order type %v, must be ask or bid",
+			ctx.String("type"))
+	}
+
+	for _, state := range ctx.StringSlice("state") {
+		name := "ORDER_" + strings.ToUpper(state)
+		rpcState, ok := clmrpc.OrderState_value[name]
+		if !ok {
+			return fmt.Errorf("invalid order state %v", state)
+		}
+		req.States = append(req.States, clmrpc.OrderState(rpcState))
+	}
+
+	if ctx.IsSet("acct_key") {
+		acctKey, err := parseAccountKey(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("unable to parse acct_key: %v", err)
+		}
+		req.TraderKey = acctKey
+	}
+
 	client, cleanup, err := getClient(ctx)
 	if err != nil {
 		return err
 	}
 	defer cleanup()
 
-	resp, err := client.ListOrders(
-		context.Background(), &clmrpc.ListOrdersRequest{},
-	)
+	resp, err := client.ListOrders(context.Background(), req)
 	if err != nil {
 		return err
 	}
@@ -341,3 +616,534 @@ func ordersCancel(ctx *cli.Context) error { // nolint: dupl
 	printRespJSON(resp)
 	return nil
 }
+
+var ordersLabelCommand = cli.Command{
+	Name:      "label",
+	Usage:     "change the label and tags of an order",
+	ArgsUsage: "order_nonce",
+	Description: `
+	Change the label of an existing order and add, change or remove its
+	tags. The label and tags are only stored locally.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "order_nonce",
+			Usage: "the order nonce of the order to label",
+		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "the new label of the order",
+		},
+		cli.BoolFlag{
+			Name:  "clear_label",
+			Usage: "remove the label of the order",
+		},
+		cli.StringSliceFlag{
+			Name: "tag",
+			Usage: "a tag in the format key=value to add or " +
+				"change, can be specified multiple times",
+		},
+		cli.StringSliceFlag{
+			Name: "remove_tag",
+			Usage: "the key of a tag to remove, can be specified " +
+				"multiple times",
+		},
+	},
+	Action: ordersLabel,
+}
+
+func ordersLabel(ctx *cli.Context) error {
+	// Show help if no arguments or flags are provided.
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		_ = cli.ShowCommandHelp(ctx, "label")
+		return nil
+	}
+
+	var nonceHex string
+	switch {
+	case ctx.IsSet("order_nonce"):
+		nonceHex = ctx.String("order_nonce")
+	case ctx.Args().Present():
+		nonceHex = ctx.Args().First()
+	default:
+		return fmt.Errorf("order_nonce argument missing")
+	}
+	nonce, err := hex.DecodeString(nonceHex)
+	if err != nil {
+		return fmt.Errorf("cannot hex decode order nonce: %v", err)
+	}
+	tags, err := parseTags(ctx.StringSlice("tag"))
+	if err != nil {
+		return err
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.LabelOrder(
+		context.Background(), &clmrpc.LabelOrderRequest{
+			OrderNonce: nonce,
+			Label:      ctx.String("label"),
+			ClearLabel: ctx.Bool("clear_label"),
+			SetTags:    tags,
+			RemoveTags: ctx.StringSlice("remove_tag"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	printRespJSON(resp)
+	return nil
+}
+
+var ordersPruneCommand = cli.Command{
+	Name:  "prune",
+	Usage: "remove orders that failed to be submitted",
+	Description: `
+	Remove orders that failed to be submitted to the auction server from
+	the local order database. Orders that are in any other state are never
+	removed.`,
+	Flags: []cli.Flag{
+		cli.DurationFlag{
+			Name: "older_than",
+			Usage: "only remove orders that failed longer ago " +
+				"than this duration (e.g. 720h), all failed " +
+				"orders are removed if not set",
+		},
+	},
+	Action: ordersPrune,
+}
+
+func ordersPrune(ctx *cli.Context) error {
+	if ctx.Duration("older_than") < 0 {
+		return fmt.Errorf("older_than must not be negative")
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.PruneFailedOrders(
+		context.Background(), &clmrpc.PruneFailedOrdersRequest{
+			OlderThanSeconds: uint64(
+				ctx.Duration("older_than").Seconds(),
+			),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	printRespJSON(resp)
+	return nil
+}
+
+var ordersAmendCommand = cli.Command{
+	Name:    "amend",
+	Aliases: []string{"a"},
+	Usage:   "replace an order with an amended version of it",
+	ArgsUsage: "order_nonce [--rate_fixed=R] [--amt=A] " +
+		"[--duration_blocks=D]",
+	Description: `
+	Replace an active order with a new order that has the same parameters
+	except for the ones given as flags. The old order is canceled before
+	the new one is submitted, so at most one of them can be matched in the
+	next batch. The new order gets a new nonce.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "order_nonce",
+			Usage: "the order nonce of the order to amend",
+		},
+		cli.Uint64Flag{
+			Name:  "rate_fixed",
+			Usage: "the new rate in parts per million",
+		},
+		cli.StringFlag{
+			Name:  "amt",
+			Usage: "the new amount, " + amtUsage,
+		},
+		roundFlag,
+		cli.Uint64Flag{
+			Name: "funding_fee_rate",
+			Usage: "the new fee rate (sat/vByte) to use to " +
+				"publish the funding transaction",
+		},
+		cli.Uint64Flag{
+			Name: "duration_blocks",
+			Usage: "the new maximum duration of an ask or " +
+				"minimum duration of a bid in blocks",
+		},
+		cli.Uint64Flag{
+			Name: "min_units_match",
+			Usage: "the new minimum number of units a single " +
+				"matched order must fill",
+		},
+		cli.BoolFlag{
+			Name: "all_or_none",
+			Usage: "whether the new order can only be filled " +
+				"completely in a single batch, use " +
+				"--all_or_none=false to remove the flag",
+		},
+	},
+	Action: ordersAmend,
+}
+
+func ordersAmend(ctx *cli.Context) error {
+	// Show help if no arguments or flags are provided.
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		_ = cli.ShowCommandHelp(ctx, "amend")
+		return nil
+	}
+
+	var nonceHex string
+	switch {
+	case ctx.IsSet("order_nonce"):
+		nonceHex = ctx.String("order_nonce")
+	case ctx.Args().Present():
+		nonceHex = ctx.Args().First()
+	default:
+		return fmt.Errorf("order_nonce argument missing")
+	}
+	nonce, err := hex.DecodeString(nonceHex)
+	if err != nil {
+		return fmt.Errorf("cannot hex decode order nonce: %v", err)
+	}
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	// We start with the parameters of the existing order.
+	orders, err := client.ListOrders(
+		context.Background(), &clmrpc.ListOrdersRequest{},
+	)
+	if err != nil {
+		return err
+	}
+	req := &clmrpc.ReplaceOrderRequest{
+		OrderNonce: nonce,
+	}
+	for _, ask := range orders.Asks {
+		if !bytes.Equal(ask.Details.OrderNonce, nonce) {
+			continue
+		}
+
+		params, err := amendParams(ctx, ask.Details)
+		if err != nil {
+			return err
+		}
+		newAsk := &clmrpc.Ask{
+			Details:           params,
+			MaxDurationBlocks: ask.MaxDurationBlocks,
+			Version:           amendVersion(ask.Version, params),
+		}
+		if ctx.IsSet("duration_blocks") {
+			newAsk.MaxDurationBlocks = uint32(
+				ctx.Uint64("duration_blocks"),
+			)
+		}
+		req.Details = &clmrpc.ReplaceOrderRequest_Ask{Ask: newAsk}
+	}
+	for _, bid := range orders.Bids {
+		if !bytes.Equal(bid.Details.OrderNonce, nonce) {
+			continue
+		}
+
+		params, err := amendParams(ctx, bid.Details)
+		if err != nil {
+			return err
+		}
+		newBid := &clmrpc.Bid{
+			Details:           params,
+			MinDurationBlocks: bid.MinDurationBlocks,
+			Version:           amendVersion(bid.Version, params),
+		}
+		if ctx.IsSet("duration_blocks") {
+			newBid.MinDurationBlocks = uint32(
+				ctx.Uint64("duration_blocks"),
+			)
+		}
+		req.Details = &clmrpc.ReplaceOrderRequest_Bid{Bid: newBid}
+	}
+	if req.Details == nil {
+		return fmt.Errorf("order %x not found", nonce)
+	}
+
+	resp, err := client.ReplaceOrder(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	printRespJSON(resp)
+	return nil
+}
+
+// amendParams returns the parameters of a new order that are copied from the
+// given existing order and amended with the parameters set on the command line.
+func amendParams(ctx *cli.Context, old *clmrpc.Order) (*clmrpc.Order, error) {
+	params := &clmrpc.Order{
+		TraderKey:       old.TraderKey,
+		RateFixed:       old.RateFixed,
+		Amt:             old.Amt,
+		FundingFeeRate:  old.FundingFeeRate,
+		ExpiryHeight:    old.ExpiryHeight,
+		ExpiryTimestamp: old.ExpiryTimestamp,
+		ExpiryBatches:   old.ExpiryBatches,
+		MinUnitsMatch:   old.MinUnitsMatch,
+		AllOrNone:       old.AllOrNone,
+		Label:           old.Label,
+		Tags:            old.Tags,
+		GroupId:         old.GroupId,
+	}
+	if ctx.IsSet("rate_fixed") {
+		params.RateFixed = uint32(ctx.Uint64("rate_fixed"))
+	}
+	if ctx.IsSet("amt") {
+		amt, err := parseOrderAmt(
+			ctx.String("amt"), ctx.String("round"),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode amount: %v",
+				err)
+		}
+		params.Amt = uint64(amt)
+	}
+	if ctx.IsSet("funding_fee_rate") {
+		params.FundingFeeRate = ctx.Uint64("funding_fee_rate")
+	}
+	if ctx.IsSet("min_units_match") {
+		params.MinUnitsMatch = uint32(ctx.Uint64("min_units_match"))
+	}
+	if ctx.IsSet("all_or_none") {
+		params.AllOrNone = ctx.Bool("all_or_none")
+	}
+	return params, nil
+}
+
+// amendVersion returns the version of an amended order, which is never lower
+// than the version of the order it replaces.
+func amendVersion(oldVersion uint32, params *clmrpc.Order) uint32 {
+	version := orderVersion(params)
+	if oldVersion > version {
+		return oldVersion
+	}
+	return version
+}
+
+// orderFile is the format of the file that describes the desired orders of
+// one or more accounts.
+type orderFile struct {
+	// TraderKeys are the keys of the accounts whose orders are fully
+	// reconciled. Active orders of these accounts that aren't in the file
+	// are canceled.
+	TraderKeys []string `yaml:"trader_keys"`
+
+	Accounts []struct {
+		AcctKey string `yaml:"acct_key"`
+		Orders  []struct {
+			Label          string `yaml:"label"`
+			Type           string `yaml:"type"`
+			Amt            string `yaml:"amt"`
+			RateFixed      uint32 `yaml:"rate_fixed"`
+			DurationBlocks uint32 `yaml:"duration_blocks"`
+			FundingFeeRate uint64 `yaml:"funding_fee_rate"`
+			MinUnitsMatch  uint32 `yaml:"min_units_match"`
+			AllOrNone      bool   `yaml:"all_or_none"`
+		} `yaml:"orders"`
+	} `yaml:"accounts"`
+}
+
+var ordersApplyCommand = cli.Command{
+	Name:      "apply",
+	Usage:     "reconcile the active orders with an order file",
+	ArgsUsage: "--file=orders.yaml [--plan]",
+	Description: `
+	Make the active orders match the orders described in the given YAML
+	file. Orders are identified by their account and label. Missing orders
+	are submitted and orders whose parameters changed are replaced. Other
+	active orders are left alone, unless the key of their account is listed
+	under trader_keys. All active orders of those accounts that aren't in
+	the file are canceled, including unlabeled and manually placed ones.
+	Use --plan to review these cancellations first. Example file:
+
+	trader_keys:
+	  - 02abc...
+	accounts:
+	  - acct_key: 02abc...
+	    orders:
+	      - label: standing-ask
+	        type: ask
+	        amt: 1000000
+	        rate_fixed: 5
+	        duration_blocks: 2016
+
+	The amount can also be given in BTC or units (e.g. 0.01btc or 10units)
+	but must be a multiple of 100k satoshis. The fields funding_fee_rate,
+	min_units_match and all_or_none can also be set per order.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "the YAML file with the desired orders",
+		},
+		cli.BoolFlag{
+			Name: "plan",
+			Usage: "only show the actions that would be executed " +
+				"without executing them",
+		},
+	},
+	Action: ordersApply,
+}
+
+func ordersApply(ctx *cli.Context) error {
+	if !ctx.IsSet("file") {
+		_ = cli.ShowCommandHelp(ctx, "apply")
+		return nil
+	}
+
+	content, err := ioutil.ReadFile(ctx.String("file"))
+	if err != nil {
+		return fmt.Errorf("unable to read order file: %v", err)
+	}
+	req, err := parseOrderFile(content)
+	if err != nil {
+		return fmt.Errorf("unable to parse order file: %v", err)
+	}
+	req.PlanOnly = ctx.Bool("plan")
+
+	client, cleanup, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	resp, err := client.ApplyOrders(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	if req.PlanOnly {
+		printOrderPlan(resp.Actions)
+		return nil
+	}
+	printRespJSON(resp)
+	return nil
+}
+
+// printOrderPlan prints the planned actions grouped by their type so the
+// cancellations of active orders stand out.
+func printOrderPlan(actions []*clmrpc.OrderAction) {
+	if len(actions) == 0 {
+		fmt.Println("No changes, the active orders match the file.")
+		return
+	}
+
+	groups := []struct {
+		action clmrpc.OrderAction_ActionType
+		title  string
+	}{
+		{clmrpc.OrderAction_CANCEL, "Orders to CANCEL"},
+		{clmrpc.OrderAction_REPLACE, "Orders to replace"},
+		{clmrpc.OrderAction_SUBMIT, "Orders to submit"},
+	}
+	for _, group := range groups {
+		var lines []string
+		for _, action := range actions {
+			if action.Action != group.action {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("  account %x: %s",
+				action.TraderKey, action.Description))
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		fmt.Printf("%s (%d):\n%s\n", group.title, len(lines),
+			strings.Join(lines, "\n"))
+	}
+}
+
+// parseOrderFile parses the content of an order file into an apply request.
+func parseOrderFile(content []byte) (*clmrpc.ApplyOrdersRequest, error) {
+	var file orderFile
+	if err := yaml.UnmarshalStrict(content, &file); err != nil {
+		return nil, err
+	}
+
+	req := &clmrpc.ApplyOrdersRequest{}
+	for _, rawKey := range file.TraderKeys {
+		traderKey, err := parseTraderKey(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trader_keys entry: %v",
+				err)
+		}
+		req.TraderKeys = append(req.TraderKeys, traderKey)
+	}
+
+	for _, acct := range file.Accounts {
+		traderKey, err := parseTraderKey(acct.AcctKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid acct_key: %v", err)
+		}
+
+		for _, o := range acct.Orders {
+			amt, err := parseOrderAmt(o.Amt, "")
+			if err != nil {
+				return nil, fmt.Errorf("invalid amt of order "+
+					"%q: %v", o.Label, err)
+			}
+			params := &clmrpc.Order{
+				TraderKey:      traderKey,
+				RateFixed:      o.RateFixed,
+				Amt:            uint64(amt),
+				FundingFeeRate: o.FundingFeeRate,
+				MinUnitsMatch:  o.MinUnitsMatch,
+				AllOrNone:      o.AllOrNone,
+				Label:          o.Label,
+			}
+			if params.FundingFeeRate == 0 {
+				params.FundingFeeRate = uint64(
+					defaultFundingFeeRate,
+				)
+			}
+
+			switch strings.ToLower(o.Type) {
+			case "ask":
+				req.Asks = append(req.Asks, &clmrpc.Ask{
+					Details:           params,
+					MaxDurationBlocks: o.DurationBlocks,
+					Version:           orderVersion(params),
+				})
+
+			case "bid":
+				req.Bids = append(req.Bids, &clmrpc.Bid{
+					Details:           params,
+					MinDurationBlocks: o.DurationBlocks,
+					Version:           orderVersion(params),
+				})
+
+			default:
+				return nil, fmt.Errorf("order %q has invalid "+
+					"type %q, must be ask or bid", o.Label,
+					o.Type)
+			}
+		}
+	}
+
+	return req, nil
+}
+
+// parseTraderKey decodes a hex encoded account key of an order file.
+func parseTraderKey(rawKey string) ([]byte, error) {
+	traderKey, err := hex.DecodeString(rawKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode key: %v", err)
+	}
+	if len(traderKey) != 33 {
+		return nil, fmt.Errorf("key must be 33 bytes")
+	}
+	return traderKey, nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// testAcctKey is a hex encoded 33 byte account key used in the order files of
+// the tests.
+var testAcctKey = "02" + strings.Repeat("ab", 32)
+
+// TestParseOrderFile makes sure order files are parsed into apply requests and
+// that invalid files are rejected.
+func TestParseOrderFile(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		content string
+		numAsks int
+		numBids int
+		numKeys int
+		amts    []uint64
+		err     string
+	}{{
+		name: "asks and bids",
+		content: `
+accounts:
+  - acct_key: ` + testAcctKey + `
+    orders:
+      - label: ask
+        type: ask
+        amt: 1000000
+        duration_blocks: 2016
+      - label: bid
+        type: BID
+        amt: 0.02btc
+        duration_blocks: 144
+      - label: units
+        type: bid
+        amt: 3units
+`,
+		numAsks: 1,
+		numBids: 2,
+		amts:    []uint64{1_000_000, 2_000_000, 300_000},
+	}, {
+		name: "managed account without orders",
+		content: `
+trader_keys:
+  - ` + testAcctKey + `
+accounts:
+  - acct_key: ` + testAcctKey + `
+    orders: []
+`,
+		numKeys: 1,
+	}, {
+		name: "invalid trader key",
+		content: `
+trader_keys:
+  - 02abcd
+accounts: []
+`,
+		err: "33 bytes",
+	}, {
+		name: "unknown field",
+		content: `
+accounts:
+  - acct_key: ` + testAcctKey + `
+    orders:
+      - label: ask
+        type: ask
+        amount: 100000
+`,
+		err: "not found",
+	}, {
+		name: "invalid type",
+		content: `
+accounts:
+  - acct_key: ` + testAcctKey + `
+    orders:
+      - label: ask
+        type: offer
+        amt: 100000
+`,
+		err: "invalid type",
+	}, {
+		name: "unaligned amount",
+		content: `
+accounts:
+  - acct_key: ` + testAcctKey + `
+    orders:
+      - label: ask
+        type: ask
+        amt: 150000
+`,
+		err: "not a multiple",
+	}, {
+		name: "invalid account key",
+		content: `
+accounts:
+  - acct_key: 02abcd
+    orders: []
+`,
+		err: "33 bytes",
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := parseOrderFile([]byte(tc.content))
+			if tc.err != "" {
+				if err == nil || !strings.Contains(
+					err.Error(), tc.err,
+				) {
+
+					t.Fatalf("expected error %q, got %v",
+						tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to parse file: %v", err)
+			}
+
+			if len(req.TraderKeys) != tc.numKeys {
+				t.Fatalf("expected %d managed accounts, got "+
+					"%d", tc.numKeys, len(req.TraderKeys))
+			}
+			if len(req.Asks) != tc.numAsks ||
+				len(req.Bids) != tc.numBids {
+
+				t.Fatalf("expected %d asks and %d bids, got "+
+					"%d and %d", tc.numAsks, tc.numBids,
+					len(req.Asks), len(req.Bids))
+			}
+
+			var amts []uint64
+			for _, ask := range req.Asks {
+				amts = append(amts, ask.Details.Amt)
+			}
+			for _, bid := range req.Bids {
+				amts = append(amts, bid.Details.Amt)
+			}
+			for i, amt := range tc.amts {
+				if amts[i] != amt {
+					t.Fatalf("expected amount %d, got %d",
+						amt, amts[i])
+				}
+			}
+		})
+	}
+}
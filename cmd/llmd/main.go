@@ -34,8 +34,32 @@ func start() error {
 	// Parse command line flags.
 	parser := flags.NewParser(&config, flags.Default)
 	parser.SubcommandsOptional = true
+	_, err := parser.AddCommand(
+		"migratedb", "Copy the bolt database into the SQL database",
+		"Copies all accounts and orders of the bolt database in the "+
+			"network directory into the SQL database selected "+
+			"with --dbbackend and --sqldsn. The target database "+
+			"must be empty.",
+		&migrateDBCommand{},
+	)
+	if err != nil {
+		return err
+	}
+	restoreCmd := &restoreDBCommand{}
+	_, err = parser.AddCommand(
+		"restoredb", "Restore a database snapshot",
+		"Replaces the bolt database in the network directory with a "+
+			"snapshot that was either taken before a database "+
+			"migration was applied or created as a backup. Lists "+
+			"all available pre-migration snapshots if no "+
+			"--backup is given. llmd must not be running.",
+		restoreCmd,
+	)
+	if err != nil {
+		return err
+	}
 
-	_, err := parser.Parse()
+	_, err = parser.Parse()
 	if e, ok := err.(*flags.Error); ok && e.Type == flags.ErrHelp {
 		return nil
 	}
@@ -100,6 +124,15 @@ func start() error {
 			os.Exit(0)
 		}
 
+		if config.DryRunMigration {
+			if err := llm.DryRunMigration(&config); err != nil {
+				return fmt.Errorf("dry run migration failed: "+
+					"%v", err)
+			}
+			fmt.Println("Dry run migration completed successfully")
+			return nil
+		}
+
 		signal.Intercept()
 		trader, err := llm.NewServer(&config)
 		if err != nil {
@@ -113,5 +146,57 @@ func start() error {
 		return trader.Stop()
 	}
 
+	switch parser.Active.Name {
+	case "migratedb":
+		if err := llm.MigrateBoltToSQL(&config); err != nil {
+			return fmt.Errorf("unable to migrate database: %v", err)
+		}
+		fmt.Println("Database migrated successfully")
+		return nil
+
+	case "restoredb":
+		return restoreDB(&config, restoreCmd.Backup)
+	}
+
 	return fmt.Errorf("unimplemented command %v", parser.Active.Name)
 }
+
+// migrateDBCommand is the one-shot command that copies the content of the bolt
+// database into a SQL database.
+type migrateDBCommand struct{}
+
+// restoreDBCommand is the one-shot command that restores a snapshot of the bolt
+// database that was taken before a migration.
+type restoreDBCommand struct {
+	Backup string `long:"backup" description:"The snapshot file to restore"`
+}
+
+// restoreDB restores the given pre-migration snapshot or lists all available
+// snapshots if none is given.
+func restoreDB(config *llm.Config, backupFile string) error {
+	if backupFile == "" {
+		backups, err := llm.ListMigrationBackups(config)
+		if err != nil {
+			return fmt.Errorf("unable to list snapshots: %v", err)
+		}
+		if len(backups) == 0 {
+			fmt.Println("No pre-migration snapshots found")
+			return nil
+		}
+		fmt.Println("Available pre-migration snapshots:")
+		for _, backup := range backups {
+			fmt.Println(backup)
+		}
+		return nil
+	}
+
+	replacedPath, err := llm.RestoreBackup(config, backupFile)
+	if err != nil {
+		return fmt.Errorf("unable to restore snapshot: %v", err)
+	}
+	fmt.Printf("Snapshot %v restored\n", backupFile)
+	if replacedPath != "" {
+		fmt.Printf("Previous database moved to %v\n", replacedPath)
+	}
+	return nil
+}
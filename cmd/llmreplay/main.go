@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/jessevdk/go-flags"
+	"github.com/lightninglabs/llm"
+	"github.com/lightninglabs/llm/clientdb"
+	"github.com/lightninglabs/llm/order"
+	"github.com/lightninglabs/llm/streamlog"
+	"github.com/lightninglabs/loop/lndclient"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+const (
+	defaultNetwork = "mainnet"
+	defaultLndHost = "localhost:10009"
+
+	// dbFilename is the name of the database file clientdb expects in its
+	// directory.
+	dbFilename = "llm.db"
+)
+
+var (
+	// errNoWallet is returned by the offline wallet if a key needs to be
+	// derived.
+	errNoWallet = errors.New("cannot derive keys without lnd, start " +
+		"llmreplay without --nolnd")
+)
+
+// config contains all options of the replay tool.
+type config struct {
+	StreamLog string `long:"streamlog" description:"Stream log file or directory of stream log files to replay" required:"true"`
+	DB        string `long:"db" description:"Snapshot of the llm.db taken before the recorded batches were executed" required:"true"`
+	Network   string `long:"network" description:"network to run on" choice:"regtest" choice:"testnet" choice:"mainnet" choice:"simnet"`
+
+	NoLnd      bool   `long:"nolnd" description:"Don't connect to lnd, batches that require a key to be derived can't be verified"`
+	NodePubkey string `long:"nodepubkey" description:"Hex encoded identity pubkey of the trader's node, required with --nolnd"`
+
+	Lnd *llm.LndConfig `group:"lnd" namespace:"lnd"`
+}
+
+// offlineWallet is a wallet that isn't connected to lnd and therefore can't
+// derive any keys.
+type offlineWallet struct {
+	lndclient.WalletKitClient
+}
+
+// DeriveKey always returns errNoWallet.
+func (w *offlineWallet) DeriveKey(context.Context,
+	*keychain.KeyLocator) (*keychain.KeyDescriptor, error) {
+
+	return nil, errNoWallet
+}
+
+func main() {
+	if err := start(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func start() error {
+	cfg := config{
+		Network: defaultNetwork,
+		Lnd: &llm.LndConfig{
+			Host: defaultLndHost,
+		},
+	}
+	if _, err := flags.Parse(&cfg); err != nil {
+		if e, ok := err.(*flags.Error); ok && e.Type == flags.ErrHelp {
+			return nil
+		}
+		return err
+	}
+
+	records, err := streamlog.ReadPath(cfg.StreamLog)
+	if err != nil {
+		if len(records) == 0 {
+			return fmt.Errorf("unable to read stream log: %v", err)
+		}
+		fmt.Printf("Warning: %v, replaying %d complete records\n",
+			err, len(records))
+	}
+
+	var (
+		wallet  lndclient.WalletKitClient
+		nodeKey [33]byte
+	)
+	if cfg.NoLnd {
+		rawKey, err := hex.DecodeString(cfg.NodePubkey)
+		if err != nil {
+			return fmt.Errorf("invalid node pubkey: %v", err)
+		}
+		_, err = btcec.ParsePubKey(rawKey, btcec.S256())
+		if err != nil {
+			return fmt.Errorf("invalid node pubkey: %v", err)
+		}
+		copy(nodeKey[:], rawKey)
+		wallet = &offlineWallet{}
+	} else {
+		lnd, err := lndclient.NewLndServices(
+			&lndclient.LndServicesConfig{
+				LndAddress:  cfg.Lnd.Host,
+				Network:     cfg.Network,
+				MacaroonDir: cfg.Lnd.MacaroonDir,
+				TLSPath:     cfg.Lnd.TLSPath,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("unable to connect to lnd: %v", err)
+		}
+		defer lnd.Close()
+
+		wallet = lnd.WalletKit
+		nodeKey = lnd.NodePubkey
+	}
+
+	// Work on a copy of the snapshot so neither the migrations nor the
+	// replay can modify it.
+	dbDir, err := ioutil.TempDir("", "llmreplay")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dbDir)
+
+	err = copyFile(cfg.DB, filepath.Join(dbDir, dbFilename))
+	if err != nil {
+		return fmt.Errorf("unable to copy database snapshot: %v", err)
+	}
+	db, err := clientdb.New(dbDir)
+	if err != nil {
+		return fmt.Errorf("unable to open database snapshot: %v", err)
+	}
+	defer db.Close()
+
+	verifier := order.NewBatchVerifier(db, db.Account, wallet, nodeKey)
+	results, err := streamlog.Replay(records, verifier)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		fmt.Println("No batches found in stream log")
+		return nil
+	}
+
+	var reproduced int
+	for _, result := range results {
+		printResult(result)
+		if result.Reproduced() {
+			reproduced++
+		}
+	}
+	fmt.Printf("\nReproduced %d of %d batch decisions\n", reproduced,
+		len(results))
+
+	return nil
+}
+
+// printResult prints the recorded and replayed decision for a single batch.
+func printResult(result *streamlog.Result) {
+	fmt.Printf("\nBatch %x (received %v)\n", result.BatchID[:],
+		result.Prepare.Timestamp.Format(time.RFC3339Nano))
+
+	switch {
+	case result.Response == nil:
+		fmt.Println("  recorded: no response")
+
+	case result.Accepted():
+		fmt.Println("  recorded: accepted")
+
+	default:
+		reject := result.Rejection()
+		fmt.Printf("  recorded: rejected (%v: %s)\n",
+			reject.ReasonCode, reject.Reason)
+	}
+
+	switch {
+	case result.VerifyErr == nil:
+		fmt.Println("  replayed: accepted")
+
+	case result.Mismatch():
+		fmt.Printf("  replayed: MISMATCH: %v\n", result.VerifyErr)
+
+	default:
+		fmt.Printf("  replayed: rejected (%v)\n", result.VerifyErr)
+	}
+
+	if result.Response != nil && !result.Reproduced() {
+		fmt.Println("  decision NOT reproduced")
+	}
+}
+
+// copyFile copies the file at src to dst.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/llm/auctioneer"
+	"github.com/lightninglabs/llm/streamlog"
 	"google.golang.org/grpc"
 )
 
@@ -29,6 +31,11 @@ var (
 
 	defaultMinBackoff = 5 * time.Second
 	defaultMaxBackoff = 1 * time.Minute
+
+	defaultKeepaliveInterval = 30 * time.Second
+
+	defaultStreamLogDirname     = "streamlog"
+	defaultMaxStreamLogFileSize = 10
 )
 
 type LndConfig struct {
@@ -43,14 +50,35 @@ type Config struct {
 	Network        string `long:"network" description:"network to run on" choice:"regtest" choice:"testnet" choice:"mainnet" choice:"simnet"`
 	AuctionServer  string `long:"auctionserver" description:"auction server address host:port"`
 	TLSPathAuctSrv string `long:"tlspathauctserver" description:"Path to auction server tls certificate"`
+	Proxy          string `long:"proxy" description:"The host:port of a SOCKS5 proxy (e.g. tor) through which the auction server is dialed, required for an auction server on an onion address"`
 	RPCListen      string `long:"rpclisten" description:"Address to listen on for gRPC clients"`
 	RESTListen     string `long:"restlisten" description:"Address to listen on for REST clients"`
 	BaseDir        string `long:"basedir" description:"The base directory where llm stores all its data"`
 
+	DBBackend string `long:"dbbackend" description:"The database backend to store all trader data in" choice:"bbolt" choice:"sqlite" choice:"postgres"`
+	SQLDSN    string `long:"sqldsn" description:"The data source name used to connect to the SQL database backend. Defaults to llm.sqlite in the network directory for the sqlite backend, required for postgres"`
+
+	DryRunMigration bool `long:"dry-run-migration" description:"Apply all outstanding database migrations to a temporary copy of the bolt database, verify the result and exit without modifying the original"`
+
+	BackupDir        string        `long:"backupdir" description:"Directory to write scheduled database backups to. Defaults to the backups directory in the network directory"`
+	BackupInterval   time.Duration `long:"backupinterval" description:"Interval in which a snapshot of the database is written to the backup directory while llmd is running. Set to 0 to disable scheduled backups. Valid time units are {s, m, h}."`
+	BackupRetention  int           `long:"backupretention" description:"Number of scheduled backups to keep in the backup directory (0 to keep all)"`
+	StaticBackupFile string        `long:"staticbackupfile" description:"Path of the encrypted static account backup that is updated on every account change. Defaults to accounts.backup in the network directory"`
+
 	LogDir         string `long:"logdir" description:"Directory to log output."`
 	MaxLogFiles    int    `long:"maxlogfiles" description:"Maximum logfiles to keep (0 for no rotation)"`
 	MaxLogFileSize int    `long:"maxlogfilesize" description:"Maximum logfile size in MB"`
 
+	RecordStream         bool   `long:"recordstream" description:"Record every message exchanged with the auction server to a binary stream log that can be replayed with llmreplay for debugging"`
+	StreamLogDir         string `long:"streamlogdir" description:"Directory to write the stream log to. Defaults to the streamlog directory in the network directory"`
+	MaxStreamLogFiles    int    `long:"maxstreamlogfiles" description:"Maximum stream log files to keep (0 to keep all)"`
+	MaxStreamLogFileSize int    `long:"maxstreamlogfilesize" description:"Maximum stream log file size in MB"`
+
+	BackupAuctionServers []string      `long:"backupauctionserver" description:"Additional auction server address host:port to fail over to if the current one is unreachable. Can be specified multiple times"`
+	FailoverThreshold    int           `long:"failoverthreshold" description:"Number of consecutive connection failures after which the next auction server address is tried"`
+	KeepaliveInterval    time.Duration `long:"keepaliveinterval" description:"Interval in which the connection to the auction server is probed. Set to 0 to disable the keepalive. Valid time units are {s, m, h}."`
+	KeepaliveTimeout     time.Duration `long:"keepalivetimeout" description:"Time the auction server has to answer a keepalive probe before the connection is considered dead. Valid time units are {s, m, h}."`
+
 	MinBackoff time.Duration `long:"minbackoff" description:"Shortest backoff when reconnecting to the server. Valid time units are {s, m, h}."`
 	MaxBackoff time.Duration `long:"maxbackoff" description:"Longest backoff when reconnecting to the server. Valid time units are {s, m, h}."`
 	DebugLevel string        `short:"d" long:"debuglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
@@ -58,6 +86,9 @@ type Config struct {
 	Profile  string `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65535"`
 	FakeAuth bool   `long:"fakeauth" description:"Disable LSAT authentication and instead use a fake LSAT ID to identify. For testing only, cannot be set on mainnet."`
 
+	LsatMaxCost btcutil.Amount `long:"lsatmaxcost" description:"The maximum amount in satoshis that is paid for an LSAT token automatically"`
+	LsatMaxFee  btcutil.Amount `long:"lsatmaxfee" description:"The maximum routing fee in satoshis that is paid for an LSAT token automatically"`
+
 	Lnd *LndConfig `group:"lnd" namespace:"lnd"`
 
 	// RPCListener is a network listener that can be set if llmd should be
@@ -83,17 +114,26 @@ const (
 )
 
 var DefaultConfig = Config{
-	Network:        "mainnet",
-	RPCListen:      "localhost:12010",
-	RESTListen:     "localhost:8281",
-	Insecure:       false,
-	BaseDir:        DefaultBaseDir,
-	LogDir:         defaultLogDir,
-	MaxLogFiles:    defaultMaxLogFiles,
-	MaxLogFileSize: defaultMaxLogFileSize,
-	MinBackoff:     defaultMinBackoff,
-	MaxBackoff:     defaultMaxBackoff,
-	DebugLevel:     defaultLogLevel,
+	Network:              "mainnet",
+	RPCListen:            "localhost:12010",
+	RESTListen:           "localhost:8281",
+	Insecure:             false,
+	BaseDir:              DefaultBaseDir,
+	DBBackend:            DBBackendBolt,
+	BackupRetention:      defaultBackupRetention,
+	LogDir:               defaultLogDir,
+	MaxLogFiles:          defaultMaxLogFiles,
+	MaxLogFileSize:       defaultMaxLogFileSize,
+	MinBackoff:           defaultMinBackoff,
+	MaxBackoff:           defaultMaxBackoff,
+	FailoverThreshold:    auctioneer.DefaultFailoverThreshold,
+	KeepaliveInterval:    defaultKeepaliveInterval,
+	KeepaliveTimeout:     auctioneer.DefaultKeepaliveTimeout,
+	LsatMaxCost:          defaultLsatMaxCost,
+	LsatMaxFee:           defaultLsatMaxFee,
+	MaxStreamLogFiles:    streamlog.DefaultMaxFiles,
+	MaxStreamLogFileSize: defaultMaxStreamLogFileSize,
+	DebugLevel:           defaultLogLevel,
 	Lnd: &LndConfig{
 		Host: "localhost:10009",
 	},
@@ -0,0 +1,154 @@
+package llm
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/auctioneer"
+	"github.com/lightninglabs/llm/clientdb"
+	"github.com/lightninglabs/llm/order"
+	"github.com/lightninglabs/llm/sqldb"
+)
+
+const (
+	// DBBackendBolt is the name of the default, bolt based database
+	// backend.
+	DBBackendBolt = "bbolt"
+
+	// DBBackendSQLite is the name of the SQLite database backend.
+	DBBackendSQLite = "sqlite"
+
+	// DBBackendPostgres is the name of the Postgres database backend.
+	DBBackendPostgres = "postgres"
+
+	// defaultSQLiteFilename is the name of the SQLite database file that
+	// is created in the network directory if no DSN is configured.
+	defaultSQLiteFilename = "llm.sqlite"
+)
+
+// traderStore is the full set of persistence functionality the trader daemon
+// needs from its database backend.
+type traderStore interface {
+	order.Store
+	auctioneer.BatchSource
+	account.RecoveryStore
+
+	// AddAccount adds a record for the account to the database.
+	AddAccount(*account.Account) error
+
+	// UpdateAccount updates an account in the database according to the
+	// given modifiers.
+	UpdateAccount(*account.Account, ...account.Modifier) error
+
+	// Account retrieves a specific account by trader key or returns
+	// clientdb.ErrAccountNotFound if it's not found.
+	Account(*btcec.PublicKey) (*account.Account, error)
+
+	// Accounts retrieves all known accounts from the database.
+	Accounts() ([]*account.Account, error)
+
+	// LockID retrieves the database's global lock ID used to lease outputs
+	// from the backing lnd node's wallet.
+	LockID() (wtxmgr.LockID, error)
+
+	// Close closes the database.
+	Close() error
+}
+
+var _ traderStore = (*clientdb.DB)(nil)
+var _ traderStore = (*sqldb.DB)(nil)
+
+// openDB opens the database backend that is selected in the configuration.
+func openDB(cfg *Config, networkDir string) (traderStore, error) {
+	switch cfg.DBBackend {
+	case "", DBBackendBolt:
+		return clientdb.New(networkDir)
+
+	case DBBackendSQLite, DBBackendPostgres:
+		return openSQLDB(cfg, networkDir)
+
+	default:
+		return nil, fmt.Errorf("unknown database backend %q",
+			cfg.DBBackend)
+	}
+}
+
+// openSQLDB opens the SQL database that is selected in the configuration.
+func openSQLDB(cfg *Config, networkDir string) (*sqldb.DB, error) {
+	switch cfg.DBBackend {
+	case DBBackendSQLite:
+		dsn := cfg.SQLDSN
+		if dsn == "" {
+			dsn = filepath.Join(networkDir, defaultSQLiteFilename)
+		}
+		return sqldb.New(sqldb.BackendSQLite, dsn)
+
+	case DBBackendPostgres:
+		if cfg.SQLDSN == "" {
+			return nil, fmt.Errorf("the postgres backend " +
+				"requires --sqldsn to be set")
+		}
+		return sqldb.New(sqldb.BackendPostgres, cfg.SQLDSN)
+
+	default:
+		return nil, fmt.Errorf("database backend %q is not a SQL "+
+			"backend", cfg.DBBackend)
+	}
+}
+
+// MigrateBoltToSQL copies the content of the bolt database in the configured
+// network directory into the configured SQL database. After a successful
+// migration, llmd can be started with the same --dbbackend option to use the
+// SQL database from then on.
+func MigrateBoltToSQL(cfg *Config) error {
+	networkDir := filepath.Join(cfg.BaseDir, cfg.Network)
+
+	sqlDB, err := openSQLDB(cfg, networkDir)
+	if err != nil {
+		return fmt.Errorf("unable to open SQL database: %v", err)
+	}
+	defer func() {
+		_ = sqlDB.Close()
+	}()
+
+	boltDB, err := clientdb.New(networkDir)
+	if err != nil {
+		return fmt.Errorf("unable to open bolt database: %v", err)
+	}
+	defer func() {
+		_ = boltDB.Close()
+	}()
+
+	return sqldb.MigrateFromBolt(boltDB, sqlDB)
+}
+
+// DryRunMigration applies all outstanding migrations to a temporary copy of the
+// bolt database in the configured network directory. The database itself is
+// left untouched.
+func DryRunMigration(cfg *Config) error {
+	networkDir := filepath.Join(cfg.BaseDir, cfg.Network)
+	return clientdb.DryRunMigration(networkDir)
+}
+
+// ListMigrationBackups returns the pre-migration snapshots of the bolt database
+// in the configured network directory, oldest first.
+func ListMigrationBackups(cfg *Config) ([]string, error) {
+	networkDir := filepath.Join(cfg.BaseDir, cfg.Network)
+	return clientdb.ListMigrationBackups(networkDir)
+}
+
+// RestoreBackup replaces the bolt database in the configured network directory
+// with the given snapshot. The path the replaced database was moved to is
+// returned. Snapshots can only be restored if the bolt backend is configured,
+// the restored database would be ignored otherwise.
+func RestoreBackup(cfg *Config, backupFile string) (string, error) {
+	if cfg.DBBackend != "" && cfg.DBBackend != DBBackendBolt {
+		return "", errBackupUnsupported
+	}
+
+	networkDir := filepath.Join(cfg.BaseDir, cfg.Network)
+	return clientdb.RestoreBackup(networkDir, backupFile)
+}
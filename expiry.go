@@ -0,0 +1,127 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/lightninglabs/llm/auctioneer"
+	"github.com/lightninglabs/llm/order"
+)
+
+const (
+	// expiryCancelTimeout is the maximum time we allow for canceling a
+	// single expired order with the auction server.
+	expiryCancelTimeout = 30 * time.Second
+)
+
+// checkOrderExpiry looks for orders that reached their expiry and hands them
+// to the expiry handler to be canceled. Orders that are part of the pending
+// batch are only checked once the batch is done.
+//
+// NOTE: This method must only be called from the serverHandler goroutine as it
+// accesses the order manager's pending batch.
+func (s *rpcServer) checkOrderExpiry() {
+	dbOrders, err := s.server.db.GetOrders()
+	if err != nil {
+		log.Errorf("Unable to check order expiry: %v", err)
+		return
+	}
+
+	var pending map[order.Nonce][]*order.MatchedOrder
+	if batch := s.orderManager.PendingBatch(); batch != nil {
+		pending = batch.MatchedOrders
+	}
+
+	batchCount, err := s.server.db.BatchCount()
+	if err != nil {
+		log.Errorf("Unable to check order expiry: %v", err)
+		return
+	}
+
+	height := atomic.LoadUint32(&s.bestHeight)
+	now := time.Now()
+	var expired []order.Nonce
+	for _, dbOrder := range dbOrders {
+		if _, ok := pending[dbOrder.Nonce()]; ok {
+			continue
+		}
+		if dbOrder.Details().Expired(height, now, batchCount) {
+			expired = append(expired, dbOrder.Nonce())
+		}
+	}
+	if len(expired) == 0 {
+		return
+	}
+
+	// If the handler is still busy with the last expired orders, we'll
+	// pick up the new ones with the next check.
+	select {
+	case s.expiredOrders <- expired:
+	default:
+	}
+}
+
+// expiryHandler cancels all expired orders it receives with the auction server
+// and marks them as expired. Orders that can't be canceled are tried again
+// with the next expiry check.
+//
+// NOTE: This method must be run as a goroutine.
+func (s *rpcServer) expiryHandler() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case nonces := <-s.expiredOrders:
+			for _, nonce := range nonces {
+				err := s.expireOrder(nonce)
+				if err != nil {
+					log.Errorf("Unable to expire order "+
+						"%v: %v", nonce, err)
+				}
+			}
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// expireOrder cancels a single order with the auction server and marks it as
+// expired in the database. As the order might have changed since it was found
+// to be expired, it is skipped if it's no longer active or part of a new
+// pending batch.
+func (s *rpcServer) expireOrder(nonce order.Nonce) error {
+	dbOrder, err := s.server.db.GetOrder(nonce)
+	if err != nil {
+		return err
+	}
+	if dbOrder.Details().State.Archived() ||
+		s.orderManager.InPendingBatch(nonce) {
+
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), expiryCancelTimeout,
+	)
+	defer cancel()
+
+	// If the server doesn't know the order, there's nothing left to cancel
+	// and we can still mark it as expired.
+	err = s.auctioneer.CancelOrder(ctx, nonce)
+	if err != nil && !errors.Is(err, auctioneer.ErrOrderNotFound) {
+		return err
+	}
+
+	err = s.server.db.UpdateOrder(
+		nonce, order.StateModifier(order.StateExpired),
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Order %v expired and was canceled", nonce)
+	return nil
+}
@@ -0,0 +1,341 @@
+package fakeauctioneer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/txsort"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/auctioneer"
+	"github.com/lightninglabs/llm/clmrpc"
+	"github.com/lightninglabs/llm/clmscript"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// ReserveAccount hands out the auctioneer key and the current batch key to a
+// trader that wants to open a new account.
+//
+// NOTE: This is part of the ChannelAuctioneerServer interface.
+func (s *Server) ReserveAccount(_ context.Context,
+	req *clmrpc.ReserveAccountRequest) (*clmrpc.ReserveAccountResponse,
+	error) {
+
+	if btcutil.Amount(req.AccountValue) < account.MinAccountValue {
+		return nil, fmt.Errorf("minimum account value is %v",
+			account.MinAccountValue)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The trader key isn't known at this point, so we just remember which
+	// batch keys we've handed out and find the right one when the account
+	// is initialized.
+	s.reservations[rawKey(s.batchKey)] = s.batchKey
+
+	return &clmrpc.ReserveAccountResponse{
+		AuctioneerKey:   s.auctioneerKey.PubKey().SerializeCompressed(),
+		InitialBatchKey: s.batchKey.SerializeCompressed(),
+	}, nil
+}
+
+// InitAccount registers a new account after making sure its script is a
+// correct account script with one of the reserved batch keys. In contrast to
+// the real auctioneer, the account is considered to be open right away.
+//
+// NOTE: This is part of the ChannelAuctioneerServer interface.
+func (s *Server) InitAccount(_ context.Context,
+	req *clmrpc.ServerInitAccountRequest) (
+	*clmrpc.ServerInitAccountResponse, error) {
+
+	traderKey, traderKeyRaw, err := parseKey(req.TraderKey)
+	if err != nil {
+		return nil, err
+	}
+	if req.AccountPoint == nil {
+		return nil, fmt.Errorf("account outpoint missing")
+	}
+	hash, err := chainhash.NewHash(req.AccountPoint.Txid)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account outpoint: %v", err)
+	}
+	secret, err := s.sharedSecret(traderKey)
+	if err != nil {
+		return nil, err
+	}
+	bestHeight, err := s.bestHeight()
+	if err != nil {
+		return nil, err
+	}
+	if req.AccountExpiry <= bestHeight {
+		return nil, fmt.Errorf("account expiry %d already reached",
+			req.AccountExpiry)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.accounts[traderKeyRaw]; ok {
+		return nil, fmt.Errorf("account %x already exists",
+			traderKeyRaw[:])
+	}
+
+	// Find the reservation the account script was created with.
+	acct := &account.Account{
+		Value:  btcutil.Amount(req.AccountValue),
+		Expiry: req.AccountExpiry,
+		TraderKey: &keychain.KeyDescriptor{
+			KeyLocator: keychain.KeyLocator{
+				Family: clmscript.AccountKeyFamily,
+			},
+			PubKey: traderKey,
+		},
+		AuctioneerKey: s.auctioneerKey.PubKey(),
+		Secret:        secret,
+		State:         account.StateOpen,
+		HeightHint:    bestHeight,
+		OutPoint: wire.OutPoint{
+			Hash:  *hash,
+			Index: req.AccountPoint.OutputIndex,
+		},
+	}
+	for batchKeyRaw, batchKey := range s.reservations {
+		script, err := clmscript.AccountScript(
+			acct.Expiry, traderKey, acct.AuctioneerKey, batchKey,
+			secret,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(script, req.AccountScript) {
+			continue
+		}
+
+		acct.BatchKey = batchKey
+		delete(s.reservations, batchKeyRaw)
+		break
+	}
+	if acct.BatchKey == nil {
+		return nil, fmt.Errorf("account script doesn't match any " +
+			"reservation")
+	}
+
+	s.accounts[traderKeyRaw] = acct
+
+	log.Infof("Initialized account %x with value %v and expiry %d",
+		traderKeyRaw[:], acct.Value, acct.Expiry)
+
+	return &clmrpc.ServerInitAccountResponse{}, nil
+}
+
+// ModifyAccount reconstructs the transaction a trader wants to spend their
+// account with and signs the account input. If no new parameters are given,
+// the account is closed, otherwise the account output is re-created with the
+// next batch key and the new value.
+//
+// NOTE: Because only the outpoints of additional inputs are known, the new
+// account outpoint is only correct if all those inputs spend native SegWit
+// outputs.
+//
+// NOTE: This is part of the ChannelAuctioneerServer interface.
+func (s *Server) ModifyAccount(_ context.Context,
+	req *clmrpc.ServerModifyAccountRequest) (
+	*clmrpc.ServerModifyAccountResponse, error) {
+
+	_, traderKeyRaw, err := parseKey(req.TraderKey)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	acct, ok := s.accounts[traderKeyRaw]
+	if !ok {
+		return nil, auctioneer.NewError(
+			auctioneer.ErrAccountNotFound, "account %x not found",
+			traderKeyRaw[:],
+		)
+	}
+	if acct.State != account.StateOpen {
+		return nil, fmt.Errorf("account %x in state %v can't be "+
+			"modified", traderKeyRaw[:], acct.State)
+	}
+	if s.hasActiveOrders(traderKeyRaw) {
+		return nil, fmt.Errorf("account %x has active orders",
+			traderKeyRaw[:])
+	}
+
+	// Re-create the transaction exactly like the trader did.
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: acct.OutPoint})
+	for _, in := range req.NewInputs {
+		if in.Outpoint == nil {
+			return nil, fmt.Errorf("input outpoint missing")
+		}
+		hash, err := chainhash.NewHash(in.Outpoint.Txid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input outpoint: %v",
+				err)
+		}
+		tx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: wire.OutPoint{
+				Hash:  *hash,
+				Index: in.Outpoint.OutputIndex,
+			},
+		})
+	}
+	for _, out := range req.NewOutputs {
+		tx.AddTxOut(&wire.TxOut{
+			Value:    int64(out.Value),
+			PkScript: out.Script,
+		})
+	}
+
+	var modifiers []account.Modifier
+	if req.NewParams != nil {
+		newValue := btcutil.Amount(req.NewParams.Value)
+		if newValue < account.MinAccountValue {
+			return nil, fmt.Errorf("minimum account value is %v",
+				account.MinAccountValue)
+		}
+		nextScript, err := acct.NextOutputScript()
+		if err != nil {
+			return nil, err
+		}
+		tx.AddTxOut(&wire.TxOut{
+			Value:    int64(newValue),
+			PkScript: nextScript,
+		})
+		modifiers = append(
+			modifiers, account.ValueModifier(newValue),
+			account.IncrementBatchKey(),
+		)
+	}
+	txsort.InPlaceSort(tx)
+
+	inputIdx := -1
+	for idx, in := range tx.TxIn {
+		if in.PreviousOutPoint == acct.OutPoint {
+			inputIdx = idx
+		}
+	}
+	sig, err := s.signAccountInput(
+		tx, txscript.NewTxSigHashes(tx), inputIdx, acct,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Now that we've signed, we can apply the modifications. The trader
+	// broadcasts the transaction and we don't watch the chain, so we
+	// assume it'll confirm and keep the account open.
+	if req.NewParams == nil {
+		modifiers = append(
+			modifiers, account.StateModifier(account.StateClosed),
+			account.CloseTxModifier(tx),
+		)
+	} else {
+		newAcct := acct.Copy(modifiers...)
+		newScript, err := newAcct.Output()
+		if err != nil {
+			return nil, err
+		}
+		idx, _ := clmscript.LocateOutputScript(tx, newScript.PkScript)
+		modifiers = append(modifiers, account.OutPointModifier(
+			wire.OutPoint{Hash: tx.TxHash(), Index: idx},
+		))
+	}
+	s.accounts[traderKeyRaw] = acct.Copy(modifiers...)
+
+	log.Infof("Signed modification of account %x in tx %v",
+		traderKeyRaw[:], tx.TxHash())
+
+	return &clmrpc.ServerModifyAccountResponse{
+		AccountSig: sig,
+	}, nil
+}
+
+// signAccountInput creates the auctioneer's signature for the account input at
+// the given index, including the sighash flag.
+func (s *Server) signAccountInput(tx *wire.MsgTx, hashes *txscript.TxSigHashes,
+	idx int, acct *account.Account) ([]byte, error) {
+
+	if idx < 0 {
+		return nil, fmt.Errorf("account input not found")
+	}
+
+	witnessScript, err := clmscript.AccountWitnessScript(
+		acct.Expiry, acct.TraderKey.PubKey, acct.AuctioneerKey,
+		acct.BatchKey, acct.Secret,
+	)
+	if err != nil {
+		return nil, err
+	}
+	tweak := clmscript.AuctioneerKeyTweak(
+		acct.TraderKey.PubKey, acct.AuctioneerKey, acct.BatchKey,
+		acct.Secret,
+	)
+	key := input.TweakPrivKey(s.auctioneerKey, tweak)
+
+	return txscript.RawTxInWitnessSignature(
+		tx, hashes, idx, int64(acct.Value), witnessScript,
+		txscript.SigHashAll, key,
+	)
+}
+
+// sharedSecret derives the static secret that is shared between the trader and
+// the auctioneer for the given trader key.
+func (s *Server) sharedSecret(traderKey *btcec.PublicKey) ([32]byte, error) {
+	ecdh := &keychain.PrivKeyECDH{PrivKey: s.auctioneerKey}
+	return ecdh.ECDH(traderKey)
+}
+
+// marshallAccount converts an account into the auctioneer's RPC
+// representation.
+func marshallAccount(acct *account.Account) (*clmrpc.AuctionAccount, error) {
+	rpcAcct := &clmrpc.AuctionAccount{
+		Value:         uint64(acct.Value),
+		Expiry:        acct.Expiry,
+		TraderKey:     acct.TraderKey.PubKey.SerializeCompressed(),
+		AuctioneerKey: acct.AuctioneerKey.SerializeCompressed(),
+		BatchKey:      acct.BatchKey.SerializeCompressed(),
+		HeightHint:    acct.HeightHint,
+		Outpoint: &clmrpc.OutPoint{
+			Txid:        acct.OutPoint.Hash[:],
+			OutputIndex: acct.OutPoint.Index,
+		},
+	}
+
+	switch acct.State {
+	case account.StateOpen:
+		rpcAcct.State = clmrpc.AuctionAccountState_STATE_OPEN
+
+	case account.StatePendingUpdate:
+		rpcAcct.State = clmrpc.AuctionAccountState_STATE_PENDING_UPDATE
+
+	case account.StateExpired:
+		rpcAcct.State = clmrpc.AuctionAccountState_STATE_EXPIRED
+
+	case account.StateClosed:
+		rpcAcct.State = clmrpc.AuctionAccountState_STATE_CLOSED
+
+		var buf bytes.Buffer
+		if err := acct.CloseTx.Serialize(&buf); err != nil {
+			return nil, err
+		}
+		rpcAcct.CloseTx = buf.Bytes()
+
+	default:
+		return nil, fmt.Errorf("unknown account state %v", acct.State)
+	}
+
+	return rpcAcct, nil
+}
@@ -0,0 +1,1004 @@
+package fakeauctioneer
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcwallet/wallet/txrules"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/auctioneer"
+	"github.com/lightninglabs/llm/clmrpc"
+	"github.com/lightninglabs/llm/clmscript"
+	"github.com/lightninglabs/llm/order"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+const (
+	// maxBatchAttempts is the maximum number of times a batch is
+	// re-assembled after traders dropped out of it.
+	maxBatchAttempts = 10
+)
+
+var (
+	// ErrNoMatch is returned by RunBatch if there are no orders in the
+	// order book that can be matched.
+	ErrNoMatch = errors.New("no orders could be matched")
+
+	// dustLimitP2WPKH is the minimum value of the auctioneer's fee output.
+	dustLimitP2WPKH = txrules.GetDustThreshold(
+		input.P2WPKHSize, txrules.DefaultRelayFeePerKb,
+	)
+)
+
+// activeBatch is the batch that is currently being executed. All batch related
+// trader messages are forwarded to it.
+type activeBatch struct {
+	msgs chan *traderMsg
+	done chan struct{}
+}
+
+// matchedPair is an ask and a bid that were matched in a batch.
+type matchedPair struct {
+	ask   *bookOrder
+	bid   *bookOrder
+	units order.SupplyUnit
+}
+
+// batchSnapshot is everything we remember about an executed batch.
+type batchSnapshot struct {
+	id            order.BatchID
+	tx            *wire.MsgTx
+	clearingPrice order.FixedRatePremium
+	feeRate       chainfee.SatPerKWeight
+	pairs         []*matchedPair
+	diffs         map[[33]byte]*clmrpc.AccountDiff
+}
+
+// exclusions are the accounts and orders that are left out when a batch is
+// re-assembled because they caused a previous attempt to fail.
+type exclusions struct {
+	accounts map[[33]byte]struct{}
+	orders   map[order.Nonce]struct{}
+}
+
+// newExclusions creates an empty set of exclusions.
+func newExclusions() *exclusions {
+	return &exclusions{
+		accounts: make(map[[33]byte]struct{}),
+		orders:   make(map[order.Nonce]struct{}),
+	}
+}
+
+// empty returns true if nothing is excluded.
+func (e *exclusions) empty() bool {
+	return len(e.accounts) == 0 && len(e.orders) == 0
+}
+
+// preparedBatch is a batch that was assembled but not yet executed.
+type preparedBatch struct {
+	*batchSnapshot
+
+	// accounts are the states of all involved accounts before the batch.
+	accounts map[[33]byte]*account.Account
+
+	// endingBalances are the balances of all involved accounts after the
+	// batch.
+	endingBalances map[[33]byte]btcutil.Amount
+
+	// traders maps the stream of each involved trader to the accounts of
+	// that trader that are involved in the batch.
+	traders map[*traderConn]map[[33]byte]struct{}
+}
+
+// newPreparedBatch creates a new prepared batch for the given matches.
+func newPreparedBatch(id order.BatchID, clearingPrice order.FixedRatePremium,
+	feeRate chainfee.SatPerKWeight, pairs []*matchedPair) *preparedBatch {
+
+	return &preparedBatch{
+		batchSnapshot: &batchSnapshot{
+			id:            id,
+			clearingPrice: clearingPrice,
+			feeRate:       feeRate,
+			pairs:         pairs,
+			diffs:         make(map[[33]byte]*clmrpc.AccountDiff),
+		},
+		accounts:       make(map[[33]byte]*account.Account),
+		endingBalances: make(map[[33]byte]btcutil.Amount),
+		traders:        make(map[*traderConn]map[[33]byte]struct{}),
+	}
+}
+
+// RunBatch matches all orders of connected traders and executes the resulting
+// batch. Traders that reject the batch, don't respond in time or send invalid
+// signatures are excluded and the batch is re-assembled without them. If a
+// trader only rejects some of its orders, just those orders are excluded. The
+// fully signed batch transaction is returned. If no orders can be matched,
+// ErrNoMatch is returned.
+func (s *Server) RunBatch(ctx context.Context) (*wire.MsgTx, error) {
+	s.batchMtx.Lock()
+	defer s.batchMtx.Unlock()
+
+	excluded := newExclusions()
+	for i := 0; i < maxBatchAttempts; i++ {
+		batch, err := s.prepareBatch(excluded)
+		if err != nil {
+			return nil, err
+		}
+
+		failed, err := s.executeBatch(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		if failed.empty() {
+			return batch.tx, nil
+		}
+
+		for acctKey := range failed.accounts {
+			log.Infof("Excluding account %x from batch %x",
+				acctKey[:], batch.id[:])
+			excluded.accounts[acctKey] = struct{}{}
+		}
+		for nonce := range failed.orders {
+			log.Infof("Excluding order %v from batch %x", nonce,
+				batch.id[:])
+			excluded.orders[nonce] = struct{}{}
+		}
+	}
+
+	return nil, fmt.Errorf("batch failed after %d attempts",
+		maxBatchAttempts)
+}
+
+// prepareBatch matches all orders of online accounts that aren't excluded and
+// creates the batch transaction.
+func (s *Server) prepareBatch(excluded *exclusions) (*preparedBatch, error) {
+
+	bestHeight, err := s.bestHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Only accounts that are subscribed can take part in a batch.
+	online := make(map[[33]byte]*traderConn)
+	for conn := range s.conns {
+		for acctKey := range conn.accounts {
+			online[acctKey] = conn
+		}
+	}
+
+	usable := func(acctKey [33]byte) bool {
+		if _, ok := excluded.accounts[acctKey]; ok {
+			return false
+		}
+		if _, ok := online[acctKey]; !ok {
+			return false
+		}
+		acct, ok := s.accounts[acctKey]
+		if !ok || acct.State != account.StateOpen {
+			return false
+		}
+		return bestHeight == 0 || acct.Expiry > bestHeight
+	}
+
+	// Keep matching until all involved accounts can pay for their part of
+	// the batch. Accounts that can't are removed from the batch.
+	feeSchedule := order.NewLinearFeeSchedule(s.cfg.BaseFee, s.cfg.FeeRate)
+	for {
+		var asks, bids []*bookOrder
+		for _, o := range s.orders {
+			if !o.active() || !usable(o.details().AcctKey) {
+				continue
+			}
+			if _, ok := excluded.orders[o.details().Nonce()]; ok {
+				continue
+			}
+			if o.ask != nil {
+				asks = append(asks, o)
+			} else {
+				bids = append(bids, o)
+			}
+		}
+
+		pairs, clearingPrice := matchOrders(asks, bids)
+		if len(pairs) == 0 {
+			return nil, ErrNoMatch
+		}
+
+		tallies := make(map[[33]byte]*order.AccountTally)
+		tally := func(acctKey [33]byte) *order.AccountTally {
+			t, ok := tallies[acctKey]
+			if !ok {
+				acct := s.accounts[acctKey]
+				t = &order.AccountTally{
+					Version:       *s.cfg.BatchVersion,
+					EndingBalance: acct.Value,
+				}
+				tallies[acctKey] = t
+			}
+			return t
+		}
+		for _, pair := range pairs {
+			amt := pair.units.ToSatoshis()
+			duration := pair.bid.Order.(*order.Bid).MinDuration
+
+			maker := tally(pair.ask.details().AcctKey)
+			_, err := maker.CalcMakerDelta(
+				feeSchedule, clearingPrice, amt, duration,
+			)
+			if err != nil {
+				return nil, err
+			}
+			maker.NumChansCreated++
+
+			taker := tally(pair.bid.details().AcctKey)
+			_, err = taker.CalcTakerDelta(
+				feeSchedule, clearingPrice, amt, duration,
+			)
+			if err != nil {
+				return nil, err
+			}
+			taker.NumChansCreated++
+		}
+
+		insufficient := false
+		for acctKey, t := range tallies {
+			t.ChainFees(s.cfg.BatchFeeRate)
+			if t.EndingBalance < 0 {
+				log.Infof("Account %x has insufficient "+
+					"balance for batch", acctKey[:])
+				excluded.accounts[acctKey] = struct{}{}
+				insufficient = true
+			}
+		}
+		if insufficient {
+			continue
+		}
+
+		batch := newPreparedBatch(
+			order.NewBatchID(s.batchKey), clearingPrice,
+			s.cfg.BatchFeeRate, pairs,
+		)
+		for acctKey, t := range tallies {
+			batch.accounts[acctKey] = s.accounts[acctKey]
+			batch.endingBalances[acctKey] = t.EndingBalance
+
+			conn := online[acctKey]
+			acctKeys, ok := batch.traders[conn]
+			if !ok {
+				acctKeys = make(map[[33]byte]struct{})
+				batch.traders[conn] = acctKeys
+			}
+			acctKeys[acctKey] = struct{}{}
+		}
+
+		if err := s.createBatchTx(batch); err != nil {
+			return nil, err
+		}
+		return batch, nil
+	}
+}
+
+// matchOrders is a simple uniform price matcher. Bids are matched with the
+// highest rate first against the asks with the lowest rate first. The clearing
+// price is the highest rate of all matched asks. Pairs whose bid rate is below
+// that price are removed so every matched order is happy with the clearing
+// price. Pairs below either order's minimum units per match are skipped and
+// all-or-none orders that can't be filled completely are left out entirely.
+func matchOrders(asks, bids []*bookOrder) ([]*matchedPair,
+	order.FixedRatePremium) {
+
+	sortOrders(asks, func(a, b uint32) bool { return a < b })
+	sortOrders(bids, func(a, b uint32) bool { return a > b })
+
+	// Every all-or-none order that isn't filled completely is excluded
+	// and the matching is started over, until all of them are either
+	// filled completely or excluded.
+	excluded := make(map[order.Nonce]struct{})
+	for {
+		pairs, clearingPrice := matchPairs(asks, bids, excluded)
+
+		filled := make(map[order.Nonce]order.SupplyUnit)
+		for _, pair := range pairs {
+			filled[pair.ask.details().Nonce()] += pair.units
+			filled[pair.bid.details().Nonce()] += pair.units
+		}
+		var incomplete bool
+		for _, o := range append(asks, bids...) {
+			kit := o.details()
+			_, ok := filled[kit.Nonce()]
+			if ok && kit.AllOrNone &&
+				filled[kit.Nonce()] != kit.UnitsUnfulfilled {
+
+				excluded[kit.Nonce()] = struct{}{}
+				incomplete = true
+			}
+		}
+		if !incomplete {
+			return pairs, clearingPrice
+		}
+	}
+}
+
+// matchPairs matches the sorted bids against the sorted asks, skipping all
+// excluded orders, and finds the clearing price of the resulting pairs.
+func matchPairs(asks, bids []*bookOrder,
+	excluded map[order.Nonce]struct{}) ([]*matchedPair,
+	order.FixedRatePremium) {
+
+	remaining := make(map[order.Nonce]order.SupplyUnit)
+	for _, o := range append(asks, bids...) {
+		if _, ok := excluded[o.details().Nonce()]; ok {
+			continue
+		}
+		remaining[o.details().Nonce()] = o.details().UnitsUnfulfilled
+	}
+
+	var pairs []*matchedPair
+	for _, bid := range bids {
+		bidOrder := bid.Order.(*order.Bid)
+		for _, ask := range asks {
+			askOrder := ask.Order.(*order.Ask)
+			if askOrder.FixedRate > bidOrder.FixedRate {
+				break
+			}
+
+			bidUnits := remaining[bidOrder.Nonce()]
+			askUnits := remaining[askOrder.Nonce()]
+			if bidUnits == 0 {
+				break
+			}
+			if askUnits == 0 ||
+				askOrder.MaxDuration < bidOrder.MinDuration ||
+				ask.NodeKey == bid.NodeKey {
+
+				continue
+			}
+
+			units := bidUnits
+			if askUnits < units {
+				units = askUnits
+			}
+			if units < askOrder.MinUnitsMatch ||
+				units < bidOrder.MinUnitsMatch {
+
+				continue
+			}
+			remaining[bidOrder.Nonce()] -= units
+			remaining[askOrder.Nonce()] -= units
+			pairs = append(pairs, &matchedPair{
+				ask:   ask,
+				bid:   bid,
+				units: units,
+			})
+		}
+	}
+
+	// Find a uniform clearing price all remaining pairs agree with.
+	for {
+		var clearingPrice uint32
+		for _, pair := range pairs {
+			if pair.ask.details().FixedRate > clearingPrice {
+				clearingPrice = pair.ask.details().FixedRate
+			}
+		}
+
+		filtered := pairs[:0]
+		for _, pair := range pairs {
+			if pair.bid.details().FixedRate >= clearingPrice {
+				filtered = append(filtered, pair)
+			}
+		}
+		if len(filtered) == len(pairs) {
+			return pairs, order.FixedRatePremium(clearingPrice)
+		}
+		pairs = filtered
+	}
+}
+
+// sortOrders sorts the orders by their rate, using the nonce as tie breaker to
+// make the matching deterministic.
+func sortOrders(orders []*bookOrder, less func(a, b uint32) bool) {
+	sort.Slice(orders, func(i, j int) bool {
+		a, b := orders[i].details(), orders[j].details()
+		if a.FixedRate != b.FixedRate {
+			return less(a.FixedRate, b.FixedRate)
+		}
+		nonceA, nonceB := a.Nonce(), b.Nonce()
+		return bytes.Compare(nonceA[:], nonceB[:]) < 0
+	})
+}
+
+// createBatchTx creates the batch transaction that spends all involved
+// accounts and creates the channel outputs, the re-created account outputs
+// and the auctioneer's fee output.
+func (s *Server) createBatchTx(batch *preparedBatch) error {
+	var (
+		tx        = wire.NewMsgTx(2)
+		weight    input.TxWeightEstimator
+		totalIn   btcutil.Amount
+		totalOut  btcutil.Amount
+		acctKeys  = make([][33]byte, 0, len(batch.accounts))
+		addOutput = func(value btcutil.Amount, script []byte) int32 {
+			tx.AddTxOut(&wire.TxOut{
+				Value:    int64(value),
+				PkScript: script,
+			})
+			totalOut += value
+			return int32(len(tx.TxOut) - 1)
+		}
+	)
+
+	for acctKey := range batch.accounts {
+		acctKeys = append(acctKeys, acctKey)
+	}
+	sort.Slice(acctKeys, func(i, j int) bool {
+		return bytes.Compare(acctKeys[i][:], acctKeys[j][:]) < 0
+	})
+
+	for _, acctKey := range acctKeys {
+		acct := batch.accounts[acctKey]
+		tx.AddTxIn(&wire.TxIn{PreviousOutPoint: acct.OutPoint})
+		weight.AddWitnessInput(clmscript.MultiSigWitnessSize)
+		totalIn += acct.Value
+	}
+
+	for _, pair := range batch.pairs {
+		amt := pair.units.ToSatoshis()
+		_, out, err := input.GenFundingPkScript(
+			pair.ask.MultiSigKey[:], pair.bid.MultiSigKey[:],
+			int64(amt),
+		)
+		if err != nil {
+			return err
+		}
+		addOutput(amt, out.PkScript)
+		weight.AddP2WSHOutput()
+	}
+
+	for _, acctKey := range acctKeys {
+		acct := batch.accounts[acctKey]
+		balance := batch.endingBalances[acctKey]
+		diff := &clmrpc.AccountDiff{
+			EndingBalance: uint64(balance),
+			OutpointIndex: -1,
+			TraderKey:     acctKey[:],
+		}
+
+		switch {
+		case balance >= order.MinNoDustAccountSize:
+			script, err := acct.NextOutputScript()
+			if err != nil {
+				return err
+			}
+			diff.EndingState = clmrpc.AccountDiff_OUTPUT_RECREATED
+			diff.OutpointIndex = addOutput(balance, script)
+			weight.AddP2WSHOutput()
+
+		case balance == 0:
+			diff.EndingState = clmrpc.AccountDiff_OUTPUT_FULLY_SPENT
+
+		default:
+			diff.EndingState =
+				clmrpc.AccountDiff_OUTPUT_DUST_ADDED_TO_FEES
+		}
+		batch.diffs[acctKey] = diff
+	}
+
+	// Everything that's left after paying the chain fees goes to the
+	// auctioneer. If that is dust, it's added to the chain fees.
+	weight.AddP2WKHOutput()
+	chainFee := s.cfg.BatchFeeRate.FeeForWeight(int64(weight.Weight()))
+	auctioneerValue := totalIn - totalOut - chainFee
+	if auctioneerValue < 0 {
+		return fmt.Errorf("batch can't pay chain fee of %v", chainFee)
+	}
+	if auctioneerValue >= dustLimitP2WPKH {
+		script, err := input.CommitScriptUnencumbered(
+			s.auctioneerKey.PubKey(),
+		)
+		if err != nil {
+			return err
+		}
+		addOutput(auctioneerValue, script)
+	}
+
+	batch.tx = tx
+	return nil
+}
+
+// executeBatch sends the batch to all involved traders and collects their
+// signatures. The accounts of all traders that failed to take part in the
+// batch and the orders that were rejected individually are returned. If
+// nothing is returned, the batch was executed.
+func (s *Server) executeBatch(ctx context.Context,
+	batch *preparedBatch) (*exclusions, error) {
+
+	active := &activeBatch{
+		msgs: make(chan *traderMsg, len(batch.traders)),
+		done: make(chan struct{}),
+	}
+	s.mu.Lock()
+	s.activeBatch = active
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.activeBatch = nil
+		s.mu.Unlock()
+		close(active.done)
+	}()
+
+	// failed collects the accounts of all traders that dropped out and
+	// the orders that were rejected.
+	failed := newExclusions()
+	fail := func(conn *traderConn) {
+		for acctKey := range batch.traders[conn] {
+			failed.accounts[acctKey] = struct{}{}
+		}
+	}
+
+	log.Infof("Preparing batch %x with %d matched pairs at clearing "+
+		"price %d", batch.id[:], len(batch.pairs), batch.clearingPrice)
+
+	for conn, acctKeys := range batch.traders {
+		msg, err := s.prepareMsg(batch, acctKeys)
+		if err != nil {
+			return nil, err
+		}
+		if err := conn.send(msg); err != nil {
+			log.Errorf("Unable to send prepare: %v", err)
+			fail(conn)
+		}
+	}
+	if !failed.empty() {
+		return failed, nil
+	}
+
+	// Wait for every trader to accept the batch.
+	err := s.collectResponses(ctx, active, batch, func(conn *traderConn,
+		msg *clmrpc.ClientAuctionMessage) (bool, error) {
+
+		switch m := msg.Msg.(type) {
+		case *clmrpc.ClientAuctionMessage_Accept:
+			if !bytes.Equal(m.Accept.BatchId, batch.id[:]) {
+				return false, nil
+			}
+			if len(m.Accept.OrderNonce) == 0 {
+				fail(conn)
+			}
+			return true, nil
+
+		case *clmrpc.ClientAuctionMessage_Reject:
+			if !bytes.Equal(m.Reject.BatchId, batch.id[:]) {
+				return false, nil
+			}
+			log.Infof("Trader rejected batch with code %v: %v",
+				m.Reject.ReasonCode, m.Reject.Reason)
+			if !s.rejectOrders(conn, m.Reject, failed.orders) {
+				fail(conn)
+			}
+			return true, nil
+
+		default:
+			return false, nil
+		}
+	}, fail)
+	if err != nil || !failed.empty() {
+		return failed, err
+	}
+
+	// Everyone accepted, so we can ask for the signatures now.
+	for conn := range batch.traders {
+		err := conn.send(&clmrpc.ServerAuctionMessage{
+			Msg: &clmrpc.ServerAuctionMessage_Sign{
+				Sign: &clmrpc.OrderMatchSignBegin{
+					BatchId: batch.id[:],
+				},
+			},
+		})
+		if err != nil {
+			log.Errorf("Unable to send sign begin: %v", err)
+			fail(conn)
+		}
+	}
+	if !failed.empty() {
+		return failed, nil
+	}
+
+	hashes := txscript.NewTxSigHashes(batch.tx)
+	err = s.collectResponses(ctx, active, batch, func(conn *traderConn,
+		msg *clmrpc.ClientAuctionMessage) (bool, error) {
+
+		switch m := msg.Msg.(type) {
+		case *clmrpc.ClientAuctionMessage_Sign:
+			if !bytes.Equal(m.Sign.BatchId, batch.id[:]) {
+				return false, nil
+			}
+			err := s.addWitnesses(
+				batch, hashes, batch.traders[conn],
+				m.Sign.AccountSigs,
+			)
+			if err != nil {
+				log.Errorf("Invalid batch signatures: %v", err)
+				fail(conn)
+			}
+			return true, nil
+
+		case *clmrpc.ClientAuctionMessage_Reject:
+			if !bytes.Equal(m.Reject.BatchId, batch.id[:]) {
+				return false, nil
+			}
+			log.Infof("Trader rejected batch with code %v: %v",
+				m.Reject.ReasonCode, m.Reject.Reason)
+			fail(conn)
+			return true, nil
+
+		default:
+			return false, nil
+		}
+	}, fail)
+	if err != nil || !failed.empty() {
+		return failed, err
+	}
+
+	// The batch transaction is complete now.
+	if s.cfg.PublishTransaction != nil {
+		if err := s.cfg.PublishTransaction(batch.tx); err != nil {
+			return nil, fmt.Errorf("unable to publish batch tx: %v",
+				err)
+		}
+	}
+	heightHint, err := s.bestHeight()
+	if err != nil {
+		return nil, err
+	}
+	s.applyBatch(batch, heightHint)
+
+	txid := batch.tx.TxHash()
+	log.Infof("Batch %x executed in tx %v", batch.id[:], txid)
+
+	for conn := range batch.traders {
+		err := conn.send(&clmrpc.ServerAuctionMessage{
+			Msg: &clmrpc.ServerAuctionMessage_Finalize{
+				Finalize: &clmrpc.OrderMatchFinalize{
+					BatchId:    batch.id[:],
+					BatchTxid:  txid[:],
+					HeightHint: heightHint,
+				},
+			},
+		})
+		if err != nil {
+			// The batch is executed already, the trader will
+			// find out through the batch snapshot on reconnect.
+			log.Errorf("Unable to send finalize: %v", err)
+		}
+	}
+
+	return failed, nil
+}
+
+// collectResponses waits until every trader of the batch sent a response that
+// is accepted by the handler. Traders that don't respond in time are failed.
+func (s *Server) collectResponses(ctx context.Context, active *activeBatch,
+	batch *preparedBatch, handle func(*traderConn,
+		*clmrpc.ClientAuctionMessage) (bool, error),
+	fail func(*traderConn)) error {
+
+	pending := make(map[*traderConn]struct{}, len(batch.traders))
+	for conn := range batch.traders {
+		pending[conn] = struct{}{}
+	}
+
+	timeout := time.After(s.cfg.ResponseTimeout)
+	for len(pending) > 0 {
+		select {
+		case traderMsg := <-active.msgs:
+			if _, ok := pending[traderMsg.conn]; !ok {
+				continue
+			}
+			handled, err := handle(traderMsg.conn, traderMsg.msg)
+			if err != nil {
+				return err
+			}
+			if handled {
+				delete(pending, traderMsg.conn)
+			}
+
+		case <-timeout:
+			for conn := range pending {
+				log.Infof("Trader didn't respond to batch %x "+
+					"in time", batch.id[:])
+				fail(conn)
+			}
+			return nil
+
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-s.quit:
+			return ErrServerShutdown
+		}
+	}
+
+	return nil
+}
+
+// addWitnesses adds the witnesses of a trader's accounts to the batch
+// transaction and makes sure they are valid.
+func (s *Server) addWitnesses(batch *preparedBatch,
+	hashes *txscript.TxSigHashes, acctKeys map[[33]byte]struct{},
+	sigs map[string][]byte) error {
+
+	for acctKey := range acctKeys {
+		traderSig, ok := sigs[hex.EncodeToString(acctKey[:])]
+		if !ok {
+			return fmt.Errorf("signature for account %x missing",
+				acctKey[:])
+		}
+
+		acct := batch.accounts[acctKey]
+		idx := -1
+		for i, in := range batch.tx.TxIn {
+			if in.PreviousOutPoint == acct.OutPoint {
+				idx = i
+			}
+		}
+		ourSig, err := s.signAccountInput(batch.tx, hashes, idx, acct)
+		if err != nil {
+			return err
+		}
+		witnessScript, err := clmscript.AccountWitnessScript(
+			acct.Expiry, acct.TraderKey.PubKey, acct.AuctioneerKey,
+			acct.BatchKey, acct.Secret,
+		)
+		if err != nil {
+			return err
+		}
+		batch.tx.TxIn[idx].Witness = clmscript.SpendMultiSig(
+			witnessScript,
+			append(traderSig, byte(txscript.SigHashAll)), ourSig,
+		)
+
+		// Make sure the trader's signature is actually valid.
+		prevOut, err := acct.Output()
+		if err != nil {
+			return err
+		}
+		vm, err := txscript.NewEngine(
+			prevOut.PkScript, batch.tx, idx,
+			txscript.StandardVerifyFlags, nil, hashes,
+			prevOut.Value,
+		)
+		if err != nil {
+			return err
+		}
+		if err := vm.Execute(); err != nil {
+			batch.tx.TxIn[idx].Witness = nil
+			return fmt.Errorf("invalid signature for account %x: "+
+				"%v", acctKey[:], err)
+		}
+	}
+
+	return nil
+}
+
+// applyBatch applies all changes of an executed batch to the accounts and
+// orders and moves on to the next batch key.
+func (s *Server) applyBatch(batch *preparedBatch, heightHint uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	txid := batch.tx.TxHash()
+	for acctKey, diff := range batch.diffs {
+		acct := s.accounts[acctKey]
+		newValue := btcutil.Amount(diff.EndingBalance)
+		modifiers := []account.Modifier{
+			account.ValueModifier(newValue),
+		}
+		if diff.EndingState == clmrpc.AccountDiff_OUTPUT_RECREATED {
+			modifiers = append(
+				modifiers, account.IncrementBatchKey(),
+				account.OutPointModifier(wire.OutPoint{
+					Hash:  txid,
+					Index: uint32(diff.OutpointIndex),
+				}),
+			)
+		} else {
+			modifiers = append(
+				modifiers,
+				account.StateModifier(account.StateClosed),
+				account.CloseTxModifier(batch.tx),
+			)
+		}
+		newAcct := acct.Copy(modifiers...)
+		newAcct.HeightHint = heightHint
+		s.accounts[acctKey] = newAcct
+	}
+
+	for _, pair := range batch.pairs {
+		for _, o := range []*bookOrder{pair.ask, pair.bid} {
+			kit := o.details()
+			kit.UnitsUnfulfilled -= pair.units
+			kit.State = order.StatePartiallyFilled
+			if kit.UnitsUnfulfilled == 0 {
+				kit.State = order.StateExecuted
+			}
+		}
+	}
+
+	s.batches[batch.id] = batch.batchSnapshot
+	s.batchKey = clmscript.IncrementKey(s.batchKey)
+}
+
+// prepareMsg creates the prepare message for a trader with the given accounts.
+func (s *Server) prepareMsg(batch *preparedBatch,
+	acctKeys map[[33]byte]struct{}) (*clmrpc.ServerAuctionMessage, error) {
+
+	var txBuf bytes.Buffer
+	if err := batch.tx.Serialize(&txBuf); err != nil {
+		return nil, err
+	}
+
+	diffs := make([]*clmrpc.AccountDiff, 0, len(acctKeys))
+	for acctKey := range acctKeys {
+		diffs = append(diffs, batch.diffs[acctKey])
+	}
+
+	return &clmrpc.ServerAuctionMessage{
+		Msg: &clmrpc.ServerAuctionMessage_Prepare{
+			Prepare: &clmrpc.OrderMatchPrepare{
+				MatchedOrders: matchedOrders(
+					batch.pairs, acctKeys,
+				),
+				ClearingPriceRate: uint32(batch.clearingPrice),
+				ChargedAccounts:   diffs,
+				ExecutionFee:      s.executionFee(),
+				BatchTransaction:  txBuf.Bytes(),
+				FeeRateSatPerKw:   uint64(batch.feeRate),
+				BatchId:           batch.id[:],
+				BatchVersion:      uint32(*s.cfg.BatchVersion),
+			},
+		},
+	}, nil
+}
+
+// matchedOrders returns the RPC representation of all matches that involve
+// orders of the given accounts, keyed by the hex encoded nonce of the
+// account's order.
+func matchedOrders(pairs []*matchedPair,
+	acctKeys map[[33]byte]struct{}) map[string]*clmrpc.MatchedOrder {
+
+	result := make(map[string]*clmrpc.MatchedOrder)
+	matched := func(o *bookOrder) *clmrpc.MatchedOrder {
+		key := o.details().Nonce().String()
+		if result[key] == nil {
+			result[key] = &clmrpc.MatchedOrder{}
+		}
+		return result[key]
+	}
+
+	for _, pair := range pairs {
+		if _, ok := acctKeys[pair.ask.details().AcctKey]; ok {
+			m := matched(pair.ask)
+			m.MatchedBids = append(
+				m.MatchedBids, &clmrpc.MatchedBid{
+					Bid:         pair.bid.bid,
+					UnitsFilled: uint32(pair.units),
+				},
+			)
+		}
+		if _, ok := acctKeys[pair.bid.details().AcctKey]; ok {
+			m := matched(pair.bid)
+			m.MatchedAsks = append(
+				m.MatchedAsks, &clmrpc.MatchedAsk{
+					Ask:         pair.ask.ask,
+					UnitsFilled: uint32(pair.units),
+				},
+			)
+		}
+	}
+
+	return result
+}
+
+// RelevantBatchSnapshot returns the parts of an executed batch that are
+// relevant to the given accounts.
+//
+// NOTE: This is part of the ChannelAuctioneerServer interface.
+func (s *Server) RelevantBatchSnapshot(_ context.Context,
+	req *clmrpc.RelevantBatchRequest) (*clmrpc.RelevantBatch, error) {
+
+	var id order.BatchID
+	copy(id[:], req.Id)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	batch, ok := s.batches[id]
+	if !ok {
+		return nil, auctioneer.NewError(
+			auctioneer.ErrBatchNotFinalized, "%v for batch %x",
+			auctioneer.ErrBatchNotFinalized, id[:],
+		)
+	}
+
+	acctKeys := make(map[[33]byte]struct{}, len(req.Accounts))
+	var diffs []*clmrpc.AccountDiff
+	for _, rawAcctKey := range req.Accounts {
+		_, acctKey, err := parseKey(rawAcctKey)
+		if err != nil {
+			return nil, err
+		}
+		acctKeys[acctKey] = struct{}{}
+		if diff, ok := batch.diffs[acctKey]; ok {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	var txBuf bytes.Buffer
+	if err := batch.tx.Serialize(&txBuf); err != nil {
+		return nil, err
+	}
+
+	return &clmrpc.RelevantBatch{
+		Version:           uint32(*s.cfg.BatchVersion),
+		Id:                batch.id[:],
+		ChargedAccounts:   diffs,
+		MatchedOrders:     matchedOrders(batch.pairs, acctKeys),
+		ClearingPriceRate: uint32(batch.clearingPrice),
+		ExecutionFee:      s.executionFee(),
+		Transaction:       txBuf.Bytes(),
+		FeeRateSatPerKw:   uint64(batch.feeRate),
+	}, nil
+}
+
+// rejectOrders adds the orders a trader rejected individually to the given
+// set. False is returned if the trader didn't reject single orders or rejected
+// an order that isn't its own, in which case the whole trader has to be
+// excluded.
+func (s *Server) rejectOrders(conn *traderConn, reject *clmrpc.OrderMatchReject,
+	rejected map[order.Nonce]struct{}) bool {
+
+	if len(reject.RejectedOrders) == 0 {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nonces := make([]order.Nonce, 0, len(reject.RejectedOrders))
+	for nonceStr := range reject.RejectedOrders {
+		nonceBytes, err := hex.DecodeString(nonceStr)
+		if err != nil || len(nonceBytes) != len(order.Nonce{}) {
+			return false
+		}
+		var nonce order.Nonce
+		copy(nonce[:], nonceBytes)
+
+		o, ok := s.orders[nonce]
+		if !ok {
+			return false
+		}
+		if _, ok := conn.accounts[o.details().AcctKey]; !ok {
+			return false
+		}
+		nonces = append(nonces, nonce)
+	}
+
+	for _, nonce := range nonces {
+		rejected[nonce] = struct{}{}
+	}
+	return true
+}
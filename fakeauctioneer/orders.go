@@ -0,0 +1,271 @@
+package fakeauctioneer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/auctioneer"
+	"github.com/lightninglabs/llm/clmrpc"
+	"github.com/lightninglabs/llm/order"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// bookOrder is an order in the order book of the fake auctioneer.
+type bookOrder struct {
+	// MatchedOrder contains the parsed order together with the node
+	// information of the trader that submitted it.
+	*order.MatchedOrder
+
+	// ask is the order as it was submitted if it is an ask.
+	ask *clmrpc.ServerAsk
+
+	// bid is the order as it was submitted if it is a bid.
+	bid *clmrpc.ServerBid
+}
+
+// details returns the common details of the order.
+func (o *bookOrder) details() *order.Kit {
+	return o.Order.Details()
+}
+
+// active returns true if the order can still be matched.
+func (o *bookOrder) active() bool {
+	switch o.details().State {
+	case order.StateSubmitted, order.StatePartiallyFilled:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// SubmitOrder validates an order and adds it to the order book.
+//
+// NOTE: This is part of the ChannelAuctioneerServer interface.
+func (s *Server) SubmitOrder(_ context.Context,
+	req *clmrpc.ServerSubmitOrderRequest) (
+	*clmrpc.ServerSubmitOrderResponse, error) {
+
+	var (
+		o       = &bookOrder{}
+		details *clmrpc.ServerOrder
+		err     error
+	)
+	switch d := req.Details.(type) {
+	case *clmrpc.ServerSubmitOrderRequest_Ask:
+		o.ask = d.Ask
+		details = d.Ask.Details
+		o.MatchedOrder, err = order.ParseRPCServerAsk(d.Ask)
+
+	case *clmrpc.ServerSubmitOrderRequest_Bid:
+		o.bid = d.Bid
+		details = d.Bid.Details
+		o.MatchedOrder, err = order.ParseRPCServerBid(d.Bid)
+
+	default:
+		return nil, fmt.Errorf("invalid order type %T", d)
+	}
+	if details == nil || len(details.OrderNonce) != 32 {
+		return nil, fmt.Errorf("order nonce missing")
+	}
+	if err != nil {
+		return nil, err
+	}
+	kit := o.details()
+
+	// The amount must be a whole number of supply units within the order
+	// size limits.
+	err = order.ValidateOrderSize(kit.Amt, kit.MinUnitsMatch)
+	if err != nil {
+		nonce := kit.Nonce()
+		invalid := &clmrpc.InvalidOrder{
+			OrderNonce: nonce[:],
+			FailReason: clmrpc.InvalidOrder_INVALID_AMT,
+			FailString: err.Error(),
+		}
+		return &clmrpc.ServerSubmitOrderResponse{
+			Details: &clmrpc.ServerSubmitOrderResponse_InvalidOrder{
+				InvalidOrder: invalid,
+			},
+		}, nil
+	}
+
+	// The fill constraints are only covered by the signature of orders
+	// with a version that knows about them.
+	if kit.Version < order.VersionFillConstraints &&
+		(kit.MinUnitsMatch != 0 || kit.AllOrNone) {
+
+		return nil, fmt.Errorf("fill constraints require order "+
+			"version %d", order.VersionFillConstraints)
+	}
+
+	traderKey, traderKeyRaw, err := parseKey(details.TraderKey)
+	if err != nil {
+		return nil, err
+	}
+	err = verifyOrderSig(o.Order, details.OrderSig, traderKey)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	acct, ok := s.accounts[traderKeyRaw]
+	if !ok {
+		return nil, auctioneer.NewError(
+			auctioneer.ErrAccountNotFound, "account %x not found",
+			traderKeyRaw[:],
+		)
+	}
+	if acct.State != account.StateOpen {
+		return nil, fmt.Errorf("account %x in state %v can't be used "+
+			"for orders", traderKeyRaw[:], acct.State)
+	}
+	if o.ask != nil && kit.Amt > acct.Value {
+		return nil, auctioneer.NewError(
+			auctioneer.ErrInsufficientBalance,
+			"insufficient account balance for ask",
+		)
+	}
+	if _, ok := s.orders[kit.Nonce()]; ok {
+		return nil, fmt.Errorf("order %v already exists", kit.Nonce())
+	}
+
+	kit.State = order.StateSubmitted
+	s.orders[kit.Nonce()] = o
+
+	log.Infof("Accepted order %v of %v at rate %d from account %x",
+		kit.Nonce(), kit.Amt, kit.FixedRate, traderKeyRaw[:])
+
+	return &clmrpc.ServerSubmitOrderResponse{
+		Details: &clmrpc.ServerSubmitOrderResponse_Accepted{
+			Accepted: true,
+		},
+	}, nil
+}
+
+// CancelOrder removes an order from the order book.
+//
+// NOTE: This is part of the ChannelAuctioneerServer interface.
+func (s *Server) CancelOrder(_ context.Context,
+	req *clmrpc.ServerCancelOrderRequest) (
+	*clmrpc.ServerCancelOrderResponse, error) {
+
+	var nonce order.Nonce
+	copy(nonce[:], req.OrderNonce)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.orders[nonce]
+	if !ok {
+		return nil, auctioneer.NewError(
+			auctioneer.ErrOrderNotFound, "order %v not found",
+			nonce,
+		)
+	}
+	if !o.active() {
+		return nil, fmt.Errorf("order %v in state %v can't be "+
+			"canceled", nonce, o.details().State)
+	}
+	o.details().State = order.StateCanceled
+
+	log.Infof("Canceled order %v", nonce)
+
+	return &clmrpc.ServerCancelOrderResponse{}, nil
+}
+
+// OrderState returns the current state of an order.
+//
+// NOTE: This is part of the ChannelAuctioneerServer interface.
+func (s *Server) OrderState(_ context.Context,
+	req *clmrpc.ServerOrderStateRequest) (*clmrpc.ServerOrderStateResponse,
+	error) {
+
+	var nonce order.Nonce
+	copy(nonce[:], req.OrderNonce)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.orders[nonce]
+	if !ok {
+		return nil, auctioneer.NewError(
+			auctioneer.ErrOrderNotFound, "order %v not found",
+			nonce,
+		)
+	}
+
+	var state clmrpc.OrderState
+	switch o.details().State {
+	case order.StateSubmitted:
+		state = clmrpc.OrderState_ORDER_SUBMITTED
+
+	case order.StateCleared:
+		state = clmrpc.OrderState_ORDER_CLEARED
+
+	case order.StatePartiallyFilled:
+		state = clmrpc.OrderState_ORDER_PARTIALLY_FILLED
+
+	case order.StateExecuted:
+		state = clmrpc.OrderState_ORDER_EXECUTED
+
+	case order.StateCanceled:
+		state = clmrpc.OrderState_ORDER_CANCELED
+
+	case order.StateExpired:
+		state = clmrpc.OrderState_ORDER_EXPIRED
+
+	default:
+		state = clmrpc.OrderState_ORDER_FAILED
+	}
+
+	return &clmrpc.ServerOrderStateResponse{
+		State:            state,
+		UnitsUnfulfilled: uint32(o.details().UnitsUnfulfilled),
+	}, nil
+}
+
+// hasActiveOrders returns true if the account with the given key has orders
+// that can still be matched. The caller must hold the server's mutex.
+func (s *Server) hasActiveOrders(traderKey [33]byte) bool {
+	for _, o := range s.orders {
+		if o.details().AcctKey == traderKey && o.active() {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyOrderSig makes sure the order's digest was signed by the account key.
+// The trader signs with lnd's SignMessage, which signs the SHA256 of the
+// digest and returns a fixed-size wire signature.
+func verifyOrderSig(o order.Order, rawSig []byte,
+	traderKey *btcec.PublicKey) error {
+
+	digest, err := o.Digest()
+	if err != nil {
+		return err
+	}
+	if !verifyMessageSig(digest[:], rawSig, traderKey) {
+		return fmt.Errorf("invalid order signature")
+	}
+	return nil
+}
+
+// verifyMessageSig verifies a signature created with lnd's SignMessage RPC.
+func verifyMessageSig(msg, rawSig []byte, pubKey *btcec.PublicKey) bool {
+	wireSig, err := lnwire.NewSigFromRawSignature(rawSig)
+	if err != nil {
+		return false
+	}
+	sig, err := wireSig.ToSignature()
+	if err != nil {
+		return false
+	}
+	return sig.Verify(chainhash.HashB(msg), pubKey)
+}
@@ -0,0 +1,281 @@
+package fakeauctioneer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/clmrpc"
+	"github.com/lightninglabs/llm/order"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"google.golang.org/grpc"
+)
+
+const (
+	// DefaultBaseFee is the default base fee in satoshis that is charged
+	// per matched order.
+	DefaultBaseFee btcutil.Amount = 1
+
+	// DefaultFeeRate is the default execution fee rate in parts per
+	// million.
+	DefaultFeeRate btcutil.Amount = 1000
+
+	// DefaultBatchFeeRate is the default fee rate of the batch
+	// transaction.
+	DefaultBatchFeeRate = chainfee.FeePerKwFloor
+
+	// DefaultResponseTimeout is the default time the traders have to
+	// respond to a batch message.
+	DefaultResponseTimeout = 30 * time.Second
+)
+
+var (
+	// ErrServerShutdown is returned if the fake auctioneer is shutting
+	// down.
+	ErrServerShutdown = errors.New("fake auctioneer shutting down")
+)
+
+// Config contains all the options of the fake auctioneer.
+type Config struct {
+	// AuctioneerKey is the auctioneer's private key. All account outputs
+	// are 2-of-2 multisig outputs with a tweaked version of this key. If
+	// it is nil, a random key is generated.
+	AuctioneerKey *btcec.PrivateKey
+
+	// InitialBatchKey is the batch key that is handed out to the first
+	// accounts and used as the ID of the first batch. If it is nil, a
+	// random key is generated.
+	InitialBatchKey *btcec.PublicKey
+
+	// BaseFee is the base fee in satoshis that is charged per matched
+	// order.
+	BaseFee btcutil.Amount
+
+	// FeeRate is the execution fee rate in parts per million.
+	FeeRate btcutil.Amount
+
+	// BatchFeeRate is the fee rate of the batch transaction.
+	BatchFeeRate chainfee.SatPerKWeight
+
+	// ResponseTimeout is the time the traders have to respond to a batch
+	// message before the batch is aborted.
+	ResponseTimeout time.Duration
+
+	// BestHeight returns the current block height. If it is nil, the
+	// height is assumed to be zero which means accounts never expire.
+	BestHeight func() (uint32, error)
+
+	// PublishTransaction is called with the fully signed batch
+	// transaction. If it is nil, the transaction is not published, which
+	// is what integration tests without a chain backend want.
+	PublishTransaction func(*wire.MsgTx) error
+
+	// BatchVersion is the version of the batch verification protocol the
+	// server uses. Traders that announce a newer version are declined,
+	// just like an older auctioneer would. If it is nil, the current
+	// version is used.
+	BatchVersion *order.BatchVersion
+}
+
+// Server is a fake auctioneer that implements the full ChannelAuctioneer gRPC
+// service in memory. It is meant to be used in integration tests and for
+// local development against a regtest lnd and is NOT a secure auction server.
+// In contrast to the real auctioneer, accounts are considered to be open as
+// soon as they are initialized and batches are only executed if RunBatch is
+// called.
+type Server struct {
+	started uint32
+	stopped uint32
+
+	cfg *Config
+
+	auctioneerKey *btcec.PrivateKey
+	grpcServer    *grpc.Server
+
+	// mu guards all fields below as well as the state of the accounts and
+	// orders they reference.
+	mu           sync.Mutex
+	batchKey     *btcec.PublicKey
+	reservations map[[33]byte]*btcec.PublicKey
+	accounts     map[[33]byte]*account.Account
+	orders       map[order.Nonce]*bookOrder
+	conns        map[*traderConn]struct{}
+	batches      map[order.BatchID]*batchSnapshot
+	activeBatch  *activeBatch
+
+	// batchMtx makes sure only one batch is executed at a time.
+	batchMtx sync.Mutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// A compile-time check to make sure Server implements the ChannelAuctioneer
+// gRPC service.
+var _ clmrpc.ChannelAuctioneerServer = (*Server)(nil)
+
+// New creates a new fake auctioneer with the given configuration.
+func New(cfg *Config) (*Server, error) {
+	if cfg.AuctioneerKey == nil {
+		key, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+		cfg.AuctioneerKey = key
+	}
+	if cfg.InitialBatchKey == nil {
+		key, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+		cfg.InitialBatchKey = key.PubKey()
+	}
+	if cfg.FeeRate == 0 && cfg.BaseFee == 0 {
+		cfg.BaseFee = DefaultBaseFee
+		cfg.FeeRate = DefaultFeeRate
+	}
+	if cfg.BatchFeeRate == 0 {
+		cfg.BatchFeeRate = DefaultBatchFeeRate
+	}
+	if cfg.ResponseTimeout == 0 {
+		cfg.ResponseTimeout = DefaultResponseTimeout
+	}
+	if cfg.BatchVersion == nil {
+		version := order.CurrentVersion
+		cfg.BatchVersion = &version
+	}
+
+	return &Server{
+		cfg:           cfg,
+		auctioneerKey: cfg.AuctioneerKey,
+		batchKey:      cfg.InitialBatchKey,
+		reservations:  make(map[[33]byte]*btcec.PublicKey),
+		accounts:      make(map[[33]byte]*account.Account),
+		orders:        make(map[order.Nonce]*bookOrder),
+		conns:         make(map[*traderConn]struct{}),
+		batches:       make(map[order.BatchID]*batchSnapshot),
+		quit:          make(chan struct{}),
+	}, nil
+}
+
+// Start starts serving the ChannelAuctioneer gRPC service on the given
+// listener.
+func (s *Server) Start(lis net.Listener) error {
+	if !atomic.CompareAndSwapUint32(&s.started, 0, 1) {
+		return nil
+	}
+
+	s.grpcServer = grpc.NewServer()
+	clmrpc.RegisterChannelAuctioneerServer(s.grpcServer, s)
+
+	log.Infof("Fake auctioneer listening on %v, auctioneer key %x",
+		lis.Addr(), s.auctioneerKey.PubKey().SerializeCompressed())
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		if err := s.grpcServer.Serve(lis); err != nil {
+			log.Errorf("Unable to serve gRPC: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop informs all connected traders about the shutdown and stops the gRPC
+// server.
+func (s *Server) Stop() {
+	if !atomic.CompareAndSwapUint32(&s.stopped, 0, 1) {
+		return
+	}
+
+	log.Infof("Shutting down fake auctioneer")
+
+	// Tell all traders we're going away so they can reconnect later.
+	s.mu.Lock()
+	for conn := range s.conns {
+		err := conn.sendError(
+			clmrpc.SubscribeError_SERVER_SHUTDOWN, nil,
+			ErrServerShutdown.Error(),
+		)
+		if err != nil {
+			log.Debugf("Unable to send shutdown message: %v", err)
+		}
+	}
+	s.mu.Unlock()
+
+	close(s.quit)
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+	}
+	s.wg.Wait()
+}
+
+// AuctioneerKey returns the public key of the auctioneer.
+func (s *Server) AuctioneerKey() *btcec.PublicKey {
+	return s.auctioneerKey.PubKey()
+}
+
+// BatchKey returns the current batch key which will be the ID of the next
+// batch.
+func (s *Server) BatchKey() *btcec.PublicKey {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.batchKey
+}
+
+// FeeQuote returns the execution fee schedule of the fake auctioneer.
+//
+// NOTE: This is part of the ChannelAuctioneerServer interface.
+func (s *Server) FeeQuote(context.Context,
+	*clmrpc.FeeQuoteRequest) (*clmrpc.FeeQuoteResponse, error) {
+
+	return &clmrpc.FeeQuoteResponse{
+		ExecutionFee: s.executionFee(),
+	}, nil
+}
+
+// executionFee returns the RPC representation of the execution fee schedule.
+func (s *Server) executionFee() *clmrpc.ExecutionFee {
+	return &clmrpc.ExecutionFee{
+		BaseFee: uint64(s.cfg.BaseFee),
+		FeeRate: uint64(s.cfg.FeeRate),
+	}
+}
+
+// bestHeight returns the current block height or zero if no height source is
+// configured.
+func (s *Server) bestHeight() (uint32, error) {
+	if s.cfg.BestHeight == nil {
+		return 0, nil
+	}
+	return s.cfg.BestHeight()
+}
+
+// rawKey returns the compressed serialization of a public key.
+func rawKey(key *btcec.PublicKey) [33]byte {
+	var raw [33]byte
+	copy(raw[:], key.SerializeCompressed())
+	return raw
+}
+
+// parseKey parses a raw public key and returns both the parsed key and its
+// compressed serialization.
+func parseKey(rawKeyBytes []byte) (*btcec.PublicKey, [33]byte, error) {
+	key, err := btcec.ParsePubKey(rawKeyBytes, btcec.S256())
+	if err != nil {
+		return nil, [33]byte{}, fmt.Errorf("invalid public key: %v",
+			err)
+	}
+	return key, rawKey(key), nil
+}
@@ -0,0 +1,727 @@
+package fakeauctioneer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/auctioneer"
+	"github.com/lightninglabs/llm/clmrpc"
+	"github.com/lightninglabs/llm/clmscript"
+	"github.com/lightninglabs/llm/order"
+	"github.com/lightninglabs/loop/lndclient"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+const (
+	testTimeout    = 5 * time.Second
+	testAcctValue  = btcutil.Amount(1_000_000)
+	testAcctExpiry = 1000
+)
+
+// testSigner is a signer that signs everything with a single private key.
+type testSigner struct {
+	lndclient.SignerClient
+
+	key *btcec.PrivateKey
+}
+
+// SignMessage signs the SHA256 of the message and returns a wire signature,
+// exactly like lnd does.
+func (s *testSigner) SignMessage(_ context.Context, msg []byte,
+	_ keychain.KeyLocator) ([]byte, error) {
+
+	sig, err := s.key.Sign(chainhash.HashB(msg))
+	if err != nil {
+		return nil, err
+	}
+	wireSig, err := lnwire.NewSigFromSignature(sig)
+	if err != nil {
+		return nil, err
+	}
+	return wireSig.ToSignatureBytes(), nil
+}
+
+// noPendingBatch is a batch source without any pending batch.
+type noPendingBatch struct{}
+
+func (noPendingBatch) PendingBatch() (order.BatchID, *wire.MsgTx, error) {
+	return order.BatchID{}, nil, account.ErrNoPendingBatch
+}
+
+func (noPendingBatch) DeletePendingBatch() error {
+	return nil
+}
+
+// testTrader is a trader with a single account connected to the fake
+// auctioneer.
+type testTrader struct {
+	t *testing.T
+
+	key      *btcec.PrivateKey
+	nodeKey  [33]byte
+	client   *auctioneer.Client
+	acct     *account.Account
+	acctKey  [33]byte
+	finalize chan *clmrpc.OrderMatchFinalize
+
+	// pendingTx is the transaction of the batch we last accepted.
+	pendingTx *wire.MsgTx
+
+	// reject makes the trader reject every batch it's part of.
+	reject bool
+
+	// rejectedOrders are the orders the trader rejects individually
+	// whenever they are part of a batch.
+	rejectedOrders map[order.Nonce]struct{}
+	rejectMtx      sync.Mutex
+}
+
+// newTestTrader creates a trader, opens an account with the auctioneer and
+// subscribes to its updates.
+func newTestTrader(t *testing.T, addr string, reject bool) *testTrader {
+	key, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodeKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := auctioneer.NewClient(&auctioneer.Config{
+		ServerAddress: addr,
+		Insecure:      true,
+		Signer:        &testSigner{key: key},
+		MinBackoff:    100 * time.Millisecond,
+		MaxBackoff:    time.Second,
+		BatchSource:   noPendingBatch{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = client.Stop() })
+
+	trader := &testTrader{
+		t:        t,
+		key:      key,
+		nodeKey:  rawKey(nodeKey.PubKey()),
+		client:   client,
+		acctKey:  rawKey(key.PubKey()),
+		finalize: make(chan *clmrpc.OrderMatchFinalize, 1),
+		reject:   reject,
+
+		rejectedOrders: make(map[order.Nonce]struct{}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	reservation, err := client.ReserveAccount(ctx, testAcctValue)
+	if err != nil {
+		t.Fatalf("unable to reserve account: %v", err)
+	}
+	secret, err := (&keychain.PrivKeyECDH{PrivKey: key}).ECDH(
+		reservation.AuctioneerKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trader.acct = &account.Account{
+		Value:  testAcctValue,
+		Expiry: testAcctExpiry,
+		TraderKey: &keychain.KeyDescriptor{
+			KeyLocator: keychain.KeyLocator{
+				Family: clmscript.AccountKeyFamily,
+			},
+			PubKey: key.PubKey(),
+		},
+		AuctioneerKey: reservation.AuctioneerKey,
+		BatchKey:      reservation.InitialBatchKey,
+		Secret:        secret,
+		State:         account.StateOpen,
+	}
+	if _, err := rand.Read(trader.acct.OutPoint.Hash[:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.InitAccount(ctx, trader.acct); err != nil {
+		t.Fatalf("unable to init account: %v", err)
+	}
+	err = client.SubscribeAccountUpdates(ctx, trader.acct.TraderKey)
+	if err != nil {
+		t.Fatalf("unable to subscribe account: %v", err)
+	}
+
+	go trader.handleBatches()
+
+	return trader
+}
+
+// submitOrder signs and submits an order of the trader's account.
+func (tr *testTrader) submitOrder(o order.Order) order.Nonce {
+	var nonce order.Nonce
+	if _, err := rand.Read(nonce[:]); err != nil {
+		tr.t.Fatal(err)
+	}
+	kit := order.NewKit(nonce)
+	kit.AcctKey = tr.acctKey
+	kit.State = order.StateSubmitted
+	kit.FixedRate = o.Details().FixedRate
+	kit.Amt = o.Details().Amt
+	kit.Units = order.NewSupplyFromSats(kit.Amt)
+	kit.UnitsUnfulfilled = kit.Units
+	kit.Version = o.Details().Version
+	kit.MinUnitsMatch = o.Details().MinUnitsMatch
+	kit.AllOrNone = o.Details().AllOrNone
+	switch o := o.(type) {
+	case *order.Ask:
+		o.Kit = *kit
+	case *order.Bid:
+		o.Kit = *kit
+	}
+
+	digest, err := o.Digest()
+	if err != nil {
+		tr.t.Fatal(err)
+	}
+	signer := &testSigner{key: tr.key}
+	sig, err := signer.SignMessage(
+		context.Background(), digest[:], keychain.KeyLocator{},
+	)
+	if err != nil {
+		tr.t.Fatal(err)
+	}
+	multiSigKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		tr.t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	err = tr.client.SubmitOrder(ctx, o, &order.ServerOrderParams{
+		MultiSigKey: rawKey(multiSigKey.PubKey()),
+		NodePubkey:  tr.nodeKey,
+		Addrs: []net.Addr{&net.TCPAddr{
+			IP:   net.IPv4(127, 0, 0, 1),
+			Port: 9735,
+		}},
+		RawSig: sig,
+	})
+	if err != nil {
+		tr.t.Fatalf("unable to submit order: %v", err)
+	}
+
+	return nonce
+}
+
+// handleBatches takes part in all batches the auctioneer sends us.
+func (tr *testTrader) handleBatches() {
+	for {
+		var (
+			msg *clmrpc.ServerAuctionMessage
+			ok  bool
+			err error
+		)
+		select {
+		case msg, ok = <-tr.client.FromServerChan:
+			if !ok {
+				return
+			}
+
+		case <-tr.client.StreamErrChan:
+			return
+		}
+
+		switch m := msg.Msg.(type) {
+		case *clmrpc.ServerAuctionMessage_Prepare:
+			err = tr.handlePrepare(m.Prepare)
+
+		case *clmrpc.ServerAuctionMessage_Sign:
+			err = tr.handleSign(m.Sign)
+
+		case *clmrpc.ServerAuctionMessage_Finalize:
+			tr.finalize <- m.Finalize
+		}
+		if err != nil {
+			tr.t.Errorf("unable to handle batch message: %v", err)
+			return
+		}
+	}
+}
+
+// rejectOrder makes the trader reject the given order individually whenever it
+// is part of a batch.
+func (tr *testTrader) rejectOrder(nonce order.Nonce) {
+	tr.rejectMtx.Lock()
+	defer tr.rejectMtx.Unlock()
+
+	tr.rejectedOrders[nonce] = struct{}{}
+}
+
+// handlePrepare accepts or rejects a batch.
+func (tr *testTrader) handlePrepare(prepare *clmrpc.OrderMatchPrepare) error {
+	tr.rejectMtx.Lock()
+	rejected := make(map[string]*clmrpc.OrderReject)
+	for nonce := range tr.rejectedOrders {
+		if _, ok := prepare.MatchedOrders[nonce.String()]; !ok {
+			continue
+		}
+		rejected[nonce.String()] = &clmrpc.OrderReject{
+			Reason:     "test order reject",
+			ReasonCode: clmrpc.OrderMatchReject_POLICY_REJECT,
+		}
+	}
+	tr.rejectMtx.Unlock()
+
+	if tr.reject || len(rejected) > 0 {
+		reject := &clmrpc.OrderMatchReject{
+			BatchId:        prepare.BatchId,
+			Reason:         "test reject",
+			RejectedOrders: rejected,
+		}
+		if len(rejected) > 0 {
+			reject.ReasonCode =
+				clmrpc.OrderMatchReject_PARTIAL_REJECT
+		}
+		msg := &clmrpc.ClientAuctionMessage{
+			Msg: &clmrpc.ClientAuctionMessage_Reject{
+				Reject: reject,
+			},
+		}
+		return tr.client.SendAuctionMessage(msg)
+	}
+
+	var nonces [][]byte
+	for nonceStr := range prepare.MatchedOrders {
+		nonce, err := hex.DecodeString(nonceStr)
+		if err != nil {
+			return err
+		}
+		nonces = append(nonces, nonce)
+	}
+
+	// Remember the prepared batch so we can sign it later.
+	tx := &wire.MsgTx{}
+	err := tx.Deserialize(bytes.NewReader(prepare.BatchTransaction))
+	if err != nil {
+		return err
+	}
+	tr.pendingTx = tx
+
+	return tr.client.SendAuctionMessage(&clmrpc.ClientAuctionMessage{
+		Msg: &clmrpc.ClientAuctionMessage_Accept{
+			Accept: &clmrpc.OrderMatchAccept{
+				BatchId:    prepare.BatchId,
+				OrderNonce: nonces,
+			},
+		},
+	})
+}
+
+// handleSign signs the trader's account input of the prepared batch.
+func (tr *testTrader) handleSign(sign *clmrpc.OrderMatchSignBegin) error {
+	tx := tr.pendingTx
+	idx := -1
+	for i, in := range tx.TxIn {
+		if in.PreviousOutPoint == tr.acct.OutPoint {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return fmt.Errorf("account input not found")
+	}
+
+	witnessScript, err := clmscript.AccountWitnessScript(
+		tr.acct.Expiry, tr.acct.TraderKey.PubKey,
+		tr.acct.AuctioneerKey, tr.acct.BatchKey, tr.acct.Secret,
+	)
+	if err != nil {
+		return err
+	}
+	tweak := clmscript.TraderKeyTweak(
+		tr.acct.BatchKey, tr.acct.Secret, tr.acct.TraderKey.PubKey,
+	)
+	sig, err := txscript.RawTxInWitnessSignature(
+		tx, txscript.NewTxSigHashes(tx), idx, int64(tr.acct.Value),
+		witnessScript, txscript.SigHashAll,
+		input.TweakPrivKey(tr.key, tweak),
+	)
+	if err != nil {
+		return err
+	}
+
+	// The sighash flag is added by the auctioneer.
+	acctKey := hex.EncodeToString(tr.acctKey[:])
+	return tr.client.SendAuctionMessage(&clmrpc.ClientAuctionMessage{
+		Msg: &clmrpc.ClientAuctionMessage_Sign{
+			Sign: &clmrpc.OrderMatchSign{
+				BatchId: sign.BatchId,
+				AccountSigs: map[string][]byte{
+					acctKey: sig[:len(sig)-1],
+				},
+			},
+		},
+	})
+}
+
+// startServer starts a fake auctioneer on a random local port and returns it
+// together with its address.
+func startServer(t *testing.T) (*Server, string) {
+	return startVersionServer(t, order.CurrentVersion)
+}
+
+// startVersionServer starts a fake auctioneer that uses the given batch version
+// on a random local port and returns it together with its address.
+func startVersionServer(t *testing.T, version order.BatchVersion) (*Server,
+	string) {
+
+	srv, err := New(&Config{
+		BaseFee:         1000,
+		FeeRate:         DefaultFeeRate,
+		ResponseTimeout: testTimeout,
+		BatchVersion:    &version,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Start(lis); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Stop)
+
+	return srv, lis.Addr().String()
+}
+
+// TestBatchExecution makes sure an ask and a bid of two traders are matched
+// and executed in a batch that spends both accounts.
+func TestBatchExecution(t *testing.T) {
+	srv, addr := startServer(t)
+	asker := newTestTrader(t, addr, false)
+	bidder := newTestTrader(t, addr, false)
+	batchKey := srv.BatchKey()
+
+	askNonce := asker.submitOrder(&order.Ask{
+		Kit: order.Kit{
+			FixedRate: 100,
+			Amt:       300_000,
+		},
+		MaxDuration: 2016,
+	})
+	bidNonce := bidder.submitOrder(&order.Bid{
+		Kit: order.Kit{
+			FixedRate: 200,
+			Amt:       200_000,
+		},
+		MinDuration: 144,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	tx, err := srv.RunBatch(ctx)
+	if err != nil {
+		t.Fatalf("unable to run batch: %v", err)
+	}
+
+	// Both accounts are spent and re-created, the channel output is
+	// created and the auctioneer collects the fees.
+	if len(tx.TxIn) != 2 {
+		t.Fatalf("expected 2 inputs, got %d", len(tx.TxIn))
+	}
+	if len(tx.TxOut) != 4 {
+		t.Fatalf("expected 4 outputs, got %d", len(tx.TxOut))
+	}
+	if tx.TxOut[0].Value != 200_000 {
+		t.Fatalf("unexpected channel output value %d",
+			tx.TxOut[0].Value)
+	}
+
+	// Both traders must be told the batch was executed.
+	for _, trader := range []*testTrader{asker, bidder} {
+		select {
+		case finalize := <-trader.finalize:
+			txid := tx.TxHash()
+			if !bytes.Equal(finalize.BatchTxid, txid[:]) {
+				t.Fatalf("unexpected batch txid")
+			}
+
+		case <-time.After(testTimeout):
+			t.Fatalf("trader didn't receive finalize")
+		}
+	}
+
+	// The ask is only partially filled while the bid is executed.
+	assertOrderState(
+		t, srv, askNonce, clmrpc.OrderState_ORDER_PARTIALLY_FILLED, 1,
+	)
+	assertOrderState(t, srv, bidNonce, clmrpc.OrderState_ORDER_EXECUTED, 0)
+
+	// The batch key is incremented and the snapshot of the executed batch
+	// can be queried.
+	if srv.BatchKey().IsEqual(batchKey) {
+		t.Fatalf("batch key not incremented")
+	}
+	snapshot, err := srv.RelevantBatchSnapshot(
+		ctx, &clmrpc.RelevantBatchRequest{
+			Id:       batchKey.SerializeCompressed(),
+			Accounts: [][]byte{asker.acctKey[:]},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unable to query batch snapshot: %v", err)
+	}
+	if len(snapshot.ChargedAccounts) != 1 ||
+		len(snapshot.MatchedOrders) != 1 {
+
+		t.Fatalf("unexpected batch snapshot: %v", snapshot)
+	}
+
+	// The asker funded the channel but earned the premium for it.
+	diff := snapshot.ChargedAccounts[0]
+	minBalance := uint64(testAcctValue) - uint64(tx.TxOut[0].Value)
+	if diff.EndingState != clmrpc.AccountDiff_OUTPUT_RECREATED ||
+		diff.EndingBalance <= minBalance {
+
+		t.Fatalf("unexpected account diff: %v", diff)
+	}
+}
+
+// TestBatchExecutionDefaultVersion makes sure traders can still subscribe to
+// and execute batches with an auctioneer that only knows the default batch
+// version.
+func TestBatchExecutionDefaultVersion(t *testing.T) {
+	srv, addr := startVersionServer(t, order.DefaultVersion)
+	asker := newTestTrader(t, addr, false)
+	bidder := newTestTrader(t, addr, false)
+
+	asker.submitOrder(&order.Ask{
+		Kit: order.Kit{
+			FixedRate: 100,
+			Amt:       100_000,
+		},
+		MaxDuration: 2016,
+	})
+	bidNonce := bidder.submitOrder(&order.Bid{
+		Kit: order.Kit{
+			FixedRate: 100,
+			Amt:       100_000,
+		},
+		MinDuration: 144,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if _, err := srv.RunBatch(ctx); err != nil {
+		t.Fatalf("unable to run batch: %v", err)
+	}
+	assertOrderState(t, srv, bidNonce, clmrpc.OrderState_ORDER_EXECUTED, 0)
+
+	// The batch is announced with the version the auctioneer uses.
+	snapshot, err := srv.RelevantBatchSnapshot(
+		ctx, &clmrpc.RelevantBatchRequest{
+			Id:       srv.cfg.InitialBatchKey.SerializeCompressed(),
+			Accounts: [][]byte{bidder.acctKey[:]},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unable to query batch snapshot: %v", err)
+	}
+	if snapshot.Version != uint32(order.DefaultVersion) {
+		t.Fatalf("expected batch version %d, got %d",
+			order.DefaultVersion, snapshot.Version)
+	}
+}
+
+// TestBatchRejectExcludesTrader makes sure a trader that rejects a batch is
+// excluded from it.
+func TestBatchRejectExcludesTrader(t *testing.T) {
+	srv, addr := startServer(t)
+	asker := newTestTrader(t, addr, false)
+	bidder := newTestTrader(t, addr, true)
+
+	asker.submitOrder(&order.Ask{
+		Kit: order.Kit{
+			FixedRate: 100,
+			Amt:       100_000,
+		},
+		MaxDuration: 2016,
+	})
+	bidNonce := bidder.submitOrder(&order.Bid{
+		Kit: order.Kit{
+			FixedRate: 100,
+			Amt:       100_000,
+		},
+		MinDuration: 144,
+	})
+
+	// Without the bidder, there's nothing left to match.
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if _, err := srv.RunBatch(ctx); err != ErrNoMatch {
+		t.Fatalf("expected ErrNoMatch, got %v", err)
+	}
+	assertOrderState(t, srv, bidNonce, clmrpc.OrderState_ORDER_SUBMITTED, 1)
+}
+
+// TestBatchPartialReject makes sure only the orders a trader rejects
+// individually are excluded from a batch and the batch is re-planned with the
+// trader's other orders.
+func TestBatchPartialReject(t *testing.T) {
+	srv, addr := startServer(t)
+	asker := newTestTrader(t, addr, false)
+	bidder := newTestTrader(t, addr, false)
+
+	askNonce := asker.submitOrder(&order.Ask{
+		Kit: order.Kit{
+			FixedRate: 100,
+			Amt:       100_000,
+		},
+		MaxDuration: 2016,
+	})
+
+	// The bid with the higher rate is matched first but rejected by the
+	// bidder.
+	rejectedNonce := bidder.submitOrder(&order.Bid{
+		Kit: order.Kit{
+			FixedRate: 200,
+			Amt:       100_000,
+		},
+		MinDuration: 144,
+	})
+	bidder.rejectOrder(rejectedNonce)
+	bidNonce := bidder.submitOrder(&order.Bid{
+		Kit: order.Kit{
+			FixedRate: 100,
+			Amt:       100_000,
+		},
+		MinDuration: 144,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if _, err := srv.RunBatch(ctx); err != nil {
+		t.Fatalf("unable to run batch: %v", err)
+	}
+
+	assertOrderState(t, srv, askNonce, clmrpc.OrderState_ORDER_EXECUTED, 0)
+	assertOrderState(t, srv, bidNonce, clmrpc.OrderState_ORDER_EXECUTED, 0)
+	assertOrderState(
+		t, srv, rejectedNonce, clmrpc.OrderState_ORDER_SUBMITTED, 1,
+	)
+}
+
+// TestBatchFillConstraints makes sure orders are only matched in a way that
+// respects their minimum units per match and all-or-none flag.
+func TestBatchFillConstraints(t *testing.T) {
+	srv, addr := startServer(t)
+	asker := newTestTrader(t, addr, false)
+	bidder := newTestTrader(t, addr, false)
+
+	// The all-or-none ask can't be filled completely by the bids, so it's
+	// not matched at all and the bids are matched with the other ask.
+	aonNonce := asker.submitOrder(&order.Ask{
+		Kit: order.Kit{
+			Version:   order.VersionFillConstraints,
+			FixedRate: 100,
+			Amt:       500_000,
+			AllOrNone: true,
+		},
+		MaxDuration: 2016,
+	})
+	askNonce := asker.submitOrder(&order.Ask{
+		Kit: order.Kit{
+			FixedRate: 150,
+			Amt:       300_000,
+		},
+		MaxDuration: 2016,
+	})
+
+	// Only one of the bids can be matched with the ask. The remaining unit
+	// of the ask is below the minimum units per match of the other bid.
+	bidNonce := bidder.submitOrder(&order.Bid{
+		Kit: order.Kit{
+			Version:       order.VersionFillConstraints,
+			FixedRate:     200,
+			Amt:           200_000,
+			MinUnitsMatch: 2,
+		},
+		MinDuration: 144,
+	})
+	otherNonce := bidder.submitOrder(&order.Bid{
+		Kit: order.Kit{
+			Version:       order.VersionFillConstraints,
+			FixedRate:     200,
+			Amt:           200_000,
+			MinUnitsMatch: 2,
+		},
+		MinDuration: 144,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if _, err := srv.RunBatch(ctx); err != nil {
+		t.Fatalf("unable to run batch: %v", err)
+	}
+
+	assertOrderState(
+		t, srv, aonNonce, clmrpc.OrderState_ORDER_SUBMITTED, 5,
+	)
+	assertOrderState(
+		t, srv, askNonce, clmrpc.OrderState_ORDER_PARTIALLY_FILLED, 1,
+	)
+	matched, unmatched := bidNonce, otherNonce
+	resp, err := srv.OrderState(
+		ctx, &clmrpc.ServerOrderStateRequest{OrderNonce: bidNonce[:]},
+	)
+	if err != nil {
+		t.Fatalf("unable to query order state: %v", err)
+	}
+	if resp.State != clmrpc.OrderState_ORDER_EXECUTED {
+		matched, unmatched = otherNonce, bidNonce
+	}
+	assertOrderState(t, srv, matched, clmrpc.OrderState_ORDER_EXECUTED, 0)
+	assertOrderState(
+		t, srv, unmatched, clmrpc.OrderState_ORDER_SUBMITTED, 2,
+	)
+}
+
+// assertOrderState makes sure the auctioneer knows the order in the given
+// state.
+func assertOrderState(t *testing.T, srv *Server, nonce order.Nonce,
+	state clmrpc.OrderState, unitsUnfulfilled uint32) {
+
+	t.Helper()
+
+	resp, err := srv.OrderState(
+		context.Background(), &clmrpc.ServerOrderStateRequest{
+			OrderNonce: nonce[:],
+		},
+	)
+	if err != nil {
+		t.Fatalf("unable to query order state: %v", err)
+	}
+	if resp.State != state || resp.UnitsUnfulfilled != unitsUnfulfilled {
+		t.Fatalf("unexpected order state %v with %d units unfulfilled",
+			resp.State, resp.UnitsUnfulfilled)
+	}
+}
@@ -0,0 +1,246 @@
+package fakeauctioneer
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/clmrpc"
+	"github.com/lightninglabs/llm/order"
+)
+
+// traderConn is a single long-lived stream of a trader. A trader multiplexes
+// the subscriptions of all of its accounts over one stream.
+type traderConn struct {
+	stream clmrpc.ChannelAuctioneer_SubscribeBatchAuctionServer
+
+	// sendMtx serializes all sends on the stream since gRPC streams are
+	// not safe for concurrent use.
+	sendMtx sync.Mutex
+
+	// challenges maps the commit hashes of unfinished handshakes to the
+	// challenge we sent for them.
+	challenges map[[32]byte][32]byte
+
+	// accounts is the set of accounts that successfully subscribed over
+	// this stream. It is guarded by the server's mutex.
+	accounts map[[33]byte]struct{}
+}
+
+// send sends a message to the trader.
+func (c *traderConn) send(msg *clmrpc.ServerAuctionMessage) error {
+	c.sendMtx.Lock()
+	defer c.sendMtx.Unlock()
+
+	return c.stream.Send(msg)
+}
+
+// sendError sends a subscription error to the trader.
+func (c *traderConn) sendError(code clmrpc.SubscribeError_Error,
+	traderKey []byte, errMsg string) error {
+
+	return c.send(&clmrpc.ServerAuctionMessage{
+		Msg: &clmrpc.ServerAuctionMessage_Error{
+			Error: &clmrpc.SubscribeError{
+				Error:     errMsg,
+				ErrorCode: code,
+				TraderKey: traderKey,
+			},
+		},
+	})
+}
+
+// traderMsg is a batch related message a trader sent over its stream.
+type traderMsg struct {
+	conn *traderConn
+	msg  *clmrpc.ClientAuctionMessage
+}
+
+// SubscribeBatchAuction handles the long-lived stream of a trader. Accounts are
+// subscribed with the 3-way authentication handshake, after which the trader
+// takes part in batches with all of its subscribed accounts.
+//
+// NOTE: This is part of the ChannelAuctioneerServer interface.
+func (s *Server) SubscribeBatchAuction(
+	stream clmrpc.ChannelAuctioneer_SubscribeBatchAuctionServer) error {
+
+	conn := &traderConn{
+		stream:     stream,
+		challenges: make(map[[32]byte][32]byte),
+		accounts:   make(map[[33]byte]struct{}),
+	}
+
+	s.mu.Lock()
+	s.conns[conn] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+	}()
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := s.handleTraderMsg(conn, msg); err != nil {
+			log.Errorf("Error handling trader message: %v", err)
+			return err
+		}
+	}
+}
+
+// handleTraderMsg handles a single message received from a trader's stream.
+func (s *Server) handleTraderMsg(conn *traderConn,
+	msg *clmrpc.ClientAuctionMessage) error {
+
+	switch m := msg.Msg.(type) {
+	// Step 1 of the 3-way handshake, we reply with a challenge.
+	case *clmrpc.ClientAuctionMessage_Commit:
+		var commitHash [32]byte
+		copy(commitHash[:], m.Commit.CommitHash)
+
+		version := order.BatchVersion(m.Commit.BatchVersion)
+		if version > *s.cfg.BatchVersion || !version.Supported() {
+			return conn.sendError(
+				clmrpc.SubscribeError_UNKNOWN, nil,
+				fmt.Sprintf("batch version %d not supported",
+					m.Commit.BatchVersion),
+			)
+		}
+
+		var nonce [32]byte
+		if _, err := rand.Read(nonce[:]); err != nil {
+			return err
+		}
+		challenge := account.AuthChallenge(commitHash, nonce)
+		conn.challenges[commitHash] = challenge
+
+		return conn.send(&clmrpc.ServerAuctionMessage{
+			Msg: &clmrpc.ServerAuctionMessage_Challenge{
+				Challenge: &clmrpc.ServerChallenge{
+					Challenge:  challenge[:],
+					CommitHash: commitHash[:],
+				},
+			},
+		})
+
+	// Step 3 of the 3-way handshake, we verify the signature and subscribe
+	// the account.
+	case *clmrpc.ClientAuctionMessage_Subscribe:
+		return s.handleSubscribe(conn, m.Subscribe)
+
+	// The trader wants to know our state of one of their subscribed
+	// accounts.
+	case *clmrpc.ClientAuctionMessage_Recover:
+		traderKey := m.Recover.TraderKey
+		_, traderKeyRaw, err := parseKey(traderKey)
+		if err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		_, subscribed := conn.accounts[traderKeyRaw]
+		acct := s.accounts[traderKeyRaw]
+		s.mu.Unlock()
+
+		if !subscribed || acct == nil {
+			return fmt.Errorf("account %x not subscribed",
+				traderKey)
+		}
+		rpcAcct, err := marshallAccount(acct)
+		if err != nil {
+			return err
+		}
+		return conn.send(&clmrpc.ServerAuctionMessage{
+			Msg: &clmrpc.ServerAuctionMessage_Account{
+				Account: rpcAcct,
+			},
+		})
+
+	// All other messages are responses to a batch in progress.
+	case *clmrpc.ClientAuctionMessage_Accept,
+		*clmrpc.ClientAuctionMessage_Reject,
+		*clmrpc.ClientAuctionMessage_Sign:
+
+		s.mu.Lock()
+		batch := s.activeBatch
+		s.mu.Unlock()
+
+		if batch == nil {
+			log.Warnf("Ignoring batch message %T without a batch "+
+				"in progress", m)
+			return nil
+		}
+
+		select {
+		case batch.msgs <- &traderMsg{conn: conn, msg: msg}:
+		case <-batch.done:
+		case <-s.quit:
+			return ErrServerShutdown
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown trader message %T", m)
+	}
+}
+
+// handleSubscribe finishes the 3-way authentication handshake and subscribes
+// the account to batch updates.
+func (s *Server) handleSubscribe(conn *traderConn,
+	sub *clmrpc.AccountSubscription) error {
+
+	traderKey, traderKeyRaw, err := parseKey(sub.TraderKey)
+	if err != nil {
+		return err
+	}
+
+	var nonce [32]byte
+	copy(nonce[:], sub.CommitNonce)
+	commitHash := account.CommitAccount(traderKeyRaw, nonce)
+	challenge, ok := conn.challenges[commitHash]
+	if !ok {
+		return fmt.Errorf("no challenge found for account %x",
+			traderKeyRaw[:])
+	}
+	delete(conn.challenges, commitHash)
+
+	authHash := account.AuthHash(commitHash, challenge)
+	if !verifyMessageSig(authHash[:], sub.AuthSig, traderKey) {
+		return fmt.Errorf("invalid auth signature for account %x",
+			traderKeyRaw[:])
+	}
+
+	s.mu.Lock()
+	_, exists := s.accounts[traderKeyRaw]
+	if exists {
+		conn.accounts[traderKeyRaw] = struct{}{}
+	}
+	s.mu.Unlock()
+
+	if !exists {
+		return conn.sendError(
+			clmrpc.SubscribeError_ACCOUNT_DOES_NOT_EXIST,
+			sub.TraderKey, "account not found",
+		)
+	}
+
+	log.Infof("Account %x subscribed", traderKeyRaw[:])
+
+	return conn.send(&clmrpc.ServerAuctionMessage{
+		Msg: &clmrpc.ServerAuctionMessage_Success{
+			Success: &clmrpc.SubscribeSuccess{
+				TraderKey: sub.TraderKey,
+			},
+		},
+	})
+}
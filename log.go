@@ -7,6 +7,7 @@ import (
 	"github.com/lightninglabs/llm/account"
 	"github.com/lightninglabs/llm/auctioneer"
 	"github.com/lightninglabs/llm/order"
+	"github.com/lightninglabs/llm/sqldb"
 	"github.com/lightninglabs/loop/lndclient"
 	"github.com/lightninglabs/loop/lsat"
 	"github.com/lightningnetwork/lnd/build"
@@ -32,6 +33,7 @@ func init() {
 	addSubLogger("SGNL", signal.UseLogger)
 	addSubLogger(account.Subsystem, account.UseLogger)
 	addSubLogger(lsat.Subsystem, lsat.UseLogger)
+	addSubLogger(sqldb.Subsystem, sqldb.UseLogger)
 }
 
 // addSubLogger is a helper method to conveniently create and register the
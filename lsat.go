@@ -0,0 +1,306 @@
+package llm
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/loop/lsat"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+const (
+	// lsatLedgerFilename is the name of the file in the network directory
+	// that every LSAT payment is recorded in.
+	lsatLedgerFilename = "lsat.ledger"
+
+	// lsatRevokedDirname is the name of the directory in the network
+	// directory that revoked tokens are moved to. Each revoked token is
+	// kept in its own sub directory named after its payment hash.
+	lsatRevokedDirname = "lsat.revoked"
+)
+
+var (
+	// errTokenNotFound is returned if no token with the requested payment
+	// hash exists in the store.
+	errTokenNotFound = errors.New("LSAT token not found")
+)
+
+// lsatTokenInfo is a token of the store together with its storage details.
+type lsatTokenInfo struct {
+	*lsat.Token
+
+	// StorageName is the name of the file the token is stored in.
+	StorageName string
+
+	// Revoked is true if the token was revoked and is no longer used.
+	Revoked bool
+}
+
+// Pending returns true if the payment of the token hasn't completed yet.
+func (t *lsatTokenInfo) Pending() bool {
+	return t.Preimage == (lntypes.Preimage{})
+}
+
+// lsatLedgerEntry is a single LSAT payment recorded in the spend ledger.
+type lsatLedgerEntry struct {
+	// PaymentHash is the payment hash of the token that was paid for.
+	PaymentHash lntypes.Hash
+
+	// AmountPaid is the amount paid for the token, excluding routing fees.
+	AmountPaid lnwire.MilliSatoshi
+
+	// RoutingFeePaid is the routing fee paid for the token.
+	RoutingFeePaid lnwire.MilliSatoshi
+
+	// TimePaid is the time the payment was recorded.
+	TimePaid time.Time
+}
+
+// jsonLedgerEntry is the JSON representation of a ledger entry as it's written
+// to the ledger file.
+type jsonLedgerEntry struct {
+	PaymentHash    string `json:"payment_hash"`
+	AmountPaid     uint64 `json:"amount_paid_msat"`
+	RoutingFeePaid uint64 `json:"routing_fee_paid_msat"`
+	TimePaid       int64  `json:"time_paid"`
+}
+
+// lsatTokenStore is a lsat.Store wrapper around the file store that records
+// every paid token in a spend ledger and allows tokens to be revoked.
+type lsatTokenStore struct {
+	*lsat.FileStore
+
+	dir string
+	mu  sync.Mutex
+}
+
+// A compile-time check to ensure lsatTokenStore implements the lsat.Store
+// interface.
+var _ lsat.Store = (*lsatTokenStore)(nil)
+
+// newLsatTokenStore creates a token store in the given directory. Paid tokens
+// that were stored before the ledger existed are added to it.
+func newLsatTokenStore(dir string) (*lsatTokenStore, error) {
+	fileStore, err := lsat.NewFileStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	s := &lsatTokenStore{
+		FileStore: fileStore,
+		dir:       dir,
+	}
+
+	tokens, err := s.Tokens()
+	if err != nil {
+		return nil, err
+	}
+	for _, token := range tokens {
+		if token.Pending() {
+			continue
+		}
+		if err := s.recordPayment(token.Token); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// StoreToken saves a token to the store and records it in the spend ledger
+// once it's paid.
+//
+// NOTE: This is part of the lsat.Store interface.
+func (s *lsatTokenStore) StoreToken(token *lsat.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.FileStore.StoreToken(token); err != nil {
+		return err
+	}
+	if token.Preimage == (lntypes.Preimage{}) {
+		return nil
+	}
+
+	return s.recordPayment(token)
+}
+
+// Tokens returns all tokens of the store, including the revoked ones, ordered
+// by their creation time.
+func (s *lsatTokenStore) Tokens() ([]*lsatTokenInfo, error) {
+	allTokens, err := s.AllTokens()
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]*lsatTokenInfo, 0, len(allTokens))
+	for fileName, token := range allTokens {
+		tokens = append(tokens, &lsatTokenInfo{
+			Token:       token,
+			StorageName: fileName,
+		})
+	}
+
+	revokedDir := filepath.Join(s.dir, lsatRevokedDirname)
+	dirs, err := ioutil.ReadDir(revokedDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		store, err := lsat.NewFileStore(
+			filepath.Join(revokedDir, dir.Name()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		revoked, err := store.AllTokens()
+		if err != nil {
+			return nil, err
+		}
+		for fileName, token := range revoked {
+			tokens = append(tokens, &lsatTokenInfo{
+				Token:       token,
+				StorageName: fileName,
+				Revoked:     true,
+			})
+		}
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].TimeCreated.Before(tokens[j].TimeCreated)
+	})
+
+	return tokens, nil
+}
+
+// Token returns the token with the given payment hash.
+func (s *lsatTokenStore) Token(hash lntypes.Hash) (*lsatTokenInfo, error) {
+	tokens, err := s.Tokens()
+	if err != nil {
+		return nil, err
+	}
+	for _, token := range tokens {
+		if token.PaymentHash == hash {
+			return token, nil
+		}
+	}
+	return nil, errTokenNotFound
+}
+
+// Revoke moves the token with the given payment hash out of the store so it's
+// no longer used to authenticate. A new token is obtained with the next
+// request to the auction server. The revoked token is kept so it can still be
+// listed.
+func (s *lsatTokenStore) Revoke(hash lntypes.Hash) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, err := s.Token(hash)
+	if err != nil {
+		return err
+	}
+	if token.Revoked {
+		return fmt.Errorf("LSAT token %v already revoked", hash)
+	}
+
+	revokedDir := filepath.Join(s.dir, lsatRevokedDirname, hash.String())
+	if err := os.MkdirAll(revokedDir, 0700); err != nil {
+		return err
+	}
+	return os.Rename(
+		token.StorageName,
+		filepath.Join(revokedDir, filepath.Base(token.StorageName)),
+	)
+}
+
+// Ledger returns all entries of the spend ledger, oldest first.
+func (s *lsatTokenStore) Ledger() ([]*lsatLedgerEntry, error) {
+	f, err := os.Open(filepath.Join(s.dir, lsatLedgerFilename))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []*lsatLedgerEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var jsonEntry jsonLedgerEntry
+		err := json.Unmarshal(scanner.Bytes(), &jsonEntry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ledger entry: %v", err)
+		}
+		hash, err := lntypes.MakeHashFromStr(jsonEntry.PaymentHash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ledger entry: %v", err)
+		}
+		amt := lnwire.MilliSatoshi(jsonEntry.AmountPaid)
+		fee := lnwire.MilliSatoshi(jsonEntry.RoutingFeePaid)
+		entries = append(entries, &lsatLedgerEntry{
+			PaymentHash:    hash,
+			AmountPaid:     amt,
+			RoutingFeePaid: fee,
+			TimePaid:       time.Unix(0, jsonEntry.TimePaid),
+		})
+	}
+
+	return entries, scanner.Err()
+}
+
+// recordPayment appends the payment of the given token to the spend ledger if
+// it isn't recorded yet.
+func (s *lsatTokenStore) recordPayment(token *lsat.Token) error {
+	entries, err := s.Ledger()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.PaymentHash == token.PaymentHash {
+			return nil
+		}
+	}
+
+	line, err := json.Marshal(&jsonLedgerEntry{
+		PaymentHash:    hex.EncodeToString(token.PaymentHash[:]),
+		AmountPaid:     uint64(token.AmountPaid),
+		RoutingFeePaid: uint64(token.RoutingFeePaid),
+		TimePaid:       time.Now().UnixNano(),
+	})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(
+		filepath.Join(s.dir, lsatLedgerFilename),
+		os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600,
+	)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	log.Infof("Recorded LSAT payment %v of %v (routing fee %v)",
+		token.PaymentHash, token.AmountPaid, token.RoutingFeePaid)
+
+	return f.Close()
+}
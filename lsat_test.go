@@ -0,0 +1,317 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/loop/lsat"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"gopkg.in/macaroon.v2"
+)
+
+// newTestToken creates a token with the given payment hash. The token is paid
+// if a non-zero preimage is given. As the macaroon of a token can't be set
+// directly, the token is written in the format of the file store and read
+// back.
+func newTestToken(t *testing.T, hash lntypes.Hash, preimage lntypes.Preimage,
+	created time.Time) *lsat.Token {
+
+	t.Helper()
+
+	mac, err := macaroon.New(
+		[]byte("root key"), hash[:], "auctioneer",
+		macaroon.LatestVersion,
+	)
+	if err != nil {
+		t.Fatalf("unable to create macaroon: %v", err)
+	}
+	macBytes, err := mac.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unable to serialize macaroon: %v", err)
+	}
+
+	var b bytes.Buffer
+	for _, field := range []interface{}{
+		uint32(len(macBytes)), macBytes, hash, preimage,
+		lnwire.MilliSatoshi(1_000), lnwire.MilliSatoshi(10),
+		created.UnixNano(),
+	} {
+		err := binary.Write(&b, binary.BigEndian, field)
+		if err != nil {
+			t.Fatalf("unable to serialize token: %v", err)
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "lsat-token")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(
+		filepath.Join(dir, "lsat.token"), b.Bytes(), 0600,
+	)
+	if err != nil {
+		t.Fatalf("unable to write token: %v", err)
+	}
+	store, err := lsat.NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("unable to create store: %v", err)
+	}
+	token, err := store.CurrentToken()
+	if err != nil {
+		t.Fatalf("unable to read token: %v", err)
+	}
+	return token
+}
+
+// newTestLsatTokenStore creates a token store in a temporary directory.
+func newTestLsatTokenStore(t *testing.T) (*lsatTokenStore, string, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "lsat-store")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	store, err := newLsatTokenStore(dir)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		t.Fatalf("unable to create token store: %v", err)
+	}
+	return store, dir, func() {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// assertLedger makes sure the spend ledger contains exactly the payments of
+// the given hashes in the given order.
+func assertLedger(t *testing.T, store *lsatTokenStore,
+	hashes ...lntypes.Hash) {
+
+	t.Helper()
+
+	entries, err := store.Ledger()
+	if err != nil {
+		t.Fatalf("unable to read ledger: %v", err)
+	}
+	if len(entries) != len(hashes) {
+		t.Fatalf("expected %d ledger entries, got %d", len(hashes),
+			len(entries))
+	}
+	for i, entry := range entries {
+		if entry.PaymentHash != hashes[i] {
+			t.Fatalf("expected payment %v, got %v", hashes[i],
+				entry.PaymentHash)
+		}
+		if entry.AmountPaid != 1_000 || entry.RoutingFeePaid != 10 {
+			t.Fatalf("unexpected amounts in ledger entry: %v",
+				entry)
+		}
+	}
+}
+
+// TestLsatTokenStoreLedger makes sure every paid token is recorded in the
+// spend ledger exactly once and pending tokens aren't recorded at all.
+func TestLsatTokenStoreLedger(t *testing.T) {
+	t.Parallel()
+
+	store, dir, cleanup := newTestLsatTokenStore(t)
+	defer cleanup()
+
+	// An empty store has an empty ledger.
+	assertLedger(t, store)
+
+	// A pending token isn't paid yet, so it isn't recorded.
+	var (
+		hash     = lntypes.Hash{0x01}
+		preimage = lntypes.Preimage{0x02}
+		created  = time.Unix(1_600_000_000, 0)
+	)
+	pending := newTestToken(t, hash, lntypes.Preimage{}, created)
+	if err := store.StoreToken(pending); err != nil {
+		t.Fatalf("unable to store token: %v", err)
+	}
+	assertLedger(t, store)
+
+	// Once the payment completes, it is recorded.
+	paid := newTestToken(t, hash, preimage, created)
+	if err := store.StoreToken(paid); err != nil {
+		t.Fatalf("unable to store token: %v", err)
+	}
+	assertLedger(t, store, hash)
+
+	// Opening the store again must not record the existing paid token a
+	// second time.
+	store, err := newLsatTokenStore(dir)
+	if err != nil {
+		t.Fatalf("unable to open token store: %v", err)
+	}
+	assertLedger(t, store, hash)
+
+	// A paid token that was stored before the ledger existed is recorded
+	// when the store is opened.
+	err = os.Remove(filepath.Join(dir, lsatLedgerFilename))
+	if err != nil {
+		t.Fatalf("unable to remove ledger: %v", err)
+	}
+	store, err = newLsatTokenStore(dir)
+	if err != nil {
+		t.Fatalf("unable to open token store: %v", err)
+	}
+	assertLedger(t, store, hash)
+}
+
+// TestLsatTokenStorePendingOnStartup makes sure a pending token that exists
+// when the store is opened isn't recorded in the ledger.
+func TestLsatTokenStorePendingOnStartup(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "lsat-store")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	fileStore, err := lsat.NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("unable to create file store: %v", err)
+	}
+	pending := newTestToken(
+		t, lntypes.Hash{0x01}, lntypes.Preimage{}, time.Now(),
+	)
+	if err := fileStore.StoreToken(pending); err != nil {
+		t.Fatalf("unable to store token: %v", err)
+	}
+
+	store, err := newLsatTokenStore(dir)
+	if err != nil {
+		t.Fatalf("unable to open token store: %v", err)
+	}
+	assertLedger(t, store)
+
+	tokens, err := store.Tokens()
+	if err != nil {
+		t.Fatalf("unable to list tokens: %v", err)
+	}
+	if len(tokens) != 1 || !tokens[0].Pending() {
+		t.Fatalf("expected a single pending token, got %v", tokens)
+	}
+}
+
+// TestLsatTokenStoreRevoke makes sure a revoked token is no longer used but
+// still listed and that it can't be revoked twice.
+func TestLsatTokenStoreRevoke(t *testing.T) {
+	t.Parallel()
+
+	store, _, cleanup := newTestLsatTokenStore(t)
+	defer cleanup()
+
+	var (
+		hash1 = lntypes.Hash{0x01}
+		hash2 = lntypes.Hash{0x02}
+	)
+	token1 := newTestToken(
+		t, hash1, lntypes.Preimage{0x01}, time.Unix(1_600_000_000, 0),
+	)
+	if err := store.StoreToken(token1); err != nil {
+		t.Fatalf("unable to store token: %v", err)
+	}
+
+	// Revoking an unknown token fails.
+	if err := store.Revoke(hash2); err != errTokenNotFound {
+		t.Fatalf("expected errTokenNotFound, got %v", err)
+	}
+
+	if err := store.Revoke(hash1); err != nil {
+		t.Fatalf("unable to revoke token: %v", err)
+	}
+
+	// The revoked token is no longer used to authenticate.
+	if _, err := store.CurrentToken(); err != lsat.ErrNoToken {
+		t.Fatalf("expected no current token, got %v", err)
+	}
+
+	// But it is still listed as revoked.
+	token, err := store.Token(hash1)
+	if err != nil {
+		t.Fatalf("unable to get revoked token: %v", err)
+	}
+	if !token.Revoked {
+		t.Fatalf("expected token to be revoked")
+	}
+
+	// Revoking it again fails.
+	if err := store.Revoke(hash1); err == nil {
+		t.Fatalf("expected error revoking token twice")
+	}
+
+	// A new token can be stored and is listed after the revoked one.
+	token2 := newTestToken(
+		t, hash2, lntypes.Preimage{0x02}, time.Unix(1_600_000_100, 0),
+	)
+	if err := store.StoreToken(token2); err != nil {
+		t.Fatalf("unable to store token: %v", err)
+	}
+	tokens, err := store.Tokens()
+	if err != nil {
+		t.Fatalf("unable to list tokens: %v", err)
+	}
+	if len(tokens) != 2 {
+		t.Fatalf("expected 2 tokens, got %d", len(tokens))
+	}
+	if tokens[0].PaymentHash != hash1 || !tokens[0].Revoked ||
+		tokens[1].PaymentHash != hash2 || tokens[1].Revoked {
+
+		t.Fatalf("unexpected tokens: %v, %v", tokens[0], tokens[1])
+	}
+
+	// Both payments were recorded, even though the first token was
+	// revoked.
+	assertLedger(t, store, hash1, hash2)
+}
+
+// TestLsatTokenStoreInvalidLedger makes sure a corrupted ledger is reported
+// instead of silently ignored.
+func TestLsatTokenStoreInvalidLedger(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		content string
+	}{{
+		name:    "invalid json",
+		content: "{\n",
+	}, {
+		name:    "invalid payment hash",
+		content: `{"payment_hash":"abcd"}` + "\n",
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, dir, cleanup := newTestLsatTokenStore(t)
+			defer cleanup()
+
+			err := ioutil.WriteFile(
+				filepath.Join(dir, lsatLedgerFilename),
+				[]byte(tc.content), 0600,
+			)
+			if err != nil {
+				t.Fatalf("unable to write ledger: %v", err)
+			}
+
+			if _, err := store.Ledger(); err == nil {
+				t.Fatalf("expected invalid ledger error")
+			}
+		})
+	}
+}
@@ -21,14 +21,31 @@ const (
 	// verification protocol.
 	DefaultVersion BatchVersion = 0
 
+	// ExactPremiumVersion is the version of the batch verification
+	// protocol that calculates premiums with exact integer arithmetic
+	// instead of single precision floating point numbers.
+	ExactPremiumVersion BatchVersion = 1
+
 	// CurrentVersion must point to the latest implemented version of the
 	// batch verification protocol. Both server and client should always
 	// refer to this constant. If a client's binary is not updated in time
 	// it will point to a previous version than the server and the mismatch
 	// will be detected during the OrderMatchPrepare call.
-	CurrentVersion = DefaultVersion
+	CurrentVersion = ExactPremiumVersion
+
+	// MinSupportedVersion is the oldest version of the batch verification
+	// protocol that is still accepted. While the server transitions to
+	// the current version, batches of older versions are verified with
+	// the rules of the version they were created with. This is the version
+	// that is announced to the server when subscribing to account updates.
+	MinSupportedVersion = DefaultVersion
 )
 
+// Supported returns true if batches of the version can be verified.
+func (v BatchVersion) Supported() bool {
+	return v >= MinSupportedVersion && v <= CurrentVersion
+}
+
 // BatchID is a 33-byte point that uniquely identifies this batch. This ID
 // will be used later for account key derivation when constructing the batch
 // execution transaction.
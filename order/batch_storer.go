@@ -48,6 +48,7 @@ func (s *batchStorer) StorePendingBatch(batch *Batch) error {
 			orderModifiers[orderIndex] = []Modifier{
 				StateModifier(StateExecuted),
 				UnitsFulfilledModifier(0),
+				BatchMatchedModifier(),
 			}
 
 		// Some units were not yet filled.
@@ -55,6 +56,7 @@ func (s *batchStorer) StorePendingBatch(batch *Batch) error {
 			orderModifiers[orderIndex] = []Modifier{
 				StateModifier(StatePartiallyFilled),
 				UnitsFulfilledModifier(unitsUnfulfilled),
+				BatchMatchedModifier(),
 			}
 		}
 
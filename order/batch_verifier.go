@@ -31,6 +31,19 @@ var (
 type MismatchErr struct {
 	msg   string
 	cause error
+
+	// ourOrder is the nonce of our order the mismatch was found in or nil
+	// if the mismatch concerns the batch as a whole.
+	ourOrder *Nonce
+}
+
+// OrderNonce returns the nonce of our order the mismatch was found in. False
+// is returned if the mismatch can't be attributed to a single order of ours.
+func (m *MismatchErr) OrderNonce() (Nonce, bool) {
+	if m.ourOrder == nil {
+		return Nonce{}, false
+	}
+	return *m.ourOrder, true
 }
 
 // Unwrap returns the underlying error cause. This is always ErrMismatchErr so
@@ -58,6 +71,18 @@ func newMismatchErr(cause error, msg string, args ...interface{}) error {
 	}
 }
 
+// newOrderMismatchErr returns a new MismatchErr that was found in the given
+// order of ours.
+func newOrderMismatchErr(ourOrder Nonce, cause error, msg string,
+	args ...interface{}) error {
+
+	return &MismatchErr{
+		msg:      fmt.Sprintf(msg, args...),
+		cause:    cause,
+		ourOrder: &ourOrder,
+	}
+}
+
 // batchVerifier is a type that implements BatchVerifier and can verify a batch
 // from the point of view of the trader.
 type batchVerifier struct {
@@ -67,16 +92,33 @@ type batchVerifier struct {
 	ourNodePubkey [33]byte
 }
 
+// NewBatchVerifier creates a new BatchVerifier that verifies batches against
+// the orders and accounts of the given stores.
+func NewBatchVerifier(orderStore Store,
+	getAccount func(*btcec.PublicKey) (*account.Account, error),
+	wallet lndclient.WalletKitClient,
+	ourNodePubkey [33]byte) BatchVerifier {
+
+	return &batchVerifier{
+		orderStore:    orderStore,
+		getAccount:    getAccount,
+		wallet:        wallet,
+		ourNodePubkey: ourNodePubkey,
+	}
+}
+
 // Verify makes sure the batch prepared by the server is correct and can be
 // accepted by the trader.
 //
 // NOTE: This method is part of the BatchVerifier interface.
 func (v *batchVerifier) Verify(batch *Batch) error {
-	// First of all, make sure we're using the same batch validation version
-	// as the server. Otherwise we bail out of the batch. This should
+	// First of all, make sure we support the batch validation version
+	// used by the server. Otherwise we bail out of the batch. This should
 	// already be handled when the client connects/authenticates. But
-	// doesn't hurt to check again.
-	if batch.Version != CurrentVersion {
+	// doesn't hurt to check again. The version is passed on to the
+	// account tallies so the premiums are calculated the way the server
+	// did.
+	if !batch.Version.Supported() {
 		return ErrVersionMismatch
 	}
 
@@ -84,6 +126,7 @@ func (v *batchVerifier) Verify(batch *Batch) error {
 	// we know of the order and that the numbers check out on a high level.
 	tallies := make(map[[33]byte]*AccountTally)
 	accounts := make(map[[33]byte]*account.Account)
+	hasGroups := false
 	for nonce, theirOrders := range batch.MatchedOrders {
 		// Find our order in the database.
 		ourOrder, err := v.orderStore.GetOrder(nonce)
@@ -91,6 +134,20 @@ func (v *batchVerifier) Verify(batch *Batch) error {
 			return fmt.Errorf("order %v not found: %v", nonce, err)
 		}
 
+		// An order we already canceled or replaced locally must not
+		// be matched anymore, even if the server didn't process the
+		// cancellation in time.
+		if ourOrder.Details().State.Archived() {
+			return newOrderMismatchErr(
+				nonce, nil, "order %v is no longer active, "+
+					"state %v", nonce,
+				ourOrder.Details().State,
+			)
+		}
+		if ourOrder.Details().GroupID != ZeroGroupID {
+			hasGroups = true
+		}
+
 		// We'll index our account tallies by the serialized form of
 		// the account key so some copying is necessary first.
 		acctKeyRaw := ourOrder.Details().AcctKey
@@ -109,6 +166,7 @@ func (v *batchVerifier) Verify(batch *Batch) error {
 					acctKeyRaw, err)
 			}
 			tally = &AccountTally{
+				Version:       batch.Version,
 				EndingBalance: acct.Value,
 			}
 			tallies[acctKeyRaw] = tally
@@ -126,8 +184,9 @@ func (v *batchVerifier) Verify(batch *Batch) error {
 				batch.ClearingPrice,
 			)
 			if err != nil {
-				return newMismatchErr(
-					err, "error matching against order %v",
+				return newOrderMismatchErr(
+					nonce, err, "error matching against "+
+						"order %v",
 					theirOrder.Order.Nonce(),
 				)
 			}
@@ -139,9 +198,9 @@ func (v *batchVerifier) Verify(batch *Batch) error {
 				batch.BatchTX, ourOrder, theirOrder,
 			)
 			if err != nil {
-				return newMismatchErr(
-					err, "error finding channel output "+
-						"for matched order %v",
+				return newOrderMismatchErr(
+					nonce, err, "error finding channel "+
+						"output for matched order %v",
 					theirOrder.Order.Nonce(),
 				)
 			}
@@ -155,15 +214,39 @@ func (v *batchVerifier) Verify(batch *Batch) error {
 		// Last check is to make sure our order has not been over
 		// filled somehow.
 		if unitsFilled > ourOrder.Details().UnitsUnfulfilled {
-			return &MismatchErr{
-				msg: fmt.Sprintf("invalid units to be filled "+
-					"for order %v. currently unfulfilled "+
-					"%d, matched with %d in total",
-					ourOrder.Nonce(),
-					ourOrder.Details().UnitsUnfulfilled,
-					unitsFilled,
-				),
-			}
+			return newOrderMismatchErr(
+				nonce, nil, "invalid units to be filled for "+
+					"order %v. currently unfulfilled %d, "+
+					"matched with %d in total",
+				ourOrder.Nonce(),
+				ourOrder.Details().UnitsUnfulfilled,
+				unitsFilled,
+			)
+		}
+
+		// An all-or-none order must be filled completely within this
+		// batch or not at all.
+		if ourOrder.Details().AllOrNone &&
+			unitsFilled != ourOrder.Details().UnitsUnfulfilled {
+
+			return newOrderMismatchErr(
+				nonce, nil, "all-or-none order %v only "+
+					"filled with %d of %d units",
+				ourOrder.Nonce(), unitsFilled,
+				ourOrder.Details().UnitsUnfulfilled,
+			)
+		}
+	}
+
+	// Only a single member of each of our order groups can be matched.
+	if hasGroups {
+		orders, err := v.orderStore.GetOrders()
+		if err != nil {
+			return err
+		}
+		err = verifyGroups(orders, batch.MatchedOrders)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -227,6 +310,20 @@ func (v *batchVerifier) validateMatchedOrder(tally *AccountTally,
 		return fmt.Errorf("other order is an order from our node")
 	}
 
+	// Each match results in its own channel, so neither side's minimum
+	// number of units per match must be violated.
+	if otherOrder.UnitsFilled < ourOrder.Details().MinUnitsMatch {
+		return fmt.Errorf("match of %d units below our minimum of %d "+
+			"units per match", otherOrder.UnitsFilled,
+			ourOrder.Details().MinUnitsMatch)
+	}
+	if otherOrder.UnitsFilled < otherOrder.Order.Details().MinUnitsMatch {
+		return fmt.Errorf("match of %d units below other order's "+
+			"minimum of %d units per match",
+			otherOrder.UnitsFilled,
+			otherOrder.Order.Details().MinUnitsMatch)
+	}
+
 	// Verify that the durations overlap. Then tally up all the fees and
 	// units that were paid/accrued in this matched order pair. We can
 	// safely cast orders here because we made sure we have the right types
@@ -245,10 +342,13 @@ func (v *batchVerifier) validateMatchedOrder(tally *AccountTally,
 		}
 
 		// This match checks out, deduct it from the account's balance.
-		tally.CalcMakerDelta(
+		_, err := tally.CalcMakerDelta(
 			executionFee, clearingPrice,
 			otherOrder.UnitsFilled.ToSatoshis(), other.MinDuration,
 		)
+		if err != nil {
+			return fmt.Errorf("unable to tally match: %w", err)
+		}
 
 	case *Bid:
 		other := otherOrder.Order.(*Ask)
@@ -263,10 +363,13 @@ func (v *batchVerifier) validateMatchedOrder(tally *AccountTally,
 		}
 
 		// This match checks out, deduct it from the account's balance.
-		tally.CalcTakerDelta(
+		_, err := tally.CalcTakerDelta(
 			executionFee, clearingPrice,
 			otherOrder.UnitsFilled.ToSatoshis(), ours.MinDuration,
 		)
+		if err != nil {
+			return fmt.Errorf("unable to tally match: %w", err)
+		}
 	}
 
 	// Everything checks out so far.
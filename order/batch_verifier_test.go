@@ -73,6 +73,16 @@ func TestBatchVerifier(t *testing.T) {
 				return v.Verify(b)
 			},
 		},
+		{
+			name:        "order no longer active",
+			expectedErr: "is no longer active",
+			doVerify: func(v BatchVerifier, a *Ask, b1, b2 *Bid,
+				b *Batch) error {
+
+				b1.State = StateCanceled
+				return v.Verify(b)
+			},
+		},
 		{
 			name:        "invalid order type",
 			expectedErr: "matched same type orders",
@@ -172,6 +182,39 @@ func TestBatchVerifier(t *testing.T) {
 				return v.Verify(b)
 			},
 		},
+		{
+			name:        "match below min units per match",
+			expectedErr: "units per match",
+			doVerify: func(v BatchVerifier, a *Ask, b1, b2 *Bid,
+				b *Batch) error {
+
+				b2.Version = VersionFillConstraints
+				b2.MinUnitsMatch = 3
+				return v.Verify(b)
+			},
+		},
+		{
+			name:        "all-or-none order partially filled",
+			expectedErr: "all-or-none order",
+			doVerify: func(v BatchVerifier, a *Ask, b1, b2 *Bid,
+				b *Batch) error {
+
+				b2.Version = VersionFillConstraints
+				b2.AllOrNone = true
+				return v.Verify(b)
+			},
+		},
+		{
+			name:        "two members of an order group matched",
+			expectedErr: "can't both be matched",
+			doVerify: func(v BatchVerifier, a *Ask, b1, b2 *Bid,
+				b *Batch) error {
+
+				b1.GroupID = GroupID{0x01}
+				b2.GroupID = GroupID{0x01}
+				return v.Verify(b)
+			},
+		},
 		{
 			name:        "invalid funding TX fee rate",
 			expectedErr: "server sent unexpected ending balance",
@@ -238,6 +281,16 @@ func TestBatchVerifier(t *testing.T) {
 				return v.Verify(b)
 			},
 		},
+		{
+			name:        "happy path with previous version",
+			expectedErr: "",
+			doVerify: func(v BatchVerifier, a *Ask, b1, b2 *Bid,
+				b *Batch) error {
+
+				b.Version = MinSupportedVersion
+				return v.Verify(b)
+			},
+		},
 	}
 
 	// Run through all the test cases, creating a new, valid batch each
@@ -394,7 +447,7 @@ func TestBatchVerifier(t *testing.T) {
 		}
 		batch := &Batch{
 			ID:            batchID,
-			Version:       DefaultVersion,
+			Version:       CurrentVersion,
 			MatchedOrders: matchedOrders,
 			AccountDiffs:  accountDiffs,
 			ExecutionFee: NewLinearFeeSchedule(
@@ -434,6 +487,20 @@ func TestBatchVerifier(t *testing.T) {
 				t.Fatalf("unexpected error, got '%v' wanted "+
 					"'%v'", err, tc.expectedErr)
 			}
+
+			// A mismatch attributed to one of our orders must name
+			// an order of the batch.
+			mismatchErr, ok := err.(*MismatchErr)
+			if !ok {
+				return
+			}
+			nonce, ok := mismatchErr.OrderNonce()
+			if !ok {
+				return
+			}
+			if _, known := batch.MatchedOrders[nonce]; !known {
+				t.Fatalf("unknown order %v in mismatch", nonce)
+			}
 		})
 	}
 }
@@ -0,0 +1,36 @@
+package order
+
+import (
+	"time"
+)
+
+// HasExpiry returns true if any of the order's expiry limits is set.
+func (k *Kit) HasExpiry() bool {
+	return k.ExpiryHeight != 0 || !k.ExpiryTime.IsZero() ||
+		k.ExpiryBatches != 0
+}
+
+// Expired returns true if the order reached any of its expiry limits at the
+// given block height, time and number of completed batches. Orders that are
+// already archived never expire.
+func (k *Kit) Expired(height uint32, now time.Time, batchCount uint32) bool {
+	if k.State.Archived() {
+		return false
+	}
+
+	switch {
+	case k.ExpiryHeight != 0 && height >= k.ExpiryHeight:
+		return true
+
+	case !k.ExpiryTime.IsZero() && !now.Before(k.ExpiryTime):
+		return true
+
+	case k.ExpiryBatches != 0 && batchCount > k.SubmittedAtBatch &&
+		batchCount-k.SubmittedAtBatch >= k.ExpiryBatches:
+
+		return true
+
+	default:
+		return false
+	}
+}
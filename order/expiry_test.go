@@ -0,0 +1,80 @@
+package order
+
+import (
+	"testing"
+	"time"
+)
+
+// TestKitExpired makes sure an order expires as soon as any of its expiry
+// limits is reached. The current height is 100 and 10 batches were completed.
+func TestKitExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_600_000_000, 0)
+	testCases := []struct {
+		name    string
+		kit     Kit
+		expired bool
+	}{{
+		name:    "no expiry",
+		kit:     Kit{},
+		expired: false,
+	}, {
+		name:    "height not reached",
+		kit:     Kit{ExpiryHeight: 101},
+		expired: false,
+	}, {
+		name:    "height reached",
+		kit:     Kit{ExpiryHeight: 100},
+		expired: true,
+	}, {
+		name:    "time not reached",
+		kit:     Kit{ExpiryTime: now.Add(time.Second)},
+		expired: false,
+	}, {
+		name:    "time reached",
+		kit:     Kit{ExpiryTime: now},
+		expired: true,
+	}, {
+		name:    "batches not reached",
+		kit:     Kit{ExpiryBatches: 3, SubmittedAtBatch: 8},
+		expired: false,
+	}, {
+		name:    "batches reached without being matched",
+		kit:     Kit{ExpiryBatches: 2, SubmittedAtBatch: 8},
+		expired: true,
+	}, {
+		name:    "batch count behind submission",
+		kit:     Kit{ExpiryBatches: 1, SubmittedAtBatch: 12},
+		expired: false,
+	}, {
+		name: "one of many limits reached",
+		kit: Kit{
+			ExpiryHeight:  101,
+			ExpiryTime:    now.Add(-time.Second),
+			ExpiryBatches: 2,
+		},
+		expired: true,
+	}, {
+		name: "archived order",
+		kit: Kit{
+			State:        StateCanceled,
+			ExpiryHeight: 100,
+		},
+		expired: false,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			expired := tc.kit.Expired(100, now, 10)
+			if expired != tc.expired {
+				t.Fatalf("expected expired=%v, got %v",
+					tc.expired, expired)
+			}
+		})
+	}
+}
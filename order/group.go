@@ -0,0 +1,203 @@
+package order
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// groupCancelTimeout is the maximum time we allow for canceling a
+	// single order of a group with the auction server.
+	groupCancelTimeout = 30 * time.Second
+)
+
+// GroupID is the identifier of a one-cancels-other order group. Only a single
+// member of a group can ever be matched. As soon as one of them is matched in
+// a batch, all other members of the group are canceled.
+type GroupID [32]byte
+
+// ZeroGroupID is the group ID of orders that don't belong to a group.
+var ZeroGroupID GroupID
+
+// String returns the hex encoded representation of the group ID.
+func (g GroupID) String() string {
+	return hex.EncodeToString(g[:])
+}
+
+// matchedBefore returns true if the order was already matched in a previous
+// batch.
+func matchedBefore(kit *Kit) bool {
+	return kit.UnitsUnfulfilled < kit.Units
+}
+
+// verifyGroups makes sure the batch doesn't match more than one member of any
+// of our order groups. A member that was already matched in an earlier batch
+// counts as well, as the other members might not be canceled on the server
+// yet. Groups are a local policy the auctioneer doesn't know about, so every
+// conflicting order of the batch is rejected individually with
+// ErrPolicyReject and the auctioneer can re-plan the batch without it.
+func verifyGroups(orders []Order, matched map[Nonce][]*MatchedOrder) error {
+	// Members that were matched before take precedence over the ones in
+	// this batch. Otherwise we keep the member with the lowest nonce so
+	// the result doesn't depend on the order of the given orders.
+	candidates := make([]Order, 0, len(orders))
+	for _, o := range orders {
+		kit := o.Details()
+		if kit.GroupID == ZeroGroupID {
+			continue
+		}
+
+		_, isMatched := matched[o.Nonce()]
+		if isMatched || matchedBefore(kit) {
+			candidates = append(candidates, o)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		before1 := matchedBefore(candidates[i].Details())
+		before2 := matchedBefore(candidates[j].Details())
+		if before1 != before2 {
+			return before1
+		}
+		nonce1, nonce2 := candidates[i].Nonce(), candidates[j].Nonce()
+		return bytes.Compare(nonce1[:], nonce2[:]) < 0
+	})
+
+	rejectErr := NewMatchRejectErr()
+	members := make(map[GroupID]Nonce)
+	for _, o := range candidates {
+		kit := o.Details()
+		member, ok := members[kit.GroupID]
+		if !ok {
+			members[kit.GroupID] = o.Nonce()
+			continue
+		}
+
+		// Two members that were both matched in earlier batches are
+		// not a problem of this batch.
+		if _, isMatched := matched[o.Nonce()]; !isMatched {
+			continue
+		}
+
+		rejectErr.RejectedOrders[o.Nonce()] = fmt.Errorf("%w: order "+
+			"%v and %v of order group %v can't both be matched",
+			ErrPolicyReject, member, o.Nonce(), kit.GroupID)
+	}
+
+	if len(rejectErr.RejectedOrders) > 0 {
+		return rejectErr
+	}
+	return nil
+}
+
+// groupMembersToCancel returns all active orders that have to be canceled
+// because another member of their group was matched. The orders to cancel are
+// mapped to the nonce of the member that was matched.
+func groupMembersToCancel(orders []Order) map[Nonce]Nonce {
+	matched := make(map[GroupID]Nonce)
+	for _, o := range orders {
+		kit := o.Details()
+		if kit.GroupID != ZeroGroupID && matchedBefore(kit) {
+			matched[kit.GroupID] = o.Nonce()
+		}
+	}
+
+	toCancel := make(map[Nonce]Nonce)
+	for _, o := range orders {
+		kit := o.Details()
+		if kit.GroupID == ZeroGroupID || kit.State.Archived() {
+			continue
+		}
+
+		member, ok := matched[kit.GroupID]
+		if ok && member != o.Nonce() {
+			toCancel[o.Nonce()] = member
+		}
+	}
+
+	return toCancel
+}
+
+// queueGroupCancels hands all active orders of which another member of their
+// group was matched to the group cancel handler. If the handler is still busy
+// with the orders of the last batch, the new ones are picked up after the next
+// batch.
+func (m *Manager) queueGroupCancels() {
+	orders, err := m.cfg.Store.GetOrders()
+	if err != nil {
+		log.Errorf("Unable to load orders to cancel: %v", err)
+		return
+	}
+
+	toCancel := groupMembersToCancel(orders)
+	if len(toCancel) == 0 {
+		return
+	}
+
+	select {
+	case m.groupCancels <- toCancel:
+	default:
+	}
+}
+
+// groupCancelHandler cancels all orders it receives with the auction server
+// and records the group member that caused the cancellation. Orders that can't
+// be canceled are tried again after the next batch.
+//
+// NOTE: This method must be run as a goroutine.
+func (m *Manager) groupCancelHandler() {
+	defer m.wg.Done()
+
+	for {
+		select {
+		case toCancel := <-m.groupCancels:
+			for nonce, matched := range toCancel {
+				err := m.cancelGroupMember(nonce, matched)
+				if err != nil {
+					log.Errorf("Unable to cancel order %v "+
+						"of the same group as matched "+
+						"order %v: %v", nonce, matched,
+						err)
+				}
+			}
+
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// cancelGroupMember cancels a single order with the auction server and records
+// the group member that caused the cancellation. As the order might have
+// changed since it was queued, it is skipped if it's no longer active or part
+// of a new pending batch.
+func (m *Manager) cancelGroupMember(nonce, matched Nonce) error {
+	o, err := m.cfg.Store.GetOrder(nonce)
+	if err != nil {
+		return err
+	}
+	if o.Details().State.Archived() || m.InPendingBatch(nonce) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), groupCancelTimeout,
+	)
+	defer cancel()
+
+	if err := m.cfg.Auctioneer.CancelOrder(ctx, nonce); err != nil {
+		return err
+	}
+
+	err = m.cfg.Store.UpdateOrder(nonce, GroupCanceledModifier(matched))
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Canceled order %v because order %v of the same group "+
+		"was matched", nonce, matched)
+	return nil
+}
@@ -0,0 +1,281 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestGroupMembersToCancel makes sure all active orders of a group are
+// canceled as soon as one of its members was matched.
+func TestGroupMembersToCancel(t *testing.T) {
+	t.Parallel()
+
+	newGroupOrder := func(nonce Nonce, group GroupID, state State,
+		unfulfilled SupplyUnit) Order {
+
+		kit := newKitFromTemplate(nonce, &Kit{
+			State:            state,
+			Units:            4,
+			UnitsUnfulfilled: unfulfilled,
+		})
+		kit.GroupID = group
+		return &Bid{Kit: kit}
+	}
+
+	var (
+		groupA = GroupID{0x0a}
+		groupB = GroupID{0x0b}
+	)
+	testCases := []struct {
+		name     string
+		orders   []Order
+		toCancel map[Nonce]Nonce
+	}{{
+		name: "no groups",
+		orders: []Order{
+			newGroupOrder(Nonce{1}, ZeroGroupID, StateExecuted, 0),
+			newGroupOrder(Nonce{2}, ZeroGroupID, StateSubmitted, 4),
+		},
+		toCancel: map[Nonce]Nonce{},
+	}, {
+		name: "no member matched",
+		orders: []Order{
+			newGroupOrder(Nonce{1}, groupA, StateSubmitted, 4),
+			newGroupOrder(Nonce{2}, groupA, StateSubmitted, 4),
+		},
+		toCancel: map[Nonce]Nonce{},
+	}, {
+		name: "partially filled member",
+		orders: []Order{
+			newGroupOrder(
+				Nonce{1}, groupA, StatePartiallyFilled, 2,
+			),
+			newGroupOrder(Nonce{2}, groupA, StateSubmitted, 4),
+			newGroupOrder(Nonce{3}, groupA, StateCanceled, 4),
+			newGroupOrder(Nonce{4}, groupB, StateSubmitted, 4),
+		},
+		toCancel: map[Nonce]Nonce{
+			{2}: {1},
+		},
+	}, {
+		name: "multiple groups",
+		orders: []Order{
+			newGroupOrder(Nonce{1}, groupA, StateExecuted, 0),
+			newGroupOrder(Nonce{2}, groupA, StateSubmitted, 4),
+			newGroupOrder(Nonce{3}, groupB, StateSubmitted, 4),
+			newGroupOrder(Nonce{4}, groupB, StateExecuted, 0),
+		},
+		toCancel: map[Nonce]Nonce{
+			{2}: {1},
+			{3}: {4},
+		},
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			toCancel := groupMembersToCancel(tc.orders)
+			if !reflect.DeepEqual(toCancel, tc.toCancel) {
+				t.Fatalf("expected orders to cancel %v, got %v",
+					tc.toCancel, toCancel)
+			}
+		})
+	}
+}
+
+// TestVerifyGroups makes sure a batch that matches more than one member of a
+// group is rejected by policy for exactly the conflicting orders of the batch.
+func TestVerifyGroups(t *testing.T) {
+	t.Parallel()
+
+	newGroupOrder := func(nonce Nonce, group GroupID,
+		unfulfilled SupplyUnit) Order {
+
+		kit := newKitFromTemplate(nonce, &Kit{
+			State:            StateSubmitted,
+			Units:            4,
+			UnitsUnfulfilled: unfulfilled,
+		})
+		kit.GroupID = group
+		return &Bid{Kit: kit}
+	}
+
+	var (
+		groupA = GroupID{0x0a}
+		groupB = GroupID{0x0b}
+	)
+	testCases := []struct {
+		name     string
+		orders   []Order
+		matched  []Nonce
+		rejected []Nonce
+	}{{
+		name: "single member matched",
+		orders: []Order{
+			newGroupOrder(Nonce{1}, groupA, 4),
+			newGroupOrder(Nonce{2}, groupA, 4),
+		},
+		matched: []Nonce{{2}},
+	}, {
+		name: "members of different groups matched",
+		orders: []Order{
+			newGroupOrder(Nonce{1}, groupA, 4),
+			newGroupOrder(Nonce{2}, groupB, 4),
+		},
+		matched: []Nonce{{1}, {2}},
+	}, {
+		name: "two members matched in batch",
+		orders: []Order{
+			newGroupOrder(Nonce{2}, groupA, 4),
+			newGroupOrder(Nonce{1}, groupA, 4),
+			newGroupOrder(Nonce{3}, groupB, 4),
+		},
+		matched:  []Nonce{{1}, {2}, {3}},
+		rejected: []Nonce{{2}},
+	}, {
+		name: "member matched in earlier batch",
+		orders: []Order{
+			newGroupOrder(Nonce{1}, groupA, 4),
+			newGroupOrder(Nonce{2}, groupA, 2),
+		},
+		matched:  []Nonce{{1}, {2}},
+		rejected: []Nonce{{1}},
+	}, {
+		name: "members matched in earlier batches only",
+		orders: []Order{
+			newGroupOrder(Nonce{1}, groupA, 2),
+			newGroupOrder(Nonce{2}, groupA, 2),
+			newGroupOrder(Nonce{3}, groupA, 4),
+		},
+		matched:  []Nonce{{3}},
+		rejected: []Nonce{{3}},
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			matched := make(map[Nonce][]*MatchedOrder)
+			for _, nonce := range tc.matched {
+				matched[nonce] = nil
+			}
+
+			err := verifyGroups(tc.orders, matched)
+			if len(tc.rejected) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			rejectErr, ok := err.(*MatchRejectErr)
+			if !ok {
+				t.Fatalf("expected MatchRejectErr, got %v", err)
+			}
+			if len(rejectErr.RejectedOrders) != len(tc.rejected) {
+				t.Fatalf("expected %d rejected orders, got %v",
+					len(tc.rejected), rejectErr)
+			}
+			for _, nonce := range tc.rejected {
+				reason, ok := rejectErr.RejectedOrders[nonce]
+				if !ok {
+					t.Fatalf("order %v not rejected", nonce)
+				}
+				if !errors.Is(reason, ErrPolicyReject) {
+					t.Fatalf("expected policy reject, got "+
+						"%v", reason)
+				}
+			}
+		})
+	}
+}
+
+// mockAuctioneer is an auctioneer that reports every canceled order.
+type mockAuctioneer struct {
+	canceled chan Nonce
+}
+
+func (a *mockAuctioneer) CancelOrder(_ context.Context, nonce Nonce) error {
+	a.canceled <- nonce
+	return nil
+}
+
+// TestGroupCancelHandler makes sure the remaining members of a group are
+// canceled in the background and that orders that changed after they were
+// queued are skipped.
+func TestGroupCancelHandler(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+	auctioneer := &mockAuctioneer{canceled: make(chan Nonce, 4)}
+	m := NewManager(&ManagerConfig{
+		Store:      store,
+		Auctioneer: auctioneer,
+	})
+
+	group := GroupID{0x0a}
+	for i, state := range []State{
+		StateExecuted, StateSubmitted, StateSubmitted, StateSubmitted,
+	} {
+		kit := newKitFromTemplate(Nonce{byte(i + 1)}, &Kit{
+			State:            state,
+			Units:            4,
+			UnitsUnfulfilled: 4,
+		})
+		kit.GroupID = group
+		if state == StateExecuted {
+			kit.UnitsUnfulfilled = 0
+		}
+		store.orders[kit.Nonce()] = &Bid{Kit: kit}
+	}
+
+	// Queueing the cancellations must never block, even if the handler
+	// isn't ready to receive them.
+	m.queueGroupCancels()
+	m.queueGroupCancels()
+
+	// Before the handler gets to the orders, one of them is canceled by
+	// the user and another one is matched in a new batch.
+	store.orders[Nonce{3}].Details().State = StateCanceled
+	m.pendingBatch = &Batch{
+		MatchedOrders: map[Nonce][]*MatchedOrder{{4}: nil},
+	}
+
+	m.wg.Add(1)
+	go m.groupCancelHandler()
+
+	select {
+	case nonce := <-auctioneer.canceled:
+		if nonce != (Nonce{2}) {
+			t.Fatalf("unexpected order %v canceled", nonce)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("order not canceled")
+	}
+	m.Stop()
+
+	if len(auctioneer.canceled) != 0 {
+		t.Fatalf("unexpected order %v canceled",
+			<-auctioneer.canceled)
+	}
+
+	expected := map[Nonce]Nonce{{2}: {1}, {3}: {}, {4}: {}}
+	for nonce, canceledBy := range expected {
+		kit := store.orders[nonce].Details()
+		if kit.CanceledBy != canceledBy {
+			t.Fatalf("expected order %v to be canceled by %v, "+
+				"got %v", nonce, canceledBy, kit.CanceledBy)
+		}
+	}
+	if store.orders[Nonce{4}].Details().State != StateSubmitted {
+		t.Fatalf("order in pending batch was canceled")
+	}
+}
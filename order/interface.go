@@ -2,11 +2,13 @@ package order
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
@@ -33,6 +35,10 @@ type Version uint32
 const (
 	// VersionDefault is the default initial version of orders.
 	VersionDefault Version = 0
+
+	// VersionFillConstraints is the order version that adds the minimum
+	// number of units per match and the all-or-none flag to an order.
+	VersionFillConstraints Version = 1
 )
 
 // Type is the type of an order. We don't use iota for the constants due to the
@@ -47,6 +53,20 @@ const (
 	TypeBid Type = 1
 )
 
+// String returns a human readable string representation of the order type.
+func (t Type) String() string {
+	switch t {
+	case TypeAsk:
+		return "ask"
+
+	case TypeBid:
+		return "bid"
+
+	default:
+		return fmt.Sprintf("unknown<%d>", t)
+	}
+}
+
 // State describes the different possible states of an order. We don't use iota
 // for the constants due to the order state being persisted to disk.
 type State uint8
@@ -114,7 +134,7 @@ func (s State) String() string {
 // fully executed and no more modifications will be done to it.
 func (s State) Archived() bool {
 	switch s {
-	case StateExecuted, StateCanceled, StateFailed:
+	case StateExecuted, StateCanceled, StateExpired, StateFailed:
 		return true
 
 	default:
@@ -198,6 +218,79 @@ type Kit struct {
 
 	// AcctKey is key of the account the order belongs to.
 	AcctKey [33]byte
+
+	// Label is an optional user-defined name of the order. It's only
+	// known to the trader and never sent to the auction server.
+	Label string
+
+	// Tags is an optional set of user-defined key/value pairs attached to
+	// the order. Just like the label, they are only known to the trader.
+	Tags map[string]string
+
+	// MinUnitsMatch is the minimum number of units that must be filled
+	// by a single matched order. Each match results in its own channel so
+	// this prevents channels that aren't worth their chain fees. Only
+	// valid for VersionFillConstraints and later.
+	MinUnitsMatch SupplyUnit
+
+	// AllOrNone signals that the order can only be filled completely in a
+	// single batch. Only valid for VersionFillConstraints and later.
+	AllOrNone bool
+
+	// ExpiryHeight is the optional block height at which the order is
+	// canceled automatically by the trader. Zero means no limit.
+	ExpiryHeight uint32
+
+	// ExpiryTime is the optional wall clock time after which the order is
+	// canceled automatically by the trader. The zero value means no limit.
+	ExpiryTime time.Time
+
+	// ExpiryBatches is the optional number of batches that can be
+	// completed after the order was submitted before the rest of it is
+	// canceled automatically by the trader. Every batch the trader takes
+	// part in counts, whether or not the order was matched in it. Zero
+	// means no limit.
+	ExpiryBatches uint32
+
+	// SubmittedAtBatch is the number of batches that were completed when
+	// the order was submitted.
+	SubmittedAtBatch uint32
+
+	// BatchesMatched is the number of batches the order was matched in so
+	// far.
+	BatchesMatched uint32
+
+	// Replaces is the nonce of the order this order replaced or the zero
+	// nonce if it didn't replace any order.
+	Replaces Nonce
+
+	// ReplacedBy is the nonce of the order this order was replaced with or
+	// the zero nonce if it wasn't replaced.
+	ReplacedBy Nonce
+
+	// GroupID is the optional one-cancels-other group the order belongs
+	// to. The group is only known to the trader.
+	GroupID GroupID
+
+	// CanceledBy is the nonce of the member of the order's group that was
+	// matched and caused this order to be canceled automatically. It's
+	// the zero nonce if the order wasn't canceled because of its group.
+	CanceledBy Nonce
+
+	// FailedAt is the time the submission of the order failed. It's only
+	// set for orders in the state StateFailed.
+	FailedAt time.Time
+
+	// FailString describes why the submission of the order failed.
+	FailString string
+
+	// Rejected is true if the auction server rejected the order because
+	// of its details. FailReason is only valid if this is set.
+	Rejected bool
+
+	// FailReason is the reason the auction server gave for rejecting the
+	// order, corresponding to clmrpc.InvalidOrder_FailReason.
+	FailReason uint32
 }
 
 // Nonce is the unique identifier of each order and MUST be created by hashing a
@@ -276,6 +369,16 @@ func (a *Ask) Digest() ([sha256.Size]byte, error) {
 			return result, err
 		}
 
+	case VersionFillConstraints:
+		err := lnwire.WriteElements(
+			&msg, a.nonce[:], uint32(a.Version), a.FixedRate,
+			a.Amt, a.MaxDuration, uint64(a.FundingFeeRate),
+			uint32(a.MinUnitsMatch), a.AllOrNone,
+		)
+		if err != nil {
+			return result, err
+		}
+
 	default:
 		return result, fmt.Errorf("unknown version %d", a.Kit.Version)
 	}
@@ -321,6 +424,16 @@ func (b *Bid) Digest() ([sha256.Size]byte, error) {
 			return result, err
 		}
 
+	case VersionFillConstraints:
+		err := lnwire.WriteElements(
+			&msg, b.nonce[:], uint32(b.Version), b.FixedRate,
+			b.Amt, b.MinDuration, uint64(b.FundingFeeRate),
+			uint32(b.MinUnitsMatch), b.AllOrNone,
+		)
+		if err != nil {
+			return result, err
+		}
+
 	default:
 		return result, fmt.Errorf("unknown version %d", b.Kit.Version)
 	}
@@ -350,6 +463,72 @@ func UnitsFulfilledModifier(newUnfulfilledUnits SupplyUnit) Modifier {
 	}
 }
 
+// BatchMatchedModifier is a functional option that increments the number of
+// batches an order was matched in.
+func BatchMatchedModifier() Modifier {
+	return func(order *Kit) {
+		order.BatchesMatched++
+	}
+}
+
+// FailedModifier is a functional option that marks the submission of an order
+// as failed at the given time. If the auction server rejected the order, its
+// reasons are recorded too.
+func FailedModifier(failedAt time.Time, failErr error) Modifier {
+	return func(order *Kit) {
+		order.State = StateFailed
+		order.FailedAt = failedAt
+		order.FailString = failErr.Error()
+
+		userErr, ok := failErr.(*UserError)
+		if ok && userErr.Details != nil {
+			order.Rejected = true
+			order.FailReason = uint32(userErr.Details.FailReason)
+			order.FailString = userErr.Details.FailString
+		}
+	}
+}
+
+// ReplacedByModifier is a functional option that records the order an order was
+// replaced with.
+func ReplacedByModifier(nonce Nonce) Modifier {
+	return func(order *Kit) {
+		order.ReplacedBy = nonce
+	}
+}
+
+// LabelModifier is a functional option that modifies the label of an order.
+func LabelModifier(label string) Modifier {
+	return func(order *Kit) {
+		order.Label = label
+	}
+}
+
+// TagsModifier is a functional option that sets the given tags of an order and
+// removes the tags with the given keys.
+func TagsModifier(setTags map[string]string, removeTags []string) Modifier {
+	return func(order *Kit) {
+		order.Tags = account.UpdateTags(order.Tags, setTags, removeTags)
+	}
+}
+
+// GroupCanceledModifier is a functional option that cancels an order because
+// the given member of its group was matched.
+func GroupCanceledModifier(matched Nonce) Modifier {
+	return func(order *Kit) {
+		order.State = StateCanceled
+		order.CanceledBy = matched
+	}
+}
+
+// Auctioneer is the interface the order manager uses to interact with the
+// auction server.
+type Auctioneer interface {
+	// CancelOrder cancels the order with the given nonce on the auction
+	// server.
+	CancelOrder(context.Context, Nonce) error
+}
+
 // Store is the interface a store has to implement to support persisting orders.
 type Store interface {
 	// SubmitOrder stores an order by using the orders's nonce as an
@@ -389,6 +568,10 @@ type Store interface {
 	// in a new batch. If a pending batch is not found, ErrNoPendingBatch is
 	// returned.
 	MarkBatchComplete() error
+
+	// BatchCount returns the number of batches that were completed so
+	// far. Every successful call to MarkBatchComplete increases it by one.
+	BatchCount() (uint32, error)
 }
 
 // UserError is an error type that is returned if an action fails because of
@@ -2,6 +2,7 @@ package order
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"strings"
@@ -21,9 +22,13 @@ const (
 
 var (
 	// ErrVersionMismatch is the error that is returned if we don't
-	// implement the same batch verification version as the server.
-	ErrVersionMismatch = fmt.Errorf("version %d mismatches server version",
-		CurrentVersion)
+	// implement the batch verification version used by the server.
+	ErrVersionMismatch = fmt.Errorf("server version not within supported "+
+		"versions %d to %d", MinSupportedVersion, CurrentVersion)
+
+	// ErrOrderInPendingBatch is returned if an order can't be canceled
+	// because it was matched in the batch we're currently executing.
+	ErrOrderInPendingBatch = errors.New("order is part of a pending batch")
 )
 
 // ManagerConfig contains all of the required dependencies for the Manager to
@@ -43,6 +48,10 @@ type ManagerConfig struct {
 
 	// Signer is used to sign orders before submitting them to the server.
 	Signer lndclient.SignerClient
+
+	// Auctioneer is used to cancel orders, for example the remaining
+	// orders of a group once one of its members was matched.
+	Auctioneer Auctioneer
 }
 
 // Manager is responsible for the management of orders.
@@ -58,14 +67,26 @@ type Manager struct {
 	batchVerifier BatchVerifier
 	batchSigner   BatchSigner
 	batchStorer   BatchStorer
-	pendingBatch  *Batch
+
+	// pendingBatch is the batch we last validated. It's guarded by
+	// pendingMtx as it's also read outside of the batch handling. The
+	// mutex is held while a batch is validated, so an order that is
+	// canceled with CancelOrder can't end up in a new pending batch.
+	pendingBatch *Batch
+	pendingMtx   sync.Mutex
+
+	// groupCancels is used to hand the orders that have to be canceled
+	// because another member of their group was matched to the group
+	// cancel handler. Each order is mapped to the matched member.
+	groupCancels chan map[Nonce]Nonce
 }
 
 // NewManager instantiates a new Manager backed by the given config.
 func NewManager(cfg *ManagerConfig) *Manager {
 	return &Manager{
-		cfg:  *cfg,
-		quit: make(chan struct{}),
+		cfg:          *cfg,
+		quit:         make(chan struct{}),
+		groupCancels: make(chan map[Nonce]Nonce, 1),
 	}
 }
 
@@ -85,12 +106,10 @@ func (m *Manager) Start() error {
 		if err != nil {
 			return
 		}
-		m.batchVerifier = &batchVerifier{
-			orderStore:    m.cfg.Store,
-			getAccount:    m.cfg.AcctStore.Account,
-			wallet:        m.cfg.Wallet,
-			ourNodePubkey: info.IdentityPubkey,
-		}
+		m.batchVerifier = NewBatchVerifier(
+			m.cfg.Store, m.cfg.AcctStore.Account, m.cfg.Wallet,
+			info.IdentityPubkey,
+		)
 		m.batchSigner = &batchSigner{
 			getAccount: m.cfg.AcctStore.Account,
 			signer:     m.cfg.Signer,
@@ -99,6 +118,9 @@ func (m *Manager) Start() error {
 			orderStore: m.cfg.Store,
 			getAccount: m.cfg.AcctStore.Account,
 		}
+
+		m.wg.Add(1)
+		go m.groupCancelHandler()
 	})
 	return err
 }
@@ -143,8 +165,8 @@ func (m *Manager) PrepareOrder(ctx context.Context, order Order,
 			"node uris: %v", err)
 	}
 	if len(nodeAddrs) == 0 {
-		return nil, fmt.Errorf("the lnd node must " +
-			"be reachable on clearnet to negotiate channel order")
+		return nil, fmt.Errorf("the lnd node must be reachable on " +
+			"clearnet or tor to negotiate channel order")
 	}
 
 	// Sign the order digest with the account key.
@@ -161,6 +183,13 @@ func (m *Manager) PrepareOrder(ctx context.Context, order Order,
 			"order: %v", err)
 	}
 
+	// An expiry by number of batches counts from the batches that were
+	// completed up to now.
+	order.Details().SubmittedAtBatch, err = m.cfg.Store.BatchCount()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get batch count: %v", err)
+	}
+
 	// There shouldn't be anything that can go wrong on our side, so store
 	// the pending order in our local database.
 	err = m.cfg.Store.SubmitOrder(order)
@@ -180,7 +209,14 @@ func (m *Manager) PrepareOrder(ctx context.Context, order Order,
 // validateOrder makes sure an order is formally correct and that the associated
 // account contains enough balance to execute the order.
 func (m *Manager) validateOrder(order Order, acct *account.Account) error {
-	// First parse order type specific fields.
+	// The order must have a size the auctioneer accepts.
+	kit := order.Details()
+	err := ValidateOrderSize(kit.Amt, kit.MinUnitsMatch)
+	if err != nil {
+		return err
+	}
+
+	// Next parse order type specific fields.
 	switch o := order.(type) {
 	case *Ask:
 		if o.MaxDuration == 0 {
@@ -204,7 +240,11 @@ func (m *Manager) validateOrder(order Order, acct *account.Account) error {
 		// make sure we have enough to pay for the fee rate we are
 		// willing to pay up to.
 		rate := FixedRatePremium(o.FixedRate)
-		orderFee := rate.LumpSumPremium(o.Amt, o.MinDuration)
+		orderFee, err := rate.LumpSumPremium(o.Amt, o.MinDuration)
+		if err != nil {
+			return fmt.Errorf("unable to calculate premium: %w",
+				err)
+		}
 		if acct.Value < orderFee {
 			return ErrInsufficientBalance
 		}
@@ -213,11 +253,45 @@ func (m *Manager) validateOrder(order Order, acct *account.Account) error {
 		return fmt.Errorf("invalid order type: %v", o)
 	}
 
+	// The fill constraints are part of the order digest only for orders
+	// that have a version that knows about them.
+	if kit.Version < VersionFillConstraints &&
+		(kit.MinUnitsMatch != 0 || kit.AllOrNone) {
+
+		return fmt.Errorf("fill constraints require order version %d "+
+			"or later", VersionFillConstraints)
+	}
+	if kit.MinUnitsMatch > kit.Units {
+		return fmt.Errorf("min units per match %d exceeds order size "+
+			"of %d units", kit.MinUnitsMatch, kit.Units)
+	}
+
+	// There's no point in adding an order to a group that already had one
+	// of its orders matched as it would be canceled right away.
+	if kit.GroupID != ZeroGroupID {
+		orders, err := m.cfg.Store.GetOrders()
+		if err != nil {
+			return err
+		}
+		for _, o := range orders {
+			if o.Details().GroupID == kit.GroupID &&
+				matchedBefore(o.Details()) {
+
+				return fmt.Errorf("order %v of group %v was "+
+					"already matched", o.Nonce(),
+					kit.GroupID)
+			}
+		}
+	}
+
 	return nil
 }
 
 // OrderMatchValidate verifies an incoming batch is sane before accepting it.
 func (m *Manager) OrderMatchValidate(batch *Batch) error {
+	m.pendingMtx.Lock()
+	defer m.pendingMtx.Unlock()
+
 	// Make sure we have no objection to the current batch. Then store
 	// it in case it ends up being the final version.
 	err := m.batchVerifier.Verify(batch)
@@ -232,20 +306,69 @@ func (m *Manager) OrderMatchValidate(batch *Batch) error {
 
 // PendingBatch returns the current pending batch being validated.
 func (m *Manager) PendingBatch() *Batch {
+	m.pendingMtx.Lock()
+	defer m.pendingMtx.Unlock()
+
 	return m.pendingBatch
 }
 
+// InPendingBatch returns true if the order with the given nonce was matched in
+// the current pending batch.
+func (m *Manager) InPendingBatch(nonce Nonce) bool {
+	m.pendingMtx.Lock()
+	defer m.pendingMtx.Unlock()
+
+	return m.inPendingBatch(nonce)
+}
+
+// inPendingBatch returns true if the order with the given nonce was matched in
+// the current pending batch.
+//
+// NOTE: The pendingMtx must be held when calling this method.
+func (m *Manager) inPendingBatch(nonce Nonce) bool {
+	if m.pendingBatch == nil {
+		return false
+	}
+	_, ok := m.pendingBatch.MatchedOrders[nonce]
+	return ok
+}
+
+// CancelOrder cancels an order with the auction server and marks it as
+// canceled in our local store. An order that was matched in the pending batch
+// can't be canceled safely anymore and ErrOrderInPendingBatch is returned. No
+// new batch can be validated while the order is canceled, so a batch that
+// still matches it is rejected.
+func (m *Manager) CancelOrder(ctx context.Context, nonce Nonce) error {
+	m.pendingMtx.Lock()
+	defer m.pendingMtx.Unlock()
+
+	if m.inPendingBatch(nonce) {
+		return ErrOrderInPendingBatch
+	}
+
+	if err := m.cfg.Auctioneer.CancelOrder(ctx, nonce); err != nil {
+		return err
+	}
+
+	err := m.cfg.Store.UpdateOrder(nonce, StateModifier(StateCanceled))
+	if err != nil {
+		return fmt.Errorf("unable to update canceled order: %v", err)
+	}
+	return nil
+}
+
 // BatchSign returns the witness stack of all account inputs in a batch that
 // belong to the trader. Before sending off the signature to the auctioneer,
 // we'll also persist the batch to disk as pending to ensure we can recover
 // after a crash.
 func (m *Manager) BatchSign() (BatchSignature, error) {
-	sig, err := m.batchSigner.Sign(m.pendingBatch)
+	batch := m.PendingBatch()
+	sig, err := m.batchSigner.Sign(batch)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := m.batchStorer.StorePendingBatch(m.pendingBatch); err != nil {
+	if err := m.batchStorer.StorePendingBatch(batch); err != nil {
 		return nil, fmt.Errorf("unable to store batch: %v", err)
 	}
 
@@ -257,9 +380,10 @@ func (m *Manager) BatchSign() (BatchSignature, error) {
 func (m *Manager) BatchFinalize(batchID BatchID) error {
 	// Only accept the last batch we verified to make sure we didn't miss
 	// a message somewhere in the process.
-	if batchID != m.pendingBatch.ID {
+	batch := m.PendingBatch()
+	if batchID != batch.ID {
 		return fmt.Errorf("unexpected batch ID %x, doesn't match last "+
-			"validated batch %x", batchID, m.pendingBatch.ID)
+			"validated batch %x", batchID, batch.ID)
 	}
 
 	// Create a diff and then persist that. Finally signal that we are ready
@@ -267,15 +391,23 @@ func (m *Manager) BatchFinalize(batchID BatchID) error {
 	if err := m.batchStorer.MarkBatchComplete(); err != nil {
 		return fmt.Errorf("unable to mark batch as complete: %v", err)
 	}
+	m.pendingMtx.Lock()
 	m.pendingBatch = nil
+	m.pendingMtx.Unlock()
+
+	// Any order of which another member of its group was matched can't
+	// be matched anymore. This also retries cancellations that failed
+	// after previous batches. The cancellations are done in the
+	// background so we don't block the handling of server messages.
+	m.queueGroupCancels()
 
 	// TODO: call lnrpc.OpenChannel to finalize channel creation
 	return nil
 }
 
 // parseNodeUris parses a list of node URIs in the format <pubkey>@addr:port
-// as it's returned in the `lnrpc.GetInfo` request.
-// TODO(guggero): What is needed to support tor as well?
+// as it's returned in the `lnrpc.GetInfo` request. Onion v2 and v3 addresses
+// are returned as tor.OnionAddr.
 func parseNodeUris(uris []string) ([]net.Addr, error) {
 	result := make([]net.Addr, 0, len(uris))
 	for _, uri := range uris {
@@ -285,13 +417,8 @@ func parseNodeUris(uris []string) ([]net.Addr, error) {
 				"<pubkey>@addr:port")
 		}
 
-		// We don't currently support tor addresses.
-		if strings.Contains(parts[1], ".onion") {
-			continue
-		}
-
 		// We don't care about the pubkey here, only the address part.
-		addr, err := net.ResolveTCPAddr("tcp", parts[1])
+		addr, err := ParseNodeAddr("tcp", parts[1])
 		if err != nil {
 			return nil, fmt.Errorf("could not parse node URI: %v",
 				err)
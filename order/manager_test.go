@@ -0,0 +1,53 @@
+package order
+
+import (
+	"context"
+	"testing"
+)
+
+// TestCancelOrder makes sure an order is only canceled if it isn't part of the
+// pending batch.
+func TestCancelOrder(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+	auctioneer := &mockAuctioneer{canceled: make(chan Nonce, 2)}
+	m := NewManager(&ManagerConfig{
+		Store:      store,
+		Auctioneer: auctioneer,
+	})
+
+	nonce := Nonce{1}
+	store.orders[nonce] = &Bid{Kit: newKitFromTemplate(nonce, &Kit{
+		State:            StateSubmitted,
+		Units:            4,
+		UnitsUnfulfilled: 4,
+	})}
+
+	// An order that was matched in the pending batch can't be canceled.
+	m.pendingBatch = &Batch{
+		MatchedOrders: map[Nonce][]*MatchedOrder{nonce: nil},
+	}
+	err := m.CancelOrder(context.Background(), nonce)
+	if err != ErrOrderInPendingBatch {
+		t.Fatalf("expected ErrOrderInPendingBatch, got %v", err)
+	}
+	if len(auctioneer.canceled) != 0 {
+		t.Fatalf("order in pending batch was canceled on the server")
+	}
+	if store.orders[nonce].Details().State != StateSubmitted {
+		t.Fatalf("order in pending batch was canceled locally")
+	}
+
+	// Once the batch is done, the order can be canceled.
+	m.pendingBatch = nil
+	if err := m.CancelOrder(context.Background(), nonce); err != nil {
+		t.Fatalf("unable to cancel order: %v", err)
+	}
+	if len(auctioneer.canceled) != 1 || <-auctioneer.canceled != nonce {
+		t.Fatalf("order not canceled on the server")
+	}
+	if store.orders[nonce].Details().State != StateCanceled {
+		t.Fatalf("order not canceled locally")
+	}
+}
@@ -13,6 +13,7 @@ type mockStore struct {
 	orders         map[Nonce]Order
 	accounts       map[[33]byte]*account.Account
 	pendingBatchID *BatchID
+	batchCount     uint32
 }
 
 func newMockStore() *mockStore {
@@ -121,9 +122,15 @@ func (s *mockStore) MarkBatchComplete() error {
 	}
 
 	s.pendingBatchID = nil
+	s.batchCount++
 	return nil
 }
 
+// BatchCount returns the number of batches that were completed so far.
+func (s *mockStore) BatchCount() (uint32, error) {
+	return s.batchCount, nil
+}
+
 func (s *mockStore) getAccount(acctKey *btcec.PublicKey) (
 	*account.Account, error) {
 
@@ -0,0 +1,44 @@
+package order
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/lightningnetwork/lnd/tor"
+)
+
+// ParseNodeAddr parses the network address of a node. Onion v2 and v3
+// addresses are returned as tor.OnionAddr without any DNS lookup, all other
+// addresses are resolved as TCP addresses.
+func ParseNodeAddr(network, addr string) (net.Addr, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasSuffix(host, tor.OnionSuffix) {
+		return net.ResolveTCPAddr(network, addr)
+	}
+
+	if network != "tcp" {
+		return nil, fmt.Errorf("invalid network %s for onion address",
+			network)
+	}
+	if !tor.IsOnionHost(host) {
+		return nil, fmt.Errorf("invalid onion address %s", host)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %s: %v", portStr, err)
+	}
+
+	return &tor.OnionAddr{OnionService: host, Port: port}, nil
+}
+
+// IsOnionAddr returns true if the given address is an onion address.
+func IsOnionAddr(addr net.Addr) bool {
+	_, ok := addr.(*tor.OnionAddr)
+	return ok
+}
@@ -0,0 +1,91 @@
+package order
+
+import (
+	"net"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/tor"
+)
+
+const (
+	onionV2 = "3g2upl4pq6kufc4m.onion"
+	onionV3 = "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad" +
+		".onion"
+)
+
+// TestParseNodeUris makes sure clearnet and onion node URIs are parsed into
+// the correct address types.
+func TestParseNodeUris(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		uris        []string
+		expected    []net.Addr
+		expectedErr bool
+	}{{
+		name: "clearnet and onion",
+		uris: []string{
+			"02aa@127.0.0.1:9735",
+			"02aa@" + onionV2 + ":9735",
+			"02aa@" + onionV3 + ":9736",
+		},
+		expected: []net.Addr{
+			&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 9735},
+			&tor.OnionAddr{OnionService: onionV2, Port: 9735},
+			&tor.OnionAddr{OnionService: onionV3, Port: 9736},
+		},
+	}, {
+		name: "onion only",
+		uris: []string{"02aa@" + onionV3 + ":9735"},
+		expected: []net.Addr{
+			&tor.OnionAddr{OnionService: onionV3, Port: 9735},
+		},
+	}, {
+		name:        "invalid onion",
+		uris:        []string{"02aa@invalid.onion:9735"},
+		expectedErr: true,
+	}, {
+		name:        "invalid port",
+		uris:        []string{"02aa@" + onionV2 + ":port"},
+		expectedErr: true,
+	}, {
+		name:        "missing pubkey",
+		uris:        []string{"127.0.0.1:9735"},
+		expectedErr: true,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			addrs, err := parseNodeUris(tc.uris)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to parse uris: %v", err)
+			}
+
+			if len(addrs) != len(tc.expected) {
+				t.Fatalf("expected %d addrs, got %d",
+					len(tc.expected), len(addrs))
+			}
+			for i, addr := range addrs {
+				expected := tc.expected[i]
+				isOnion := IsOnionAddr(expected)
+				if addr.String() != expected.String() ||
+					IsOnionAddr(addr) != isOnion {
+
+					t.Fatalf("expected addr %v, got %v",
+						expected, addr)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrChannelFundingFailed is the wrapped error that is returned if the
+	// funding of a channel in a batch can't be prepared or completed.
+	ErrChannelFundingFailed = errors.New("channel funding failed")
+
+	// ErrPolicyReject is the wrapped error that is returned if a valid
+	// batch or order is rejected because of the trader's local policy.
+	ErrPolicyReject = errors.New("rejected by local policy")
+)
+
+// MatchRejectErr is an error type that is returned if only some of our orders
+// in a batch can't be executed. Each of those orders is rejected individually
+// so the auctioneer can re-plan the batch without them instead of excluding
+// all of our orders.
+type MatchRejectErr struct {
+	// RejectedOrders maps the nonce of every rejected order of ours to the
+	// reason it was rejected for.
+	RejectedOrders map[Nonce]error
+}
+
+// NewMatchRejectErr creates a new MatchRejectErr without any rejected orders.
+func NewMatchRejectErr() *MatchRejectErr {
+	return &MatchRejectErr{
+		RejectedOrders: make(map[Nonce]error),
+	}
+}
+
+// Error returns a description of all rejected orders, ordered by their nonce.
+//
+// NOTE: This method is part of the error interface.
+func (m *MatchRejectErr) Error() string {
+	nonces := make([]Nonce, 0, len(m.RejectedOrders))
+	for nonce := range m.RejectedOrders {
+		nonces = append(nonces, nonce)
+	}
+	sort.Slice(nonces, func(i, j int) bool {
+		return nonces[i].String() < nonces[j].String()
+	})
+
+	reasons := make([]string, 0, len(nonces))
+	for _, nonce := range nonces {
+		reasons = append(reasons, fmt.Sprintf(
+			"%v: %v", nonce, m.RejectedOrders[nonce],
+		))
+	}
+	return fmt.Sprintf("rejected %d order(s): %s", len(nonces),
+		strings.Join(reasons, "; "))
+}
@@ -6,10 +6,12 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/llm/account"
 	"github.com/lightninglabs/llm/clmrpc"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
@@ -38,8 +40,32 @@ func ParseRPCOrder(version uint32, details *clmrpc.Order) (*Kit, error) {
 	kit.FixedRate = details.RateFixed
 	kit.Amt = btcutil.Amount(details.Amt)
 	kit.FundingFeeRate = chainfee.SatPerKWeight(details.FundingFeeRate)
-	kit.Units = NewSupplyFromSats(kit.Amt)
+
+	// We never round the amount of our own orders silently, otherwise the
+	// amount we store would disagree with the units that are traded.
+	units, err := NewSupplyFromSatsRounded(kit.Amt, RoundingStrict)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order amount: %v", err)
+	}
+	kit.Units = units
 	kit.UnitsUnfulfilled = kit.Units
+	kit.ExpiryHeight = details.ExpiryHeight
+	if details.ExpiryTimestamp != 0 {
+		kit.ExpiryTime = time.Unix(details.ExpiryTimestamp, 0)
+	}
+	kit.ExpiryBatches = details.ExpiryBatches
+	kit.MinUnitsMatch = SupplyUnit(details.MinUnitsMatch)
+	kit.AllOrNone = details.AllOrNone
+	kit.Label = details.Label
+	kit.Tags = account.CopyTags(details.Tags)
+	if len(details.GroupId) > 0 {
+		if len(details.GroupId) != len(kit.GroupID) {
+			return nil, fmt.Errorf("invalid group ID length %d, "+
+				"must be %d bytes", len(details.GroupId),
+				len(kit.GroupID))
+		}
+		copy(kit.GroupID[:], details.GroupId)
+	}
 	return kit, nil
 }
 
@@ -79,6 +105,8 @@ func ParseRPCServerOrder(version uint32, details *clmrpc.ServerOrder) (*Kit,
 	}
 
 	copy(kit.AcctKey[:], details.TraderKey)
+	kit.MinUnitsMatch = SupplyUnit(details.MinUnitsMatch)
+	kit.AllOrNone = details.AllOrNone
 
 	nodePubKey, err := btcec.ParsePubKey(details.NodePub, btcec.S256())
 	if err != nil {
@@ -92,7 +120,7 @@ func ParseRPCServerOrder(version uint32, details *clmrpc.ServerOrder) (*Kit,
 			fmt.Errorf("invalid node addresses")
 	}
 	for _, rpcAddr := range details.NodeAddr {
-		addr, err := net.ResolveTCPAddr(rpcAddr.Network, rpcAddr.Addr)
+		addr, err := ParseNodeAddr(rpcAddr.Network, rpcAddr.Addr)
 		if err != nil {
 			return nil, nodeKey, nodeAddrs, multiSigKey,
 				fmt.Errorf("unable to parse node ddr: %v", err)
@@ -1,6 +1,10 @@
 package order
 
-import "github.com/btcsuite/btcutil"
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcutil"
+)
 
 // SupplyUnit is a type that represents the smallest unit of an order that can
 // be fulfilled. One unit corresponds to the smallest channel size that can be
@@ -11,16 +15,111 @@ const (
 	// BaseSupplyUnit is the smallest channel that can be bought or sold in
 	// the system. These units are expressed in satoshis.
 	BaseSupplyUnit SupplyUnit = 100_000
+
+	// maxChannelSize is the largest channel lnd can open without support
+	// for wumbo channels.
+	maxChannelSize btcutil.Amount = (1 << 24) - 1
+
+	// MaxOrderUnits is the largest order size the auctioneer accepts. An
+	// order can be split across many matches, this limits how much of an
+	// account's balance a single order can lock up and how many channels
+	// it can result in.
+	MaxOrderUnits = SupplyUnit(10*btcutil.SatoshiPerBitcoin) /
+		BaseSupplyUnit
+
+	// MaxMatchUnits is the largest number of units a single match of an
+	// order can fill. Each match results in its own channel, so it can't
+	// be larger than the largest possible channel. An order as a whole can
+	// be larger than this as it can be split across many matches.
+	MaxMatchUnits = SupplyUnit(maxChannelSize) / BaseSupplyUnit
+)
+
+// RoundingMode describes how an amount that isn't a multiple of the base
+// supply unit is turned into supply units.
+type RoundingMode uint8
+
+const (
+	// RoundingStrict doesn't round at all and rejects any amount that
+	// isn't a multiple of the base supply unit.
+	RoundingStrict RoundingMode = 0
+
+	// RoundingDown rounds the amount down to the next multiple of the base
+	// supply unit.
+	RoundingDown RoundingMode = 1
+
+	// RoundingUp rounds the amount up to the next multiple of the base
+	// supply unit.
+	RoundingUp RoundingMode = 2
 )
 
 // NewSupplyFromSats calculates the number of supply units that can be bought or
-// sold with a given amount in satoshis.
+// sold with a given amount in satoshis. Any amount that isn't a multiple of the
+// base supply unit is rounded down, use NewSupplyFromSatsRounded to detect or
+// control this.
 func NewSupplyFromSats(sats btcutil.Amount) SupplyUnit {
-	// TODO(roasbeef): ensure proper rounding, etc.
-
 	return SupplyUnit(uint64(sats) / uint64(BaseSupplyUnit))
 }
 
+// NewSupplyFromSatsRounded calculates the number of supply units for the given
+// amount in satoshis, rounding it according to the given mode. An error is
+// returned if the amount isn't a multiple of the base supply unit and the mode
+// is RoundingStrict.
+func NewSupplyFromSatsRounded(sats btcutil.Amount,
+	mode RoundingMode) (SupplyUnit, error) {
+
+	if sats < 0 {
+		return 0, fmt.Errorf("amount %d must not be negative",
+			int64(sats))
+	}
+
+	units := NewSupplyFromSats(sats)
+	if units.ToSatoshis() == sats {
+		return units, nil
+	}
+
+	switch mode {
+	case RoundingStrict:
+		return 0, fmt.Errorf("amount %d is not a multiple of the "+
+			"supply unit of %d satoshis", int64(sats),
+			BaseSupplyUnit)
+
+	case RoundingDown:
+		return units, nil
+
+	case RoundingUp:
+		return units + 1, nil
+
+	default:
+		return 0, fmt.Errorf("unknown rounding mode %d", mode)
+	}
+}
+
+// ValidateOrderSize makes sure the given amount is a non-zero multiple of the
+// base supply unit and not above the maximum order size of the auctioneer. The
+// minimum number of units per match must not exceed the size of a single
+// match, otherwise the order could never be matched.
+func ValidateOrderSize(amt btcutil.Amount, minUnitsMatch SupplyUnit) error {
+	units, err := NewSupplyFromSatsRounded(amt, RoundingStrict)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case units == 0:
+		return fmt.Errorf("order amount must be greater than zero")
+
+	case units > MaxOrderUnits:
+		return fmt.Errorf("order amount %d is above the maximum of "+
+			"%d satoshis", int64(amt), MaxOrderUnits.ToSatoshis())
+
+	case minUnitsMatch > MaxMatchUnits:
+		return fmt.Errorf("min units match %d is above the maximum "+
+			"of %d units per match", minUnitsMatch, MaxMatchUnits)
+	}
+
+	return nil
+}
+
 // ToSatoshis maps a set number of supply units to the corresponding number of
 // satoshis.
 func (s SupplyUnit) ToSatoshis() btcutil.Amount {
@@ -0,0 +1,166 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcutil"
+)
+
+// TestNewSupplyFromSatsRounded makes sure amounts are only rounded to supply
+// units if it was explicitly requested.
+func TestNewSupplyFromSatsRounded(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		amt    btcutil.Amount
+		mode   RoundingMode
+		units  SupplyUnit
+		hasErr bool
+	}{{
+		name:  "aligned amount, strict",
+		amt:   300_000,
+		mode:  RoundingStrict,
+		units: 3,
+	}, {
+		name:  "zero amount, strict",
+		amt:   0,
+		mode:  RoundingStrict,
+		units: 0,
+	}, {
+		name:   "unaligned amount, strict",
+		amt:    250_000,
+		mode:   RoundingStrict,
+		hasErr: true,
+	}, {
+		name:  "unaligned amount, round down",
+		amt:   250_000,
+		mode:  RoundingDown,
+		units: 2,
+	}, {
+		name:  "unaligned amount, round up",
+		amt:   250_000,
+		mode:  RoundingUp,
+		units: 3,
+	}, {
+		name:  "aligned amount, round up",
+		amt:   200_000,
+		mode:  RoundingUp,
+		units: 2,
+	}, {
+		name:  "below one unit, round down",
+		amt:   99_999,
+		mode:  RoundingDown,
+		units: 0,
+	}, {
+		name:   "negative amount",
+		amt:    -100_000,
+		mode:   RoundingDown,
+		hasErr: true,
+	}, {
+		name:   "unknown mode",
+		amt:    150_000,
+		mode:   99,
+		hasErr: true,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			units, err := NewSupplyFromSatsRounded(tc.amt, tc.mode)
+			if tc.hasErr {
+				if err == nil {
+					t.Fatalf("expected error, got %d units",
+						units)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if units != tc.units {
+				t.Fatalf("expected %d units, got %d", tc.units,
+					units)
+			}
+		})
+	}
+}
+
+// TestValidateOrderSize makes sure only unit aligned amounts within the order
+// size limits are accepted and that large orders can be split across matches.
+func TestValidateOrderSize(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		amt           btcutil.Amount
+		minUnitsMatch SupplyUnit
+		valid         bool
+	}{{
+		name:  "zero",
+		amt:   0,
+		valid: false,
+	}, {
+		name:  "single unit",
+		amt:   100_000,
+		valid: true,
+	}, {
+		name:  "larger than a single match",
+		amt:   (MaxMatchUnits + 1).ToSatoshis(),
+		valid: true,
+	}, {
+		name:  "large order",
+		amt:   btcutil.SatoshiPerBitcoin * 5,
+		valid: true,
+	}, {
+		name:  "maximum",
+		amt:   MaxOrderUnits.ToSatoshis(),
+		valid: true,
+	}, {
+		name:  "above maximum",
+		amt:   (MaxOrderUnits + 1).ToSatoshis(),
+		valid: false,
+	}, {
+		name:  "above total supply",
+		amt:   btcutil.MaxSatoshi + 100_000,
+		valid: false,
+	}, {
+		name:  "negative",
+		amt:   -100_000,
+		valid: false,
+	}, {
+		name:  "unaligned",
+		amt:   250_000,
+		valid: false,
+	}, {
+		name:          "min units match of a single match",
+		amt:           btcutil.SatoshiPerBitcoin,
+		minUnitsMatch: MaxMatchUnits,
+		valid:         true,
+	}, {
+		name:          "min units match above a single match",
+		amt:           btcutil.SatoshiPerBitcoin,
+		minUnitsMatch: MaxMatchUnits + 1,
+		valid:         false,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateOrderSize(tc.amt, tc.minUnitsMatch)
+			if tc.valid && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Fatalf("expected amount %d to be invalid",
+					tc.amt)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package order
 
 import (
+	"errors"
+	"math"
+	"math/big"
+
 	"github.com/btcsuite/btcd/blockchain"
 	"github.com/btcsuite/btcutil"
 	"github.com/btcsuite/btcwallet/wallet/txrules"
@@ -9,12 +13,14 @@ import (
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 )
 
-var (
+const (
 	// FeeRateTotalParts defines the granularity of the fee rate.
 	// Throughout the codebase, we'll use fix based arithmetic to compute
 	// fees.
-	FeeRateTotalParts = 1e6
+	FeeRateTotalParts = 1_000_000
+)
 
+var (
 	// dustLimitP2WPKH is the minimum size of a P2WPKH output to not be
 	// considered dust.
 	dustLimitP2WPKH = txrules.GetDustThreshold(
@@ -26,6 +32,10 @@ var (
 	// the cost of a spend TX at 1 sat/byte plus the minimum non-dust output
 	// size.
 	MinNoDustAccountSize = minNoDustAccountSize()
+
+	// ErrAmountOverflow is returned if the result of a fee or balance
+	// calculation doesn't fit into an amount.
+	ErrAmountOverflow = errors.New("amount overflow")
 )
 
 // FixedRatePremium is the unit that we'll use to express the "lease" rate of
@@ -40,7 +50,23 @@ type FixedRatePremium uint32
 // computes the total amount that will be paid over the lifetime of the asset.
 // We'll use this to compute the total amount that a taker needs to set aside
 // once they're matched.
+//
+// The premium is calculated exactly as amt * rate * duration / 1_000_000 and
+// rounded down to the next full satoshi only once at the very end.
+// ErrAmountOverflow is returned if the result doesn't fit into an amount. This
+// is the premium calculation of ExactPremiumVersion and later.
 func (f FixedRatePremium) LumpSumPremium(amt btcutil.Amount,
+	durationBlocks uint32) (btcutil.Amount, error) {
+
+	return mulDivFloor(
+		amt, FeeRateTotalParts, uint64(f), uint64(durationBlocks),
+	)
+}
+
+// legacyLumpSumPremium calculates the lump sum premium the way DefaultVersion
+// does, with single precision floating point numbers. The result can be off by
+// many satoshis for large amounts and durations.
+func (f FixedRatePremium) legacyLumpSumPremium(amt btcutil.Amount,
 	durationBlocks uint32) btcutil.Amount {
 
 	// First, we'll compute the premium that will be paid each block over
@@ -54,6 +80,17 @@ func (f FixedRatePremium) LumpSumPremium(amt btcutil.Amount,
 	return btcutil.Amount(premiumPerBlock * float32(durationBlocks))
 }
 
+// lumpSumPremium calculates the lump sum premium the way the given batch
+// version does.
+func (f FixedRatePremium) lumpSumPremium(version BatchVersion,
+	amt btcutil.Amount, durationBlocks uint32) (btcutil.Amount, error) {
+
+	if version < ExactPremiumVersion {
+		return f.legacyLumpSumPremium(amt, durationBlocks), nil
+	}
+	return f.LumpSumPremium(amt, durationBlocks)
+}
+
 // FeeSchedule is an interface that represents the configuration source that
 // the auctioneer will use to determine how much to charge in fees for each
 // trader.
@@ -64,7 +101,7 @@ type FeeSchedule interface {
 
 	// ExecutionFee computes the execution fee (usually based off of a
 	// rate) for the target amount.
-	ExecutionFee(amt btcutil.Amount) btcutil.Amount
+	ExecutionFee(amt btcutil.Amount) (btcutil.Amount, error)
 }
 
 // LinearFeeSchedule is a FeeSchedule that calculates the execution fee based
@@ -88,11 +125,18 @@ func (s *LinearFeeSchedule) FeeRate() btcutil.Amount {
 }
 
 // ExecutionFee computes the execution fee (usually based off of a rate) for
-// the target amount.
+// the target amount. The fee is calculated exactly as amt * rate / 1_000_000
+// and rounded down to the next full satoshi. ErrAmountOverflow is returned if
+// the fee doesn't fit into an amount.
 //
 // NOTE: This method is part of the orderT.FeeSchedule interface.
-func (s *LinearFeeSchedule) ExecutionFee(amt btcutil.Amount) btcutil.Amount {
-	return amt * s.feeRate / 1_000_000
+func (s *LinearFeeSchedule) ExecutionFee(amt btcutil.Amount) (btcutil.Amount,
+	error) {
+
+	if s.feeRate < 0 {
+		return 0, nil
+	}
+	return mulDivFloor(amt, FeeRateTotalParts, uint64(s.feeRate))
 }
 
 // NewLinearFeeSchedule creates a new linear fee schedule based upon a static
@@ -111,6 +155,9 @@ var _ FeeSchedule = (*LinearFeeSchedule)(nil)
 // PerBlockPremium calculates the absolute premium in fractions of satoshis for
 // a one block duration from the amount and the specified fee rate in parts per
 // million.
+//
+// NOTE: The result is only an approximation and must not be used for any
+// balance calculation. Use FixedRatePremium.LumpSumPremium instead.
 func PerBlockPremium(amt btcutil.Amount, fixedRate uint32) float32 {
 	return float32(amt) * float32(fixedRate) / float32(FeeRateTotalParts)
 }
@@ -148,9 +195,54 @@ func EstimateTraderFee(numTraderChans uint32,
 	return feeRate.FeeForWeight(weightEstimate)
 }
 
+// mulDivFloor returns amt multiplied by all factors and divided by divisor,
+// rounded down to the next full satoshi. All intermediate results are exact.
+// Negative amounts result in zero and ErrAmountOverflow is returned if the
+// result doesn't fit into an amount.
+func mulDivFloor(amt btcutil.Amount, divisor uint64,
+	factors ...uint64) (btcutil.Amount, error) {
+
+	if amt <= 0 {
+		return 0, nil
+	}
+
+	result := new(big.Int).SetInt64(int64(amt))
+	for _, factor := range factors {
+		result.Mul(result, new(big.Int).SetUint64(factor))
+	}
+	result.Quo(result, new(big.Int).SetUint64(divisor))
+
+	if !result.IsInt64() {
+		return 0, ErrAmountOverflow
+	}
+	return btcutil.Amount(result.Int64()), nil
+}
+
+// addAmount returns the sum of both amounts or ErrAmountOverflow if it doesn't
+// fit into an amount.
+func addAmount(a, b btcutil.Amount) (btcutil.Amount, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, ErrAmountOverflow
+	}
+	return a + b, nil
+}
+
+// subAmount returns the difference of both amounts or ErrAmountOverflow if it
+// doesn't fit into an amount.
+func subAmount(a, b btcutil.Amount) (btcutil.Amount, error) {
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return 0, ErrAmountOverflow
+	}
+	return a - b, nil
+}
+
 // AccountTally keeps track of an account's balance and fees for all orders in
 // a batch that spend from/use that account.
 type AccountTally struct {
+	// Version is the batch version that determines how premiums are
+	// calculated.
+	Version BatchVersion
+
 	// EndingBalance is the ending balance for a trader's account.
 	EndingBalance btcutil.Amount
 
@@ -174,48 +266,99 @@ type AccountTally struct {
 
 // CalcMakerDelta calculates an account's balance and fee difference for a
 // single order where the account is involved on the maker side. It returns the
-// execution fee that is collected by the auctioneer.
+// execution fee that is collected by the auctioneer. ErrAmountOverflow is
+// returned and the tally is left unchanged if any of the values overflows.
 func (t *AccountTally) CalcMakerDelta(feeSchedule FeeSchedule,
 	price FixedRatePremium, totalSats btcutil.Amount,
-	duration uint32) btcutil.Amount {
-
-	// First, we'll need to subtract the total amount of sats cleared (the
-	// channel size), from the account of the maker.
-	t.EndingBalance -= totalSats
+	duration uint32) (btcutil.Amount, error) {
 
 	// Calculate the premium based on the duration the capital will be
 	// locked for.
-	satsPremium := price.LumpSumPremium(totalSats, duration)
-	t.EndingBalance += satsPremium
-	t.TotalMakerFeesAccrued += satsPremium
+	satsPremium, err := price.lumpSumPremium(
+		t.Version, totalSats, duration,
+	)
+	if err != nil {
+		return 0, err
+	}
+	executionFee, err := executionFee(totalSats, feeSchedule)
+	if err != nil {
+		return 0, err
+	}
+
+	// The maker pays the total amount of sats cleared (the channel size)
+	// and the execution fee and receives the premium in return.
+	endingBalance, err := subAmount(t.EndingBalance, totalSats)
+	if err != nil {
+		return 0, err
+	}
+	endingBalance, err = addAmount(endingBalance, satsPremium)
+	if err != nil {
+		return 0, err
+	}
+	endingBalance, err = subAmount(endingBalance, executionFee)
+	if err != nil {
+		return 0, err
+	}
+	makerFees, err := addAmount(t.TotalMakerFeesAccrued, satsPremium)
+	if err != nil {
+		return 0, err
+	}
+	executionFees, err := addAmount(t.TotalExecutionFeesPaid, executionFee)
+	if err != nil {
+		return 0, err
+	}
 
-	// Finally, we'll subtract our execution fees from both sides as well.
-	executionFee := executionFee(totalSats, feeSchedule)
-	t.EndingBalance -= executionFee
-	t.TotalExecutionFeesPaid += executionFee
+	t.EndingBalance = endingBalance
+	t.TotalMakerFeesAccrued = makerFees
+	t.TotalExecutionFeesPaid = executionFees
 
-	return executionFee
+	return executionFee, nil
 }
 
 // CalcTakerDelta calculates an account's balance and fee difference for a
 // single order where the account is involved on the taker side. It returns the
-// execution fee that is collected by the auctioneer.
+// execution fee that is collected by the auctioneer. ErrAmountOverflow is
+// returned and the tally is left unchanged if any of the values overflows.
 func (t *AccountTally) CalcTakerDelta(feeSchedule FeeSchedule,
 	price FixedRatePremium, totalSats btcutil.Amount,
-	duration uint32) btcutil.Amount {
+	duration uint32) (btcutil.Amount, error) {
 
 	// Calculate the premium based on the duration the capital will be
 	// locked for.
-	satsPremium := price.LumpSumPremium(totalSats, duration)
-	t.EndingBalance -= satsPremium
-	t.TotalTakerFeesPaid += satsPremium
+	satsPremium, err := price.lumpSumPremium(
+		t.Version, totalSats, duration,
+	)
+	if err != nil {
+		return 0, err
+	}
+	executionFee, err := executionFee(totalSats, feeSchedule)
+	if err != nil {
+		return 0, err
+	}
 
-	// Finally, we'll subtract our execution fees from both sides as well.
-	executionFee := executionFee(totalSats, feeSchedule)
-	t.EndingBalance -= executionFee
-	t.TotalExecutionFeesPaid += executionFee
+	// The taker pays both the premium and the execution fee.
+	endingBalance, err := subAmount(t.EndingBalance, satsPremium)
+	if err != nil {
+		return 0, err
+	}
+	endingBalance, err = subAmount(endingBalance, executionFee)
+	if err != nil {
+		return 0, err
+	}
+	takerFees, err := addAmount(t.TotalTakerFeesPaid, satsPremium)
+	if err != nil {
+		return 0, err
+	}
+	executionFees, err := addAmount(t.TotalExecutionFeesPaid, executionFee)
+	if err != nil {
+		return 0, err
+	}
 
-	return executionFee
+	t.EndingBalance = endingBalance
+	t.TotalTakerFeesPaid = takerFees
+	t.TotalExecutionFeesPaid = executionFees
+
+	return executionFee, nil
 }
 
 // ChainFees estimates the chain fees that need to be paid for the number of
@@ -246,6 +389,12 @@ func minNoDustAccountSize() btcutil.Amount {
 
 // executionFee calculates the execution fee which is the base fee plus the
 // execution fee which scales based on the order size.
-func executionFee(amount btcutil.Amount, schedule FeeSchedule) btcutil.Amount {
-	return schedule.BaseFee() + schedule.ExecutionFee(amount)
+func executionFee(amount btcutil.Amount, schedule FeeSchedule) (btcutil.Amount,
+	error) {
+
+	fee, err := schedule.ExecutionFee(amount)
+	if err != nil {
+		return 0, err
+	}
+	return addAmount(schedule.BaseFee(), fee)
 }
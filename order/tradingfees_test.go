@@ -0,0 +1,346 @@
+package order
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/davecgh/go-spew/spew"
+)
+
+// quickConfig is the configuration of all property tests of the premium and
+// fee calculation.
+var quickConfig = &quick.Config{MaxCount: 10_000}
+
+// premiumInput is a random input for the premium calculation that covers the
+// full range of possible amounts, rates and durations.
+type premiumInput struct {
+	Amt      btcutil.Amount
+	Rate     FixedRatePremium
+	Duration uint32
+}
+
+// Generate creates a random premium input. Each value is either a boundary
+// value, a value from the full range or a small, realistic value, so all kinds
+// of edge cases are covered.
+//
+// NOTE: This method is part of the quick.Generator interface.
+func (premiumInput) Generate(r *rand.Rand, _ int) reflect.Value {
+	pick := func(values []uint64, max uint64) uint64 {
+		switch r.Intn(3) {
+		case 0:
+			return values[r.Intn(len(values))]
+		case 1:
+			return uint64(r.Int63n(int64(max)) + 1)
+		default:
+			return uint64(r.Int63n(10_000))
+		}
+	}
+
+	maxSats := uint64(btcutil.MaxSatoshi)
+	return reflect.ValueOf(premiumInput{
+		Amt: btcutil.Amount(pick(
+			[]uint64{0, 1, 100_000, 16_777_217, maxSats}, maxSats,
+		)),
+		Rate: FixedRatePremium(pick(
+			[]uint64{0, 1, FeeRateTotalParts, math.MaxUint32},
+			math.MaxUint32,
+		)),
+		Duration: uint32(pick(
+			[]uint64{0, 1, 144, 2016, math.MaxUint32},
+			math.MaxUint32,
+		)),
+	})
+}
+
+// exactPremium returns the exact premium amt * rate * duration / 1_000_000 as
+// a big integer numerator that must be divided by FeeRateTotalParts.
+func exactPremium(in premiumInput) *big.Int {
+	result := big.NewInt(int64(in.Amt))
+	result.Mul(result, big.NewInt(int64(in.Rate)))
+	result.Mul(result, big.NewInt(int64(in.Duration)))
+	return result
+}
+
+// TestLumpSumPremiumRounding makes sure the premium is always the exact
+// premium rounded down to the next full satoshi for the full input range.
+func TestLumpSumPremiumRounding(t *testing.T) {
+	t.Parallel()
+
+	totalParts := big.NewInt(FeeRateTotalParts)
+	prop := func(in premiumInput) bool {
+		premium, err := in.Rate.LumpSumPremium(in.Amt, in.Duration)
+		exact := exactPremium(in)
+
+		// Results that don't fit into an amount are rejected.
+		maxPremium := new(big.Int).Mul(
+			new(big.Int).SetUint64(math.MaxInt64+1), totalParts,
+		)
+		if exact.Cmp(maxPremium) >= 0 {
+			return err == ErrAmountOverflow
+		}
+		if err != nil {
+			return false
+		}
+
+		// Otherwise premium <= exact < premium + 1 must hold.
+		lower := new(big.Int).Mul(
+			big.NewInt(int64(premium)), totalParts,
+		)
+		upper := new(big.Int).Add(lower, totalParts)
+		return lower.Cmp(exact) <= 0 && exact.Cmp(upper) < 0
+	}
+
+	if err := quick.Check(prop, quickConfig); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestLumpSumPremiumMonotonic makes sure the premium never decreases if the
+// amount, rate or duration increases.
+func TestLumpSumPremiumMonotonic(t *testing.T) {
+	t.Parallel()
+
+	prop := func(in premiumInput, delta uint16) bool {
+		premium, err := in.Rate.LumpSumPremium(in.Amt, in.Duration)
+		if err != nil {
+			return true
+		}
+
+		// A larger premium may overflow, which is fine as well.
+		notLess := func(rate FixedRatePremium, amt btcutil.Amount,
+			duration uint32) bool {
+
+			other, err := rate.LumpSumPremium(amt, duration)
+			return err == ErrAmountOverflow ||
+				(err == nil && premium <= other)
+		}
+
+		moreAmt := in.Amt + btcutil.Amount(delta)
+		moreRate := in.Rate
+		if uint64(in.Rate)+uint64(delta) <= math.MaxUint32 {
+			moreRate += FixedRatePremium(delta)
+		}
+		moreDuration := in.Duration
+		if uint64(in.Duration)+uint64(delta) <= math.MaxUint32 {
+			moreDuration += uint32(delta)
+		}
+
+		rate, amt, duration := in.Rate, in.Amt, in.Duration
+		return notLess(rate, moreAmt, duration) &&
+			notLess(moreRate, amt, duration) &&
+			notLess(rate, amt, moreDuration)
+	}
+
+	if err := quick.Check(prop, quickConfig); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestLumpSumPremiumSplit makes sure splitting an amount into two channels
+// never loses more than a single satoshi of premium compared to a single
+// channel with the total amount.
+func TestLumpSumPremiumSplit(t *testing.T) {
+	t.Parallel()
+
+	prop := func(in premiumInput, split uint32) bool {
+		// Premiums that overflow can't be compared meaningfully. The
+		// premiums of both parts are smaller so they can't overflow.
+		total, err := in.Rate.LumpSumPremium(in.Amt, in.Duration)
+		if err != nil {
+			return true
+		}
+
+		amt1 := btcutil.Amount(int64(split) % (int64(in.Amt) + 1))
+		amt2 := in.Amt - amt1
+		premium1, err1 := in.Rate.LumpSumPremium(amt1, in.Duration)
+		premium2, err2 := in.Rate.LumpSumPremium(amt2, in.Duration)
+		sum := premium1 + premium2
+
+		return err1 == nil && err2 == nil && sum <= total &&
+			total <= sum+1
+	}
+
+	if err := quick.Check(prop, quickConfig); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestExecutionFeeRounding makes sure the execution fee is always the exact fee
+// rounded down to the next full satoshi, even for rates that would overflow a
+// naive calculation.
+func TestExecutionFeeRounding(t *testing.T) {
+	t.Parallel()
+
+	totalParts := big.NewInt(FeeRateTotalParts)
+	prop := func(in premiumInput, baseFee uint16) bool {
+		schedule := NewLinearFeeSchedule(
+			btcutil.Amount(baseFee), btcutil.Amount(in.Rate),
+		)
+		fee, err := schedule.ExecutionFee(in.Amt)
+		if err != nil {
+			return false
+		}
+		totalFee, err := executionFee(in.Amt, schedule)
+		if err != nil {
+			return false
+		}
+
+		exact := big.NewInt(int64(in.Amt))
+		exact.Mul(exact, big.NewInt(int64(in.Rate)))
+
+		lower := new(big.Int).Mul(big.NewInt(int64(fee)), totalParts)
+		upper := new(big.Int).Add(lower, totalParts)
+		return lower.Cmp(exact) <= 0 && exact.Cmp(upper) < 0 &&
+			totalFee == fee+schedule.BaseFee()
+	}
+
+	if err := quick.Check(prop, quickConfig); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestAccountTallySymmetric makes sure the premium the taker pays is always
+// exactly the premium the maker receives.
+func TestAccountTallySymmetric(t *testing.T) {
+	t.Parallel()
+
+	prop := func(in premiumInput) bool {
+		schedule := NewLinearFeeSchedule(1, 1_000)
+		maker := &AccountTally{Version: CurrentVersion}
+		taker := &AccountTally{Version: CurrentVersion}
+
+		makerFee, makerErr := maker.CalcMakerDelta(
+			schedule, in.Rate, in.Amt, in.Duration,
+		)
+		takerFee, takerErr := taker.CalcTakerDelta(
+			schedule, in.Rate, in.Amt, in.Duration,
+		)
+
+		return maker.TotalMakerFeesAccrued ==
+			taker.TotalTakerFeesPaid && makerFee == takerFee &&
+			makerErr == takerErr
+	}
+
+	if err := quick.Check(prop, quickConfig); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestLumpSumPremiumVersions makes sure the premium calculation depends on the
+// batch version of the account tally.
+func TestLumpSumPremiumVersions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		version  BatchVersion
+		amt      btcutil.Amount
+		rate     FixedRatePremium
+		duration uint32
+		premium  btcutil.Amount
+		err      error
+	}{{
+		name:     "small order, default version",
+		version:  DefaultVersion,
+		amt:      200_000,
+		rate:     5,
+		duration: 2000,
+		premium:  2000,
+	}, {
+		name:     "small order, exact version",
+		version:  ExactPremiumVersion,
+		amt:      200_000,
+		rate:     5,
+		duration: 2000,
+		premium:  2000,
+	}, {
+		name:     "large order, default version",
+		version:  DefaultVersion,
+		amt:      1_234_567_890,
+		rate:     12_345,
+		duration: 52_560,
+		premium:  801_053_343_744,
+	}, {
+		name:     "large order, exact version",
+		version:  ExactPremiumVersion,
+		amt:      1_234_567_890,
+		rate:     12_345,
+		duration: 52_560,
+		premium:  801_053_326_043,
+	}, {
+		name:     "premium overflow",
+		version:  ExactPremiumVersion,
+		amt:      btcutil.MaxSatoshi,
+		rate:     math.MaxUint32,
+		duration: math.MaxUint32,
+		err:      ErrAmountOverflow,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tally := &AccountTally{Version: tc.version}
+			_, err := tally.CalcTakerDelta(
+				NewLinearFeeSchedule(0, 0), tc.rate, tc.amt,
+				tc.duration,
+			)
+			if err != tc.err {
+				t.Fatalf("expected error %v, got %v", tc.err,
+					err)
+			}
+			if tally.TotalTakerFeesPaid != tc.premium {
+				t.Fatalf("expected premium %d, got %d",
+					tc.premium, tally.TotalTakerFeesPaid)
+			}
+		})
+	}
+}
+
+// TestAccountTallyOverflow makes sure a balance that overflows is rejected
+// instead of wrapping around and that the tally is left unchanged.
+func TestAccountTallyOverflow(t *testing.T) {
+	t.Parallel()
+
+	// The premium itself fits into an amount, but adding it to the ending
+	// balance of the maker doesn't.
+	const startBalance = math.MaxInt64 - 1_000
+	schedule := NewLinearFeeSchedule(0, 0)
+	maker := &AccountTally{
+		Version:       ExactPremiumVersion,
+		EndingBalance: startBalance,
+	}
+	_, err := maker.CalcMakerDelta(
+		schedule, FeeRateTotalParts, 1_000_000, 1_000_000,
+	)
+	if err != ErrAmountOverflow {
+		t.Fatalf("expected ErrAmountOverflow, got %v", err)
+	}
+	if maker.EndingBalance != startBalance ||
+		maker.TotalMakerFeesAccrued != 0 {
+
+		t.Fatalf("tally was changed: %v", spew.Sdump(maker))
+	}
+
+	// The same is true for a taker whose balance is already very low.
+	taker := &AccountTally{
+		Version:       ExactPremiumVersion,
+		EndingBalance: math.MinInt64 + 1_000,
+	}
+	_, err = taker.CalcTakerDelta(
+		schedule, FeeRateTotalParts, 1_000_000, 1_000_000,
+	)
+	if err != ErrAmountOverflow {
+		t.Fatalf("expected ErrAmountOverflow, got %v", err)
+	}
+	if taker.TotalTakerFeesPaid != 0 {
+		t.Fatalf("tally was changed: %v", spew.Sdump(taker))
+	}
+}
@@ -0,0 +1,112 @@
+package llm
+
+import (
+	"context"
+	"sync"
+
+	"github.com/lightninglabs/llm/order"
+)
+
+const (
+	// orderSyncParallelism is the maximum number of order state queries we
+	// send to the auction server at the same time.
+	orderSyncParallelism = 8
+)
+
+// syncOrderStates queries the auction server for the current state of all
+// given orders that can still change. The given orders are updated in place
+// and, if persist is true, all changes are stored in a single database
+// transaction. The returned set contains the nonces of all orders whose state
+// wasn't refreshed, either because it can't change anymore or because the
+// server couldn't be queried.
+func (s *rpcServer) syncOrderStates(ctx context.Context, orders []order.Order,
+	persist bool) (map[order.Nonce]struct{}, error) {
+
+	type syncResult struct {
+		state            order.State
+		unitsUnfulfilled order.SupplyUnit
+		err              error
+	}
+
+	var (
+		cached  = make(map[order.Nonce]struct{})
+		pending []order.Order
+	)
+	for _, o := range orders {
+		if o.Details().State.Archived() {
+			cached[o.Nonce()] = struct{}{}
+			continue
+		}
+		pending = append(pending, o)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		semaphore = make(chan struct{}, orderSyncParallelism)
+		results   = make([]syncResult, len(pending))
+	)
+	for i, o := range pending {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, nonce order.Nonce) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			resp, err := s.auctioneer.OrderState(ctx, nonce)
+			if err != nil {
+				results[i].err = err
+				return
+			}
+			results[i].state = order.State(resp.State)
+			results[i].unitsUnfulfilled = order.SupplyUnit(
+				resp.UnitsUnfulfilled,
+			)
+		}(i, o.Nonce())
+	}
+	wg.Wait()
+
+	var (
+		nonces    []order.Nonce
+		modifiers [][]order.Modifier
+	)
+	for i, o := range pending {
+		result := results[i]
+		if result.err != nil {
+			log.Warnf("Unable to query state of order %v, using "+
+				"cached state: %v", o.Nonce(), result.err)
+			cached[o.Nonce()] = struct{}{}
+			continue
+		}
+
+		kit := o.Details()
+		if kit.State == result.state &&
+			kit.UnitsUnfulfilled == result.unitsUnfulfilled {
+
+			continue
+		}
+
+		mods := []order.Modifier{
+			order.StateModifier(result.state),
+			order.UnitsFulfilledModifier(result.unitsUnfulfilled),
+		}
+		for _, mod := range mods {
+			mod(kit)
+		}
+		nonces = append(nonces, o.Nonce())
+		modifiers = append(modifiers, mods)
+	}
+
+	if len(nonces) == 0 || !persist {
+		return cached, nil
+	}
+	if err := s.server.db.UpdateOrders(nonces, modifiers); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("Updated state of %d orders from auction server",
+		len(nonces))
+
+	return cached, nil
+}
@@ -0,0 +1,222 @@
+package llm
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/llm/order"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+const (
+	// peerConnectTimeout is the maximum time we wait for a single
+	// connection attempt to a matched peer.
+	peerConnectTimeout = 10 * time.Second
+
+	// peerConnectAttempts is the number of times we try all addresses of
+	// a matched peer before we consider it unreachable.
+	peerConnectAttempts = 2
+
+	// peerConnectRetryDelay is the time we wait before we try all
+	// addresses of a matched peer again.
+	peerConnectRetryDelay = time.Second
+)
+
+var (
+	// requiredPeerFeatures are the features a matched peer must support
+	// for us to be able to open the channel of the batch with it. Either
+	// the required or the optional bit of each feature must be set.
+	requiredPeerFeatures = []lnwire.FeatureBit{
+		// The channels of a batch use the tweakless commitment
+		// format.
+		lnwire.StaticRemoteKeyOptional,
+	}
+)
+
+// sortNodeAddrs returns the clearnet addresses first, followed by the onion
+// addresses. Connecting to an onion address only works if our lnd node runs
+// with tor enabled and takes longer.
+func sortNodeAddrs(nodeAddrs []net.Addr) []net.Addr {
+	addrs := make([]net.Addr, 0, len(nodeAddrs))
+	var onionAddrs []net.Addr
+	for _, addr := range nodeAddrs {
+		if order.IsOnionAddr(addr) {
+			onionAddrs = append(onionAddrs, addr)
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	return append(addrs, onionAddrs...)
+}
+
+// checkMatchedPeers makes sure we have a live connection to the nodes of all
+// orders we were matched with in the batch and that they support all features
+// required to open the channels with them. The nodes are checked in parallel.
+// The returned map contains the error for each node that can't be used.
+func checkMatchedPeers(lndClient lnrpc.LightningClient, batch *order.Batch,
+	quit <-chan struct{}) map[[33]byte]error {
+
+	nodes := make(map[[33]byte][]net.Addr)
+	for _, matchedOrders := range batch.MatchedOrders {
+		for _, matchedOrder := range matchedOrders {
+			nodes[matchedOrder.NodeKey] = append(
+				nodes[matchedOrder.NodeKey],
+				matchedOrder.NodeAddrs...,
+			)
+		}
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failures = make(map[[33]byte]error)
+	)
+	for nodeKey, addrs := range nodes {
+		wg.Add(1)
+		go func(nodeKey [33]byte, addrs []net.Addr) {
+			defer wg.Done()
+
+			err := checkPeer(lndClient, nodeKey, addrs, quit)
+			if err == nil {
+				return
+			}
+
+			log.Warnf("Matched peer %x can't be used: %v",
+				nodeKey[:], err)
+
+			mu.Lock()
+			failures[nodeKey] = err
+			mu.Unlock()
+		}(nodeKey, addrs)
+	}
+	wg.Wait()
+
+	return failures
+}
+
+// checkPeer makes sure we are connected to the given node, trying all of its
+// addresses a few times if we aren't yet, and that it supports the features we
+// need.
+func checkPeer(lndClient lnrpc.LightningClient, nodeKey [33]byte,
+	nodeAddrs []net.Addr, quit <-chan struct{}) error {
+
+	pubKey := hex.EncodeToString(nodeKey[:])
+	peer, err := findPeer(lndClient, pubKey)
+	if err != nil {
+		return err
+	}
+	if peer != nil {
+		return checkPeerFeatures(peer)
+	}
+
+	addrs := sortNodeAddrs(nodeAddrs)
+	if len(addrs) == 0 {
+		return fmt.Errorf("peer %v unreachable: no addresses known",
+			pubKey)
+	}
+
+	var lastErr error
+	for i := 0; i < peerConnectAttempts; i++ {
+		if i > 0 {
+			select {
+			case <-time.After(peerConnectRetryDelay):
+			case <-quit:
+				return fmt.Errorf("server shutting down")
+			}
+		}
+
+		for _, addr := range addrs {
+			err := connectPeer(lndClient, pubKey, addr)
+			if err != nil {
+				log.Debugf("Unable to connect to matched "+
+					"peer %v@%v: %v", pubKey, addr, err)
+				lastErr = err
+				continue
+			}
+
+			// The connection succeeded, but the peer might have
+			// disconnected right away again, so we only trust a
+			// connection that's listed as active.
+			peer, err := findPeer(lndClient, pubKey)
+			if err != nil {
+				return err
+			}
+			if peer == nil {
+				lastErr = fmt.Errorf("peer disconnected " +
+					"after connecting")
+				continue
+			}
+			return checkPeerFeatures(peer)
+		}
+	}
+
+	return fmt.Errorf("peer %v unreachable after trying %d addresses "+
+		"%d times: %v", pubKey, len(addrs), peerConnectAttempts,
+		lastErr)
+}
+
+// connectPeer attempts a single connection to the given node at the given
+// address, waiting until the connection is established.
+func connectPeer(lndClient lnrpc.LightningClient, pubKey string,
+	addr net.Addr) error {
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), peerConnectTimeout,
+	)
+	defer cancel()
+
+	// A non-permanent connection request only returns once the connection
+	// is established or failed.
+	_, err := lndClient.ConnectPeer(ctx, &lnrpc.ConnectPeerRequest{
+		Addr: &lnrpc.LightningAddress{
+			Pubkey: pubKey,
+			Host:   addr.String(),
+		},
+	})
+	if err != nil && !strings.Contains(err.Error(), "already connected") {
+		return err
+	}
+	return nil
+}
+
+// findPeer returns the currently connected peer with the given public key or
+// nil if we aren't connected to it.
+func findPeer(lndClient lnrpc.LightningClient, pubKey string) (*lnrpc.Peer,
+	error) {
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), peerConnectTimeout,
+	)
+	defer cancel()
+
+	resp, err := lndClient.ListPeers(ctx, &lnrpc.ListPeersRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list peers: %v", err)
+	}
+	for _, peer := range resp.Peers {
+		if peer.PubKey == pubKey {
+			return peer, nil
+		}
+	}
+	return nil, nil
+}
+
+// checkPeerFeatures makes sure the peer supports all features we need to open
+// a batch channel with it.
+func checkPeerFeatures(peer *lnrpc.Peer) error {
+	for _, bit := range requiredPeerFeatures {
+		_, optional := peer.Features[uint32(bit)]
+		_, required := peer.Features[uint32(bit^1)]
+		if !optional && !required {
+			return fmt.Errorf("peer %v doesn't support feature "+
+				"%v", peer.PubKey, lnwire.Features[bit])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,356 @@
+package llm
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lightninglabs/llm/order"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tor"
+	"google.golang.org/grpc"
+)
+
+var (
+	// staticRemoteKeyFeatures are the features of a peer that supports
+	// the tweakless commitment format.
+	staticRemoteKeyFeatures = map[uint32]*lnrpc.Feature{
+		uint32(lnwire.StaticRemoteKeyOptional): {},
+	}
+
+	clearnetAddr1 = &net.TCPAddr{IP: net.IP{10, 0, 0, 1}, Port: 9735}
+	clearnetAddr2 = &net.TCPAddr{IP: net.IP{10, 0, 0, 2}, Port: 9735}
+	onionAddr     = &tor.OnionAddr{
+		OnionService: "3g2upl4pq6kufc4m.onion",
+		Port:         9735,
+	}
+)
+
+// mockPeerLightningClient is a lightning client that simulates the peer
+// connections of our node.
+type mockPeerLightningClient struct {
+	lnrpc.LightningClient
+
+	mu sync.Mutex
+
+	// peers are the currently connected peers by their public key.
+	peers map[string]*lnrpc.Peer
+
+	// features are the features a peer announces once it's connected.
+	features map[string]map[uint32]*lnrpc.Feature
+
+	// connectErrs are the errors returned for connections to a host.
+	connectErrs map[string]error
+
+	// disconnect makes every peer disconnect right after connecting.
+	disconnect bool
+
+	// listErr is returned when listing the peers.
+	listErr error
+
+	// connects records the hosts of all connection attempts.
+	connects []string
+}
+
+func newMockPeerLightningClient() *mockPeerLightningClient {
+	return &mockPeerLightningClient{
+		peers:       make(map[string]*lnrpc.Peer),
+		features:    make(map[string]map[uint32]*lnrpc.Feature),
+		connectErrs: make(map[string]error),
+	}
+}
+
+func (m *mockPeerLightningClient) ConnectPeer(_ context.Context,
+	req *lnrpc.ConnectPeerRequest, _ ...grpc.CallOption) (
+	*lnrpc.ConnectPeerResponse, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pubKey, host := req.Addr.Pubkey, req.Addr.Host
+	m.connects = append(m.connects, host)
+
+	if err := m.connectErrs[host]; err != nil {
+		return nil, err
+	}
+	if !m.disconnect {
+		m.peers[pubKey] = &lnrpc.Peer{
+			PubKey:   pubKey,
+			Address:  host,
+			Features: m.features[pubKey],
+		}
+	}
+	return &lnrpc.ConnectPeerResponse{}, nil
+}
+
+func (m *mockPeerLightningClient) ListPeers(context.Context,
+	*lnrpc.ListPeersRequest, ...grpc.CallOption) (*lnrpc.ListPeersResponse,
+	error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	resp := &lnrpc.ListPeersResponse{}
+	for _, peer := range m.peers {
+		resp.Peers = append(resp.Peers, peer)
+	}
+	return resp, nil
+}
+
+// TestCheckPeer makes sure a matched peer is only accepted if we have a live
+// connection to it and it supports all required features.
+func TestCheckPeer(t *testing.T) {
+	t.Parallel()
+
+	nodeKey := [33]byte{0x02, 0x01}
+	pubKey := hex.EncodeToString(nodeKey[:])
+
+	testCases := []struct {
+		name     string
+		addrs    []net.Addr
+		setup    func(m *mockPeerLightningClient)
+		shutdown bool
+		err      string
+		connects []string
+	}{{
+		name:  "already connected",
+		addrs: []net.Addr{clearnetAddr1},
+		setup: func(m *mockPeerLightningClient) {
+			m.peers[pubKey] = &lnrpc.Peer{
+				PubKey:   pubKey,
+				Features: staticRemoteKeyFeatures,
+			}
+		},
+	}, {
+		name:  "already connected without required feature",
+		addrs: []net.Addr{clearnetAddr1},
+		setup: func(m *mockPeerLightningClient) {
+			m.peers[pubKey] = &lnrpc.Peer{PubKey: pubKey}
+		},
+		err: "doesn't support feature",
+	}, {
+		name:  "required instead of optional feature bit",
+		addrs: []net.Addr{clearnetAddr1},
+		setup: func(m *mockPeerLightningClient) {
+			bit := lnwire.StaticRemoteKeyRequired
+			m.peers[pubKey] = &lnrpc.Peer{
+				PubKey: pubKey,
+				Features: map[uint32]*lnrpc.Feature{
+					uint32(bit): {},
+				},
+			}
+		},
+	}, {
+		name:  "unrelated feature bit",
+		addrs: []net.Addr{clearnetAddr1},
+		setup: func(m *mockPeerLightningClient) {
+			bit := lnwire.DataLossProtectOptional
+			m.peers[pubKey] = &lnrpc.Peer{
+				PubKey: pubKey,
+				Features: map[uint32]*lnrpc.Feature{
+					uint32(bit): {},
+				},
+			}
+		},
+		err: "doesn't support feature",
+	}, {
+		name: "no addresses",
+		err:  "no addresses known",
+	}, {
+		name:  "listing peers fails",
+		addrs: []net.Addr{clearnetAddr1},
+		setup: func(m *mockPeerLightningClient) {
+			m.listErr = errors.New("lnd unavailable")
+		},
+		err: "lnd unavailable",
+	}, {
+		name:  "connect to clearnet address before onion address",
+		addrs: []net.Addr{onionAddr, clearnetAddr1, clearnetAddr2},
+		setup: func(m *mockPeerLightningClient) {
+			m.features[pubKey] = staticRemoteKeyFeatures
+			m.connectErrs[clearnetAddr1.String()] = errors.New(
+				"connection refused",
+			)
+		},
+		connects: []string{
+			clearnetAddr1.String(), clearnetAddr2.String(),
+		},
+	}, {
+		name:  "connected without required feature",
+		addrs: []net.Addr{clearnetAddr1},
+		setup: func(m *mockPeerLightningClient) {
+			m.features[pubKey] = nil
+		},
+		err:      "doesn't support feature",
+		connects: []string{clearnetAddr1.String()},
+	}, {
+		name:  "peer disconnects after connecting",
+		addrs: []net.Addr{clearnetAddr1},
+		setup: func(m *mockPeerLightningClient) {
+			m.disconnect = true
+		},
+		err:      "peer disconnected after connecting",
+		connects: repeat(clearnetAddr1.String(), peerConnectAttempts),
+	}, {
+		name:  "shutdown before retry",
+		addrs: []net.Addr{clearnetAddr1},
+		setup: func(m *mockPeerLightningClient) {
+			m.connectErrs[clearnetAddr1.String()] = errors.New(
+				"connection refused",
+			)
+		},
+		shutdown: true,
+		err:      "server shutting down",
+		connects: []string{clearnetAddr1.String()},
+	}, {
+		name:  "all addresses unreachable",
+		addrs: []net.Addr{clearnetAddr1, onionAddr},
+		setup: func(m *mockPeerLightningClient) {
+			for _, addr := range []net.Addr{
+				clearnetAddr1, onionAddr,
+			} {
+				m.connectErrs[addr.String()] = errors.New(
+					"connection refused",
+				)
+			}
+		},
+		err: "unreachable after trying 2 addresses",
+		connects: []string{
+			clearnetAddr1.String(), onionAddr.String(),
+			clearnetAddr1.String(), onionAddr.String(),
+		},
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			lnd := newMockPeerLightningClient()
+			if tc.setup != nil {
+				tc.setup(lnd)
+			}
+
+			quit := make(chan struct{})
+			if tc.shutdown {
+				close(quit)
+			}
+
+			err := checkPeer(lnd, nodeKey, tc.addrs, quit)
+			switch {
+			case tc.err == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+
+			case tc.err != "" && (err == nil ||
+				!strings.Contains(err.Error(), tc.err)):
+
+				t.Fatalf("expected error %q, got %v", tc.err,
+					err)
+			}
+
+			if !reflect.DeepEqual(lnd.connects, tc.connects) {
+				t.Fatalf("expected connection attempts %v, "+
+					"got %v", tc.connects, lnd.connects)
+			}
+		})
+	}
+}
+
+// TestCheckPeerAlreadyConnected makes sure an "already connected" error of a
+// connection attempt is treated as success if the peer is listed as active.
+func TestCheckPeerAlreadyConnected(t *testing.T) {
+	t.Parallel()
+
+	nodeKey := [33]byte{0x02, 0x01}
+	pubKey := hex.EncodeToString(nodeKey[:])
+
+	lnd := &alreadyConnectedClient{
+		mockPeerLightningClient: newMockPeerLightningClient(),
+	}
+	lnd.features[pubKey] = staticRemoteKeyFeatures
+
+	err := checkPeer(
+		lnd, nodeKey, []net.Addr{clearnetAddr1}, make(chan struct{}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// alreadyConnectedClient is a lightning client of which every connection
+// attempt fails because the peer connected to us in the meantime.
+type alreadyConnectedClient struct {
+	*mockPeerLightningClient
+}
+
+func (a *alreadyConnectedClient) ConnectPeer(ctx context.Context,
+	req *lnrpc.ConnectPeerRequest, opts ...grpc.CallOption) (
+	*lnrpc.ConnectPeerResponse, error) {
+
+	_, err := a.mockPeerLightningClient.ConnectPeer(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return nil, errors.New("already connected to peer: " +
+		req.Addr.Pubkey)
+}
+
+// TestCheckMatchedPeers makes sure all nodes of a batch are checked and only
+// the ones that can't be used are reported.
+func TestCheckMatchedPeers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		goodNode = [33]byte{0x02, 0x01}
+		badNode  = [33]byte{0x02, 0x02}
+		lnd      = newMockPeerLightningClient()
+	)
+	lnd.features[hex.EncodeToString(goodNode[:])] = staticRemoteKeyFeatures
+	lnd.connectErrs[clearnetAddr2.String()] = errors.New(
+		"connection refused",
+	)
+
+	batch := &order.Batch{
+		MatchedOrders: map[order.Nonce][]*order.MatchedOrder{
+			{0x01}: {{
+				NodeKey:   goodNode,
+				NodeAddrs: []net.Addr{clearnetAddr1},
+			}, {
+				NodeKey:   badNode,
+				NodeAddrs: []net.Addr{clearnetAddr2},
+			}},
+			{0x02}: {{
+				NodeKey:   goodNode,
+				NodeAddrs: []net.Addr{clearnetAddr1},
+			}},
+		},
+	}
+
+	failures := checkMatchedPeers(lnd, batch, make(chan struct{}))
+	if len(failures) != 1 {
+		t.Fatalf("expected 1 failure, got %v", failures)
+	}
+	if _, ok := failures[badNode]; !ok {
+		t.Fatalf("expected failure of node %x, got %v", badNode,
+			failures)
+	}
+}
+
+// repeat returns a slice that contains the given string n times.
+func repeat(s string, n int) []string {
+	result := make([]string, n)
+	for i := range result {
+		result[i] = s
+	}
+	return result
+}
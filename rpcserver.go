@@ -1,10 +1,12 @@
 package llm
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -18,12 +20,12 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightninglabs/llm/account"
 	"github.com/lightninglabs/llm/auctioneer"
-	"github.com/lightninglabs/llm/clientdb"
 	"github.com/lightninglabs/llm/clmrpc"
 	"github.com/lightninglabs/llm/order"
 	"github.com/lightninglabs/loop/lndclient"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"golang.org/x/sync/errgroup"
 )
@@ -51,6 +53,10 @@ type rpcServer struct {
 	accountManager *account.Manager
 	orderManager   *order.Manager
 
+	// expiredOrders is used to hand orders that reached their expiry to
+	// the expiry handler.
+	expiredOrders chan []order.Nonce
+
 	quit            chan struct{}
 	wg              sync.WaitGroup
 	blockNtfnCancel func()
@@ -58,16 +64,16 @@ type rpcServer struct {
 	recoveryPending bool
 }
 
-// accountStore is a clientdb.DB wrapper to implement the account.Store
+// accountStore is a traderStore wrapper to implement the account.Store
 // interface.
 type accountStore struct {
-	*clientdb.DB
+	traderStore
 }
 
 var _ account.Store = (*accountStore)(nil)
 
 func (s *accountStore) PendingBatch() error {
-	_, _, err := s.DB.PendingBatch()
+	_, _, err := s.traderStore.PendingBatch()
 	return err
 }
 
@@ -91,13 +97,15 @@ func newRPCServer(server *Server) *rpcServer {
 			TxSource:      lnd.Client,
 		}),
 		orderManager: order.NewManager(&order.ManagerConfig{
-			Store:     server.db,
-			AcctStore: accountStore,
-			Lightning: lnd.Client,
-			Wallet:    lnd.WalletKit,
-			Signer:    lnd.Signer,
+			Store:      server.db,
+			AcctStore:  accountStore,
+			Lightning:  lnd.Client,
+			Wallet:     lnd.WalletKit,
+			Signer:     lnd.Signer,
+			Auctioneer: server.AuctioneerClient,
 		}),
-		quit: make(chan struct{}),
+		expiredOrders: make(chan []order.Nonce, 1),
+		quit:          make(chan struct{}),
 	}
 }
 
@@ -148,6 +156,13 @@ func (s *rpcServer) Start() error {
 		return fmt.Errorf("unable to start auctioneer client: %v", err)
 	}
 
+	// Make sure the static account backup reflects the current state of
+	// all accounts before we start making any changes to them.
+	if err := s.server.db.updateBackup(); err != nil {
+		return fmt.Errorf("unable to update static account backup: %v",
+			err)
+	}
+
 	// Start managers.
 	if err := s.accountManager.Start(); err != nil {
 		return fmt.Errorf("unable to start account manager: %v", err)
@@ -156,8 +171,16 @@ func (s *rpcServer) Start() error {
 		return fmt.Errorf("unable to start order manager: %v", err)
 	}
 
-	s.wg.Add(1)
+	s.wg.Add(2)
 	go s.serverHandler(blockChan, blockErrChan)
+	go s.expiryHandler()
+
+	// Start writing scheduled backups if configured. The backend was
+	// already checked to support them when the server was created.
+	if s.server.cfg.BackupInterval > 0 && s.server.backupDB != nil {
+		s.wg.Add(1)
+		go s.backupHandler(s.server.backupDB)
+	}
 
 	log.Infof("Trader server is now active")
 
@@ -189,6 +212,9 @@ func (s *rpcServer) Stop() error {
 func (s *rpcServer) serverHandler(blockChan chan int32, blockErrChan chan error) {
 	defer s.wg.Done()
 
+	// Catch up on all orders that expired while we were offline.
+	s.checkOrderExpiry()
+
 	for {
 		select {
 		case msg := <-s.auctioneer.FromServerChan:
@@ -226,6 +252,7 @@ func (s *rpcServer) serverHandler(blockChan chan int32, blockErrChan chan error)
 			log.Infof("Received new block notification: height=%v",
 				height)
 			s.updateHeight(height)
+			s.checkOrderExpiry()
 
 		case err := <-blockErrChan:
 			if err != nil {
@@ -263,7 +290,9 @@ func connectToMatchedTrader(lndClient lnrpc.LightningClient,
 	ctxb := context.Background()
 	nodeKey := hex.EncodeToString(matchedOrder.NodeKey[:])
 
-	for _, addr := range matchedOrder.NodeAddrs {
+	// Try the clearnet addresses first as connecting to an onion address
+	// only works if our lnd node runs with tor enabled and takes longer.
+	for _, addr := range sortNodeAddrs(matchedOrder.NodeAddrs) {
 		_, err := lndClient.ConnectPeer(ctxb, &lnrpc.ConnectPeerRequest{
 			Addr: &lnrpc.LightningAddress{
 				Pubkey: nodeKey,
@@ -278,8 +307,8 @@ func connectToMatchedTrader(lndClient lnrpc.LightningClient,
 				return nil
 			}
 
-			log.Warnf("unable to connect to trader at %v@%v",
-				nodeKey, addr)
+			log.Warnf("unable to connect to trader at %v@%v: %v",
+				nodeKey, addr, err)
 
 			continue
 		}
@@ -419,12 +448,25 @@ func (s *rpcServer) registerFundingShim(ourOrder order.Order,
 }
 
 // prepChannelFunding preps the backing node to either receive or initiate a
-// channel funding based on the items in the order batch.
+// channel funding based on the items in the order batch. If the funding of some
+// of our orders can't be prepared, an order.MatchRejectErr listing those orders
+// is returned.
 //
 // TODO(roasbeef): move?
 func (s *rpcServer) prepChannelFunding(batch *order.Batch) error {
 	// Now that we know this batch passes our sanity checks, we'll register
 	// all the funding shims we need to be able to respond
+	rejectErr := order.NewMatchRejectErr()
+	rejectOrder := func(nonce order.Nonce, err error) {
+		rejectErr.RejectedOrders[nonce] = fmt.Errorf("%w: %v",
+			order.ErrChannelFundingFailed, err)
+	}
+
+	// Make sure we can actually reach every peer we were matched with
+	// before accepting. We'll need a live connection to each of them to
+	// negotiate the channel funding.
+	peerFailures := checkMatchedPeers(s.lndClient, batch, s.quit)
+
 	for ourOrderNonce, matchedOrders := range batch.MatchedOrders {
 		ourOrder, err := s.server.db.GetOrder(ourOrderNonce)
 		if err != nil {
@@ -436,6 +478,12 @@ func (s *rpcServer) prepChannelFunding(batch *order.Batch) error {
 		// Depending on if this is a ask or not, we'll either just try
 		// to connect out, or register the full funding shim.
 		for _, matchedOrder := range matchedOrders {
+			peerErr, ok := peerFailures[matchedOrder.NodeKey]
+			if ok {
+				rejectOrder(ourOrderNonce, peerErr)
+				break
+			}
+
 			// We only need to create a shim if we're the taker, so
 			// if we had an ask matched, then we can skip this
 			// step, as we'll be the ones creating the channel.
@@ -452,7 +500,8 @@ func (s *rpcServer) prepChannelFunding(batch *order.Batch) error {
 					s.lndClient, matchedOrder,
 				)
 				if err != nil {
-					return err
+					rejectOrder(ourOrderNonce, err)
+					break
 				}
 
 				continue
@@ -466,13 +515,16 @@ func (s *rpcServer) prepChannelFunding(batch *order.Batch) error {
 				ourOrder, matchedOrder, batch.BatchTX,
 			)
 			if err != nil {
-				return fmt.Errorf("unable to register "+
-					"funding shim: %v", err)
+				rejectOrder(ourOrderNonce, fmt.Errorf("unable "+
+					"to register funding shim: %v", err))
+				break
 			}
-
 		}
 	}
 
+	if len(rejectErr.RejectedOrders) > 0 {
+		return rejectErr
+	}
 	return nil
 }
 
@@ -595,13 +647,9 @@ func (s *rpcServer) handleServerMessage(rpcMsg *clmrpc.ServerAuctionMessage) err
 			return s.sendRejectBatch(batch, err)
 		}
 
-		// TODO(roasbeef): make sure able to connect out to peers
-		// before sending accept?
-		//  * also need to handle reject on the server-side
-
 		// Before we accept the batch, we'll finish preparations on our
-		// end which include connecting out to peers, and registering
-		// funding shim.
+		// end which include making sure we're connected to all
+		// matched peers, and registering funding shim.
 		err = s.prepChannelFunding(batch)
 		if err != nil {
 			log.Errorf("Error preparing channel funding: %v", err)
@@ -626,7 +674,8 @@ func (s *rpcServer) handleServerMessage(rpcMsg *clmrpc.ServerAuctionMessage) err
 		err := s.batchChannelSetup(batch)
 		if err != nil {
 			log.Errorf("Error setting up channels: %v", err)
-			return s.sendRejectBatch(batch, err)
+			return s.sendRejectBatch(batch, fmt.Errorf("%w: %v",
+				order.ErrChannelFundingFailed, err))
 		}
 
 		// Sign for the accounts in the batch.
@@ -649,7 +698,14 @@ func (s *rpcServer) handleServerMessage(rpcMsg *clmrpc.ServerAuctionMessage) err
 
 		var batchID order.BatchID
 		copy(batchID[:], msg.Finalize.BatchId)
-		return s.orderManager.BatchFinalize(batchID)
+		err := s.orderManager.BatchFinalize(batchID)
+		if err != nil {
+			return err
+		}
+
+		// Orders might have reached the maximum number of batches
+		// they can stay active for.
+		s.checkOrderExpiry()
 
 	default:
 		return fmt.Errorf("unknown server message: %v", msg)
@@ -661,15 +717,17 @@ func (s *rpcServer) handleServerMessage(rpcMsg *clmrpc.ServerAuctionMessage) err
 func (s *rpcServer) InitAccount(ctx context.Context,
 	req *clmrpc.InitAccountRequest) (*clmrpc.Account, error) {
 
-	account, err := s.accountManager.InitAccount(
+	acct, err := s.accountManager.InitAccount(
 		ctx, btcutil.Amount(req.AccountValue), req.AccountExpiry,
 		atomic.LoadUint32(&s.bestHeight),
+		account.LabelModifier(req.Label),
+		account.TagsModifier(req.Tags, nil),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return marshallAccount(account)
+	return marshallAccount(acct)
 }
 
 func (s *rpcServer) ListAccounts(ctx context.Context,
@@ -681,8 +739,15 @@ func (s *rpcServer) ListAccounts(ctx context.Context,
 	}
 
 	rpcAccounts := make([]*clmrpc.Account, 0, len(accounts))
-	for _, account := range accounts {
-		rpcAccount, err := marshallAccount(account)
+	for _, acct := range accounts {
+		if req.Label != "" && acct.Label != req.Label {
+			continue
+		}
+		if !account.MatchesTags(acct.Tags, req.Tags) {
+			continue
+		}
+
+		rpcAccount, err := marshallAccount(acct)
 		if err != nil {
 			return nil, err
 		}
@@ -734,9 +799,42 @@ func marshallAccount(a *account.Account) (*clmrpc.Account, error) {
 		ExpirationHeight: a.Expiry,
 		State:            rpcState,
 		CloseTxid:        closeTxHash[:],
+		Label:            a.Label,
+		Tags:             a.Tags,
 	}, nil
 }
 
+// LabelAccount changes the label and tags of an existing account.
+func (s *rpcServer) LabelAccount(_ context.Context,
+	req *clmrpc.LabelAccountRequest) (*clmrpc.Account, error) {
+
+	traderKey, err := btcec.ParsePubKey(req.TraderKey, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	acct, err := s.server.db.Account(traderKey)
+	if err != nil {
+		return nil, err
+	}
+
+	modifiers := []account.Modifier{
+		account.TagsModifier(req.SetTags, req.RemoveTags),
+	}
+	switch {
+	case req.ClearLabel && req.Label != "":
+		return nil, fmt.Errorf("cannot set and clear the label at " +
+			"the same time")
+
+	case req.ClearLabel || req.Label != "":
+		modifiers = append(modifiers, account.LabelModifier(req.Label))
+	}
+	if err := s.server.db.UpdateAccount(acct, modifiers...); err != nil {
+		return nil, err
+	}
+
+	return marshallAccount(acct)
+}
+
 // DepositAccount handles a trader's request to deposit funds into the specified
 // account by spending the specified inputs.
 func (s *rpcServer) DepositAccount(ctx context.Context,
@@ -895,9 +993,86 @@ func (s *rpcServer) parseRPCOutputs(outputs []*clmrpc.Output) ([]*wire.TxOut,
 }
 
 func (s *rpcServer) RecoverAccounts(ctx context.Context,
-	_ *clmrpc.RecoverAccountsRequest) (*clmrpc.RecoverAccountsResponse,
+	req *clmrpc.RecoverAccountsRequest) (*clmrpc.RecoverAccountsResponse,
+	error) {
+
+	if req.ChainScan {
+		return s.recoverAccountsFromChain(ctx, req)
+	}
+
+	s.recoveryMutex.Lock()
+	if s.recoveryPending {
+		defer s.recoveryMutex.Unlock()
+		return nil, fmt.Errorf("recovery already in progress")
+	}
+	s.recoveryPending = true
+	s.recoveryMutex.Unlock()
+
+	defer func() {
+		s.recoveryMutex.Lock()
+		s.recoveryPending = false
+		s.recoveryMutex.Unlock()
+	}()
+
+	// Either continue where an interrupted recovery left off or start a
+	// new one at the requested key index.
+	progress := account.NewRecoveryProgress(req.StartIndex, req.Gap)
+	if req.Resume {
+		var err error
+		progress, err = s.server.db.RecoveryProgress()
+		if err != nil {
+			return nil, fmt.Errorf("unable to resume recovery: %v",
+				err)
+		}
+	}
+
+	// The auctioneer client will try to recover accounts for the derived
+	// keys as long as the auctioneer is able to find them in its database.
+	// If a certain number of successive keys result in an "account not
+	// found" error, the recovery stops. Each recovered account is stored
+	// right away and the progress is persisted after each key, so an
+	// interrupted recovery can be resumed without asking for the same keys
+	// again.
+	err := account.RecoverAccounts(ctx, &account.RecoveryConfig{
+		Wallet:         s.lndServices.WalletKit,
+		Store:          &accountStore{s.server.db},
+		FetchAccount:   s.auctioneer.RecoverAccount,
+		RecoverAccount: s.accountManager.RecoverAccount,
+	}, progress)
+	if err != nil {
+		return nil, fmt.Errorf("error performing recovery, resume "+
+			"at key index %d: %v", progress.NextIndex, err)
+	}
+
+	return &clmrpc.RecoverAccountsResponse{
+		NumRecoveredAccounts: progress.NumRecovered,
+		StartIndex:           progress.StartIndex,
+		NextIndex:            progress.NextIndex,
+		NumUnusedKeys:        progress.NumUnused,
+		Complete:             progress.Complete,
+	}, nil
+}
+
+// recoverAccountsFromChain recovers all accounts by scanning the chain for
+// their outputs. This only requires the connected lnd node and the
+// auctioneer's static keys but not the auctioneer itself.
+func (s *rpcServer) recoverAccountsFromChain(ctx context.Context,
+	req *clmrpc.RecoverAccountsRequest) (*clmrpc.RecoverAccountsResponse,
 	error) {
 
+	auctioneerKey, err := btcec.ParsePubKey(
+		req.AuctioneerKey, btcec.S256(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auctioneer key: %v", err)
+	}
+	initialBatchKey, err := btcec.ParsePubKey(
+		req.InitialBatchKey, btcec.S256(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid initial batch key: %v", err)
+	}
+
 	s.recoveryMutex.Lock()
 	if s.recoveryPending {
 		defer s.recoveryMutex.Unlock()
@@ -906,45 +1081,120 @@ func (s *rpcServer) RecoverAccounts(ctx context.Context,
 	s.recoveryPending = true
 	s.recoveryMutex.Unlock()
 
-	// Prepare the keys we are going to try. Possibly not all of them will
-	// be used.
-	acctKeys, err := account.GenerateRecoveryKeys(
-		ctx, s.lndServices.WalletKit,
+	defer func() {
+		s.recoveryMutex.Lock()
+		s.recoveryPending = false
+		s.recoveryMutex.Unlock()
+	}()
+
+	chainAccounts, err := account.RecoverAccountsFromChain(
+		ctx, &account.ChainRecoveryConfig{
+			Wallet:          s.lndServices.WalletKit,
+			Signer:          s.lndServices.Signer,
+			ChainNotifier:   s.lndServices.ChainNotifier,
+			TxSource:        s.lndServices.Client,
+			AuctioneerKey:   auctioneerKey,
+			InitialBatchKey: initialBatchKey,
+			StartIndex:      req.StartIndex,
+			NumKeys:         req.NumKeys,
+			BatchKeyWindow:  req.BatchKeyWindow,
+			HeightHint:      req.StartHeight,
+			BestHeight:      atomic.LoadUint32(&s.bestHeight),
+		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("error generating keys: %v", err)
+		return nil, fmt.Errorf("error scanning chain: %v", err)
 	}
 
-	// The auctioneer client will try to recover accounts for these keys as
-	// long as the auctioneer is able to find them in its database. If a
-	// certain number of keys result in an "account not found" error, the
-	// client will stop trying.
-	recoveredAccounts, err := s.auctioneer.RecoverAccounts(ctx, acctKeys)
+	numRecovered, err := s.restoreAccounts(ctx, chainAccounts)
 	if err != nil {
-		return nil, fmt.Errorf("error performing recovery: %v", err)
+		return nil, err
 	}
 
-	// Store the recovered accounts now and start watching them. If anything
-	// went wrong during recovery before, no account is stored yet. This is
-	// nice since it allows us to try recovery multiple times until it
-	// actually works.
-	numRecovered := len(recoveredAccounts)
-	for _, acct := range recoveredAccounts {
-		err = s.accountManager.RecoverAccount(ctx, acct)
+	return &clmrpc.RecoverAccountsResponse{
+		NumRecoveredAccounts: uint32(numRecovered),
+	}, nil
+}
+
+// restoreAccounts stores all given accounts that aren't yet known to the
+// trader database and starts watching them on-chain. The number of restored
+// accounts is returned.
+func (s *rpcServer) restoreAccounts(ctx context.Context,
+	accounts []*account.Account) (int, error) {
+
+	// Accounts we still know about must not be overwritten with the
+	// possibly outdated state of the backup or the chain.
+	dbAccounts, err := s.server.db.Accounts()
+	if err != nil {
+		return 0, err
+	}
+	knownAccounts := make(map[[33]byte]struct{}, len(dbAccounts))
+	for _, acct := range dbAccounts {
+		var traderKey [33]byte
+		copy(traderKey[:], acct.TraderKey.PubKey.SerializeCompressed())
+		knownAccounts[traderKey] = struct{}{}
+	}
+
+	bestHeight := atomic.LoadUint32(&s.bestHeight)
+	numRestored := 0
+	for _, acct := range accounts {
+		var traderKey [33]byte
+		copy(traderKey[:], acct.TraderKey.PubKey.SerializeCompressed())
+		if _, ok := knownAccounts[traderKey]; ok {
+			log.Infof("Skipping restore of known account %x",
+				traderKey[:])
+			continue
+		}
+
+		err := s.accountManager.RestoreAccount(ctx, acct, bestHeight)
 		if err != nil {
 			// If something goes wrong for one account we still want
 			// to continue with the others.
-			numRecovered--
-			log.Errorf("error storing recovered account: %v", err)
+			log.Errorf("Error restoring account %x: %v",
+				traderKey[:], err)
+			continue
 		}
+		numRestored++
 	}
 
+	return numRestored, nil
+}
+
+// RestoreAccountBackup restores all accounts of a static account backup that
+// aren't yet known to the trader database and starts watching them on-chain.
+// This only requires the connected lnd node and not the auctioneer.
+func (s *rpcServer) RestoreAccountBackup(ctx context.Context,
+	req *clmrpc.RestoreAccountBackupRequest) (
+	*clmrpc.RestoreAccountBackupResponse, error) {
+
 	s.recoveryMutex.Lock()
-	s.recoveryPending = false
+	if s.recoveryPending {
+		defer s.recoveryMutex.Unlock()
+		return nil, fmt.Errorf("recovery already in progress")
+	}
+	s.recoveryPending = true
 	s.recoveryMutex.Unlock()
 
-	return &clmrpc.RecoverAccountsResponse{
-		NumRecoveredAccounts: uint32(numRecovered),
+	defer func() {
+		s.recoveryMutex.Lock()
+		s.recoveryPending = false
+		s.recoveryMutex.Unlock()
+	}()
+
+	backupAccounts, err := account.ReadStaticBackup(
+		ctx, req.Backup, s.lndServices.WalletKit, s.lndServices.Signer,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	numRestored, err := s.restoreAccounts(ctx, backupAccounts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clmrpc.RestoreAccountBackupResponse{
+		NumRestoredAccounts: uint32(numRestored),
 	}, nil
 }
 
@@ -954,33 +1204,88 @@ func (s *rpcServer) RecoverAccounts(ctx context.Context,
 func (s *rpcServer) SubmitOrder(ctx context.Context,
 	req *clmrpc.SubmitOrderRequest) (*clmrpc.SubmitOrderResponse, error) {
 
-	var o order.Order
+	var (
+		o   order.Order
+		err error
+	)
 	switch requestOrder := req.Details.(type) {
 	case *clmrpc.SubmitOrderRequest_Ask:
-		a := requestOrder.Ask
-		kit, err := order.ParseRPCOrder(a.Version, a.Details)
-		if err != nil {
-			return nil, err
-		}
-		o = &order.Ask{
-			Kit:         *kit,
-			MaxDuration: a.MaxDurationBlocks,
-		}
+		o, err = parseRPCAsk(requestOrder.Ask)
 
 	case *clmrpc.SubmitOrderRequest_Bid:
-		b := requestOrder.Bid
-		kit, err := order.ParseRPCOrder(b.Version, b.Details)
-		if err != nil {
-			return nil, err
-		}
-		o = &order.Bid{
-			Kit:         *kit,
-			MinDuration: b.MinDurationBlocks,
-		}
+		o, err = parseRPCBid(requestOrder.Bid)
 
 	default:
 		return nil, fmt.Errorf("invalid order request")
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	serverParams, err := s.prepareOrder(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+	invalidOrder, err := s.sendOrder(ctx, o, serverParams)
+	if err != nil {
+		return nil, err
+	}
+	if invalidOrder != nil {
+		return &clmrpc.SubmitOrderResponse{
+			Details: &clmrpc.SubmitOrderResponse_InvalidOrder{
+				InvalidOrder: invalidOrder,
+			},
+		}, nil
+	}
+
+	// ServerOrder is accepted.
+	orderNonce := o.Nonce()
+	return &clmrpc.SubmitOrderResponse{
+		Details: &clmrpc.SubmitOrderResponse_AcceptedOrderNonce{
+			AcceptedOrderNonce: orderNonce[:],
+		},
+	}, nil
+}
+
+// parseRPCAsk parses an ask order of an RPC request.
+func parseRPCAsk(a *clmrpc.Ask) (order.Order, error) {
+	kit, err := order.ParseRPCOrder(a.Version, a.Details)
+	if err != nil {
+		return nil, err
+	}
+	return &order.Ask{
+		Kit:         *kit,
+		MaxDuration: a.MaxDurationBlocks,
+	}, nil
+}
+
+// parseRPCBid parses a bid order of an RPC request.
+func parseRPCBid(b *clmrpc.Bid) (order.Order, error) {
+	kit, err := order.ParseRPCOrder(b.Version, b.Details)
+	if err != nil {
+		return nil, err
+	}
+	return &order.Bid{
+		Kit:         *kit,
+		MinDuration: b.MinDurationBlocks,
+	}, nil
+}
+
+// prepareOrder validates a new order, collects all the order data from our lnd
+// node and signs it. The order is stored in our local database but not yet
+// sent to the auction server.
+func (s *rpcServer) prepareOrder(ctx context.Context,
+	o order.Order) (*order.ServerOrderParams, error) {
+
+	// An order that would expire right away is most likely a mistake. No
+	// batch can be completed before the order is submitted, so only the
+	// height and time need to be checked.
+	height := atomic.LoadUint32(&s.bestHeight)
+	kit := o.Details()
+	if kit.Expired(height, time.Now(), kit.SubmittedAtBatch) {
+		return nil, fmt.Errorf("order expiry already reached at "+
+			"height %d", height)
+	}
 
 	// Verify that the account exists.
 	acctKey, err := btcec.ParsePubKey(
@@ -996,19 +1301,26 @@ func (s *rpcServer) SubmitOrder(ctx context.Context,
 
 	// Collect all the order data and sign it before sending it to the
 	// auction server.
-	serverParams, err := s.orderManager.PrepareOrder(ctx, o, acct)
-	if err != nil {
-		return nil, err
-	}
+	return s.orderManager.PrepareOrder(ctx, o, acct)
+}
+
+// sendOrder sends a prepared order to the auction server. If the server
+// rejected the order because of the information the user provided, the reason
+// is returned instead of an error.
+func (s *rpcServer) sendOrder(ctx context.Context, o order.Order,
+	serverParams *order.ServerOrderParams) (*clmrpc.InvalidOrder, error) {
 
 	// Send the order to the server. If this fails, then the order is
-	// certain to never get into the order book. We don't need to keep it
-	// around in that case.
-	err = s.auctioneer.SubmitOrder(ctx, o, serverParams)
+	// certain to never get into the order book. We keep it around in the
+	// failed state together with the reason so the user can look it up
+	// later.
+	err := s.auctioneer.SubmitOrder(ctx, o, serverParams)
 	if err != nil {
-		// TODO(guggero): Put in state failed instead of removing?
-		if err2 := s.server.db.DelOrder(o.Nonce()); err2 != nil {
-			log.Errorf("Could not delete failed order: %v", err2)
+		err2 := s.server.db.UpdateOrder(
+			o.Nonce(), order.FailedModifier(time.Now(), err),
+		)
+		if err2 != nil {
+			log.Errorf("Could not mark order as failed: %v", err2)
 		}
 
 		// If there was something wrong with the information the user
@@ -1017,35 +1329,26 @@ func (s *rpcServer) SubmitOrder(ctx context.Context,
 		if userErr, ok := err.(*order.UserError); ok {
 			log.Warnf("Invalid order details: %v", userErr)
 
-			return &clmrpc.SubmitOrderResponse{
-				Details: &clmrpc.SubmitOrderResponse_InvalidOrder{
-					InvalidOrder: userErr.Details,
-				},
-			}, nil
+			return userErr.Details, nil
 		}
 
 		// Any other error we return normally as a gRPC status level
 		// error.
 		return nil, fmt.Errorf("error submitting order to auctioneer: "+
-			"%v", err)
+			"%w", err)
 	}
 
 	log.Infof("New order submitted: nonce=%v, type=%v", o.Nonce(), o.Type())
 
-	// ServerOrder is accepted.
-	orderNonce := o.Nonce()
-	return &clmrpc.SubmitOrderResponse{
-		Details: &clmrpc.SubmitOrderResponse_AcceptedOrderNonce{
-			AcceptedOrderNonce: orderNonce[:],
-		},
-	}, nil
+	return nil, nil
 }
 
 // ListOrders returns a list of all orders that is currently known to the trader
-// client's local store. The state of each order is queried on the auction
-// server and returned as well.
-func (s *rpcServer) ListOrders(ctx context.Context, _ *clmrpc.ListOrdersRequest) (
-	*clmrpc.ListOrdersResponse, error) {
+// client's local store and match the request's filters. The state of each
+// order that can still change is refreshed from the auction server first,
+// unless only the locally stored state is requested.
+func (s *rpcServer) ListOrders(ctx context.Context,
+	req *clmrpc.ListOrdersRequest) (*clmrpc.ListOrdersResponse, error) {
 
 	// Get all orders from our local store first.
 	dbOrders, err := s.server.db.GetOrders()
@@ -1053,29 +1356,141 @@ func (s *rpcServer) ListOrders(ctx context.Context, _ *clmrpc.ListOrdersRequest)
 		return nil, err
 	}
 
-	// The RPC is split by order type so we have to separate them now.
-	asks := make([]*clmrpc.Ask, 0, len(dbOrders))
-	bids := make([]*clmrpc.Bid, 0, len(dbOrders))
+	// Apply all filters that don't depend on the state of the order
+	// before we query the server so we only refresh what we need.
+	var traderKey [33]byte
+	copy(traderKey[:], req.TraderKey)
+	orders := make([]order.Order, 0, len(dbOrders))
 	for _, dbOrder := range dbOrders {
-		nonce := dbOrder.Nonce()
+		switch {
+		case req.TypeFilter == clmrpc.ListOrdersRequest_ASKS_ONLY &&
+			dbOrder.Type() != order.TypeAsk:
+
+			continue
+
+		case req.TypeFilter == clmrpc.ListOrdersRequest_BIDS_ONLY &&
+			dbOrder.Type() != order.TypeBid:
 
-		// Ask the server about the order's current status.
-		orderStateResp, err := s.auctioneer.OrderState(ctx, nonce)
+			continue
+
+		case len(req.TraderKey) > 0 &&
+			dbOrder.Details().AcctKey != traderKey:
+
+			continue
+
+		case req.Label != "" && dbOrder.Details().Label != req.Label:
+			continue
+
+		case !account.MatchesTags(dbOrder.Details().Tags, req.Tags):
+			continue
+		}
+		orders = append(orders, dbOrder)
+	}
+
+	// Refresh the state of all orders that can still change. If the
+	// server can't tell us the state of an order, we fall back to the one
+	// we stored last.
+	cached := make(map[order.Nonce]struct{})
+	if req.LocalOnly {
+		for _, o := range orders {
+			cached[o.Nonce()] = struct{}{}
+		}
+	} else {
+		cached, err = s.syncOrderStates(ctx, orders, true)
 		if err != nil {
-			return nil, fmt.Errorf("unable to query order state on"+
-				"server for order %v: %v", nonce.String(), err)
+			return nil, err
+		}
+	}
+
+	states := make(map[order.State]struct{}, len(req.States))
+	for _, state := range req.States {
+		states[order.State(state)] = struct{}{}
+	}
+	filtered := orders[:0]
+	for _, o := range orders {
+		state := o.Details().State
+		_, stateRequested := states[state]
+		switch {
+		case len(states) > 0 && !stateRequested:
+			continue
+
+		// The server never got to know failed orders, they are only
+		// listed if explicitly requested.
+		case state == order.StateFailed && !req.IncludeFailed &&
+			!stateRequested:
+
+			continue
+
+		case req.ActiveOnly && state.Archived():
+			continue
 		}
+		filtered = append(filtered, o)
+	}
 
+	// The orders are returned ordered by their nonce so the pagination is
+	// stable.
+	sort.Slice(filtered, func(i, j int) bool {
+		nonceI, nonceJ := filtered[i].Nonce(), filtered[j].Nonce()
+		return bytes.Compare(nonceI[:], nonceJ[:]) < 0
+	})
+	total := uint32(len(filtered))
+	if req.Offset >= total {
+		filtered = nil
+	} else {
+		filtered = filtered[req.Offset:]
+	}
+	if req.Limit > 0 && uint32(len(filtered)) > req.Limit {
+		filtered = filtered[:req.Limit]
+	}
+
+	// The RPC is split by order type so we have to separate them now.
+	asks := make([]*clmrpc.Ask, 0, len(filtered))
+	bids := make([]*clmrpc.Bid, 0, len(filtered))
+	for _, dbOrder := range filtered {
+		nonce := dbOrder.Nonce()
 		dbDetails := dbOrder.Details()
+		_, stateCached := cached[nonce]
+
 		details := &clmrpc.Order{
 			TraderKey:        dbDetails.AcctKey[:],
 			RateFixed:        dbDetails.FixedRate,
 			Amt:              uint64(dbDetails.Amt),
 			FundingFeeRate:   uint64(dbDetails.FundingFeeRate),
 			OrderNonce:       nonce[:],
-			State:            orderStateResp.State,
+			State:            clmrpc.OrderState(dbDetails.State),
 			Units:            uint32(dbDetails.Units),
-			UnitsUnfulfilled: orderStateResp.UnitsUnfulfilled,
+			UnitsUnfulfilled: uint32(dbDetails.UnitsUnfulfilled),
+			ExpiryHeight:     dbDetails.ExpiryHeight,
+			ExpiryBatches:    dbDetails.ExpiryBatches,
+			BatchesMatched:   dbDetails.BatchesMatched,
+			MinUnitsMatch:    uint32(dbDetails.MinUnitsMatch),
+			AllOrNone:        dbDetails.AllOrNone,
+			StateCached:      stateCached,
+			Label:            dbDetails.Label,
+			Tags:             dbDetails.Tags,
+		}
+		if dbDetails.Replaces != order.ZeroNonce {
+			details.ReplacesOrderNonce = dbDetails.Replaces[:]
+		}
+		if dbDetails.ReplacedBy != order.ZeroNonce {
+			details.ReplacedByOrderNonce = dbDetails.ReplacedBy[:]
+		}
+		if dbDetails.GroupID != order.ZeroGroupID {
+			details.GroupId = dbDetails.GroupID[:]
+		}
+		if dbDetails.CanceledBy != order.ZeroNonce {
+			details.CanceledByOrderNonce = dbDetails.CanceledBy[:]
+		}
+		if !dbDetails.ExpiryTime.IsZero() {
+			details.ExpiryTimestamp = dbDetails.ExpiryTime.Unix()
+		}
+		if !dbDetails.FailedAt.IsZero() {
+			details.FailTimestamp = dbDetails.FailedAt.UnixNano()
+			details.FailString = dbDetails.FailString
+			details.Rejected = dbDetails.Rejected
+			details.FailReason = clmrpc.InvalidOrder_FailReason(
+				dbDetails.FailReason,
+			)
 		}
 
 		switch o := dbOrder.(type) {
@@ -1101,8 +1516,9 @@ func (s *rpcServer) ListOrders(ctx context.Context, _ *clmrpc.ListOrdersRequest)
 		}
 	}
 	return &clmrpc.ListOrdersResponse{
-		Asks: asks,
-		Bids: bids,
+		Asks:        asks,
+		Bids:        bids,
+		TotalOrders: total,
 	}, nil
 }
 
@@ -1113,11 +1529,372 @@ func (s *rpcServer) CancelOrder(ctx context.Context,
 
 	var nonce order.Nonce
 	copy(nonce[:], req.OrderNonce)
+	if err := s.cancelOrder(ctx, nonce); err != nil {
+		return nil, err
+	}
+	return &clmrpc.CancelOrderResponse{}, nil
+}
+
+// LabelOrder changes the label and tags of an existing order.
+func (s *rpcServer) LabelOrder(_ context.Context,
+	req *clmrpc.LabelOrderRequest) (*clmrpc.LabelOrderResponse, error) {
+
+	var nonce order.Nonce
+	copy(nonce[:], req.OrderNonce)
+
+	modifiers := []order.Modifier{
+		order.TagsModifier(req.SetTags, req.RemoveTags),
+	}
+	switch {
+	case req.ClearLabel && req.Label != "":
+		return nil, fmt.Errorf("cannot set and clear the label at " +
+			"the same time")
+
+	case req.ClearLabel || req.Label != "":
+		modifiers = append(modifiers, order.LabelModifier(req.Label))
+	}
+	if err := s.server.db.UpdateOrder(nonce, modifiers...); err != nil {
+		return nil, err
+	}
+
+	return &clmrpc.LabelOrderResponse{}, nil
+}
+
+// cancelOrder cancels the order on the server and marks it as canceled in our
+// local database.
+func (s *rpcServer) cancelOrder(ctx context.Context, nonce order.Nonce) error {
 	err := s.auctioneer.CancelOrder(ctx, nonce)
+	if err != nil {
+		return err
+	}
+
+	err = s.server.db.UpdateOrder(
+		nonce, order.StateModifier(order.StateCanceled),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to update canceled order: %v", err)
+	}
+	return nil
+}
+
+// PruneFailedOrders removes orders that failed to be submitted to the auction
+// server from our local database.
+func (s *rpcServer) PruneFailedOrders(_ context.Context,
+	req *clmrpc.PruneFailedOrdersRequest) (
+	*clmrpc.PruneFailedOrdersResponse, error) {
+
+	dbOrders, err := s.server.db.GetOrders()
 	if err != nil {
 		return nil, err
 	}
-	return &clmrpc.CancelOrderResponse{}, nil
+
+	cutoff := time.Now().Add(
+		-time.Duration(req.OlderThanSeconds) * time.Second,
+	)
+	var numPruned uint32
+	for _, dbOrder := range dbOrders {
+		details := dbOrder.Details()
+		if details.State != order.StateFailed ||
+			details.FailedAt.After(cutoff) {
+
+			continue
+		}
+
+		if err := s.server.db.DelOrder(dbOrder.Nonce()); err != nil {
+			return nil, fmt.Errorf("unable to remove order %v: %v",
+				dbOrder.Nonce(), err)
+		}
+		numPruned++
+	}
+
+	log.Infof("Pruned %d failed orders", numPruned)
+
+	return &clmrpc.PruneFailedOrdersResponse{
+		NumPruned: numPruned,
+	}, nil
+}
+
+// ReplaceOrder replaces an active order with a new one. The new order is
+// prepared and signed first, then the old order is canceled and only after that
+// the new order is submitted. This makes sure at most one of the two orders can
+// be matched in the next batch.
+func (s *rpcServer) ReplaceOrder(ctx context.Context,
+	req *clmrpc.ReplaceOrderRequest) (*clmrpc.ReplaceOrderResponse, error) {
+
+	var (
+		oldNonce order.Nonce
+		newOrder order.Order
+		err      error
+	)
+	copy(oldNonce[:], req.OrderNonce)
+	switch requestOrder := req.Details.(type) {
+	case *clmrpc.ReplaceOrderRequest_Ask:
+		newOrder, err = parseRPCAsk(requestOrder.Ask)
+
+	case *clmrpc.ReplaceOrderRequest_Bid:
+		newOrder, err = parseRPCBid(requestOrder.Bid)
+
+	default:
+		return nil, fmt.Errorf("invalid order request")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	invalidOrder, err := s.replaceOrder(ctx, oldNonce, newOrder)
+	if err != nil {
+		return nil, err
+	}
+	if invalidOrder != nil {
+		return &clmrpc.ReplaceOrderResponse{
+			Details: &clmrpc.ReplaceOrderResponse_InvalidOrder{
+				InvalidOrder: invalidOrder,
+			},
+		}, nil
+	}
+
+	newNonce := newOrder.Nonce()
+	return &clmrpc.ReplaceOrderResponse{
+		Details: &clmrpc.ReplaceOrderResponse_AcceptedOrderNonce{
+			AcceptedOrderNonce: newNonce[:],
+		},
+	}, nil
+}
+
+// replaceOrder replaces the active order with the given nonce with the new
+// order. If the server rejected the new order because of its details, the
+// reason is returned instead of an error.
+func (s *rpcServer) replaceOrder(ctx context.Context, oldNonce order.Nonce,
+	newOrder order.Order) (*clmrpc.InvalidOrder, error) {
+
+	oldOrder, err := s.server.db.GetOrder(oldNonce)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find order %v: %v", oldNonce,
+			err)
+	}
+	if oldOrder.Details().State.Archived() {
+		return nil, fmt.Errorf("order %v is no longer active, state %v",
+			oldNonce, oldOrder.Details().State)
+	}
+	if newOrder.Type() != oldOrder.Type() {
+		return nil, fmt.Errorf("cannot replace %v order with %v order",
+			oldOrder.Type(), newOrder.Type())
+	}
+	newOrder.Details().Replaces = oldNonce
+
+	// Everything that could go wrong on our side happens before we cancel
+	// the old order.
+	serverParams, err := s.prepareOrder(ctx, newOrder)
+	if err != nil {
+		return nil, err
+	}
+	newNonce := newOrder.Nonce()
+
+	// Mark the old order as canceled locally too, so we reject any batch
+	// that still matches it. An order that was matched in the batch we're
+	// currently executing can't be canceled safely anymore. The order
+	// manager makes sure no new batch is accepted in the meantime.
+	err = s.orderManager.CancelOrder(ctx, oldNonce)
+	if err != nil {
+		if err2 := s.server.db.DelOrder(newNonce); err2 != nil {
+			log.Errorf("Could not delete replacement order: %v",
+				err2)
+		}
+		return nil, fmt.Errorf("unable to cancel order %v: %w",
+			oldNonce, err)
+	}
+
+	invalidOrder, err := s.sendOrder(ctx, newOrder, serverParams)
+	if err != nil {
+		return nil, fmt.Errorf("order %v was canceled but its "+
+			"replacement failed: %w", oldNonce, err)
+	}
+	if invalidOrder != nil {
+		return invalidOrder, nil
+	}
+
+	// The replacement is only recorded once the new order is in the
+	// order book. A failed new order still references the old one.
+	err = s.server.db.UpdateOrder(
+		oldNonce, order.ReplacedByModifier(newNonce),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update replaced order: %v",
+			err)
+	}
+
+	log.Infof("Order %v replaced by %v", oldNonce, newNonce)
+
+	return nil, nil
+}
+
+// BackupDB streams a consistent snapshot of the trader database to the client
+// while llmd keeps running.
+func (s *rpcServer) BackupDB(_ *clmrpc.BackupDBRequest,
+	stream clmrpc.Trader_BackupDBServer) error {
+
+	if s.server.backupDB == nil {
+		return errBackupUnsupported
+	}
+
+	n, err := streamBackup(s.server.backupDB, stream)
+	if err != nil {
+		return fmt.Errorf("unable to back up database: %v", err)
+	}
+
+	log.Infof("Streamed database backup of %d bytes to client", n)
+
+	return nil
+}
+
+// AuctionConnection returns the state and history of the connection to the
+// auction server.
+func (s *rpcServer) AuctionConnection(_ context.Context,
+	_ *clmrpc.AuctionConnectionRequest) (*clmrpc.AuctionConnectionResponse,
+	error) {
+
+	state := s.auctioneer.ConnectionState()
+
+	resp := &clmrpc.AuctionConnectionResponse{
+		CurrentEndpoint: state.CurrentEndpoint,
+		Endpoints:       state.Endpoints,
+		Connected:       state.Connected,
+		Events: make(
+			[]*clmrpc.ConnectionEvent, 0, len(state.Events),
+		),
+	}
+	if state.LastError != nil {
+		resp.LastError = state.LastError.Error()
+		resp.LastErrorTimestampNs = state.LastErrorTime.UnixNano()
+	}
+	for _, event := range state.Events {
+		rpcEvent := &clmrpc.ConnectionEvent{
+			TimestampNs: event.Timestamp.UnixNano(),
+			Type:        event.Type.String(),
+			Endpoint:    event.Endpoint,
+		}
+		if event.Err != nil {
+			rpcEvent.Error = event.Err.Error()
+		}
+		resp.Events = append(resp.Events, rpcEvent)
+	}
+
+	return resp, nil
+}
+
+// ListLsatTokens returns all LSAT tokens of the store, including the revoked
+// ones.
+func (s *rpcServer) ListLsatTokens(_ context.Context,
+	_ *clmrpc.ListLsatTokensRequest) (*clmrpc.ListLsatTokensResponse,
+	error) {
+
+	tokens, err := s.server.lsatStore.Tokens()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list LSAT tokens: %v", err)
+	}
+
+	rpcTokens := make([]*clmrpc.LsatToken, 0, len(tokens))
+	for _, token := range tokens {
+		rpcToken, err := marshallLsatToken(token)
+		if err != nil {
+			return nil, err
+		}
+		rpcTokens = append(rpcTokens, rpcToken)
+	}
+
+	return &clmrpc.ListLsatTokensResponse{
+		Tokens: rpcTokens,
+	}, nil
+}
+
+// GetLsatToken returns the LSAT token with the given payment hash.
+func (s *rpcServer) GetLsatToken(_ context.Context,
+	req *clmrpc.GetLsatTokenRequest) (*clmrpc.LsatToken, error) {
+
+	hash, err := lntypes.MakeHash(req.PaymentHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment hash: %v", err)
+	}
+	token, err := s.server.lsatStore.Token(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshallLsatToken(token)
+}
+
+// RevokeLsatToken revokes the LSAT token with the given payment hash so it's no
+// longer used to authenticate with the auction server.
+func (s *rpcServer) RevokeLsatToken(_ context.Context,
+	req *clmrpc.RevokeLsatTokenRequest) (*clmrpc.RevokeLsatTokenResponse,
+	error) {
+
+	hash, err := lntypes.MakeHash(req.PaymentHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment hash: %v", err)
+	}
+	if err := s.server.lsatStore.Revoke(hash); err != nil {
+		return nil, err
+	}
+
+	log.Infof("Revoked LSAT token %v", hash)
+
+	return &clmrpc.RevokeLsatTokenResponse{}, nil
+}
+
+// LsatLedger returns all recorded LSAT payments and their totals.
+func (s *rpcServer) LsatLedger(_ context.Context,
+	_ *clmrpc.LsatLedgerRequest) (*clmrpc.LsatLedgerResponse, error) {
+
+	entries, err := s.server.lsatStore.Ledger()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read LSAT ledger: %v", err)
+	}
+
+	resp := &clmrpc.LsatLedgerResponse{
+		Entries: make([]*clmrpc.LsatLedgerEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		hash := entry.PaymentHash
+		resp.Entries = append(resp.Entries, &clmrpc.LsatLedgerEntry{
+			PaymentHash:        hash[:],
+			AmountPaidMsat:     uint64(entry.AmountPaid),
+			RoutingFeePaidMsat: uint64(entry.RoutingFeePaid),
+			TimePaid:           entry.TimePaid.Unix(),
+		})
+		resp.TotalAmountPaidMsat += uint64(entry.AmountPaid)
+		resp.TotalRoutingFeePaidMsat += uint64(entry.RoutingFeePaid)
+	}
+
+	return resp, nil
+}
+
+// marshallLsatToken translates a token of the store into its RPC counterpart.
+func marshallLsatToken(token *lsatTokenInfo) (*clmrpc.LsatToken, error) {
+	macBytes, err := token.BaseMacaroon().MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize macaroon: %v", err)
+	}
+
+	// A token can only be used if it's fully paid and wasn't revoked.
+	valid := !token.Pending() && !token.Revoked && token.IsValid()
+
+	rpcToken := &clmrpc.LsatToken{
+		BaseMacaroon:       macBytes,
+		PaymentHash:        token.PaymentHash[:],
+		AmountPaidMsat:     uint64(token.AmountPaid),
+		RoutingFeePaidMsat: uint64(token.RoutingFeePaid),
+		TimeCreated:        token.TimeCreated.Unix(),
+		Valid:              valid,
+		Pending:            token.Pending(),
+		Revoked:            token.Revoked,
+		StorageName:        token.StorageName,
+	}
+	if !token.Pending() {
+		rpcToken.PaymentPreimage = token.Preimage[:]
+	}
+
+	return rpcToken, nil
 }
 
 // sendRejectBatch sends a reject message to the server with the properly
@@ -1131,19 +1908,40 @@ func (s *rpcServer) sendRejectBatch(batch *order.Batch, failure error) error {
 	}
 
 	// Attach the status code to the message to give a bit more context.
+	msg.Reject.ReasonCode = rejectReasonCode(failure)
+
+	// If we can tell which of our orders caused the failure, we list them
+	// so the auctioneer can re-plan the batch without just those orders.
+	rejectOrder := func(nonce order.Nonce, err error) {
+		if msg.Reject.RejectedOrders == nil {
+			msg.Reject.RejectedOrders = make(
+				map[string]*clmrpc.OrderReject,
+			)
+		}
+		msg.Reject.RejectedOrders[nonce.String()] = &clmrpc.OrderReject{
+			Reason:     err.Error(),
+			ReasonCode: rejectReasonCode(err),
+		}
+	}
+	var (
+		matchRejectErr *order.MatchRejectErr
+		mismatchErr    *order.MismatchErr
+	)
 	switch {
-	case errors.Is(failure, order.ErrVersionMismatch):
-		msg.Reject.ReasonCode = clmrpc.OrderMatchReject_BATCH_VERSION_MISMATCH
-
-	case errors.Is(failure, order.ErrMismatchErr):
-		msg.Reject.ReasonCode = clmrpc.OrderMatchReject_SERVER_MISBEHAVIOR
+	case errors.As(failure, &matchRejectErr):
+		msg.Reject.ReasonCode = clmrpc.OrderMatchReject_PARTIAL_REJECT
+		for nonce, err := range matchRejectErr.RejectedOrders {
+			rejectOrder(nonce, err)
+		}
 
-	default:
-		msg.Reject.ReasonCode = clmrpc.OrderMatchReject_UNKNOWN
+	case errors.As(failure, &mismatchErr):
+		if nonce, ok := mismatchErr.OrderNonce(); ok {
+			rejectOrder(nonce, failure)
+		}
 	}
 	log.Infof("Sending batch rejection message for batch %x with "+
-		"code %v and message: %v", batch.ID, msg.Reject.ReasonCode,
-		failure)
+		"code %v (%d orders rejected) and message: %v", batch.ID,
+		msg.Reject.ReasonCode, len(msg.Reject.RejectedOrders), failure)
 
 	// Send the message to the server. If a new error happens we return that
 	// one because we know the causing error has at least been logged at
@@ -1157,6 +1955,27 @@ func (s *rpcServer) sendRejectBatch(batch *order.Batch, failure error) error {
 	return failure
 }
 
+// rejectReasonCode maps an error that caused a batch or order to be rejected to
+// its RPC reject reason code.
+func rejectReasonCode(err error) clmrpc.OrderMatchReject_RejectReason {
+	switch {
+	case errors.Is(err, order.ErrVersionMismatch):
+		return clmrpc.OrderMatchReject_BATCH_VERSION_MISMATCH
+
+	case errors.Is(err, order.ErrMismatchErr):
+		return clmrpc.OrderMatchReject_SERVER_MISBEHAVIOR
+
+	case errors.Is(err, order.ErrChannelFundingFailed):
+		return clmrpc.OrderMatchReject_CHANNEL_FUNDING_FAILED
+
+	case errors.Is(err, order.ErrPolicyReject):
+		return clmrpc.OrderMatchReject_POLICY_REJECT
+
+	default:
+		return clmrpc.OrderMatchReject_UNKNOWN
+	}
+}
+
 // sendAcceptBatch sends an accept message to the server with the list of order
 // nonces that we accept in the batch.
 func (s *rpcServer) sendAcceptBatch(batch *order.Batch) error {
@@ -0,0 +1,348 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/llm/auctioneer"
+	"github.com/lightninglabs/llm/clientdb"
+	"github.com/lightninglabs/llm/clmrpc"
+	"github.com/lightninglabs/llm/order"
+	"github.com/lightninglabs/loop/lsat"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockAuctioneerServer is an auction server that fails every order submission
+// and cancellation with a configurable error.
+type mockAuctioneerServer struct {
+	clmrpc.UnimplementedChannelAuctioneerServer
+
+	err error
+
+	// orderState is the state that is returned for every order. If it's
+	// nil, the state can't be queried.
+	orderState *clmrpc.ServerOrderStateResponse
+
+	// numCancels is the number of cancellations received. This MUST be
+	// used atomically.
+	numCancels uint32
+}
+
+func (m *mockAuctioneerServer) SubmitOrder(context.Context,
+	*clmrpc.ServerSubmitOrderRequest) (*clmrpc.ServerSubmitOrderResponse,
+	error) {
+
+	return nil, m.err
+}
+
+func (m *mockAuctioneerServer) CancelOrder(context.Context,
+	*clmrpc.ServerCancelOrderRequest) (*clmrpc.ServerCancelOrderResponse,
+	error) {
+
+	atomic.AddUint32(&m.numCancels, 1)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &clmrpc.ServerCancelOrderResponse{}, nil
+}
+
+func (m *mockAuctioneerServer) OrderState(context.Context,
+	*clmrpc.ServerOrderStateRequest) (*clmrpc.ServerOrderStateResponse,
+	error) {
+
+	if m.orderState == nil {
+		return nil, errors.New("unknown order")
+	}
+	return m.orderState, nil
+}
+
+// newTestRPCServer creates an RPC server that is connected to the given mock
+// auction server and uses a temporary database that contains a single order.
+func newTestRPCServer(t *testing.T, mock *mockAuctioneerServer) (*rpcServer,
+	order.Order, func()) {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	clmrpc.RegisterChannelAuctioneerServer(grpcServer, mock)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+
+	client, err := auctioneer.NewClient(&auctioneer.Config{
+		ServerAddress: listener.Addr().String(),
+		Insecure:      true,
+	})
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+	if err := client.Start(); err != nil {
+		t.Fatalf("unable to start client: %v", err)
+	}
+
+	tempDir, err := ioutil.TempDir("", "rpcserver")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	db, err := clientdb.New(tempDir)
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+
+	var preimage lntypes.Preimage
+	preimage[0] = 1
+	kit := order.NewKitWithPreimage(preimage)
+	kit.Amt = 100_000
+	kit.Units = 1
+	kit.UnitsUnfulfilled = 1
+	o := &order.Bid{Kit: *kit, MinDuration: 144}
+	if err := db.SubmitOrder(o); err != nil {
+		t.Fatalf("unable to store order: %v", err)
+	}
+
+	store := &staticBackupStore{traderStore: db}
+	server := &rpcServer{
+		server: &Server{
+			db: store,
+		},
+		auctioneer: client,
+		orderManager: order.NewManager(&order.ManagerConfig{
+			Store:      store,
+			Auctioneer: client,
+		}),
+	}
+	return server, o, func() {
+		_ = client.Stop()
+		grpcServer.Stop()
+		_ = db.Close()
+		_ = os.RemoveAll(tempDir)
+	}
+}
+
+// TestAuctioneerErrorStatus makes sure errors returned by the auctioneer when
+// submitting or canceling an order are returned to the RPC client with the
+// gRPC status code of their type.
+func TestAuctioneerErrorStatus(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{{
+		name: "insufficient balance",
+		err: auctioneer.NewError(
+			auctioneer.ErrInsufficientBalance, "no balance",
+		),
+		code: codes.FailedPrecondition,
+	}, {
+		name: "account not found",
+		err: auctioneer.NewError(
+			auctioneer.ErrAccountNotFound, "no account",
+		),
+		code: codes.NotFound,
+	}, {
+		name: "rate limited",
+		err: auctioneer.NewError(
+			auctioneer.ErrRateLimited, "slow down",
+		),
+		code: codes.ResourceExhausted,
+	}, {
+		name: "payment required",
+		err: auctioneer.NewError(
+			auctioneer.ErrPaymentRequired, "pay up",
+		),
+		code: lsat.GRPCErrCode,
+	}, {
+		name: "untyped error",
+		err:  errors.New("something went wrong"),
+		code: codes.Unknown,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &mockAuctioneerServer{err: tc.err}
+			if typed, ok := tc.err.(*auctioneer.Error); ok {
+				mock.err = typed.GRPCStatus().Err()
+			}
+
+			server, o, cleanup := newTestRPCServer(t, mock)
+			defer cleanup()
+
+			ctx := context.Background()
+			submit := func(context.Context, interface{}) (
+				interface{}, error) {
+
+				return server.sendOrder(
+					ctx, o, &order.ServerOrderParams{},
+				)
+			}
+			cancel := func(context.Context, interface{}) (
+				interface{}, error) {
+
+				nonce := o.Nonce()
+				return server.CancelOrder(
+					ctx, &clmrpc.CancelOrderRequest{
+						OrderNonce: nonce[:],
+					},
+				)
+			}
+
+			for _, handler := range []grpc.UnaryHandler{
+				submit, cancel,
+			} {
+				_, err := errorUnaryServerInterceptor(
+					ctx, nil, nil, handler,
+				)
+				if status.Code(err) != tc.code {
+					t.Fatalf("expected code %v, got %v",
+						tc.code, err)
+				}
+			}
+		})
+	}
+}
+
+// TestExpireOrder makes sure an expired order is only canceled if it's still
+// active when the expiry handler gets to it.
+func TestExpireOrder(t *testing.T) {
+	testCases := []struct {
+		state         order.State
+		expectedState order.State
+		numCancels    uint32
+	}{{
+		state:         order.StateSubmitted,
+		expectedState: order.StateExpired,
+		numCancels:    1,
+	}, {
+		state:         order.StatePartiallyFilled,
+		expectedState: order.StateExpired,
+		numCancels:    1,
+	}, {
+		state:         order.StateExecuted,
+		expectedState: order.StateExecuted,
+	}, {
+		state:         order.StateCanceled,
+		expectedState: order.StateCanceled,
+	}, {
+		state:         order.StateExpired,
+		expectedState: order.StateExpired,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.state.String(), func(t *testing.T) {
+			mock := &mockAuctioneerServer{}
+			server, o, cleanup := newTestRPCServer(t, mock)
+			defer cleanup()
+
+			db := server.server.db
+			err := db.UpdateOrder(
+				o.Nonce(), order.StateModifier(tc.state),
+			)
+			if err != nil {
+				t.Fatalf("unable to update order: %v", err)
+			}
+
+			// Expiring the same order twice, as it happens if it
+			// is queued again before the handler is done, must
+			// only cancel it once.
+			for i := 0; i < 2; i++ {
+				err := server.expireOrder(o.Nonce())
+				if err != nil {
+					t.Fatalf("unable to expire order: %v",
+						err)
+				}
+			}
+
+			dbOrder, err := db.GetOrder(o.Nonce())
+			if err != nil {
+				t.Fatalf("unable to get order: %v", err)
+			}
+			state := dbOrder.Details().State
+			if state != tc.expectedState {
+				t.Fatalf("expected state %v, got %v",
+					tc.expectedState, state)
+			}
+			numCancels := atomic.LoadUint32(&mock.numCancels)
+			if numCancels != tc.numCancels {
+				t.Fatalf("expected %d cancellations, got %d",
+					tc.numCancels, numCancels)
+			}
+		})
+	}
+}
+
+// TestBatchExpiry makes sure an order expires once the given number of batches
+// were completed after it was submitted, even if it was never matched in any
+// of them.
+func TestBatchExpiry(t *testing.T) {
+	server, _, cleanup := newTestRPCServer(t, &mockAuctioneerServer{})
+	defer cleanup()
+	server.expiredOrders = make(chan []order.Nonce, 1)
+
+	// There are no accounts in the batches, so we can skip the static
+	// backup.
+	db := server.server.db.traderStore
+
+	// Complete a batch before the order is submitted, it must not count
+	// towards the order's expiry.
+	completeBatch := func() {
+		err := db.StorePendingBatch(
+			order.BatchID{}, wire.NewMsgTx(2), nil, nil, nil, nil,
+		)
+		if err != nil {
+			t.Fatalf("unable to store batch: %v", err)
+		}
+		if err := db.MarkBatchComplete(); err != nil {
+			t.Fatalf("unable to complete batch: %v", err)
+		}
+	}
+	completeBatch()
+
+	var preimage lntypes.Preimage
+	preimage[0] = 2
+	kit := order.NewKitWithPreimage(preimage)
+	kit.Amt = 100_000
+	kit.Units = 1
+	kit.UnitsUnfulfilled = 1
+	kit.ExpiryBatches = 2
+	kit.SubmittedAtBatch = 1
+	o := &order.Bid{Kit: *kit, MinDuration: 144}
+	if err := db.SubmitOrder(o); err != nil {
+		t.Fatalf("unable to store order: %v", err)
+	}
+
+	// One more batch isn't enough to expire the order.
+	completeBatch()
+	server.checkOrderExpiry()
+	select {
+	case expired := <-server.expiredOrders:
+		t.Fatalf("unexpected orders expired: %v", expired)
+	default:
+	}
+
+	// But the second one is.
+	completeBatch()
+	server.checkOrderExpiry()
+	select {
+	case expired := <-server.expiredOrders:
+		if len(expired) != 1 || expired[0] != o.Nonce() {
+			t.Fatalf("unexpected orders expired: %v", expired)
+		}
+	default:
+		t.Fatalf("order not expired")
+	}
+}
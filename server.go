@@ -13,9 +13,10 @@ import (
 
 	proxy "github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/lightninglabs/kirin/auth"
+	"github.com/lightninglabs/llm/account"
 	"github.com/lightninglabs/llm/auctioneer"
-	"github.com/lightninglabs/llm/clientdb"
 	"github.com/lightninglabs/llm/clmrpc"
+	"github.com/lightninglabs/llm/streamlog"
 	"github.com/lightninglabs/loop/lndclient"
 	"github.com/lightninglabs/loop/lsat"
 	"github.com/lightningnetwork/lnd/build"
@@ -37,7 +38,10 @@ type Server struct {
 	GetIdentity func() (*lsat.TokenID, error)
 
 	cfg          *Config
-	db           *clientdb.DB
+	db           *staticBackupStore
+	backupDB     backupStore
+	lsatStore    *lsatTokenStore
+	recorder     *streamlog.Recorder
 	lndServices  *lndclient.GrpcLndServices
 	lndClient    lnrpc.LightningClient
 	traderServer *rpcServer
@@ -90,28 +94,56 @@ func NewServer(cfg *Config) (*Server, error) {
 	}
 
 	log.Infof("Auction server address: %v", cfg.AuctionServer)
+	if cfg.Proxy != "" {
+		log.Infof("Connecting to auction server through proxy %v",
+			cfg.Proxy)
+	}
+	if len(cfg.BackupAuctionServers) > 0 {
+		log.Infof("Backup auction server addresses: %v",
+			cfg.BackupAuctionServers)
+	}
+
+	// Backups are only supported by the bolt backend.
+	isBolt := cfg.DBBackend == "" || cfg.DBBackend == DBBackendBolt
+	if !isBolt && cfg.BackupInterval > 0 {
+		return nil, errBackupUnsupported
+	}
 
 	// Open the main database.
 	networkDir := filepath.Join(cfg.BaseDir, cfg.Network)
-	db, err := clientdb.New(networkDir)
+	rawDB, err := openDB(cfg, networkDir)
 	if err != nil {
 		return nil, err
 	}
 
-	// Setup the LSAT interceptor for the client.
-	fileStore, err := lsat.NewFileStore(networkDir)
+	// Hot backups are only possible if the backend supports them.
+	backupDB, _ := rawDB.(backupStore)
+
+	// All account changes are also written to the static account backup
+	// so the accounts can be swept even if the database is lost.
+	db := &staticBackupStore{
+		traderStore: rawDB,
+		backup: account.NewStaticBackup(
+			staticBackupFile(cfg), lndServices.WalletKit,
+			lndServices.Signer,
+		),
+	}
+
+	// Setup the LSAT interceptor for the client. All paid tokens are
+	// recorded in the spend ledger of the store.
+	lsatStore, err := newLsatTokenStore(networkDir)
 	if err != nil {
 		return nil, err
 	}
 	var interceptor Interceptor = lsat.NewInterceptor(
-		&lndServices.LndServices, fileStore, defaultRPCTimeout,
-		defaultLsatMaxCost, defaultLsatMaxFee,
+		&lndServices.LndServices, lsatStore, defaultRPCTimeout,
+		cfg.LsatMaxCost, cfg.LsatMaxFee,
 	)
 
 	// getIdentity can be used to determine the current LSAT identification
 	// of the trader.
 	getIdentity := func() (*lsat.TokenID, error) {
-		token, err := fileStore.CurrentToken()
+		token, err := lsatStore.CurrentToken()
 		if err != nil {
 			return nil, err
 		}
@@ -144,16 +176,37 @@ func NewServer(cfg *Config) (*Server, error) {
 		grpc.WithStreamInterceptor(interceptor.StreamInterceptor),
 	)
 
+	// Record the auction stream for debugging if requested.
+	var recorder *streamlog.Recorder
+	if cfg.RecordStream {
+		recorder, err = streamlog.NewRecorder(
+			streamLogDir(cfg),
+			int64(cfg.MaxStreamLogFileSize)*1024*1024,
+			cfg.MaxStreamLogFiles,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create stream "+
+				"recorder: %v", err)
+		}
+		log.Infof("Recording auction stream to %v", streamLogDir(cfg))
+	}
+
 	// Create an instance of the auctioneer client library.
 	clientCfg := &auctioneer.Config{
-		ServerAddress: cfg.AuctionServer,
-		Insecure:      cfg.Insecure,
-		TLSPathServer: cfg.TLSPathAuctSrv,
-		DialOpts:      cfg.AuctioneerDialOpts,
-		Signer:        lndServices.Signer,
-		MinBackoff:    cfg.MinBackoff,
-		MaxBackoff:    cfg.MaxBackoff,
-		BatchSource:   db,
+		ServerAddress:         cfg.AuctionServer,
+		BackupServerAddresses: cfg.BackupAuctionServers,
+		FailoverThreshold:     cfg.FailoverThreshold,
+		KeepaliveInterval:     cfg.KeepaliveInterval,
+		KeepaliveTimeout:      cfg.KeepaliveTimeout,
+		Insecure:              cfg.Insecure,
+		Proxy:                 cfg.Proxy,
+		TLSPathServer:         cfg.TLSPathAuctSrv,
+		DialOpts:              cfg.AuctioneerDialOpts,
+		Signer:                lndServices.Signer,
+		MinBackoff:            cfg.MinBackoff,
+		MaxBackoff:            cfg.MaxBackoff,
+		BatchSource:           db,
+		StreamRecorder:        recorder,
 	}
 	auctioneerClient, err := auctioneer.NewClient(clientCfg)
 	if err != nil {
@@ -175,6 +228,9 @@ func NewServer(cfg *Config) (*Server, error) {
 	return &Server{
 		cfg:              cfg,
 		db:               db,
+		backupDB:         backupDB,
+		lsatStore:        lsatStore,
+		recorder:         recorder,
 		lndServices:      lndServices,
 		lndClient:        baseClient,
 		AuctioneerClient: auctioneerClient,
@@ -190,7 +246,12 @@ func (s *Server) Start() error {
 	// Instantiate the llmd gRPC server.
 	s.traderServer = newRPCServer(s)
 
-	serverOpts := []grpc.ServerOption{}
+	// Auctioneer errors are forwarded with their gRPC status so clients can
+	// branch on their type.
+	serverOpts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(errorUnaryServerInterceptor),
+		grpc.StreamInterceptor(errorStreamServerInterceptor),
+	}
 	s.grpcServer = grpc.NewServer(serverOpts...)
 	clmrpc.RegisterTraderServer(s.grpcServer, s.traderServer)
 
@@ -281,6 +342,11 @@ func (s *Server) Stop() error {
 	if err != nil {
 		return err
 	}
+	if s.recorder != nil {
+		if err := s.recorder.Close(); err != nil {
+			log.Errorf("Error closing stream recorder: %v", err)
+		}
+	}
 
 	// Don't return any errors yet, give everything else a chance to shut
 	// down first.
@@ -362,3 +428,32 @@ func (i *regtestInterceptor) StreamInterceptor(ctx context.Context,
 	)
 	return streamer(idCtx, desc, cc, method, opts...)
 }
+
+// errorUnaryServerInterceptor converts typed auctioneer errors returned from a
+// non-streaming RPC into their gRPC status.
+func errorUnaryServerInterceptor(ctx context.Context, req interface{},
+	_ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{},
+	error) {
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, auctioneer.StatusError(err)
+	}
+	return resp, nil
+}
+
+// errorStreamServerInterceptor converts typed auctioneer errors returned from a
+// streaming RPC into their gRPC status.
+func errorStreamServerInterceptor(srv interface{}, ss grpc.ServerStream,
+	_ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+	return auctioneer.StatusError(handler(srv, ss))
+}
+
+// streamLogDir returns the directory the auction stream is recorded to.
+func streamLogDir(cfg *Config) string {
+	if cfg.StreamLogDir != "" {
+		return cfg.StreamLogDir
+	}
+	return filepath.Join(cfg.BaseDir, cfg.Network, defaultStreamLogDirname)
+}
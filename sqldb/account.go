@@ -0,0 +1,199 @@
+package sqldb
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/clientdb"
+)
+
+const (
+	// accountsTable is the table that stores all complete accounts,
+	// indexed by their trader key.
+	accountsTable = "accounts"
+
+	// pendingAccountsTable is the table that stores the staged updates of
+	// all accounts that participate in the current pending batch.
+	pendingAccountsTable = "pending_accounts"
+)
+
+// getAccountKey returns the key for an account which is not partial.
+func getAccountKey(acct *account.Account) []byte {
+	return acct.TraderKey.PubKey.SerializeCompressed()
+}
+
+// AddAccount adds a record for the account to the database.
+func (db *DB) AddAccount(acct *account.Account) error {
+	return db.executeTx(func(tx *sql.Tx) error {
+		return db.putAccount(tx, accountsTable, acct)
+	})
+}
+
+// UpdateAccount updates an account in the database according to the given
+// modifiers.
+func (db *DB) UpdateAccount(acct *account.Account,
+	modifiers ...account.Modifier) error {
+
+	err := db.executeTx(func(tx *sql.Tx) error {
+		return db.updateAccount(
+			tx, accountsTable, accountsTable, getAccountKey(acct),
+			modifiers,
+		)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, modifier := range modifiers {
+		modifier(acct)
+	}
+
+	return nil
+}
+
+// Account retrieves a specific account by trader key or returns
+// clientdb.ErrAccountNotFound if it's not found.
+func (db *DB) Account(traderKey *btcec.PublicKey) (*account.Account, error) {
+	var acct *account.Account
+	err := db.executeTx(func(tx *sql.Tx) error {
+		var err error
+		acct, err = db.fetchAccount(
+			tx, accountsTable, traderKey.SerializeCompressed(),
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return acct, nil
+}
+
+// Accounts retrieves all known accounts from the database.
+func (db *DB) Accounts() ([]*account.Account, error) {
+	var res []*account.Account
+	err := db.executeTx(func(tx *sql.Tx) error {
+		var err error
+		res, err = db.fetchAccounts(tx, accountsTable)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// updateAccount reads an account from the src table, applies the given
+// modifiers to it, and stores it back into the dst table.
+func (db *DB) updateAccount(tx *sql.Tx, src, dst string, accountKey []byte,
+	modifiers []account.Modifier) error {
+
+	rawAccount, err := db.fetchRawAccount(tx, src, accountKey)
+	if err != nil {
+		return err
+	}
+
+	dbAccount, newRawAccount, err := clientdb.ApplyAccountModifiers(
+		rawAccount, modifiers,
+	)
+	if err != nil {
+		return err
+	}
+
+	return db.putRawAccount(tx, dst, dbAccount, newRawAccount)
+}
+
+// fetchAccount reads a single account from the given table.
+func (db *DB) fetchAccount(tx *sql.Tx, table string,
+	accountKey []byte) (*account.Account, error) {
+
+	rawAccount, err := db.fetchRawAccount(tx, table, accountKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return clientdb.DeserializeAccount(bytes.NewReader(rawAccount))
+}
+
+// fetchRawAccount reads the serialized form of a single account from the given
+// table.
+func (db *DB) fetchRawAccount(tx *sql.Tx, table string,
+	accountKey []byte) ([]byte, error) {
+
+	var raw []byte
+	query := fmt.Sprintf("SELECT raw FROM %s WHERE trader_key = ?", table)
+	err := tx.QueryRow(db.rebind(query), accountKey).Scan(&raw)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, clientdb.ErrAccountNotFound
+
+	case err != nil:
+		return nil, err
+	}
+
+	return raw, nil
+}
+
+// fetchAccounts reads all accounts from the given table, ordered by their
+// trader key.
+func (db *DB) fetchAccounts(tx *sql.Tx, table string) ([]*account.Account,
+	error) {
+
+	query := fmt.Sprintf("SELECT raw FROM %s ORDER BY trader_key", table)
+	rows, err := tx.Query(db.rebind(query))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*account.Account
+	for rows.Next() {
+		var raw []byte
+		if err := rows.Scan(&raw); err != nil {
+			return nil, err
+		}
+
+		acct, err := clientdb.DeserializeAccount(bytes.NewReader(raw))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, acct)
+	}
+
+	return res, rows.Err()
+}
+
+// putAccount inserts or overwrites an account in the given table.
+func (db *DB) putAccount(tx *sql.Tx, table string,
+	acct *account.Account) error {
+
+	var accountBuf bytes.Buffer
+	if err := clientdb.SerializeAccount(&accountBuf, acct); err != nil {
+		return err
+	}
+
+	return db.putRawAccount(tx, table, acct, accountBuf.Bytes())
+}
+
+// putRawAccount inserts or overwrites an account in the given table using its
+// given serialized form.
+func (db *DB) putRawAccount(tx *sql.Tx, table string, acct *account.Account,
+	rawAccount []byte) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (trader_key, state, value, expiry, raw)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (trader_key) DO UPDATE SET
+			state = excluded.state, value = excluded.value,
+			expiry = excluded.expiry, raw = excluded.raw`, table,
+	)
+	_, err := tx.Exec(
+		db.rebind(query), getAccountKey(acct), int64(acct.State),
+		int64(acct.Value), int64(acct.Expiry), rawAccount,
+	)
+	return err
+}
@@ -0,0 +1,259 @@
+package sqldb
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/clientdb"
+	"github.com/lightninglabs/llm/order"
+)
+
+// StorePendingBatch atomically stages all modified orders/accounts as a result
+// of a pending batch. If any single operation fails, the whole set of changes
+// is rolled back. Once the batch has been finalized/confirmed on-chain, then
+// the stage modifications will be applied atomically as a result of
+// MarkBatchComplete.
+func (db *DB) StorePendingBatch(batchID order.BatchID, batchTx *wire.MsgTx,
+	orders []order.Nonce, orderModifiers [][]order.Modifier,
+	accounts []*account.Account, accountModifiers [][]account.Modifier) error {
+
+	// Catch the most obvious problems first.
+	if len(orders) != len(orderModifiers) {
+		return fmt.Errorf("order modifier length mismatch")
+	}
+	if len(accounts) != len(accountModifiers) {
+		return fmt.Errorf("account modifier length mismatch")
+	}
+
+	var txBuf bytes.Buffer
+	if err := clientdb.WriteElement(&txBuf, batchTx); err != nil {
+		return err
+	}
+
+	// Wrap the whole batch update in a single database transaction.
+	return db.executeTx(func(tx *sql.Tx) error {
+		// Before updating the set of orders and accounts, we'll first
+		// delete any existing staged updates. This is to done to handle
+		// the case where the first version of a batch updated an
+		// order/account, but its second version didn't. Without this,
+		// our state would become desynchronized with the auction.
+		if err := db.clearStagedUpdates(tx); err != nil {
+			return err
+		}
+
+		// Update orders first.
+		for idx, nonce := range orders {
+			err := db.updateOrder(
+				tx, ordersTable, pendingOrdersTable, nonce,
+				orderModifiers[idx],
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Then update the accounts.
+		for idx, acct := range accounts {
+			err := db.updateAccount(
+				tx, accountsTable, pendingAccountsTable,
+				getAccountKey(acct), accountModifiers[idx],
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Finally, write the ID and transaction of the pending batch.
+		_, err := tx.Exec(db.rebind(`
+			INSERT INTO pending_batch (id, batch_id, batch_tx)
+			VALUES (?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				batch_id = excluded.batch_id,
+				batch_tx = excluded.batch_tx`,
+		), pendingBatchRowID, batchID[:], txBuf.Bytes())
+		return err
+	})
+}
+
+// PendingBatch retrieves the ID and transaction of the currently pending batch.
+// If there isn't one, account.ErrNoPendingBatch is returned.
+func (db *DB) PendingBatch() (order.BatchID, *wire.MsgTx, error) {
+	var (
+		batchID order.BatchID
+		batchTx *wire.MsgTx
+	)
+	err := db.executeTx(func(tx *sql.Tx) error {
+		var err error
+		batchID, batchTx, err = db.pendingBatch(tx)
+		return err
+	})
+	return batchID, batchTx, err
+}
+
+// pendingBatch retrieves the stored pending batch ID and transaction within a
+// database transaction.
+func (db *DB) pendingBatch(tx *sql.Tx) (order.BatchID, *wire.MsgTx, error) {
+	var (
+		batchID           order.BatchID
+		rawID, rawBatchTx []byte
+		batchTx           *wire.MsgTx
+	)
+	err := tx.QueryRow(db.rebind(`
+		SELECT batch_id, batch_tx FROM pending_batch WHERE id = ?`,
+	), pendingBatchRowID).Scan(&rawID, &rawBatchTx)
+	switch {
+	case err == sql.ErrNoRows:
+		return batchID, nil, account.ErrNoPendingBatch
+
+	case err != nil:
+		return batchID, nil, err
+	}
+
+	copy(batchID[:], rawID)
+	err = clientdb.ReadElement(bytes.NewReader(rawBatchTx), &batchTx)
+	if err != nil {
+		return batchID, nil, err
+	}
+
+	return batchID, batchTx, nil
+}
+
+// DeletePendingBatch removes all references to the current pending batch
+// without applying its staged updates to accounts and orders. If no pending
+// batch exists, this acts as a no-op.
+func (db *DB) DeletePendingBatch() error {
+	return db.executeTx(func(tx *sql.Tx) error {
+		_, err := tx.Exec("DELETE FROM pending_batch")
+		if err != nil {
+			return err
+		}
+		return db.clearStagedUpdates(tx)
+	})
+}
+
+// MarkBatchComplete marks a pending batch as complete, applying any staged
+// modifications necessary, and allowing a trader to participate in a new batch.
+// If a pending batch is not found, account.ErrNoPendingBatch is returned.
+func (db *DB) MarkBatchComplete() error {
+	return db.executeTx(func(tx *sql.Tx) error {
+		if _, _, err := db.pendingBatch(tx); err != nil {
+			return err
+		}
+		if err := db.applyBatchUpdates(tx); err != nil {
+			return err
+		}
+
+		count, err := db.batchCount(tx)
+		if err != nil {
+			return err
+		}
+		return db.putMetadata(
+			tx, metadataBatchCountKey, encodeUint32(count+1),
+		)
+	})
+}
+
+// BatchCount returns the number of batches that were completed so far.
+func (db *DB) BatchCount() (uint32, error) {
+	var count uint32
+	err := db.executeTx(func(tx *sql.Tx) error {
+		var err error
+		count, err = db.batchCount(tx)
+		return err
+	})
+	return count, err
+}
+
+// batchCount returns the number of completed batches stored in the metadata
+// table.
+func (db *DB) batchCount(tx *sql.Tx) (uint32, error) {
+	countBytes, err := db.getMetadata(tx, metadataBatchCountKey)
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, nil
+
+	case err != nil:
+		return 0, err
+	}
+	return decodeUint32(countBytes), nil
+}
+
+// applyBatchUpdates applies the staged updates for any accounts and orders that
+// participated in the latest pending batch.
+func (db *DB) applyBatchUpdates(tx *sql.Tx) error {
+	// We'll start by first applying the account updates. This simply
+	// involves fetching the updated state as part of the batch, and copying
+	// it over to the main account state.
+	accountKeys, err := db.fetchKeys(tx, pendingAccountsTable, "trader_key")
+	if err != nil {
+		return err
+	}
+	for _, accountKey := range accountKeys {
+		err := db.updateAccount(
+			tx, pendingAccountsTable, accountsTable, accountKey,
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// We'll do the same for orders as well.
+	nonces, err := db.fetchKeys(tx, pendingOrdersTable, "nonce")
+	if err != nil {
+		return err
+	}
+	for _, nonceBytes := range nonces {
+		var nonce order.Nonce
+		copy(nonce[:], nonceBytes)
+		err := db.updateOrder(
+			tx, pendingOrdersTable, ordersTable, nonce, nil,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Once all updates have been committed, we can remove them together
+	// with the reference to the pending batch ID and transaction.
+	if _, err := tx.Exec("DELETE FROM pending_batch"); err != nil {
+		return err
+	}
+	return db.clearStagedUpdates(tx)
+}
+
+// fetchKeys returns the values of the given key column of all rows in a table.
+func (db *DB) fetchKeys(tx *sql.Tx, table, keyColumn string) ([][]byte,
+	error) {
+
+	rows, err := tx.Query(fmt.Sprintf(
+		"SELECT %s FROM %s ORDER BY %s", keyColumn, table, keyColumn,
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys [][]byte
+	for rows.Next() {
+		var key []byte
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// clearStagedUpdates removes all staged account and order updates.
+func (db *DB) clearStagedUpdates(tx *sql.Tx) error {
+	if _, err := tx.Exec("DELETE FROM pending_accounts"); err != nil {
+		return err
+	}
+	_, err := tx.Exec("DELETE FROM pending_orders")
+	return err
+}
@@ -0,0 +1,183 @@
+package sqldb
+
+import (
+	"testing"
+
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/order"
+)
+
+// TestPersistBatchResult tests that the updates of a pending batch are staged
+// and only applied once the batch is marked as complete.
+func TestPersistBatchResult(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+
+	acct := testAccount()
+	ask := &order.Ask{
+		Kit:         *dummyOrder(t, 900000),
+		MaxDuration: 1337,
+	}
+	bid := &order.Bid{
+		Kit:         *dummyOrder(t, 900000),
+		MinDuration: 1337,
+	}
+	if err := db.AddAccount(acct); err != nil {
+		t.Fatalf("error storing test account: %v", err)
+	}
+	if err := db.SubmitOrder(ask); err != nil {
+		t.Fatalf("error storing test ask: %v", err)
+	}
+	if err := db.SubmitOrder(bid); err != nil {
+		t.Fatalf("error storing test bid: %v", err)
+	}
+
+	// Without a pending batch, we can't mark one as complete.
+	if err := db.MarkBatchComplete(); err != account.ErrNoPendingBatch {
+		t.Fatalf("expected ErrNoPendingBatch, got %v", err)
+	}
+
+	// Mismatched modifiers and non-existent records are rejected.
+	err := db.StorePendingBatch(
+		testBatchID, testBatchTx, []order.Nonce{ask.Nonce()}, nil,
+		nil, nil,
+	)
+	if err == nil {
+		t.Fatalf("expected order modifier length mismatch")
+	}
+	err = db.StorePendingBatch(
+		testBatchID, testBatchTx, []order.Nonce{{0, 1, 2}},
+		[][]order.Modifier{{order.StateModifier(order.StateExecuted)}},
+		nil, nil,
+	)
+	if err == nil {
+		t.Fatalf("expected error for unknown order")
+	}
+	if _, _, err := db.PendingBatch(); err != account.ErrNoPendingBatch {
+		t.Fatalf("failed batch should not be stored, got %v", err)
+	}
+
+	// Stage a first version of the batch that updates both orders and the
+	// account.
+	err = db.StorePendingBatch(
+		testBatchID, testBatchTx,
+		[]order.Nonce{ask.Nonce(), bid.Nonce()},
+		[][]order.Modifier{
+			{order.UnitsFulfilledModifier(42)},
+			{order.UnitsFulfilledModifier(21)},
+		},
+		[]*account.Account{acct},
+		[][]account.Modifier{{
+			account.StateModifier(account.StatePendingUpdate),
+		}},
+	)
+	if err != nil {
+		t.Fatalf("unable to store pending batch: %v", err)
+	}
+
+	dbBatchID, dbBatchTx, err := db.PendingBatch()
+	if err != nil {
+		t.Fatalf("unable to retrieve pending batch: %v", err)
+	}
+	if dbBatchID != testBatchID {
+		t.Fatalf("expected pending batch id %x, got %x", testBatchID,
+			dbBatchID)
+	}
+	if dbBatchTx.TxHash() != testBatchTx.TxHash() {
+		t.Fatalf("expected pending batch tx %v, got %v",
+			testBatchTx.TxHash(), dbBatchTx.TxHash())
+	}
+
+	// Nothing should be applied yet.
+	assertOrderExists(t, db, ask)
+	assertOrderExists(t, db, bid)
+	assertAccountExists(t, db, acct)
+
+	// Overwrite the batch with a version that only includes the ask. Only
+	// the ask's update should be applied when completing the batch.
+	err = db.StorePendingBatch(
+		testBatchID, testBatchTx, []order.Nonce{ask.Nonce()},
+		[][]order.Modifier{{order.UnitsFulfilledModifier(42)}},
+		nil, nil,
+	)
+	if err != nil {
+		t.Fatalf("unable to store pending batch: %v", err)
+	}
+	if err := db.MarkBatchComplete(); err != nil {
+		t.Fatalf("unable to mark batch complete: %v", err)
+	}
+
+	ask.UnitsUnfulfilled = 42
+	assertOrderExists(t, db, ask)
+	assertOrderExists(t, db, bid)
+	assertAccountExists(t, db, acct)
+
+	if _, _, err := db.PendingBatch(); err != account.ErrNoPendingBatch {
+		t.Fatalf("expected ErrNoPendingBatch, got %v", err)
+	}
+
+	// Only the completed batch is counted.
+	count, err := db.BatchCount()
+	if err != nil {
+		t.Fatalf("unable to get batch count: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 completed batch, got %d", count)
+	}
+}
+
+// TestDeletePendingBatch ensures that all references of a pending batch have
+// been removed after an invocation of DeletePendingBatch.
+func TestDeletePendingBatch(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+
+	acct := testAccount()
+	if err := db.AddAccount(acct); err != nil {
+		t.Fatalf("error storing test account: %v", err)
+	}
+
+	// Helper closure to assert the number of rows in the pending tables.
+	assertPendingRows := func(expected int) {
+		t.Helper()
+
+		for _, table := range []string{
+			"pending_batch", "pending_accounts",
+		} {
+			var numRows int
+			err := db.QueryRow(
+				"SELECT COUNT(*) FROM " + table,
+			).Scan(&numRows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if numRows != expected {
+				t.Fatalf("expected %d rows in %s, got %d",
+					expected, table, numRows)
+			}
+		}
+	}
+
+	err := db.StorePendingBatch(
+		testBatchID, testBatchTx, nil, nil, []*account.Account{acct},
+		[][]account.Modifier{{
+			account.StateModifier(account.StatePendingUpdate),
+		}},
+	)
+	if err != nil {
+		t.Fatalf("unable to store pending batch: %v", err)
+	}
+	assertPendingRows(1)
+
+	if err := db.DeletePendingBatch(); err != nil {
+		t.Fatalf("unable to delete pending batch: %v", err)
+	}
+	assertPendingRows(0)
+
+	// The staged account update must not have been applied.
+	assertAccountExists(t, db, acct)
+}
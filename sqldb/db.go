@@ -0,0 +1,358 @@
+package sqldb
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcwallet/wtxmgr"
+
+	// Register the SQL drivers of all supported backends.
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Backend is the type of SQL database server the store is connected to.
+type Backend string
+
+const (
+	// BackendSQLite is an embedded SQLite database file.
+	BackendSQLite Backend = "sqlite"
+
+	// BackendPostgres is a remote Postgres database server.
+	BackendPostgres Backend = "postgres"
+)
+
+// driverName returns the name of the database/sql driver that is registered
+// for the backend.
+func (b Backend) driverName() (string, error) {
+	switch b {
+	case BackendSQLite:
+		return "sqlite3", nil
+
+	case BackendPostgres:
+		return "postgres", nil
+
+	default:
+		return "", fmt.Errorf("unknown SQL backend %q", b)
+	}
+}
+
+const (
+	// metadataVersionKey is the key of the metadata row that stores the
+	// current schema version of the database.
+	metadataVersionKey = "version"
+
+	// metadataLockIDKey is the key of the metadata row that stores the
+	// global lock ID used to lease outputs from the backing lnd node's
+	// wallet.
+	metadataLockIDKey = "lock-id"
+
+	// metadataRecoveryProgressKey is the key of the metadata row that
+	// stores the progress of the last account recovery.
+	metadataRecoveryProgressKey = "recovery-progress"
+
+	// metadataBatchCountKey is the key of the metadata row that stores
+	// the number of batches that were completed so far.
+	metadataBatchCountKey = "batch-count"
+
+	// pendingBatchRowID is the ID of the single row in the pending_batch
+	// table. There can only ever be one pending batch at any time.
+	pendingBatchRowID = 0
+)
+
+var (
+	// byteOrder is the default byte order we'll use for serialization
+	// within the database.
+	byteOrder = binary.BigEndian
+
+	// ErrDBReversion is returned when detecting an attempt to revert to a
+	// prior database version.
+	ErrDBReversion = errors.New("cannot revert to prior version")
+
+	// schema is the list of statements executed to create all tables the
+	// store needs. Each record is stored in the same TLV format the bolt
+	// based clientdb uses. The most commonly queried fields are duplicated
+	// into their own columns to allow for easier inspection and reporting
+	// with standard SQL tools.
+	schema = []string{`
+		CREATE TABLE IF NOT EXISTS metadata (
+			key TEXT PRIMARY KEY,
+			value BLOB NOT NULL
+		)`, `
+		CREATE TABLE IF NOT EXISTS accounts (
+			trader_key BLOB PRIMARY KEY,
+			state INTEGER NOT NULL,
+			value BIGINT NOT NULL,
+			expiry BIGINT NOT NULL,
+			raw BLOB NOT NULL
+		)`, `
+		CREATE TABLE IF NOT EXISTS orders (
+			nonce BLOB PRIMARY KEY,
+			order_type INTEGER NOT NULL,
+			state INTEGER NOT NULL,
+			amount BIGINT NOT NULL,
+			units_unfulfilled BIGINT NOT NULL,
+			account_key BLOB NOT NULL,
+			raw BLOB NOT NULL
+		)`, `
+		CREATE TABLE IF NOT EXISTS pending_batch (
+			id INTEGER PRIMARY KEY,
+			batch_id BLOB NOT NULL,
+			batch_tx BLOB NOT NULL
+		)`, `
+		CREATE TABLE IF NOT EXISTS pending_accounts (
+			trader_key BLOB PRIMARY KEY,
+			state INTEGER NOT NULL,
+			value BIGINT NOT NULL,
+			expiry BIGINT NOT NULL,
+			raw BLOB NOT NULL
+		)`, `
+		CREATE TABLE IF NOT EXISTS pending_orders (
+			nonce BLOB PRIMARY KEY,
+			order_type INTEGER NOT NULL,
+			state INTEGER NOT NULL,
+			amount BIGINT NOT NULL,
+			units_unfulfilled BIGINT NOT NULL,
+			account_key BLOB NOT NULL,
+			raw BLOB NOT NULL
+		)`, `
+		CREATE TABLE IF NOT EXISTS account_key_indexes (
+			trader_key BLOB PRIMARY KEY,
+			key_index BIGINT NOT NULL
+		)`,
+	}
+)
+
+// migration is a function which takes a prior outdated version of the database
+// instances and mutates the schema/data to the latest version.
+type migration func(tx *sql.Tx) error
+
+var (
+	// migrations is the list of schema migrations that need to be applied
+	// to an outdated database. The index of a migration in this list is
+	// the version the database was at before it ran.
+	migrations = []migration{}
+
+	// latestSchemaVersion is the latest version of the database schema.
+	latestSchemaVersion = uint32(len(migrations))
+)
+
+// DB is a persistent store backed by a SQL database server. It implements the
+// same set of storage interfaces as the bolt based clientdb.DB.
+type DB struct {
+	*sql.DB
+
+	backend Backend
+}
+
+// New connects to the SQL database of the given backend using the given data
+// source name, creates all tables if they don't exist yet and applies any
+// outstanding schema migrations.
+func New(backend Backend, dsn string) (*DB, error) {
+	driverName, err := backend.driverName()
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite only supports a single writer at a time. We serialize all
+	// access through one connection to avoid running into "database is
+	// locked" errors under concurrent use.
+	if backend == BackendSQLite {
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	db := &DB{
+		DB:      sqlDB,
+		backend: backend,
+	}
+	if err := db.initDB(); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// initDB creates all tables of the schema, initializes the metadata on first
+// start and brings the schema up to date.
+func (db *DB) initDB() error {
+	return db.executeTx(func(tx *sql.Tx) error {
+		for _, stmt := range schema {
+			if _, err := tx.Exec(db.rebind(stmt)); err != nil {
+				return fmt.Errorf("unable to create schema: %v",
+					err)
+			}
+		}
+
+		// A database without a version is one that was just created.
+		// We'll mark it as being on the latest version and give it a
+		// new random lock ID.
+		version, err := db.getMetadata(tx, metadataVersionKey)
+		switch {
+		case err == sql.ErrNoRows:
+			err := db.putMetadata(
+				tx, metadataVersionKey,
+				encodeUint32(latestSchemaVersion),
+			)
+			if err != nil {
+				return err
+			}
+			return storeRandomLockID(db, tx)
+
+		case err != nil:
+			return err
+		}
+
+		return db.syncVersions(tx, decodeUint32(version))
+	})
+}
+
+// syncVersions applies all migrations needed to bring the schema from the
+// given version to the latest one.
+func (db *DB) syncVersions(tx *sql.Tx, currentVersion uint32) error {
+	log.Infof("Checking for schema updates: latest_version=%v, "+
+		"db_version=%v", latestSchemaVersion, currentVersion)
+
+	switch {
+	// If the database reports a higher version than we are aware of, the
+	// user is probably trying to revert to a prior version of llm.
+	case currentVersion > latestSchemaVersion:
+		return ErrDBReversion
+
+	// If the database reports a lower version than we are aware of, we'll
+	// apply all the required migrations.
+	case currentVersion < latestSchemaVersion:
+		for v := currentVersion; v < latestSchemaVersion; v++ {
+			log.Infof("Applying migration #%v", v+1)
+			if err := migrations[v](tx); err != nil {
+				return fmt.Errorf("unable to apply migration "+
+					"#%v: %v", v+1, err)
+			}
+		}
+
+		return db.putMetadata(
+			tx, metadataVersionKey,
+			encodeUint32(latestSchemaVersion),
+		)
+	}
+
+	return nil
+}
+
+// executeTx runs the given function within a single database transaction. The
+// transaction is committed if the function returns without error and rolled
+// back otherwise.
+func (db *DB) executeTx(f func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := f(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// rebind converts a query that uses '?' as its argument placeholder into the
+// placeholder and type dialect of the store's backend.
+func (db *DB) rebind(query string) string {
+	if db.backend != BackendPostgres {
+		return query
+	}
+
+	query = strings.Replace(query, "BLOB", "BYTEA", -1)
+
+	var (
+		b      strings.Builder
+		argNum = 1
+	)
+	for _, c := range query {
+		if c != '?' {
+			b.WriteRune(c)
+			continue
+		}
+
+		b.WriteString("$" + strconv.Itoa(argNum))
+		argNum++
+	}
+
+	return b.String()
+}
+
+// getMetadata returns the value stored for the given metadata key or
+// sql.ErrNoRows if it doesn't exist.
+func (db *DB) getMetadata(tx *sql.Tx, key string) ([]byte, error) {
+	var value []byte
+	err := tx.QueryRow(
+		db.rebind("SELECT value FROM metadata WHERE key = ?"), key,
+	).Scan(&value)
+	return value, err
+}
+
+// putMetadata inserts or overwrites the value of the given metadata key.
+func (db *DB) putMetadata(tx *sql.Tx, key string, value []byte) error {
+	_, err := tx.Exec(db.rebind(`
+		INSERT INTO metadata (key, value) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value`,
+	), key, value)
+	return err
+}
+
+// storeRandomLockID creates a new random lock ID and stores it in the metadata
+// table.
+func storeRandomLockID(db *DB, tx *sql.Tx) error {
+	var lockID wtxmgr.LockID
+	if _, err := rand.Read(lockID[:]); err != nil {
+		return err
+	}
+	return db.putMetadata(tx, metadataLockIDKey, lockID[:])
+}
+
+// LockID retrieves the database's global lock ID used to lease outputs from the
+// backing lnd node's wallet.
+func (db *DB) LockID() (wtxmgr.LockID, error) {
+	var lockID wtxmgr.LockID
+	err := db.executeTx(func(tx *sql.Tx) error {
+		lockIDBytes, err := db.getMetadata(tx, metadataLockIDKey)
+		if err == sql.ErrNoRows {
+			return errors.New("lock ID not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		copy(lockID[:], lockIDBytes)
+		return nil
+	})
+	return lockID, err
+}
+
+// encodeUint32 encodes a counter like the schema version as a big endian byte
+// slice.
+func encodeUint32(value uint32) []byte {
+	var b [4]byte
+	byteOrder.PutUint32(b[:], value)
+	return b[:]
+}
+
+// decodeUint32 decodes a counter like the schema version from its big endian
+// encoding.
+func decodeUint32(b []byte) uint32 {
+	if len(b) != 4 {
+		return 0
+	}
+	return byteOrder.Uint32(b)
+}
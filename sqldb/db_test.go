@@ -0,0 +1,364 @@
+package sqldb
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/clientdb"
+	"github.com/lightninglabs/llm/clmscript"
+	"github.com/lightninglabs/llm/order"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+var (
+	testOutPoint = wire.OutPoint{Index: 1}
+
+	testRawAuctioneerKey, _ = hex.DecodeString("02187d1a0e30f4e5016fc1137363ee9e7ed5dde1e6c50f367422336df7a108b716")
+	testAuctioneerKey, _    = btcec.ParsePubKey(testRawAuctioneerKey, btcec.S256())
+
+	testRawTraderKey, _ = hex.DecodeString("036b51e0cc2d9e5988ee4967e0ba67ef3727bb633fea21a0af58e0c9395446ba09")
+	testTraderKey, _    = btcec.ParsePubKey(testRawTraderKey, btcec.S256())
+	testTraderKeyDesc   = &keychain.KeyDescriptor{
+		KeyLocator: keychain.KeyLocator{
+			Family: clmscript.AccountKeyFamily,
+			Index:  0,
+		},
+		PubKey: testTraderKey,
+	}
+
+	testRawBatchKey, _ = hex.DecodeString("02824d0cbac65e01712124c50ff2cc74ce22851d7b444c1bf2ae66afefb8eaf27f")
+	testBatchKey, _    = btcec.ParsePubKey(testRawBatchKey, btcec.S256())
+
+	sharedSecret = [32]byte{0x73, 0x65, 0x63, 0x72, 0x65, 0x74}
+
+	testBatchID = order.BatchID{0x01, 0x02, 0x03}
+
+	testBatchTx = &wire.MsgTx{
+		Version: 2,
+		TxIn: []*wire.TxIn{
+			wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil),
+		},
+	}
+)
+
+func newTestDB(t *testing.T) (*DB, func()) {
+	tempDir, err := ioutil.TempDir("", "sql-db")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+
+	db, err := New(BackendSQLite, filepath.Join(tempDir, "llm.sqlite"))
+	if err != nil {
+		os.RemoveAll(tempDir)
+		t.Fatalf("unable to create new db: %v", err)
+	}
+
+	return db, func() {
+		db.Close()
+		os.RemoveAll(tempDir)
+	}
+}
+
+func testAccount() *account.Account {
+	return &account.Account{
+		Value:         btcutil.SatoshiPerBitcoin,
+		Expiry:        1337,
+		TraderKey:     testTraderKeyDesc,
+		AuctioneerKey: testAuctioneerKey,
+		BatchKey:      testBatchKey,
+		Secret:        sharedSecret,
+		State:         account.StateOpen,
+		HeightHint:    1,
+	}
+}
+
+func dummyOrder(t *testing.T, amt btcutil.Amount) *order.Kit {
+	var testPreimage lntypes.Preimage
+	if _, err := rand.Read(testPreimage[:]); err != nil {
+		t.Fatalf("could not create private key: %v", err)
+	}
+	kit := order.NewKitWithPreimage(testPreimage)
+	kit.Version = order.VersionDefault
+	kit.State = order.StateSubmitted
+	kit.FixedRate = 21
+	kit.Amt = amt
+	kit.MultiSigKeyLocator = keychain.KeyLocator{
+		Family: 123,
+		Index:  345,
+	}
+	kit.FundingFeeRate = chainfee.FeePerKwFloor
+	copy(kit.AcctKey[:], testTraderKey.SerializeCompressed())
+	kit.UnitsUnfulfilled = 741
+	return kit
+}
+
+func assertAccountExists(t *testing.T, db *DB, expected *account.Account) {
+	t.Helper()
+
+	found, err := db.Account(expected.TraderKey.PubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(found, expected) {
+		t.Fatalf("expected account: %v\ngot: %v", spew.Sdump(expected),
+			spew.Sdump(found))
+	}
+}
+
+func assertOrderExists(t *testing.T, db *DB, expected order.Order) {
+	t.Helper()
+
+	found, err := db.GetOrder(expected.Nonce())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(found, expected) {
+		t.Fatalf("expected order: %v\ngot: %v", spew.Sdump(expected),
+			spew.Sdump(found))
+	}
+}
+
+// TestAccounts ensures that all database operations involving accounts run as
+// expected.
+func TestAccounts(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+
+	a := testAccount()
+	a.State = account.StateInitiated
+
+	// Unknown accounts should return the same error as the bolt store.
+	_, err := db.Account(a.TraderKey.PubKey)
+	if err != clientdb.ErrAccountNotFound {
+		t.Fatalf("expected ErrAccountNotFound, got %v", err)
+	}
+
+	if err := db.AddAccount(a); err != nil {
+		t.Fatalf("unable to add account: %v", err)
+	}
+	assertAccountExists(t, db, a)
+
+	// Update the account and make sure both the in-memory and the stored
+	// version reflect the change.
+	err = db.UpdateAccount(
+		a, account.StateModifier(account.StatePendingOpen),
+		account.OutPointModifier(testOutPoint),
+	)
+	if err != nil {
+		t.Fatalf("unable to update account: %v", err)
+	}
+	if a.State != account.StatePendingOpen {
+		t.Fatalf("in-memory account was not updated")
+	}
+	assertAccountExists(t, db, a)
+
+	// Close the account, which also persists the close transaction.
+	closeTx := &wire.MsgTx{
+		Version: 2,
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: testOutPoint,
+			SignatureScript:  []byte{},
+		}},
+		TxOut: []*wire.TxOut{},
+	}
+	err = db.UpdateAccount(
+		a, account.StateModifier(account.StateClosed),
+		account.CloseTxModifier(closeTx),
+	)
+	if err != nil {
+		t.Fatalf("unable to update account: %v", err)
+	}
+	assertAccountExists(t, db, a)
+
+	accounts, err := db.Accounts()
+	if err != nil {
+		t.Fatalf("unable to retrieve accounts: %v", err)
+	}
+	if len(accounts) != 1 {
+		t.Fatalf("expected 1 account, found %v", len(accounts))
+	}
+	if !reflect.DeepEqual(accounts[0], a) {
+		t.Fatalf("expected account: %v\ngot: %v", spew.Sdump(a),
+			spew.Sdump(accounts[0]))
+	}
+}
+
+// TestOrders tests that orders can be stored, updated, retrieved and deleted
+// correctly.
+func TestOrders(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+
+	bid := &order.Bid{
+		Kit:         *dummyOrder(t, 500000),
+		MinDuration: 1337,
+	}
+	ask := &order.Ask{
+		Kit:         *dummyOrder(t, 500000),
+		MaxDuration: 1337,
+	}
+	if err := db.SubmitOrder(bid); err != nil {
+		t.Fatalf("unable to store order: %v", err)
+	}
+	if err := db.SubmitOrder(ask); err != nil {
+		t.Fatalf("unable to store order: %v", err)
+	}
+	assertOrderExists(t, db, bid)
+	assertOrderExists(t, db, ask)
+
+	// Submitting the same order twice should fail.
+	if err := db.SubmitOrder(bid); err != clientdb.ErrOrderExists {
+		t.Fatalf("expected ErrOrderExists, got %v", err)
+	}
+
+	// Update a single order, then both at once.
+	err := db.UpdateOrder(
+		bid.Nonce(), order.StateModifier(order.StatePartiallyFilled),
+	)
+	if err != nil {
+		t.Fatalf("unable to update order: %v", err)
+	}
+	bid.State = order.StatePartiallyFilled
+	assertOrderExists(t, db, bid)
+
+	stateModifier := order.StateModifier(order.StateCleared)
+	err = db.UpdateOrders(
+		[]order.Nonce{bid.Nonce(), ask.Nonce()},
+		[][]order.Modifier{{stateModifier}, {stateModifier}},
+	)
+	if err != nil {
+		t.Fatalf("unable to update orders: %v", err)
+	}
+	allOrders, err := db.GetOrders()
+	if err != nil {
+		t.Fatalf("unable to get all orders: %v", err)
+	}
+	if len(allOrders) != 2 {
+		t.Fatalf("unexpected number of orders. got %d expected %d",
+			len(allOrders), 2)
+	}
+	for _, o := range allOrders {
+		if o.Details().State != order.StateCleared {
+			t.Fatalf("unexpected order state. got %d expected %d",
+				o.Details().State, order.StateCleared)
+		}
+	}
+
+	// Finally, delete an order and make sure it's gone.
+	if err := db.DelOrder(bid.Nonce()); err != nil {
+		t.Fatalf("could not delete order: %v", err)
+	}
+	if _, err := db.GetOrder(bid.Nonce()); err != clientdb.ErrNoOrder {
+		t.Fatalf("expected ErrNoOrder, got %v", err)
+	}
+	if err := db.DelOrder(bid.Nonce()); err != clientdb.ErrNoOrder {
+		t.Fatalf("expected ErrNoOrder, got %v", err)
+	}
+}
+
+// TestLockIDPersisted makes sure the lock ID is created once and survives a
+// restart of the database.
+func TestLockIDPersisted(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "sql-db")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dsn := filepath.Join(tempDir, "llm.sqlite")
+	db, err := New(BackendSQLite, dsn)
+	if err != nil {
+		t.Fatalf("unable to create new db: %v", err)
+	}
+	lockID, err := db.LockID()
+	if err != nil {
+		t.Fatalf("unable to get lock ID: %v", err)
+	}
+	db.Close()
+
+	db, err = New(BackendSQLite, dsn)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	defer db.Close()
+
+	lockID2, err := db.LockID()
+	if err != nil {
+		t.Fatalf("unable to get lock ID: %v", err)
+	}
+	if lockID != lockID2 {
+		t.Fatalf("lock ID changed after restart")
+	}
+}
+
+// TestRecoveryStore makes sure account key indexes and the recovery progress
+// can be stored and retrieved.
+func TestRecoveryStore(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+
+	_, err := db.AccountKeyIndex(testTraderKey)
+	if err != account.ErrKeyIndexNotFound {
+		t.Fatalf("expected ErrKeyIndexNotFound, got %v", err)
+	}
+
+	// Adding the same key twice must overwrite its index.
+	for _, index := range []uint32{1, 1337} {
+		keyDesc := &keychain.KeyDescriptor{
+			KeyLocator: keychain.KeyLocator{Index: index},
+			PubKey:     testTraderKey,
+		}
+		err := db.AddAccountKeyIndexes(
+			[]*keychain.KeyDescriptor{keyDesc},
+		)
+		if err != nil {
+			t.Fatalf("unable to add key index: %v", err)
+		}
+	}
+	index, err := db.AccountKeyIndex(testTraderKey)
+	if err != nil {
+		t.Fatalf("unable to look up key index: %v", err)
+	}
+	if index != 1337 {
+		t.Fatalf("expected index 1337, got %d", index)
+	}
+
+	_, err = db.RecoveryProgress()
+	if err != account.ErrNoRecoveryProgress {
+		t.Fatalf("expected ErrNoRecoveryProgress, got %v", err)
+	}
+	progress := account.NewRecoveryProgress(10, 0)
+	progress.NextIndex = 20
+	progress.NumRecovered = 2
+	if err := db.PutRecoveryProgress(progress); err != nil {
+		t.Fatalf("unable to store progress: %v", err)
+	}
+	dbProgress, err := db.RecoveryProgress()
+	if err != nil {
+		t.Fatalf("unable to fetch progress: %v", err)
+	}
+	if !reflect.DeepEqual(dbProgress, progress) {
+		t.Fatalf("expected progress: %v\ngot: %v", spew.Sdump(progress),
+			spew.Sdump(dbProgress))
+	}
+}
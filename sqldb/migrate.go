@@ -0,0 +1,112 @@
+package sqldb
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/clientdb"
+)
+
+var (
+	// ErrMigrationTargetNotEmpty is returned if a bolt database is
+	// migrated into a SQL database that already contains accounts or
+	// orders.
+	ErrMigrationTargetNotEmpty = errors.New("target SQL database is not " +
+		"empty")
+
+	// ErrMigrationPendingBatch is returned if a bolt database is migrated
+	// while it still has a pending batch with staged updates.
+	ErrMigrationPendingBatch = errors.New("source database has a pending " +
+		"batch, wait for it to complete before migrating")
+)
+
+// MigrateFromBolt copies all accounts, orders, the wallet lock ID and the batch
+// count of the given bolt based clientdb into the SQL database. The copy is
+// done within a single SQL transaction so a failed migration leaves the target
+// untouched. The target database must not contain any accounts or orders yet
+// and the source database must not have a pending batch.
+func MigrateFromBolt(src *clientdb.DB, dst *DB) error {
+	// The staged updates of a pending batch are internal to the bolt
+	// database, so we refuse to migrate until the batch is either
+	// completed or abandoned.
+	_, _, err := src.PendingBatch()
+	switch {
+	case err == nil:
+		return ErrMigrationPendingBatch
+
+	case err != account.ErrNoPendingBatch:
+		return fmt.Errorf("unable to query pending batch: %v", err)
+	}
+
+	lockID, err := src.LockID()
+	if err != nil {
+		return fmt.Errorf("unable to read lock ID: %v", err)
+	}
+	batchCount, err := src.BatchCount()
+	if err != nil {
+		return fmt.Errorf("unable to read batch count: %v", err)
+	}
+	accounts, err := src.Accounts()
+	if err != nil {
+		return fmt.Errorf("unable to read accounts: %v", err)
+	}
+	orders, err := src.GetOrders()
+	if err != nil {
+		return fmt.Errorf("unable to read orders: %v", err)
+	}
+
+	err = dst.executeTx(func(tx *sql.Tx) error {
+		var numRecords int64
+		err := tx.QueryRow(`
+			SELECT (SELECT COUNT(*) FROM accounts) +
+				(SELECT COUNT(*) FROM orders)`,
+		).Scan(&numRecords)
+		if err != nil {
+			return err
+		}
+		if numRecords != 0 {
+			return ErrMigrationTargetNotEmpty
+		}
+
+		// We keep the lock ID of the source database so any outputs
+		// that are currently leased from lnd's wallet stay locked by
+		// us.
+		err = dst.putMetadata(tx, metadataLockIDKey, lockID[:])
+		if err != nil {
+			return err
+		}
+
+		// The batch count is needed to expire orders after a number
+		// of batches.
+		err = dst.putMetadata(
+			tx, metadataBatchCountKey, encodeUint32(batchCount),
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, acct := range accounts {
+			err := dst.putAccount(tx, accountsTable, acct)
+			if err != nil {
+				return err
+			}
+		}
+		for _, o := range orders {
+			if err := dst.putOrder(tx, ordersTable, o); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Migrated %d accounts and %d orders from bolt to SQL",
+		len(accounts), len(orders))
+
+	return nil
+}
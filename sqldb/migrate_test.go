@@ -0,0 +1,85 @@
+package sqldb
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/lightninglabs/llm/clientdb"
+	"github.com/lightninglabs/llm/order"
+)
+
+// TestMigrateFromBolt makes sure all records of a bolt database are copied
+// into the SQL database and that the migration refuses to run if it would be
+// unsafe.
+func TestMigrateFromBolt(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "bolt-db")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	boltDB, err := clientdb.New(tempDir)
+	if err != nil {
+		t.Fatalf("unable to create bolt db: %v", err)
+	}
+	defer boltDB.Close()
+
+	acct := testAccount()
+	bid := &order.Bid{
+		Kit:         *dummyOrder(t, 500000),
+		MinDuration: 1337,
+	}
+	if err := boltDB.AddAccount(acct); err != nil {
+		t.Fatalf("unable to add account: %v", err)
+	}
+	if err := boltDB.SubmitOrder(bid); err != nil {
+		t.Fatalf("unable to submit order: %v", err)
+	}
+
+	// A pending batch in the source must prevent the migration.
+	err = boltDB.StorePendingBatch(
+		testBatchID, testBatchTx, nil, nil, nil, nil,
+	)
+	if err != nil {
+		t.Fatalf("unable to store pending batch: %v", err)
+	}
+	sqlDB, cleanup := newTestDB(t)
+	defer cleanup()
+
+	err = MigrateFromBolt(boltDB, sqlDB)
+	if err != ErrMigrationPendingBatch {
+		t.Fatalf("expected ErrMigrationPendingBatch, got %v", err)
+	}
+	if err := boltDB.DeletePendingBatch(); err != nil {
+		t.Fatalf("unable to delete pending batch: %v", err)
+	}
+
+	// Now the migration should succeed and copy everything, including the
+	// lock ID.
+	if err := MigrateFromBolt(boltDB, sqlDB); err != nil {
+		t.Fatalf("unable to migrate: %v", err)
+	}
+	assertAccountExists(t, sqlDB, acct)
+	assertOrderExists(t, sqlDB, bid)
+
+	boltLockID, err := boltDB.LockID()
+	if err != nil {
+		t.Fatalf("unable to get lock ID: %v", err)
+	}
+	sqlLockID, err := sqlDB.LockID()
+	if err != nil {
+		t.Fatalf("unable to get lock ID: %v", err)
+	}
+	if boltLockID != sqlLockID {
+		t.Fatalf("lock ID was not migrated")
+	}
+
+	// A second migration into the now non-empty database must fail.
+	err = MigrateFromBolt(boltDB, sqlDB)
+	if err != ErrMigrationTargetNotEmpty {
+		t.Fatalf("expected ErrMigrationTargetNotEmpty, got %v", err)
+	}
+}
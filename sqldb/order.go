@@ -0,0 +1,259 @@
+package sqldb
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+
+	"github.com/lightninglabs/llm/clientdb"
+	"github.com/lightninglabs/llm/order"
+)
+
+const (
+	// ordersTable is the table that stores all orders that are currently
+	// pending or completed, indexed by their nonce.
+	ordersTable = "orders"
+
+	// pendingOrdersTable is the table that stores the staged updates of
+	// all orders that matched in the current pending batch.
+	pendingOrdersTable = "pending_orders"
+)
+
+// SubmitOrder stores an order by using the orders's nonce as an identifier. If
+// an order with the given nonce already exists in the store,
+// clientdb.ErrOrderExists is returned.
+//
+// NOTE: This is part of the order.Store interface.
+func (db *DB) SubmitOrder(o order.Order) error {
+	return db.executeTx(func(tx *sql.Tx) error {
+		_, err := db.fetchOrder(tx, ordersTable, o.Nonce())
+		switch err {
+		// No error means there is an order with that nonce in the DB
+		// already.
+		case nil:
+			return clientdb.ErrOrderExists
+
+		// This is what we want, no order with that nonce should be
+		// known.
+		case clientdb.ErrNoOrder:
+
+		// Surface any other error.
+		default:
+			return err
+		}
+
+		return db.putOrder(tx, ordersTable, o)
+	})
+}
+
+// UpdateOrder updates an order in the database according to the given
+// modifiers.
+//
+// NOTE: This is part of the order.Store interface.
+func (db *DB) UpdateOrder(nonce order.Nonce, modifiers ...order.Modifier) error {
+	return db.executeTx(func(tx *sql.Tx) error {
+		return db.updateOrder(
+			tx, ordersTable, ordersTable, nonce, modifiers,
+		)
+	})
+}
+
+// UpdateOrders atomically updates a list of orders in the database according to
+// the given modifiers.
+//
+// NOTE: This is part of the order.Store interface.
+func (db *DB) UpdateOrders(nonces []order.Nonce,
+	modifiers [][]order.Modifier) error {
+
+	if len(nonces) != len(modifiers) {
+		return fmt.Errorf("invalid number of modifiers")
+	}
+
+	return db.executeTx(func(tx *sql.Tx) error {
+		for idx, nonce := range nonces {
+			err := db.updateOrder(
+				tx, ordersTable, ordersTable, nonce,
+				modifiers[idx],
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetOrder returns an order by looking up the nonce. If no order with that
+// nonce exists in the store, clientdb.ErrNoOrder is returned.
+//
+// NOTE: This is part of the order.Store interface.
+func (db *DB) GetOrder(nonce order.Nonce) (order.Order, error) {
+	var o order.Order
+	err := db.executeTx(func(tx *sql.Tx) error {
+		var err error
+		o, err = db.fetchOrder(tx, ordersTable, nonce)
+		return err
+	})
+	return o, err
+}
+
+// GetOrders returns all orders that are currently known to the store.
+//
+// NOTE: This is part of the order.Store interface.
+func (db *DB) GetOrders() ([]order.Order, error) {
+	var orders []order.Order
+	err := db.executeTx(func(tx *sql.Tx) error {
+		var err error
+		orders, err = db.fetchOrders(tx, ordersTable)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// DelOrder removes the order with the given nonce from the local store.
+//
+// NOTE: This is part of the order.Store interface.
+func (db *DB) DelOrder(nonce order.Nonce) error {
+	return db.executeTx(func(tx *sql.Tx) error {
+		res, err := tx.Exec(
+			db.rebind("DELETE FROM orders WHERE nonce = ?"),
+			nonce[:],
+		)
+		if err != nil {
+			return err
+		}
+
+		// If the order doesn't exist, we're probably in an inconsistent
+		// state and need to return a specific error.
+		numRows, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if numRows == 0 {
+			return clientdb.ErrNoOrder
+		}
+
+		return nil
+	})
+}
+
+// updateOrder reads an order from the src table, applies the modifiers, and
+// stores it back into the dst table.
+func (db *DB) updateOrder(tx *sql.Tx, src, dst string, nonce order.Nonce,
+	modifiers []order.Modifier) error {
+
+	rawOrder, err := db.fetchRawOrder(tx, src, nonce)
+	if err != nil {
+		return err
+	}
+
+	o, newRawOrder, err := clientdb.ApplyOrderModifiers(
+		nonce, rawOrder, modifiers,
+	)
+	if err != nil {
+		return err
+	}
+
+	return db.putRawOrder(tx, dst, o, newRawOrder)
+}
+
+// fetchOrder reads a single order from the given table.
+func (db *DB) fetchOrder(tx *sql.Tx, table string,
+	nonce order.Nonce) (order.Order, error) {
+
+	rawOrder, err := db.fetchRawOrder(tx, table, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return clientdb.DeserializeOrder(nonce, bytes.NewReader(rawOrder))
+}
+
+// fetchRawOrder reads the serialized form of a single order from the given
+// table.
+func (db *DB) fetchRawOrder(tx *sql.Tx, table string,
+	nonce order.Nonce) ([]byte, error) {
+
+	var raw []byte
+	query := fmt.Sprintf("SELECT raw FROM %s WHERE nonce = ?", table)
+	err := tx.QueryRow(db.rebind(query), nonce[:]).Scan(&raw)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, clientdb.ErrNoOrder
+
+	case err != nil:
+		return nil, err
+	}
+
+	return raw, nil
+}
+
+// fetchOrders reads all orders from the given table, ordered by their nonce.
+func (db *DB) fetchOrders(tx *sql.Tx, table string) ([]order.Order, error) {
+	query := fmt.Sprintf("SELECT nonce, raw FROM %s ORDER BY nonce", table)
+	rows, err := tx.Query(db.rebind(query))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []order.Order
+	for rows.Next() {
+		var (
+			nonceBytes, raw []byte
+			nonce           order.Nonce
+		)
+		if err := rows.Scan(&nonceBytes, &raw); err != nil {
+			return nil, err
+		}
+		copy(nonce[:], nonceBytes)
+
+		o, err := clientdb.DeserializeOrder(nonce, bytes.NewReader(raw))
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, o)
+	}
+
+	return orders, rows.Err()
+}
+
+// putOrder inserts or overwrites an order in the given table.
+func (db *DB) putOrder(tx *sql.Tx, table string, o order.Order) error {
+	var w bytes.Buffer
+	if err := clientdb.SerializeOrder(o, &w); err != nil {
+		return err
+	}
+
+	return db.putRawOrder(tx, table, o, w.Bytes())
+}
+
+// putRawOrder inserts or overwrites an order in the given table using its given
+// serialized form.
+func (db *DB) putRawOrder(tx *sql.Tx, table string, o order.Order,
+	rawOrder []byte) error {
+
+	kit := o.Details()
+	nonce := o.Nonce()
+	query := fmt.Sprintf(`
+		INSERT INTO %s (
+			nonce, order_type, state, amount, units_unfulfilled,
+			account_key, raw
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (nonce) DO UPDATE SET
+			order_type = excluded.order_type,
+			state = excluded.state, amount = excluded.amount,
+			units_unfulfilled = excluded.units_unfulfilled,
+			account_key = excluded.account_key,
+			raw = excluded.raw`, table,
+	)
+	_, err := tx.Exec(
+		db.rebind(query), nonce[:], int64(o.Type()), int64(kit.State),
+		int64(kit.Amt), int64(kit.UnitsUnfulfilled), kit.AcctKey[:],
+		rawOrder,
+	)
+	return err
+}
@@ -0,0 +1,106 @@
+package sqldb
+
+import (
+	"bytes"
+	"database/sql"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/lightninglabs/llm/account"
+	"github.com/lightninglabs/llm/clientdb"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// AddAccountKeyIndexes adds the derivation index of each of the given account
+// keys to the key index cache.
+func (db *DB) AddAccountKeyIndexes(keys []*keychain.KeyDescriptor) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return db.executeTx(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(db.rebind(`
+			INSERT INTO account_key_indexes (trader_key, key_index)
+			VALUES (?, ?)
+			ON CONFLICT (trader_key) DO UPDATE
+			SET key_index = excluded.key_index`,
+		))
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, key := range keys {
+			_, err := stmt.Exec(
+				key.PubKey.SerializeCompressed(),
+				int64(key.Index),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// AccountKeyIndex looks up the derivation index of the given account key in
+// the key index cache. If it isn't found, account.ErrKeyIndexNotFound is
+// returned.
+func (db *DB) AccountKeyIndex(key *btcec.PublicKey) (uint32, error) {
+	var index int64
+	err := db.executeTx(func(tx *sql.Tx) error {
+		return tx.QueryRow(db.rebind(`
+			SELECT key_index FROM account_key_indexes
+			WHERE trader_key = ?`,
+		), key.SerializeCompressed()).Scan(&index)
+	})
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, account.ErrKeyIndexNotFound
+
+	case err != nil:
+		return 0, err
+	}
+
+	return uint32(index), nil
+}
+
+// PutRecoveryProgress stores the progress of the current recovery, replacing
+// any previously stored progress.
+func (db *DB) PutRecoveryProgress(progress *account.RecoveryProgress) error {
+	var buf bytes.Buffer
+	err := clientdb.SerializeRecoveryProgress(&buf, progress)
+	if err != nil {
+		return err
+	}
+
+	return db.executeTx(func(tx *sql.Tx) error {
+		return db.putMetadata(
+			tx, metadataRecoveryProgressKey, buf.Bytes(),
+		)
+	})
+}
+
+// RecoveryProgress returns the stored progress of the last recovery. If there
+// is none, account.ErrNoRecoveryProgress is returned.
+func (db *DB) RecoveryProgress() (*account.RecoveryProgress, error) {
+	var rawProgress []byte
+	err := db.executeTx(func(tx *sql.Tx) error {
+		var err error
+		rawProgress, err = db.getMetadata(
+			tx, metadataRecoveryProgressKey,
+		)
+		return err
+	})
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, account.ErrNoRecoveryProgress
+
+	case err != nil:
+		return nil, err
+	}
+
+	return clientdb.DeserializeRecoveryProgress(
+		bytes.NewReader(rawProgress),
+	)
+}
@@ -0,0 +1,93 @@
+package llm
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/lightninglabs/llm/account"
+)
+
+const (
+	// defaultStaticBackupFilename is the name of the static account backup
+	// file that is created in the network directory if no other path is
+	// configured.
+	defaultStaticBackupFilename = "accounts.backup"
+
+	// staticBackupTimeout is the maximum time we allow for deriving the
+	// encryption key and writing the static account backup.
+	staticBackupTimeout = 10 * time.Second
+)
+
+// staticBackupFile returns the path of the static account backup file.
+func staticBackupFile(cfg *Config) string {
+	if cfg.StaticBackupFile != "" {
+		return cfg.StaticBackupFile
+	}
+	return filepath.Join(
+		cfg.BaseDir, cfg.Network, defaultStaticBackupFilename,
+	)
+}
+
+// staticBackupStore is a traderStore wrapper that keeps the static account
+// backup up to date with every change to the accounts in the database.
+type staticBackupStore struct {
+	traderStore
+
+	backup *account.StaticBackup
+}
+
+// AddAccount adds a record for the account to the database and updates the
+// static account backup.
+func (s *staticBackupStore) AddAccount(a *account.Account) error {
+	if err := s.traderStore.AddAccount(a); err != nil {
+		return err
+	}
+	s.logBackupErr(s.updateBackup())
+	return nil
+}
+
+// UpdateAccount updates an account in the database according to the given
+// modifiers and updates the static account backup.
+func (s *staticBackupStore) UpdateAccount(a *account.Account,
+	modifiers ...account.Modifier) error {
+
+	if err := s.traderStore.UpdateAccount(a, modifiers...); err != nil {
+		return err
+	}
+	s.logBackupErr(s.updateBackup())
+	return nil
+}
+
+// MarkBatchComplete applies the staged updates of the pending batch and
+// updates the static account backup as accounts are modified by a batch.
+func (s *staticBackupStore) MarkBatchComplete() error {
+	if err := s.traderStore.MarkBatchComplete(); err != nil {
+		return err
+	}
+	s.logBackupErr(s.updateBackup())
+	return nil
+}
+
+// updateBackup writes all current accounts to the static account backup.
+func (s *staticBackupStore) updateBackup() error {
+	accounts, err := s.traderStore.Accounts()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), staticBackupTimeout,
+	)
+	defer cancel()
+	return s.backup.Update(ctx, accounts)
+}
+
+// logBackupErr logs a failed update of the static account backup. The account
+// change itself was already persisted at that point, so we don't want to fail
+// the operation because of the backup.
+func (s *staticBackupStore) logBackupErr(err error) {
+	if err != nil {
+		log.Errorf("Unable to update static account backup: %v", err)
+	}
+}
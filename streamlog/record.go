@@ -0,0 +1,230 @@
+package streamlog
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/lightninglabs/llm/clmrpc"
+)
+
+const (
+	// fileVersion is the version of the binary format of a stream log
+	// file.
+	fileVersion uint16 = 1
+
+	// maxMessageSize is the maximum size of a single recorded message. A
+	// larger size in a record means the file is corrupt.
+	maxMessageSize = 32 * 1024 * 1024
+)
+
+var (
+	// byteOrder is the byte order all numbers are serialized with.
+	byteOrder = binary.BigEndian
+
+	// fileMagic are the bytes every stream log file starts with.
+	fileMagic = [4]byte{'L', 'L', 'M', 'S'}
+
+	// ErrInvalidFile is returned if a file doesn't start with the stream
+	// log file header.
+	ErrInvalidFile = errors.New("not a stream log file")
+)
+
+// Direction is the direction a recorded message was sent in.
+type Direction uint8
+
+const (
+	// DirectionClient is the direction of a message sent by the trader to
+	// the auctioneer.
+	DirectionClient Direction = 1
+
+	// DirectionServer is the direction of a message sent by the auctioneer
+	// to the trader.
+	DirectionServer Direction = 2
+)
+
+// String returns a human readable representation of the direction.
+func (d Direction) String() string {
+	switch d {
+	case DirectionClient:
+		return "client->server"
+
+	case DirectionServer:
+		return "server->client"
+
+	default:
+		return fmt.Sprintf("unknown<%d>", d)
+	}
+}
+
+// Record is a single message of the auction stream as it was recorded.
+type Record struct {
+	// Timestamp is the time the message was sent or received.
+	Timestamp time.Time
+
+	// Direction is the direction the message was sent in.
+	Direction Direction
+
+	// AccountKey is the raw trader account key the message refers to. It
+	// is all zeroes for messages that don't refer to a single account,
+	// like the messages of the batch execution.
+	AccountKey [33]byte
+
+	// ClientMsg is the recorded message if it was sent by the trader.
+	ClientMsg *clmrpc.ClientAuctionMessage
+
+	// ServerMsg is the recorded message if it was sent by the auctioneer.
+	ServerMsg *clmrpc.ServerAuctionMessage
+}
+
+// NewClientRecord creates a record of a message sent by the trader.
+func NewClientRecord(msg *clmrpc.ClientAuctionMessage) *Record {
+	r := &Record{
+		Timestamp: time.Now(),
+		Direction: DirectionClient,
+		ClientMsg: msg,
+	}
+	switch m := msg.Msg.(type) {
+	case *clmrpc.ClientAuctionMessage_Subscribe:
+		copy(r.AccountKey[:], m.Subscribe.TraderKey)
+
+	case *clmrpc.ClientAuctionMessage_Recover:
+		copy(r.AccountKey[:], m.Recover.TraderKey)
+	}
+	return r
+}
+
+// NewServerRecord creates a record of a message sent by the auctioneer.
+func NewServerRecord(msg *clmrpc.ServerAuctionMessage) *Record {
+	r := &Record{
+		Timestamp: time.Now(),
+		Direction: DirectionServer,
+		ServerMsg: msg,
+	}
+	switch m := msg.Msg.(type) {
+	case *clmrpc.ServerAuctionMessage_Success:
+		copy(r.AccountKey[:], m.Success.TraderKey)
+
+	case *clmrpc.ServerAuctionMessage_Error:
+		copy(r.AccountKey[:], m.Error.TraderKey)
+
+	case *clmrpc.ServerAuctionMessage_Account:
+		copy(r.AccountKey[:], m.Account.TraderKey)
+	}
+	return r
+}
+
+// writeFileHeader writes the header every stream log file starts with.
+func writeFileHeader(w io.Writer) error {
+	if _, err := w.Write(fileMagic[:]); err != nil {
+		return err
+	}
+	return binary.Write(w, byteOrder, fileVersion)
+}
+
+// readFileHeader reads and validates the header of a stream log file.
+func readFileHeader(r io.Reader) error {
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return ErrInvalidFile
+	}
+	if magic != fileMagic {
+		return ErrInvalidFile
+	}
+
+	var version uint16
+	if err := binary.Read(r, byteOrder, &version); err != nil {
+		return ErrInvalidFile
+	}
+	if version != fileVersion {
+		return fmt.Errorf("unknown stream log version %d", version)
+	}
+	return nil
+}
+
+// serialize writes the binary representation of the record to the writer and
+// returns the number of bytes written.
+func (r *Record) serialize(w io.Writer) (int, error) {
+	var (
+		msg []byte
+		err error
+	)
+	switch r.Direction {
+	case DirectionClient:
+		msg, err = proto.Marshal(r.ClientMsg)
+
+	case DirectionServer:
+		msg, err = proto.Marshal(r.ServerMsg)
+
+	default:
+		err = fmt.Errorf("invalid direction %v", r.Direction)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var header [8 + 1 + 33 + 4]byte
+	byteOrder.PutUint64(header[:8], uint64(r.Timestamp.UnixNano()))
+	header[8] = uint8(r.Direction)
+	copy(header[9:42], r.AccountKey[:])
+	byteOrder.PutUint32(header[42:], uint32(len(msg)))
+
+	if _, err := w.Write(header[:]); err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return 0, err
+	}
+	return len(header) + len(msg), nil
+}
+
+// deserializeRecord reads the next record from the reader. io.EOF is returned
+// if there are no more records.
+func deserializeRecord(rd io.Reader) (*Record, error) {
+	var header [8 + 1 + 33 + 4]byte
+	if _, err := io.ReadFull(rd, header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("truncated record header")
+		}
+		return nil, err
+	}
+
+	r := &Record{
+		Timestamp: time.Unix(0, int64(byteOrder.Uint64(header[:8]))),
+		Direction: Direction(header[8]),
+	}
+	copy(r.AccountKey[:], header[9:42])
+
+	msgLen := byteOrder.Uint32(header[42:])
+	if msgLen > maxMessageSize {
+		return nil, fmt.Errorf("invalid message size %d", msgLen)
+	}
+	msg := make([]byte, msgLen)
+	if _, err := io.ReadFull(rd, msg); err != nil {
+		return nil, fmt.Errorf("truncated record: %v", err)
+	}
+
+	switch r.Direction {
+	case DirectionClient:
+		r.ClientMsg = &clmrpc.ClientAuctionMessage{}
+		err := proto.Unmarshal(msg, r.ClientMsg)
+		if err != nil {
+			return nil, err
+		}
+
+	case DirectionServer:
+		r.ServerMsg = &clmrpc.ServerAuctionMessage{}
+		err := proto.Unmarshal(msg, r.ServerMsg)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("invalid direction %v", r.Direction)
+	}
+
+	return r, nil
+}
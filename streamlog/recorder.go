@@ -0,0 +1,207 @@
+package streamlog
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// filePrefix is the prefix of all stream log file names.
+	filePrefix = "stream-"
+
+	// fileSuffix is the suffix of all stream log file names.
+	fileSuffix = ".log"
+
+	// fileTimeFormat is the format of the time stamp in the name of a
+	// stream log file. It sorts lexicographically.
+	fileTimeFormat = "20060102T150405.000000000Z"
+
+	// DefaultMaxFileSize is the default size in bytes after which a new
+	// stream log file is started.
+	DefaultMaxFileSize = 10 * 1024 * 1024
+
+	// DefaultMaxFiles is the default number of stream log files that are
+	// kept.
+	DefaultMaxFiles = 10
+)
+
+// Recorder writes records of the auction stream to a rotating set of binary
+// log files in a directory.
+type Recorder struct {
+	dir         string
+	maxFileSize int64
+	maxFiles    int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewRecorder creates a recorder that writes to the given directory. A new
+// file is started once the current one exceeds maxFileSize bytes and only the
+// newest maxFiles files are kept. A value of zero for maxFiles keeps all files.
+func NewRecorder(dir string, maxFileSize int64, maxFiles int) (*Recorder,
+	error) {
+
+	if maxFileSize <= 0 {
+		maxFileSize = DefaultMaxFileSize
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &Recorder{
+		dir:         dir,
+		maxFileSize: maxFileSize,
+		maxFiles:    maxFiles,
+	}, nil
+}
+
+// Record appends a record to the current log file, starting a new file first
+// if the current one is full.
+func (r *Recorder) Record(rec *Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil || r.size >= r.maxFileSize {
+		if err := r.rotate(); err != nil {
+			return fmt.Errorf("unable to rotate stream log: %v",
+				err)
+		}
+	}
+
+	n, err := rec.serialize(r.file)
+	r.size += int64(n)
+	return err
+}
+
+// Close closes the current log file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// rotate closes the current log file, starts a new one and removes the oldest
+// files if there are more than allowed. The caller must hold the mutex.
+func (r *Recorder) rotate() error {
+	if r.file != nil {
+		if err := r.file.Close(); err != nil {
+			return err
+		}
+		r.file = nil
+	}
+
+	fileName := filepath.Join(
+		r.dir, filePrefix+time.Now().UTC().Format(fileTimeFormat)+
+			fileSuffix,
+	)
+	f, err := os.OpenFile(
+		fileName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600,
+	)
+	if err != nil {
+		return err
+	}
+	if err := writeFileHeader(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	r.file = f
+	r.size = 0
+
+	if r.maxFiles <= 0 {
+		return nil
+	}
+	files, err := ListFiles(r.dir)
+	if err != nil {
+		return err
+	}
+	for len(files) > r.maxFiles {
+		if err := os.Remove(files[0]); err != nil {
+			return err
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+// ListFiles returns the full paths of all stream log files in the given
+// directory, oldest first.
+func ListFiles(dir string) ([]string, error) {
+	matches, err := filepath.Glob(
+		filepath.Join(dir, filePrefix+"*"+fileSuffix),
+	)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// ReadFile reads all records of a single stream log file. If the file ends
+// with a partially written record, for example because llmd crashed, all
+// complete records are returned together with the error.
+func ReadFile(path string) ([]*Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rd := bufio.NewReader(f)
+	if err := readFileHeader(rd); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	var records []*Record
+	for {
+		rec, err := deserializeRecord(rd)
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, fmt.Errorf("%s: %v", path, err)
+		}
+		records = append(records, rec)
+	}
+}
+
+// ReadPath reads all records of a stream log file or, if the path is a
+// directory, of all stream log files in it, oldest first.
+func ReadPath(path string) ([]*Record, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return ReadFile(path)
+	}
+
+	files, err := ListFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no stream log files found in %s", path)
+	}
+	var records []*Record
+	for _, file := range files {
+		fileRecords, err := ReadFile(file)
+		records = append(records, fileRecords...)
+		if err != nil {
+			return records, err
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,181 @@
+package streamlog
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/lightninglabs/llm/clmrpc"
+)
+
+var (
+	testTraderKey = [33]byte{0x02, 0x01, 0x02, 0x03}
+
+	testClientMsg = &clmrpc.ClientAuctionMessage{
+		Msg: &clmrpc.ClientAuctionMessage_Subscribe{
+			Subscribe: &clmrpc.AccountSubscription{
+				TraderKey: testTraderKey[:],
+			},
+		},
+	}
+
+	testServerMsg = &clmrpc.ServerAuctionMessage{
+		Msg: &clmrpc.ServerAuctionMessage_Prepare{
+			Prepare: &clmrpc.OrderMatchPrepare{
+				BatchId:      []byte{0x03, 0x04},
+				BatchVersion: 1,
+			},
+		},
+	}
+)
+
+// TestRecorderRoundTrip makes sure recorded messages are read back exactly as
+// they were recorded.
+func TestRecorderRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "streamlog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	recorder, err := NewRecorder(dir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientRec := NewClientRecord(testClientMsg)
+	serverRec := NewServerRecord(testServerMsg)
+	for _, rec := range []*Record{clientRec, serverRec} {
+		if err := recorder.Record(rec); err != nil {
+			t.Fatalf("unable to record: %v", err)
+		}
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := ReadPath(dir)
+	if err != nil {
+		t.Fatalf("unable to read records: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	if records[0].Direction != DirectionClient ||
+		records[0].AccountKey != testTraderKey ||
+		!records[0].Timestamp.Equal(clientRec.Timestamp) ||
+		!proto.Equal(records[0].ClientMsg, testClientMsg) {
+
+		t.Fatalf("unexpected client record: %v", records[0])
+	}
+	if records[1].Direction != DirectionServer ||
+		records[1].AccountKey != [33]byte{} ||
+		!records[1].Timestamp.Equal(serverRec.Timestamp) ||
+		!proto.Equal(records[1].ServerMsg, testServerMsg) {
+
+		t.Fatalf("unexpected server record: %v", records[1])
+	}
+}
+
+// TestRecorderRotation makes sure a new file is started once the current one
+// is full and only the configured number of files is kept.
+func TestRecorderRotation(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "streamlog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Every record is larger than a single byte, so each one is written
+	// to its own file.
+	const maxFiles = 3
+	recorder, err := NewRecorder(dir, 1, maxFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer recorder.Close()
+
+	for i := 0; i < 5; i++ {
+		err := recorder.Record(NewServerRecord(testServerMsg))
+		if err != nil {
+			t.Fatalf("unable to record: %v", err)
+		}
+	}
+
+	files, err := ListFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != maxFiles {
+		t.Fatalf("expected %d files, got %d", maxFiles, len(files))
+	}
+	for _, file := range files {
+		records, err := ReadFile(file)
+		if err != nil {
+			t.Fatalf("unable to read %v: %v", file, err)
+		}
+		if len(records) != 1 {
+			t.Fatalf("expected 1 record in %v, got %d", file,
+				len(records))
+		}
+	}
+}
+
+// TestReadTruncatedFile makes sure all complete records of a file that ends
+// with a partially written record are returned together with an error.
+func TestReadTruncatedFile(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "streamlog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	recorder, err := NewRecorder(dir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		err := recorder.Record(NewClientRecord(testClientMsg))
+		if err != nil {
+			t.Fatalf("unable to record: %v", err)
+		}
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := ListFiles(dir)
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected a single file, got %v (%v)", files, err)
+	}
+	info, err := os.Stat(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(files[0], info.Size()-3); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := ReadFile(files[0])
+	if err == nil {
+		t.Fatalf("expected error reading truncated file")
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 complete record, got %d", len(records))
+	}
+
+	// A file without the header isn't accepted at all.
+	if err := ioutil.WriteFile(files[0], []byte("foo"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadFile(files[0]); err == nil {
+		t.Fatalf("expected error reading invalid file")
+	}
+}
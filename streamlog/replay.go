@@ -0,0 +1,131 @@
+package streamlog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/lightninglabs/llm/clmrpc"
+	"github.com/lightninglabs/llm/order"
+)
+
+// Result is the outcome of replaying a single recorded batch.
+type Result struct {
+	// Prepare is the record of the prepare message of the batch.
+	Prepare *Record
+
+	// BatchID is the ID of the batch.
+	BatchID order.BatchID
+
+	// VerifyErr is the error the batch verification returned during the
+	// replay or nil if the batch was found to be correct.
+	VerifyErr error
+
+	// Response is the record of the trader's accept or reject message for
+	// the batch or nil if no response was recorded.
+	Response *Record
+}
+
+// Accepted returns true if the trader accepted the batch in the recorded
+// session.
+func (r *Result) Accepted() bool {
+	if r.Response == nil {
+		return false
+	}
+	_, ok := r.Response.ClientMsg.Msg.(*clmrpc.ClientAuctionMessage_Accept)
+	return ok
+}
+
+// Rejection returns the recorded reject message of the trader for the batch or
+// nil if the batch wasn't rejected.
+func (r *Result) Rejection() *clmrpc.OrderMatchReject {
+	if r.Response == nil {
+		return nil
+	}
+	msg := r.Response.ClientMsg.Msg
+	reject, ok := msg.(*clmrpc.ClientAuctionMessage_Reject)
+	if !ok {
+		return nil
+	}
+	return reject.Reject
+}
+
+// Mismatch returns true if the replayed verification failed because the
+// trader came to a different result than the auctioneer.
+func (r *Result) Mismatch() bool {
+	return errors.Is(r.VerifyErr, order.ErrMismatchErr)
+}
+
+// Reproduced returns true if the replay came to the same decision as the
+// trader in the recorded session. If no response was recorded, there is
+// nothing to compare against and false is returned.
+func (r *Result) Reproduced() bool {
+	if r.Response == nil {
+		return false
+	}
+	return r.Accepted() == (r.VerifyErr == nil)
+}
+
+// Replay parses every recorded prepare message into a batch and verifies it
+// with the given verifier, just like the trader did when the message was
+// received. Each batch is paired with the trader's recorded response so the
+// decision of the replay can be compared with the original one. The verifier
+// should be backed by a database snapshot taken before the batches were
+// executed, otherwise the orders and accounts won't be in the state they were
+// in at the time.
+func Replay(records []*Record, verifier order.BatchVerifier) ([]*Result,
+	error) {
+
+	var results []*Result
+	for i, rec := range records {
+		if rec.Direction != DirectionServer {
+			continue
+		}
+		msg := rec.ServerMsg.Msg
+		prepare, ok := msg.(*clmrpc.ServerAuctionMessage_Prepare)
+		if !ok {
+			continue
+		}
+
+		batch, err := order.ParseRPCBatch(prepare.Prepare)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse batch "+
+				"recorded at %v: %v", rec.Timestamp, err)
+		}
+
+		results = append(results, &Result{
+			Prepare:   rec,
+			BatchID:   batch.ID,
+			VerifyErr: verifier.Verify(batch),
+			Response:  findResponse(records[i+1:], batch.ID),
+		})
+	}
+
+	return results, nil
+}
+
+// findResponse returns the first accept or reject message for the given batch
+// in the records or nil if there is none.
+func findResponse(records []*Record, batchID order.BatchID) *Record {
+	for _, rec := range records {
+		if rec.Direction != DirectionClient {
+			continue
+		}
+
+		var responseID []byte
+		switch m := rec.ClientMsg.Msg.(type) {
+		case *clmrpc.ClientAuctionMessage_Accept:
+			responseID = m.Accept.BatchId
+
+		case *clmrpc.ClientAuctionMessage_Reject:
+			responseID = m.Reject.BatchId
+
+		default:
+			continue
+		}
+		if bytes.Equal(responseID, batchID[:]) {
+			return rec
+		}
+	}
+	return nil
+}